@@ -6,7 +6,6 @@ import (
 
 	"github.com/rozdolsky33/ocloud/internal/app"
 	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
-	"github.com/rozdolsky33/ocloud/internal/services/util"
 	"github.com/spf13/cobra"
 )
 
@@ -16,7 +15,7 @@ func NewCreateCmd(appCtx *app.ApplicationContext) *cobra.Command {
 		Use:           "create",
 		Aliases:       []string{"c"},
 		Short:         "Create a Bastion or a Session",
-		Long:          "Interactively create a session on a selected bastion and target (Instance, OKE, Database).",
+		Long:          "Interactively create a new bastion in a selected VCN and subnet, or a session on a selected bastion and target (Instance, OKE, Database).",
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -43,8 +42,7 @@ func runCreateCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error
 		return ErrAborted
 	}
 	if choice == TypeBastion {
-		util.ShowConstructionAnimation()
-		return nil
+		return createBastion(ctx, appCtx, svc)
 	}
 	b, err := SelectBastion(ctx, svc, choice)
 	if err != nil {
@@ -0,0 +1,81 @@
+package bastion
+
+import (
+	"fmt"
+
+	sharedflags "github.com/rozdolsky33/ocloud/cmd/shared/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/rozdolsky33/ocloud/internal/services/util"
+	"github.com/spf13/cobra"
+)
+
+var deleteLong = `
+Delete a bastion by display name or OCID.
+
+The bastion is resolved in the current compartment. Before deleting, the command shows the
+bastion details and asks for confirmation; use --yes (-y) to skip the prompt in scripts.
+Active sessions on the bastion are terminated when the bastion is deleted.
+`
+
+var deleteExamples = `
+  # Delete a bastion by name (asks for confirmation)
+  ocloud identity bastion delete prodbastion
+
+  # Delete a bastion by OCID without confirmation
+  ocloud identity bastion delete ocid1.bastion.oc1..example --yes
+`
+
+// NewDeleteCmd returns "bastion delete".
+func NewDeleteCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "delete <name|ocid>",
+		Aliases:       []string{"rm"},
+		Short:         "Delete a bastion",
+		Long:          deleteLong,
+		Example:       deleteExamples,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeleteCommand(cmd, args, appCtx)
+		},
+	}
+	sharedflags.YesFlag.Add(cmd)
+	return cmd
+}
+
+// runDeleteCommand resolves the bastion, confirms with the user and deletes it.
+func runDeleteCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	ctx := cmd.Context()
+	skipConfirm := flags.GetBoolFlag(cmd, flags.FlagNameYes, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion delete command", "target", args[0], "yes", skipConfirm)
+
+	svc, err := bastionSvc.NewServiceFromAppContext(appCtx)
+	if err != nil {
+		return fmt.Errorf("create bastion service: %w", err)
+	}
+
+	b, err := svc.Resolve(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !skipConfirm {
+		if err := bastionSvc.PrintBastionInfo([]bastionSvc.Bastion{*b}, appCtx, false); err != nil {
+			return err
+		}
+		if !util.PromptYesNo(fmt.Sprintf("Delete bastion %q?", b.DisplayName)) {
+			return ErrAborted
+		}
+	}
+
+	if err := svc.Delete(ctx, b.OCID); err != nil {
+		return err
+	}
+
+	logger.Logger.Info("Bastion deletion initiated", "name", b.DisplayName, "bastion_id", b.OCID)
+	return nil
+}
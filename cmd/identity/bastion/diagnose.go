@@ -0,0 +1,104 @@
+package bastion
+
+import (
+	"fmt"
+
+	bastionFlags "github.com/rozdolsky33/ocloud/cmd/identity/bastion/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/spf13/cobra"
+)
+
+var diagnoseLong = `
+Check whether a bastion can reach a target before creating a session.
+
+The target is given as <kind>:<name|ocid> like for "bastion session create". The command reports
+one finding per check:
+
+  Client CIDR allow-list  --client-ip is inside the bastion's client CIDR allow-list
+  Target VCN              the target is in the bastion's target VCN
+  Routing                 route tables lead from the bastion subnet to the target and back (peered VCNs)
+  Bastion egress          the bastion subnet's security lists allow TCP to the target port
+  Target ingress          the target subnet's security lists or the target's NSGs allow the target port
+                          from the bastion's private endpoint IP
+
+Checks that cannot be evaluated, for example because a security list cannot be read, are reported
+as WARN or SKIP. The command exits non-zero when any check fails.
+`
+
+var diagnoseExamples = `
+  # Check that prodbastion can reach SSH on an instance
+  ocloud identity bastion diagnose --bastion prodbastion --target instance:web-1
+
+  # Check a non-standard database port and the client allow-list
+  ocloud identity bastion diagnose --bastion prodbastion --target instance:db-1 --remote-port 5432 --client-ip 203.0.113.10
+
+  # Output the findings as JSON
+  ocloud identity bastion diagnose --bastion prodbastion --target adb:salesdb --json
+`
+
+// NewDiagnoseCmd returns "bastion diagnose".
+func NewDiagnoseCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "diagnose",
+		Short:         "Check routes, security rules and the CIDR allow-list between a bastion and a target",
+		Long:          diagnoseLong,
+		Example:       diagnoseExamples,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDiagnoseCommand(cmd, appCtx)
+		},
+	}
+	bastionFlags.BastionFlag.Add(cmd)
+	bastionFlags.TargetFlag.Add(cmd)
+	bastionFlags.RemotePortFlag.Add(cmd)
+	bastionFlags.ClientIPFlag.Add(cmd)
+	_ = cmd.MarkFlagRequired(flags.FlagNameBastion)
+	_ = cmd.MarkFlagRequired(flags.FlagNameTarget)
+	return cmd
+}
+
+// runDiagnoseCommand resolves the bastion and target and prints the reachability findings.
+func runDiagnoseCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	ctx := cmd.Context()
+	bastionName := flags.GetStringFlag(cmd, flags.FlagNameBastion, "")
+	remotePort := flags.GetIntFlag(cmd, flags.FlagNameRemotePort, 0)
+	clientIP := flags.GetStringFlag(cmd, flags.FlagNameClientIP, "")
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+
+	kind, name, err := bastionSvc.ParseTargetSpec(flags.GetStringFlag(cmd, flags.FlagNameTarget, ""))
+	if err != nil {
+		return err
+	}
+	if remotePort < 0 || remotePort > 65535 {
+		return fmt.Errorf("--%s must be between 1 and 65535, got %d", flags.FlagNameRemotePort, remotePort)
+	}
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion diagnose command",
+		"bastion", bastionName, "target_kind", kind, "target", name, "remote_port", remotePort)
+
+	svc, err := bastionSvc.NewServiceFromAppContext(appCtx)
+	if err != nil {
+		return fmt.Errorf("create bastion service: %w", err)
+	}
+	b, err := svc.Resolve(ctx, bastionName)
+	if err != nil {
+		return err
+	}
+	target, err := bastionSvc.ResolveTarget(ctx, appCtx, kind, name)
+	if err != nil {
+		return err
+	}
+
+	report := svc.AnalyzeReachability(ctx, *b, target.ReachabilityTarget(remotePort, clientIP))
+	if err := bastionSvc.PrintReachabilityReport(report, appCtx, useJSON); err != nil {
+		return err
+	}
+	if !report.Reachable {
+		return &bastionSvc.ResolveError{Kind: kind, Name: name, Err: bastionSvc.ErrTargetUnreachable, Reason: report.FailureReason()}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package bastion
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnoseCommand(t *testing.T) {
+	cmd := NewDiagnoseCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "diagnose", cmd.Use)
+	assert.True(t, cmd.SilenceUsage)
+	for _, name := range []string{"bastion", "target", "remote-port", "client-ip"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "expected --%s flag", name)
+	}
+}
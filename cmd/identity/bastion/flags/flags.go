@@ -0,0 +1,92 @@
+package flags
+
+import "github.com/rozdolsky33/ocloud/internal/config/flags"
+
+// Flags used by the non-interactive bastion session commands.
+var (
+	BastionFlag = flags.StringFlag{
+		Name:    flags.FlagNameBastion,
+		Default: "",
+		Usage:   flags.FlagDescBastion,
+	}
+	TargetFlag = flags.StringFlag{
+		Name:    flags.FlagNameTarget,
+		Default: "",
+		Usage:   flags.FlagDescTarget,
+	}
+	SessionTypeFlag = flags.StringFlag{
+		Name:    flags.FlagNameSessionType,
+		Default: "port-forward",
+		Usage:   flags.FlagDescSessionType,
+	}
+	LocalPortFlag = flags.IntFlag{
+		Name:    flags.FlagNameLocalPort,
+		Default: 0,
+		Usage:   flags.FlagDescLocalPort,
+	}
+	RemotePortFlag = flags.IntFlag{
+		Name:    flags.FlagNameRemotePort,
+		Default: 0,
+		Usage:   flags.FlagDescRemotePort,
+	}
+	SSHKeyFlag = flags.StringFlag{
+		Name:    flags.FlagNameSSHKey,
+		Default: "~/.ssh/id_rsa",
+		Usage:   flags.FlagDescSSHKey,
+	}
+	SSHUserFlag = flags.StringFlag{
+		Name:    flags.FlagNameSSHUser,
+		Default: "opc",
+		Usage:   flags.FlagDescSSHUser,
+	}
+	PrintOnlyFlag = flags.BoolFlag{
+		Name:    flags.FlagNamePrintOnly,
+		Default: false,
+		Usage:   flags.FlagDescPrintOnly,
+	}
+	KeepAliveFlag = flags.BoolFlag{
+		Name:    flags.FlagNameKeepAlive,
+		Default: false,
+		Usage:   flags.FlagDescKeepAlive,
+	}
+	ClientIPFlag = flags.StringFlag{
+		Name:    flags.FlagNameClientIP,
+		Default: "",
+		Usage:   flags.FlagDescClientIP,
+	}
+	SkipReachabilityFlag = flags.BoolFlag{
+		Name:    flags.FlagNameSkipReach,
+		Default: false,
+		Usage:   flags.FlagDescSkipReach,
+	}
+)
+
+// Flags used by the bastion tunnel commands.
+var (
+	AllTunnelsFlag = flags.BoolFlag{
+		Name:      flags.FlagNameAll,
+		Shorthand: flags.FlagShortAll,
+		Default:   false,
+		Usage:     flags.FlagDescAllTunnels,
+	}
+	LinesFlag = flags.IntFlag{
+		Name:    flags.FlagNameLines,
+		Default: 50,
+		Usage:   flags.FlagDescLines,
+	}
+	FollowFlag = flags.BoolFlag{
+		Name:    flags.FlagNameFollow,
+		Default: false,
+		Usage:   flags.FlagDescFollow,
+	}
+	OlderThanFlag = flags.StringFlag{
+		Name:    flags.FlagNameOlderThan,
+		Default: "168h",
+		Usage:   flags.FlagDescOlderThan,
+	}
+	DryRunFlag = flags.BoolFlag{
+		Name:    flags.FlagNameDryRun,
+		Default: false,
+		Usage:   flags.FlagDescDryRun,
+	}
+)
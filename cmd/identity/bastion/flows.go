@@ -9,6 +9,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rozdolsky33/ocloud/internal/app"
+	instSvc "github.com/rozdolsky33/ocloud/internal/services/compute/instance"
 	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
 )
 
@@ -73,6 +74,25 @@ func SelectTargetType(ctx context.Context, bastionID string) (TargetType, error)
 	return out.Choice, nil
 }
 
+// ensureReachable analyses whether bastion b can reach target on port, prints the findings and returns an
+// error when a check fails, so no session is created for a target the bastion cannot reach.
+func ensureReachable(ctx context.Context, appCtx *app.ApplicationContext, svc *bastionSvc.Service, b bastionSvc.Bastion, target bastionSvc.ResolvedTarget, port int) error {
+	report := svc.AnalyzeReachability(ctx, b, target.ReachabilityTarget(port, ""))
+	if err := bastionSvc.PrintReachabilityReport(report, appCtx, false); err != nil {
+		return err
+	}
+	if !report.Reachable {
+		return &bastionSvc.ResolveError{Kind: target.Kind, Name: target.Name, Err: bastionSvc.ErrTargetUnreachable, Reason: report.FailureReason()}
+	}
+	return nil
+}
+
+// instanceTarget describes a compute instance as a bastion target.
+func instanceTarget(inst instSvc.Instance) bastionSvc.ResolvedTarget {
+	return bastionSvc.ResolvedTarget{Kind: bastionSvc.KindInstance, ID: inst.OCID, Name: inst.DisplayName, IP: inst.PrimaryIP,
+		Port: 22, VcnID: inst.VcnID, SubnetID: inst.SubnetID, NsgIDs: inst.NsgIDs}
+}
+
 // SelectSessionType chooses a session type for the selected bastion and target type.
 // If only one session type is valid for the target, it is auto-selected.
 func SelectSessionType(ctx context.Context, bastionID string, tType TargetType) (SessionType, error) {
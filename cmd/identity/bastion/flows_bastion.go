@@ -0,0 +1,156 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	ocivcn "github.com/rozdolsky33/ocloud/internal/oci/network/vcn"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	vcnSvc "github.com/rozdolsky33/ocloud/internal/services/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/services/util"
+)
+
+// defaultClientCIDR is offered as the allow-list default; users are expected to narrow it.
+const defaultClientCIDR = "0.0.0.0/0"
+
+// createBastion runs the guided Bastion creation flow:
+// 1. Select target VCN
+// 2. Select target subnet within the VCN
+// 3. Prompt for name, client CIDR allow-list and max session TTL
+// 4. Create the bastion and wait for it to become ACTIVE
+func createBastion(ctx context.Context, appCtx *app.ApplicationContext, svc *bastionSvc.Service) error {
+	networkClient, err := oci.NewNetworkClient(appCtx.Provider)
+	if err != nil {
+		return fmt.Errorf("creating network client: %w", err)
+	}
+	vcnService := vcnSvc.NewService(ocivcn.NewAdapter(networkClient), appCtx.Logger, appCtx.CompartmentID)
+
+	vcns, err := vcnService.ListVcns(ctx)
+	if err != nil {
+		return fmt.Errorf("list VCNs: %w", err)
+	}
+	vcns = slices.DeleteFunc(vcns, func(v vcnSvc.VCN) bool {
+		return v.LifecycleState != "AVAILABLE"
+	})
+	if len(vcns) == 0 {
+		logger.Logger.Info("No available VCNs found in compartment.")
+		return nil
+	}
+
+	// Step 1: Select VCN
+	vm := NewVCNListModelFancy(vcns)
+	vp := tea.NewProgram(vm, tea.WithContext(ctx))
+	vres, err := vp.Run()
+	if err != nil {
+		return fmt.Errorf("VCN selection TUI: %w", err)
+	}
+	chosenVcn, ok := vres.(ResourceListModel)
+	if !ok || chosenVcn.Choice() == "" {
+		return ErrAborted
+	}
+	var vcn vcnSvc.VCN
+	for _, v := range vcns {
+		if v.OCID == chosenVcn.Choice() {
+			vcn = v
+			break
+		}
+	}
+
+	// Step 2: Select subnet
+	subnets := slices.DeleteFunc(slices.Clone(vcn.Subnets), func(s vcnSvc.Subnet) bool {
+		return s.LifecycleState != "AVAILABLE"
+	})
+	if len(subnets) == 0 {
+		logger.Logger.Info("No available subnets found in VCN.", "vcn", vcn.DisplayName)
+		return nil
+	}
+	sm := NewSubnetListModelFancy(subnets)
+	sp := tea.NewProgram(sm, tea.WithContext(ctx))
+	sres, err := sp.Run()
+	if err != nil {
+		return fmt.Errorf("subnet selection TUI: %w", err)
+	}
+	chosenSubnet, ok := sres.(ResourceListModel)
+	if !ok || chosenSubnet.Choice() == "" {
+		return ErrAborted
+	}
+	var subnet vcnSvc.Subnet
+	for _, s := range subnets {
+		if s.OCID == chosenSubnet.Choice() {
+			subnet = s
+			break
+		}
+	}
+
+	// Step 3: Prompt for bastion settings
+	defaultName := sanitizeBastionName("bastion" + vcn.DisplayName)
+	name, err := util.PromptString("Enter bastion name", defaultName)
+	if err != nil {
+		return fmt.Errorf("read bastion name: %w", err)
+	}
+	name = sanitizeBastionName(name)
+	if name == "" {
+		return fmt.Errorf("bastion name must contain at least one alphanumeric character")
+	}
+
+	var cidrs []string
+	for {
+		raw, err := util.PromptString("Enter client CIDR allow-list (comma separated)", defaultClientCIDR)
+		if err != nil {
+			return fmt.Errorf("read CIDR allow-list: %w", err)
+		}
+		cidrs, err = bastionSvc.ParseCIDRList(raw)
+		if err == nil {
+			break
+		}
+		fmt.Fprintf(appCtx.Stderr, "Invalid CIDR allow-list: %v\n", err)
+	}
+	if slices.Contains(cidrs, defaultClientCIDR) {
+		logger.Logger.Info("The allow-list permits connections from any IP address; consider restricting it to your network")
+	}
+
+	ttl, err := util.PromptInt("Enter max session TTL in seconds", bastionSvc.MaxSessionTTL, bastionSvc.MinSessionTTL, bastionSvc.MaxSessionTTL)
+	if err != nil {
+		return fmt.Errorf("read max session TTL: %w", err)
+	}
+
+	// Step 4: Create and wait for ACTIVE
+	logger.Logger.Info("Creating bastion", "name", name, "vcn", vcn.DisplayName, "subnet", subnet.DisplayName, "client_cidrs", cidrs, "max_session_ttl", ttl)
+	created, err := svc.Create(ctx, bastionSvc.CreateBastionRequest{
+		DisplayName:    name,
+		CompartmentID:  appCtx.CompartmentID,
+		TargetSubnetID: subnet.OCID,
+		BastionType:    bastionSvc.BastionTypeStandard,
+		ClientCIDRList: cidrs,
+		MaxSessionTTL:  ttl,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Logger.Info("Waiting for bastion to become ACTIVE (this usually takes a few minutes)...", "bastion_id", created.OCID)
+	active, err := svc.WaitForActive(ctx, created.OCID)
+	if err != nil {
+		return err
+	}
+
+	logger.Logger.Info("Bastion is ACTIVE", "name", active.DisplayName, "bastion_id", active.OCID)
+	return bastionSvc.PrintBastionInfo([]bastionSvc.Bastion{*active}, appCtx, false)
+}
+
+// sanitizeBastionName reduces a name to the characters OCI accepts for bastion names (alphanumerics only).
+func sanitizeBastionName(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
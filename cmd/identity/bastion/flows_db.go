@@ -102,8 +102,6 @@ func connectHeatWaveDatabase(ctx context.Context, appCtx *app.ApplicationContext
 		}
 	}
 
-	_, reason := svc.CanReach(ctx, b, db.VcnID, db.SubnetId)
-	logger.Logger.Info("Reachability to HeatWave DB cannot be automatically verified", "reason", reason)
 	logger.Logger.Info("Selected HeatWave database", "name", db.DisplayName, "id", db.ID)
 
 	// Get SSH key pair
@@ -128,6 +126,12 @@ func connectHeatWaveDatabase(ctx context.Context, appCtx *app.ApplicationContext
 		return fmt.Errorf("read port: %w", err)
 	}
 
+	target := bastionSvc.ResolvedTarget{Kind: bastionSvc.KindHeatWave, ID: db.ID, Name: db.DisplayName, IP: db.IpAddress,
+		VcnID: db.VcnID, SubnetID: db.SubnetId, NsgIDs: db.NsgIds}
+	if err := ensureReachable(ctx, appCtx, svc, b, target, port); err != nil {
+		return err
+	}
+
 	// Create a port forwarding session
 	sessID, err := svc.EnsurePortForwardSession(ctx, b.OCID, db.IpAddress, port, pubKey)
 	if err != nil {
@@ -135,12 +139,12 @@ func connectHeatWaveDatabase(ctx context.Context, appCtx *app.ApplicationContext
 	}
 
 	// Build and spawn SSH tunnel
-	sshTunnelArgs, err := bastionSvc.BuildPortForwardArgs(privKey, sessID, region, db.IpAddress, port, port)
+	forwarderCfg, err := bastionSvc.BuildPortForwardConfig(privKey, sessID, region, db.IpAddress, port, port)
 	if err != nil {
-		return fmt.Errorf("build args: %w", err)
+		return fmt.Errorf("build forwarder config: %w", err)
 	}
 
-	pid, logFile, err := bastionSvc.SpawnDetached(sshTunnelArgs, port, db.IpAddress)
+	pid, logFile, err := bastionSvc.SpawnDetached(forwarderCfg)
 	if err != nil {
 		return fmt.Errorf("spawn detached: %w", err)
 	}
@@ -148,13 +152,19 @@ func connectHeatWaveDatabase(ctx context.Context, appCtx *app.ApplicationContext
 
 	// Save tunnel state for tracking
 	tunnelInfo := bastionSvc.TunnelInfo{
-		PID:       pid,
-		LocalPort: port,
-		TargetIP:  db.IpAddress,
-		StartedAt: time.Now(),
-		LogFile:   logFile,
-	}
-	if err := bastionSvc.SaveTunnelState(tunnelInfo); err != nil {
+		PID:        pid,
+		LocalPort:  port,
+		TargetIP:   db.IpAddress,
+		StartedAt:  time.Now(),
+		LogFile:    logFile,
+		SessionID:  sessID,
+		BastionID:  b.OCID,
+		TargetID:   db.ID,
+		TargetName: db.DisplayName,
+		TargetKind: string(bastionSvc.KindHeatWave),
+		RemotePort: port,
+	}
+	if err := svc.RecordTunnel(ctx, tunnelInfo); err != nil {
 		logger.Logger.Error(err, "failed to save tunnel state")
 	}
 
@@ -208,8 +218,6 @@ func connectAutonomousDatabase(ctx context.Context, appCtx *app.ApplicationConte
 		}
 	}
 
-	_, reason := svc.CanReach(ctx, b, db.VcnID, db.SubnetId)
-	logger.Logger.Info("Reachability to Autonomous DB cannot be automatically verified", "reason", reason)
 	logger.Logger.Info("Selected Autonomous database", "name", db.Name, "id", db.ID)
 
 	// Get SSH key pair
@@ -237,6 +245,12 @@ func connectAutonomousDatabase(ctx context.Context, appCtx *app.ApplicationConte
 		return fmt.Errorf("no private endpoint IP available for database %s", db.Name)
 	}
 
+	target := bastionSvc.ResolvedTarget{Kind: bastionSvc.KindADB, ID: db.ID, Name: db.Name, IP: targetIP,
+		VcnID: db.VcnID, SubnetID: db.SubnetId, NsgIDs: db.NsgIds}
+	if err := ensureReachable(ctx, appCtx, svc, b, target, port); err != nil {
+		return err
+	}
+
 	// Create a port forwarding session
 	sessID, err := svc.EnsurePortForwardSession(ctx, b.OCID, targetIP, port, pubKey)
 	if err != nil {
@@ -244,12 +258,12 @@ func connectAutonomousDatabase(ctx context.Context, appCtx *app.ApplicationConte
 	}
 
 	// Build and spawn SSH tunnel
-	sshTunnelArgs, err := bastionSvc.BuildPortForwardArgs(privKey, sessID, region, targetIP, port, port)
+	forwarderCfg, err := bastionSvc.BuildPortForwardConfig(privKey, sessID, region, targetIP, port, port)
 	if err != nil {
-		return fmt.Errorf("build args: %w", err)
+		return fmt.Errorf("build forwarder config: %w", err)
 	}
 
-	pid, logFile, err := bastionSvc.SpawnDetached(sshTunnelArgs, port, targetIP)
+	pid, logFile, err := bastionSvc.SpawnDetached(forwarderCfg)
 	if err != nil {
 		return fmt.Errorf("spawn detached: %w", err)
 	}
@@ -257,13 +271,19 @@ func connectAutonomousDatabase(ctx context.Context, appCtx *app.ApplicationConte
 
 	// Save tunnel state for tracking
 	tunnelInfo := bastionSvc.TunnelInfo{
-		PID:       pid,
-		LocalPort: port,
-		TargetIP:  targetIP,
-		StartedAt: time.Now(),
-		LogFile:   logFile,
-	}
-	if err := bastionSvc.SaveTunnelState(tunnelInfo); err != nil {
+		PID:        pid,
+		LocalPort:  port,
+		TargetIP:   targetIP,
+		StartedAt:  time.Now(),
+		LogFile:    logFile,
+		SessionID:  sessID,
+		BastionID:  b.OCID,
+		TargetID:   db.ID,
+		TargetName: db.Name,
+		TargetKind: string(bastionSvc.KindADB),
+		RemotePort: port,
+	}
+	if err := svc.RecordTunnel(ctx, tunnelInfo); err != nil {
 		logger.Logger.Error(err, "failed to save tunnel state")
 	}
 
@@ -317,8 +337,6 @@ func connectCacheCluster(ctx context.Context, appCtx *app.ApplicationContext, sv
 		}
 	}
 
-	_, reason := svc.CanReach(ctx, b, cluster.VcnID, cluster.SubnetId)
-	logger.Logger.Info("Reachability to OCI Cache cluster cannot be automatically verified", "reason", reason)
 	logger.Logger.Info("Selected OCI Cache cluster", "name", cluster.DisplayName, "id", cluster.ID)
 
 	// Get SSH key pair
@@ -344,6 +362,12 @@ func connectCacheCluster(ctx context.Context, appCtx *app.ApplicationContext, sv
 		return fmt.Errorf("read port: %w", err)
 	}
 
+	target := bastionSvc.ResolvedTarget{Kind: bastionSvc.KindCache, ID: cluster.ID, Name: cluster.DisplayName, IP: targetIP,
+		VcnID: cluster.VcnID, SubnetID: cluster.SubnetId, NsgIDs: cluster.NsgIds}
+	if err := ensureReachable(ctx, appCtx, svc, b, target, port); err != nil {
+		return err
+	}
+
 	// Create a port forwarding session
 	sessID, err := svc.EnsurePortForwardSession(ctx, b.OCID, targetIP, port, pubKey)
 	if err != nil {
@@ -351,12 +375,12 @@ func connectCacheCluster(ctx context.Context, appCtx *app.ApplicationContext, sv
 	}
 
 	// Build and spawn SSH tunnel
-	sshTunnelArgs, err := bastionSvc.BuildPortForwardArgs(privKey, sessID, region, targetIP, port, port)
+	forwarderCfg, err := bastionSvc.BuildPortForwardConfig(privKey, sessID, region, targetIP, port, port)
 	if err != nil {
-		return fmt.Errorf("build args: %w", err)
+		return fmt.Errorf("build forwarder config: %w", err)
 	}
 
-	pid, logFile, err := bastionSvc.SpawnDetached(sshTunnelArgs, port, targetIP)
+	pid, logFile, err := bastionSvc.SpawnDetached(forwarderCfg)
 	if err != nil {
 		return fmt.Errorf("spawn detached: %w", err)
 	}
@@ -364,13 +388,19 @@ func connectCacheCluster(ctx context.Context, appCtx *app.ApplicationContext, sv
 
 	// Save tunnel state for tracking
 	tunnelInfo := bastionSvc.TunnelInfo{
-		PID:       pid,
-		LocalPort: port,
-		TargetIP:  targetIP,
-		StartedAt: time.Now(),
-		LogFile:   logFile,
-	}
-	if err := bastionSvc.SaveTunnelState(tunnelInfo); err != nil {
+		PID:        pid,
+		LocalPort:  port,
+		TargetIP:   targetIP,
+		StartedAt:  time.Now(),
+		LogFile:    logFile,
+		SessionID:  sessID,
+		BastionID:  b.OCID,
+		TargetID:   cluster.ID,
+		TargetName: cluster.DisplayName,
+		TargetKind: string(bastionSvc.KindCache),
+		RemotePort: port,
+	}
+	if err := svc.RecordTunnel(ctx, tunnelInfo); err != nil {
 		logger.Logger.Error(err, "failed to save tunnel state")
 	}
 
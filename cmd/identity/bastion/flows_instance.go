@@ -65,12 +65,7 @@ func connectInstance(ctx context.Context, appCtx *app.ApplicationContext, svc *b
 		}
 	}
 
-	if ok, reason := svc.CanReach(ctx, b, inst.VcnID, inst.SubnetID); !ok {
-		logger.Logger.Info("Bastion cannot reach selected instance", "reason", reason)
-		return nil
-	}
-
-	logger.Logger.Info("Validated session on Bastion to Instance", "session_type", sType, "bastion_name", b.DisplayName, "bastion_id", b.OCID, "instance_name", inst.DisplayName)
+	logger.Logger.Info("Preparing session on Bastion to Instance", "session_type", sType, "bastion_name", b.DisplayName, "bastion_id", b.OCID, "instance_name", inst.DisplayName)
 
 	region, regErr := appCtx.Provider.Region()
 	if regErr != nil {
@@ -79,6 +74,9 @@ func connectInstance(ctx context.Context, appCtx *app.ApplicationContext, svc *b
 
 	switch sType {
 	case TypeManagedSSH:
+		if err := ensureReachable(ctx, appCtx, svc, b, instanceTarget(inst), 22); err != nil {
+			return err
+		}
 		sshUser, err := util.PromptString("Enter SSH username", "opc")
 		if err != nil {
 			return fmt.Errorf("read ssh username: %w", err)
@@ -96,16 +94,19 @@ func connectInstance(ctx context.Context, appCtx *app.ApplicationContext, svc *b
 		if err != nil {
 			return fmt.Errorf("read port: %w", err)
 		}
+		if err := ensureReachable(ctx, appCtx, svc, b, instanceTarget(inst), port); err != nil {
+			return err
+		}
 		sessID, err := svc.EnsurePortForwardSession(ctx, b.OCID, inst.PrimaryIP, port, pubKey)
 		if err != nil {
 			return fmt.Errorf("ensure port forward: %w", err)
 		}
-		sshTunnelArgs, err := bastionSvc.BuildPortForwardArgs(privKey, sessID, region, inst.PrimaryIP, port, port)
+		forwarderCfg, err := bastionSvc.BuildPortForwardConfig(privKey, sessID, region, inst.PrimaryIP, port, port)
 		if err != nil {
-			return fmt.Errorf("build args: %w", err)
+			return fmt.Errorf("build forwarder config: %w", err)
 		}
 
-		pid, logFile, err := bastionSvc.SpawnDetached(sshTunnelArgs, port, inst.PrimaryIP)
+		pid, logFile, err := bastionSvc.SpawnDetached(forwarderCfg)
 
 		if err != nil {
 			return fmt.Errorf("spawn detached: %w", err)
@@ -114,13 +115,19 @@ func connectInstance(ctx context.Context, appCtx *app.ApplicationContext, svc *b
 
 		// Save tunnel state for tracking
 		tunnelInfo := bastionSvc.TunnelInfo{
-			PID:       pid,
-			LocalPort: port,
-			TargetIP:  inst.PrimaryIP,
-			StartedAt: time.Now(),
-			LogFile:   logFile,
+			PID:        pid,
+			LocalPort:  port,
+			TargetIP:   inst.PrimaryIP,
+			StartedAt:  time.Now(),
+			LogFile:    logFile,
+			SessionID:  sessID,
+			BastionID:  b.OCID,
+			TargetID:   inst.OCID,
+			TargetName: inst.DisplayName,
+			TargetKind: string(bastionSvc.KindInstance),
+			RemotePort: port,
 		}
-		if err := bastionSvc.SaveTunnelState(tunnelInfo); err != nil {
+		if err := svc.RecordTunnel(ctx, tunnelInfo); err != nil {
 			logger.Logger.Error(err, "failed to save tunnel state")
 		}
 
@@ -82,14 +82,7 @@ func connectLoadBalancer(ctx context.Context, appCtx *app.ApplicationContext, sv
 		}
 	}
 
-	// Check bastion reachability to load balancer's VCN
-	subnetID := ""
-	if len(lb.Subnets) > 0 {
-		subnetID = lb.Subnets[0]
-	}
-	_, reason := svc.CanReach(ctx, b, lb.VcnID, subnetID)
-	logger.Logger.Info("Reachability to Load Balancer cannot be automatically verified", "reason", reason)
-	logger.Logger.Info("Validated session on Bastion to Load Balancer",
+	logger.Logger.Info("Preparing session on Bastion to Load Balancer",
 		"session_type", sType,
 		"bastion_name", b.DisplayName,
 		"bastion_id", b.OCID,
@@ -115,13 +108,27 @@ func connectLoadBalancer(ctx context.Context, appCtx *app.ApplicationContext, sv
 	if len(lb.IPAddresses) == 0 {
 		return fmt.Errorf("no IP addresses found for load balancer %s", lb.Name)
 	}
-	targetIP := extractIPAddress(lb.IPAddresses[0])
+	targetIP := bastionSvc.ExtractIPAddress(lb.IPAddresses[0])
 
 	// The LB target port (what the LB is listening to on) - typically 443
 	lbTargetPort := 443
 
-	// The default local port is 8443 to avoid sudo requirement
-	// User can choose 443 if they want to match the LB port (requires sudo)
+	// Check bastion reachability to the load balancer's first subnet; NSGs still holding an OCID can be evaluated
+	target := bastionSvc.ResolvedTarget{Kind: bastionSvc.KindLB, ID: lb.OCID, Name: lb.Name, IP: targetIP, VcnID: lb.VcnID}
+	if len(lb.Subnets) > 0 {
+		target.SubnetID = lb.Subnets[0]
+	}
+	for _, nsg := range lb.NSGs {
+		if strings.HasPrefix(nsg, "ocid1.networksecuritygroup.") {
+			target.NsgIDs = append(target.NsgIDs, nsg)
+		}
+	}
+	if err := ensureReachable(ctx, appCtx, svc, b, target, lbTargetPort); err != nil {
+		return err
+	}
+
+	// The default local port is 8443 to avoid needing privileges
+	// User can choose 443 if they want to match the LB port (requires privileges to bind)
 	defaultLocalPort := 8443
 
 	// Prompt for local port
@@ -135,19 +142,11 @@ func connectLoadBalancer(ctx context.Context, appCtx *app.ApplicationContext, sv
 		return fmt.Errorf("local port %d is already in use on 127.0.0.1; choose another port", localPort)
 	}
 
-	var sudoPassword string
-	// For privileged ports, prompt for sudo password and validate
+	// The forwarder runs as the current user, so a privileged port must be bindable without sudo
 	if localPort < 1024 {
-		logger.Logger.Info("Validating sudo access for privileged port...")
-		var err error
-		sudoPassword, err = util.PromptPassword("Password")
-		if err != nil {
-			return fmt.Errorf("read password: %w", err)
-		}
-		if err := bastionSvc.ValidateSudoPassword(sudoPassword); err != nil {
-			return fmt.Errorf("sudo validation failed: %w", err)
+		if err := util.CheckLocalTCPPortBindable(localPort); err != nil {
+			return fmt.Errorf("%w; run ocloud with privileges to bind low ports or choose a port >= 1024", err)
 		}
-		logger.Logger.Info("Sudo access validated successfully")
 	}
 
 	// Create a port forwarding session to the LB's target port
@@ -161,10 +160,10 @@ func connectLoadBalancer(ctx context.Context, appCtx *app.ApplicationContext, sv
 		return fmt.Errorf("ensure port forward: %w", err)
 	}
 
-	// Build SSH tunnel arguments: localPort -> targetIP:lbTargetPort
-	sshTunnelArgs, err := bastionSvc.BuildPortForwardArgs(privKey, sessID, region, targetIP, localPort, lbTargetPort)
+	// Build the forwarder configuration: localPort -> targetIP:lbTargetPort
+	forwarderCfg, err := bastionSvc.BuildPortForwardConfig(privKey, sessID, region, targetIP, localPort, lbTargetPort)
 	if err != nil {
-		return fmt.Errorf("build args: %w", err)
+		return fmt.Errorf("build forwarder config: %w", err)
 	}
 
 	logger.Logger.Info("Starting SSH tunnel",
@@ -172,19 +171,8 @@ func connectLoadBalancer(ctx context.Context, appCtx *app.ApplicationContext, sv
 		"target", fmt.Sprintf("%s:%d", targetIP, lbTargetPort),
 		"lb_name", lb.Name)
 
-	var pid int
-	var (
-		logFile string
-	)
-
-	if localPort < 1024 {
-		// Run with sudo in the background
-		pid, logFile, err = bastionSvc.SpawnDetachedWithSudo(sshTunnelArgs, localPort, targetIP, sudoPassword)
-	} else {
-		// Run normally in the background
-		pid, logFile, err = bastionSvc.SpawnDetached(sshTunnelArgs, localPort, targetIP)
-	}
-
+	// Run in the background
+	pid, logFile, err := bastionSvc.SpawnDetached(forwarderCfg)
 	if err != nil {
 		return fmt.Errorf("spawn detached: %w", err)
 	}
@@ -192,13 +180,19 @@ func connectLoadBalancer(ctx context.Context, appCtx *app.ApplicationContext, sv
 
 	// Save tunnel state for tracking
 	tunnelInfo := bastionSvc.TunnelInfo{
-		PID:       pid,
-		LocalPort: localPort,
-		TargetIP:  targetIP,
-		StartedAt: time.Now(),
-		LogFile:   logFile,
-	}
-	if err := bastionSvc.SaveTunnelState(tunnelInfo); err != nil {
+		PID:        pid,
+		LocalPort:  localPort,
+		TargetIP:   targetIP,
+		StartedAt:  time.Now(),
+		LogFile:    logFile,
+		SessionID:  sessID,
+		BastionID:  b.OCID,
+		TargetID:   lb.OCID,
+		TargetName: lb.Name,
+		TargetKind: string(bastionSvc.KindLB),
+		RemotePort: lbTargetPort,
+	}
+	if err := svc.RecordTunnel(ctx, tunnelInfo); err != nil {
 		logger.Logger.Error(err, "failed to save tunnel state")
 	}
 
@@ -217,12 +211,11 @@ func connectLoadBalancer(ctx context.Context, appCtx *app.ApplicationContext, sv
 	return nil
 }
 
-// promptPortWithPrivilegedWarning prompts for a port and warns about sudo requirement for privileged ports.
+// promptPortWithPrivilegedWarning prompts for a port and warns that privileged ports need elevated privileges.
 func promptPortWithPrivilegedWarning(question string, defaultPort int) (int, error) {
 	// First, warn if the default port is privileged
 	if defaultPort < 1024 {
-		logger.Logger.Info("Note: Ports below 1024 require sudo/root privileges")
-		logger.Logger.Info(`You will be prompted for your password when the tunnel is created`)
+		logger.Logger.Info("Note: Ports below 1024 may require root privileges on this system")
 	}
 
 	port, err := util.PromptPort(question, defaultPort)
@@ -232,17 +225,8 @@ func promptPortWithPrivilegedWarning(question string, defaultPort int) (int, err
 
 	// Warn if the chosen port is privileged
 	if port < 1024 {
-		logger.Logger.Info("Port requires sudo/root privileges - you may be prompted for your password", "port", port)
+		logger.Logger.Info("Port may require root privileges to bind", "port", port)
 	}
 
 	return port, nil
 }
-
-// extractIPAddress extracts just the IP address from a string that may contain
-// a suffix like "(private)" or "(public)" added by the mapping layer.
-func extractIPAddress(ipWithSuffix string) string {
-	if idx := strings.Index(ipWithSuffix, " "); idx > 0 {
-		return ipWithSuffix[:idx]
-	}
-	return ipWithSuffix
-}
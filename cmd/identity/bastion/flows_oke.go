@@ -57,12 +57,7 @@ func connectOKE(ctx context.Context, appCtx *app.ApplicationContext, svc *bastio
 		}
 	}
 
-	if ok, reason := svc.CanReach(ctx, b, cluster.VcnOCID, ""); !ok {
-		logger.Logger.Info("Bastion cannot reach selected OKE cluster", "reason", reason)
-		return nil
-	}
-
-	logger.Logger.Info("Validated session on Bastion to OKE cluster", "session_type", sType, "bastion_name", b.DisplayName, "bastion_id", b.OCID, "cluster_name", cluster.DisplayName)
+	logger.Logger.Info("Preparing session on Bastion to OKE cluster", "session_type", sType, "bastion_name", b.DisplayName, "bastion_id", b.OCID, "cluster_name", cluster.DisplayName)
 
 	switch sType {
 	case TypeManagedSSH:
@@ -115,9 +110,8 @@ func connectOKE(ctx context.Context, appCtx *app.ApplicationContext, svc *bastio
 			return err
 		}
 
-		if ok, reason := svc.CanReach(ctx, b, inst.VcnID, inst.SubnetID); !ok {
-			logger.Logger.Info("Bastion cannot reach selected instance", "reason", reason)
-			return nil
+		if err := ensureReachable(ctx, appCtx, svc, b, instanceTarget(inst), 22); err != nil {
+			return err
 		}
 
 		region, regErr := appCtx.Provider.Region()
@@ -170,6 +164,10 @@ func connectOKE(ctx context.Context, appCtx *app.ApplicationContext, svc *bastio
 		}
 
 		okeTargetPort := 6443
+		target := bastionSvc.ResolvedTarget{Kind: bastionSvc.KindOKE, ID: cluster.OCID, Name: cluster.DisplayName, IP: targetIP, VcnID: cluster.VcnOCID}
+		if err := ensureReachable(ctx, appCtx, svc, b, target, okeTargetPort); err != nil {
+			return err
+		}
 		sessID, err := svc.EnsurePortForwardSession(ctx, b.OCID, targetIP, okeTargetPort, pubKey)
 		if err != nil {
 			return fmt.Errorf("ensure port forward: %w", err)
@@ -205,13 +203,13 @@ func connectOKE(ctx context.Context, appCtx *app.ApplicationContext, svc *bastio
 		}
 
 		localPort := port
-		sshTunnelArgs, err := bastionSvc.BuildPortForwardArgs(privKey, sessID, region, targetIP, localPort, okeTargetPort)
+		forwarderCfg, err := bastionSvc.BuildPortForwardConfig(privKey, sessID, region, targetIP, localPort, okeTargetPort)
 
 		if err != nil {
-			return fmt.Errorf("build args: %w", err)
+			return fmt.Errorf("build forwarder config: %w", err)
 		}
 
-		pid, logFile, err := bastionSvc.SpawnDetached(sshTunnelArgs, localPort, targetIP)
+		pid, logFile, err := bastionSvc.SpawnDetached(forwarderCfg)
 		if err != nil {
 			return fmt.Errorf("spawn detached: %w", err)
 		}
@@ -219,13 +217,19 @@ func connectOKE(ctx context.Context, appCtx *app.ApplicationContext, svc *bastio
 
 		// Save tunnel state for tracking
 		tunnelInfo := bastionSvc.TunnelInfo{
-			PID:       pid,
-			LocalPort: localPort,
-			TargetIP:  targetIP,
-			StartedAt: time.Now(),
-			LogFile:   logFile,
-		}
-		if err := bastionSvc.SaveTunnelState(tunnelInfo); err != nil {
+			PID:        pid,
+			LocalPort:  localPort,
+			TargetIP:   targetIP,
+			StartedAt:  time.Now(),
+			LogFile:    logFile,
+			SessionID:  sessID,
+			BastionID:  b.OCID,
+			TargetID:   cluster.OCID,
+			TargetName: cluster.DisplayName,
+			TargetKind: string(bastionSvc.KindOKE),
+			RemotePort: okeTargetPort,
+		}
+		if err := svc.RecordTunnel(ctx, tunnelInfo); err != nil {
 			logger.Logger.Error(err, "failed to save tunnel state")
 		}
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rozdolsky33/ocloud/internal/app"
@@ -20,10 +21,10 @@ import (
 // connectSCP runs the SCP flow for an Instance target:
 // 1. Select instance
 // 2. Select SSH key pair
-// 3. Select local file to upload (file picker TUI)
+// 3. Select local files and directories to upload (multi-select file picker TUI)
 // 4. Prompt for remote destination path
 // 5. Create managed SSH session
-// 6. Execute SCP with progress
+// 6. Upload over SSH with aggregate progress, resuming partial files and verifying sha256
 func connectSCP(ctx context.Context, appCtx *app.ApplicationContext, svc *bastionSvc.Service,
 	b bastionSvc.Bastion) error {
 
@@ -75,31 +76,48 @@ func connectSCP(ctx context.Context, appCtx *app.ApplicationContext, svc *bastio
 	}
 
 	// Validate bastion can reach instance
-	if ok, reason := svc.CanReach(ctx, b, inst.VcnID, inst.SubnetID); !ok {
-		logger.Logger.Info("Bastion cannot reach selected instance", "reason", reason)
-		return nil
+	if err := ensureReachable(ctx, appCtx, svc, b, instanceTarget(inst), 22); err != nil {
+		return err
 	}
 
-	// Step 3: Select local file to upload using the file picker TUI
+	// Step 3: Select local files and directories to upload using the file picker TUI
 	cwd, err := os.Getwd()
 	if err != nil {
 		cwd = "."
 	}
-	fileModel, err := tui.NewFilePickerModel(cwd)
+	fileModel, err := tui.NewMultiFilePickerModel(cwd)
 	if err != nil {
 		return fmt.Errorf("creating file picker: %w", err)
 	}
 
-	localFile, err := tui.RunFilePicker(fileModel)
+	sources, err := tui.RunMultiFilePicker(fileModel)
 	if err != nil {
 		return ErrAborted
 	}
 
-	// Step 4: Prompt for remote destination path
-	remotePath, err := util.PromptString("Enter remote destination path", "/tmp/"+filepath.Base(localFile))
+	// Step 4: Prompt for remote destination path; a single file may be renamed, anything else goes into a directory
+	toDir := true
+	prompt, defaultRemote := "Enter remote destination directory", "/tmp/"
+	if info, statErr := os.Stat(sources[0]); len(sources) == 1 && statErr == nil && !info.IsDir() {
+		toDir = false
+		prompt, defaultRemote = "Enter remote destination path", "/tmp/"+filepath.Base(sources[0])
+	}
+	remotePath, err := util.PromptString(prompt, defaultRemote)
 	if err != nil {
 		return fmt.Errorf("read remote path: %w", err)
 	}
+	if toDir {
+		remotePath = strings.TrimSuffix(remotePath, "/") + "/"
+	}
+
+	files, err := bastionSvc.PlanUpload(sources, remotePath)
+	if err != nil {
+		return err
+	}
+	var totalBytes int64
+	for _, f := range files {
+		totalBytes += f.Size
+	}
 
 	// Step 5: Prompt for SSH username
 	sshUser, err := util.PromptString("Enter SSH username", "opc")
@@ -110,11 +128,12 @@ func connectSCP(ctx context.Context, appCtx *app.ApplicationContext, svc *bastio
 	logger.Logger.Info("Creating SCP session",
 		"bastion", b.DisplayName,
 		"instance", inst.DisplayName,
-		"file", filepath.Base(localFile),
+		"files", len(files),
+		"bytes", totalBytes,
 		"remote_path", remotePath,
 	)
 
-	// Step 6: Create managed SSH session (SCP uses managed SSH under the hood)
+	// Step 6: Create managed SSH session (the upload uses managed SSH under the hood)
 	sessID, err := svc.EnsureManagedSSHSession(ctx, b.OCID, inst.OCID, inst.PrimaryIP, sshUser, 22, pubKey, 0)
 	if err != nil {
 		return fmt.Errorf("ensure managed SSH session for SCP: %w", err)
@@ -125,52 +144,78 @@ func connectSCP(ctx context.Context, appCtx *app.ApplicationContext, svc *bastio
 		return fmt.Errorf("get region: %w", regErr)
 	}
 
-	// Step 7: Build and execute SCP command with progress TUI
-	scpCmd := bastionSvc.BuildSCPCommand(privKey, sessID, region, inst.PrimaryIP, sshUser, localFile, remotePath)
+	// Step 7: Upload with an aggregate progress TUI
+	uploader, err := bastionSvc.NewUploader(bastionSvc.TransferTarget{
+		PrivateKeyPath: privKey,
+		SessionID:      sessID,
+		Region:         region,
+		TargetIP:       inst.PrimaryIP,
+		TargetUser:     sshUser,
+	})
+	if err != nil {
+		return err
+	}
+	defer uploader.Close()
 
-	title := fmt.Sprintf("Uploading %s to %s:%s", filepath.Base(localFile), inst.DisplayName, remotePath)
+	title := fmt.Sprintf("Uploading %d file(s) (%s) to %s:%s", len(files), util.HumanizeBytesIEC(totalBytes), inst.DisplayName, remotePath)
 	progressRunner := tui.NewProgressRunner(title)
 	progressRunner.Start()
 
-	done := make(chan error, 1)
+	type uploadOutcome struct {
+		results []bastionSvc.UploadResult
+		err     error
+	}
+	done := make(chan uploadOutcome, 1)
 
+	uploadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	go func() {
-		fileInfo, statErr := os.Stat(localFile)
-		if statErr != nil {
-			progressRunner.SendError(statErr)
-			done <- statErr
-			return
-		}
-
-		totalBytes := fileInfo.Size()
-		bytesInfo := fmt.Sprintf("0 B / %s", util.HumanizeBytesIEC(totalBytes))
-		progressRunner.UpdateProgress(0, bytesInfo, "", "Connecting via bastion...")
-
-		progressRunner.UpdateProgress(0.05, bytesInfo, "", "SCP transfer in progress...")
-
-		err := bastionSvc.RunShell(ctx, appCtx.Stdout, appCtx.Stderr, scpCmd)
+		results, err := uploader.Upload(uploadCtx, files, func(p bastionSvc.UploadProgress) {
+			progressRunner.UpdateTransfer(tui.TransferStats{
+				FileIndex:  p.FileIndex,
+				FileCount:  p.FileCount,
+				File:       p.File,
+				BytesDone:  p.BytesDone,
+				BytesTotal: p.BytesTotal,
+				Status:     p.Status,
+			})
+		})
 		if err != nil {
 			progressRunner.SendError(err)
-			done <- err
-			return
+		} else {
+			progressRunner.SendDone()
 		}
-
-		bytesInfo = fmt.Sprintf("%s / %s", util.HumanizeBytesIEC(totalBytes), util.HumanizeBytesIEC(totalBytes))
-		progressRunner.UpdateProgress(1.0, bytesInfo, "", "Complete!")
-		done <- nil
+		done <- uploadOutcome{results: results, err: err}
 	}()
 
-	if err := progressRunner.Run(); err != nil {
-		return err
+	// The TUI also exits when the user quits it; stop the upload then and wait for it to report how far it got.
+	runErr := progressRunner.Run()
+	cancel()
+	outcome := <-done
+	for _, r := range outcome.results {
+		logger.Logger.V(logger.Debug).Info("Verified upload", "file", r.LocalPath, "remote_path", r.RemotePath,
+			"sha256", r.SHA256, "resumed_at", r.ResumedAt, "skipped", r.Skipped)
 	}
-
-	uploadErr := <-done
-	if uploadErr != nil {
-		return fmt.Errorf("SCP transfer: %w", uploadErr)
+	if outcome.err != nil {
+		return fmt.Errorf("SCP transfer (%d of %d files completed; run again to resume): %w", len(outcome.results), len(files), outcome.err)
+	}
+	if runErr != nil {
+		return fmt.Errorf("upload progress TUI: %w", runErr)
 	}
 
-	logger.Logger.Info("Successfully copied file to remote instance",
-		"file", filepath.Base(localFile),
+	var resumed, skipped int
+	for _, r := range outcome.results {
+		if r.Skipped {
+			skipped++
+		} else if r.ResumedAt > 0 {
+			resumed++
+		}
+	}
+	logger.Logger.Info("Successfully copied files to remote instance",
+		"files", len(outcome.results),
+		"resumed", resumed,
+		"skipped", skipped,
+		"bytes", totalBytes,
 		"instance", inst.DisplayName,
 		"remote_path", remotePath,
 	)
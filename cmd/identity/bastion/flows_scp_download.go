@@ -75,9 +75,8 @@ func connectSCPDownload(ctx context.Context, appCtx *app.ApplicationContext, svc
 	}
 
 	// Validate bastion can reach instance
-	if ok, reason := svc.CanReach(ctx, b, inst.VcnID, inst.SubnetID); !ok {
-		logger.Logger.Info("Bastion cannot reach selected instance", "reason", reason)
-		return nil
+	if err := ensureReachable(ctx, appCtx, svc, b, instanceTarget(inst), 22); err != nil {
+		return err
 	}
 
 	// Step 3: Enter remote file/directory path to download (TUI text input)
@@ -0,0 +1,22 @@
+package bastion
+
+import (
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/spf13/cobra"
+)
+
+// NewForwardCmd returns the hidden command that runs a detached port forwarder.
+// It is started by the tunnel commands in a background process and needs no OCI context.
+func NewForwardCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:           bastionSvc.ForwarderCommand,
+		Short:         "Run a bastion port forwarder (internal)",
+		Hidden:        true,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return bastionSvc.ServeForwarder(cmd.Context())
+		},
+	}
+}
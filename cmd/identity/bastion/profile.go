@@ -0,0 +1,353 @@
+package bastion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	bastionFlags "github.com/rozdolsky33/ocloud/cmd/identity/bastion/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/printer"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/spf13/cobra"
+)
+
+// Per-tunnel outcomes reported by "bastion up" and "bastion down".
+const (
+	ProfileStatusUp        = "up"
+	ProfileStatusAlreadyUp = "already-up"
+	ProfileStatusStopped   = "stopped"
+	ProfileStatusFailed    = "failed"
+	ProfileStatusNotReady  = "not-ready"
+)
+
+var upLong = `
+Bring up every tunnel defined in a tunnel profile.
+
+Profiles are YAML files in ~/.oci/.ocloud/tunnel-profiles/<profile>.yaml (a path to a .yaml file also works):
+
+  bastion: prodbastion
+  key: ~/.ssh/id_ed25519        # optional, defaults to ~/.ssh/id_rsa
+  tunnels:
+    - target: adb:salesdb
+      local_port: 1521
+    - target: heatwave:reporting
+      local_port: 3306
+    - target: oke:prod-cluster
+      local_port: 6443
+    - target: cache:sessions
+      local_port: 6379
+      bastion: cachebastion     # optional per-tunnel bastion
+
+Targets use the same <kind>:<name|ocid> form as "bastion session create". remote_port defaults to the
+target's standard port and local_port to the remote port. Tunnels are started in parallel; a target that
+cannot be resolved or reached is reported as failed without affecting the others. Tunnels from the profile
+that are already running are left alone, so "up" can be run again to restore missing tunnels.
+`
+
+var upExamples = `
+  # Start the morning tunnels
+  ocloud identity bastion up dev
+
+  # Use a profile file outside the profiles directory and print the result as JSON
+  ocloud identity bastion up ./tunnels.yaml --json
+
+  # Tear them down again
+  ocloud identity bastion down dev
+`
+
+// ProfileTunnelResult is the outcome of bringing up or tearing down one tunnel of a profile.
+type ProfileTunnelResult struct {
+	Target    string `json:"target"`
+	LocalPort int    `json:"local_port,omitempty"`
+	Status    string `json:"status"`
+	PID       int    `json:"pid,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewUpCmd returns "bastion up".
+func NewUpCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "up <profile>",
+		Short:         "Start all port-forward tunnels of a tunnel profile",
+		Long:          upLong,
+		Example:       upExamples,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpCommand(cmd, args[0], appCtx)
+		},
+	}
+	bastionFlags.SkipReachabilityFlag.Add(cmd)
+	return cmd
+}
+
+// NewDownCmd returns "bastion down".
+func NewDownCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	return &cobra.Command{
+		Use:           "down <profile>",
+		Short:         "Stop the tunnels started from a tunnel profile",
+		Long:          "Stop every running tunnel that was started with \"bastion up <profile>\". Other tunnels are not touched.",
+		Example:       "  ocloud identity bastion down dev",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDownCommand(cmd, args[0], appCtx)
+		},
+	}
+}
+
+// loadProfile loads a tunnel profile and lists the available profiles when it does not exist.
+func loadProfile(name string) (*bastionSvc.TunnelProfile, error) {
+	profile, err := bastionSvc.LoadTunnelProfile(name)
+	if errors.Is(err, bastionSvc.ErrProfileNotFound) {
+		if names, listErr := bastionSvc.ListTunnelProfiles(); listErr == nil && len(names) > 0 {
+			return nil, fmt.Errorf("%w; available profiles: %s", err, strings.Join(names, ", "))
+		}
+	}
+	return profile, err
+}
+
+// runUpCommand starts the tunnels of a profile in parallel and reports the status of each.
+func runUpCommand(cmd *cobra.Command, name string, appCtx *app.ApplicationContext) error {
+	ctx := cmd.Context()
+	skipReach := flags.GetBoolFlag(cmd, flags.FlagNameSkipReach, false)
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion up command", "profile", name)
+
+	profile, err := loadProfile(name)
+	if err != nil {
+		return err
+	}
+	key := profile.Key
+	if key == "" {
+		key = bastionFlags.SSHKeyFlag.Default
+	}
+	privateKey, err := bastionSvc.ExpandTilde(key)
+	if err != nil {
+		return fmt.Errorf("expand key path: %w", err)
+	}
+	for _, p := range []string{privateKey, privateKey + ".pub"} {
+		if _, err := os.Stat(p); err != nil {
+			return fmt.Errorf("ssh key: %w", err)
+		}
+	}
+
+	svc, err := bastionSvc.NewServiceFromAppContext(appCtx)
+	if err != nil {
+		return fmt.Errorf("create bastion service: %w", err)
+	}
+	region, err := appCtx.Provider.Region()
+	if err != nil {
+		return fmt.Errorf("get region: %w", err)
+	}
+
+	// Resolve each bastion once; tunnels on a bastion that cannot be resolved fail individually.
+	bastions := make(map[string]*bastionSvc.Bastion)
+	bastionErrs := make(map[string]error)
+	for _, t := range profile.Tunnels {
+		bName := t.BastionName(profile)
+		if _, ok := bastions[bName]; ok || bastionErrs[bName] != nil {
+			continue
+		}
+		if b, err := svc.Resolve(ctx, bName); err != nil {
+			bastionErrs[bName] = err
+		} else {
+			bastions[bName] = b
+		}
+	}
+
+	// Resolve every target first: a local port defaults to the target's port, so conflicts only show afterwards.
+	results := make([]ProfileTunnelResult, len(profile.Tunnels))
+	targets := make([]*bastionSvc.ResolvedTarget, len(profile.Tunnels))
+	var wg sync.WaitGroup
+	for i, t := range profile.Tunnels {
+		wg.Add(1)
+		go func(i int, t bastionSvc.ProfileTunnel) {
+			defer wg.Done()
+			results[i] = ProfileTunnelResult{Target: t.Target, LocalPort: t.LocalPort, Status: ProfileStatusFailed}
+			if err := bastionErrs[t.BastionName(profile)]; err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			target, err := resolveProfileTarget(ctx, appCtx, t.Target)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			targets[i] = target
+		}(i, t)
+	}
+	wg.Wait()
+
+	usedBy := make(map[int]string)
+	for i, t := range profile.Tunnels {
+		if targets[i] == nil {
+			continue
+		}
+		local, _ := t.Ports(*targets[i])
+		results[i].LocalPort = local
+		if other, ok := usedBy[local]; ok {
+			results[i].Error = fmt.Sprintf("tunnels %s and %s both use local port %d", other, t.Target, local)
+			targets[i] = nil
+			continue
+		}
+		usedBy[local] = t.Target
+	}
+
+	for i, t := range profile.Tunnels {
+		if targets[i] == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, t bastionSvc.ProfileTunnel) {
+			defer wg.Done()
+			bName := t.BastionName(profile)
+			local, remote := t.Ports(*targets[i])
+			opts := sessionCreateOptions{
+				Bastion:    bName,
+				Type:       TypePortForwarding,
+				LocalPort:  local,
+				RemotePort: remote,
+				PrivateKey: privateKey,
+				PublicKey:  privateKey + ".pub",
+				SkipReach:  skipReach,
+				Profile:    profile.Name,
+			}
+			results[i] = upProfileTunnel(ctx, svc, *bastions[bName], t, *targets[i], opts, region)
+		}(i, t)
+	}
+	wg.Wait()
+
+	if err := printProfileResults(appCtx, "Tunnel Profile "+profile.Name, results, useJSON); err != nil {
+		return err
+	}
+	failed := 0
+	for _, r := range results {
+		if r.Status == ProfileStatusFailed {
+			failed++
+		}
+	}
+	if failed == len(results) {
+		return fmt.Errorf("no tunnel of profile %s could be started", profile.Name)
+	}
+	if failed > 0 {
+		logger.Logger.Info("Some tunnels of the profile failed to start", "profile", profile.Name, "failed", failed, "total", len(results))
+	}
+	return nil
+}
+
+// resolveProfileTarget resolves the "<kind>:<name|ocid>" target of a profile tunnel.
+func resolveProfileTarget(ctx context.Context, appCtx *app.ApplicationContext, spec string) (*bastionSvc.ResolvedTarget, error) {
+	kind, name, err := bastionSvc.ParseTargetSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	return bastionSvc.ResolveTarget(ctx, appCtx, kind, name)
+}
+
+// upProfileTunnel checks and starts one tunnel of a profile to its resolved target, on the ports in opts.
+func upProfileTunnel(ctx context.Context, svc *bastionSvc.Service, b bastionSvc.Bastion,
+	t bastionSvc.ProfileTunnel, target bastionSvc.ResolvedTarget, opts sessionCreateOptions, region string) ProfileTunnelResult {
+
+	result := ProfileTunnelResult{Target: t.Target, LocalPort: opts.LocalPort, Status: ProfileStatusFailed}
+	fail := func(err error) ProfileTunnelResult {
+		result.Error = err.Error()
+		return result
+	}
+
+	if existing, err := bastionSvc.FindTunnel(opts.LocalPort); err == nil {
+		if existing.TargetID == target.ID && existing.RemotePort == opts.RemotePort {
+			result.Status = ProfileStatusAlreadyUp
+			result.PID = existing.PID
+			result.SessionID = existing.SessionID
+			return result
+		}
+		return fail(fmt.Errorf("local port %d is used by the tunnel to %s", opts.LocalPort, existing.Target()))
+	}
+
+	report := svc.AnalyzeReachability(ctx, b, target.ReachabilityTarget(opts.RemotePort, ""))
+	if !report.Reachable && !opts.SkipReach {
+		return fail(&bastionSvc.ResolveError{Kind: target.Kind, Name: target.Name, Err: bastionSvc.ErrTargetUnreachable, Reason: report.FailureReason()})
+	}
+
+	session, err := createPortForwardSession(ctx, svc, b, target, opts, region)
+	if err != nil {
+		return fail(err)
+	}
+	result.Status = ProfileStatusUp
+	if !session.Ready {
+		result.Status = ProfileStatusNotReady
+	}
+	result.PID = session.PID
+	result.SessionID = session.SessionID
+	return result
+}
+
+// runDownCommand stops every running tunnel that was started from the profile.
+func runDownCommand(cmd *cobra.Command, name string, appCtx *app.ApplicationContext) error {
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion down command", "profile", name)
+
+	profile, err := loadProfile(name)
+	if err != nil {
+		return err
+	}
+	tunnels, err := bastionSvc.ProfileTunnels(profile.Name)
+	if err != nil {
+		return err
+	}
+	if len(tunnels) == 0 && !useJSON {
+		fmt.Fprintf(appCtx.Stdout, "No tunnels from profile %s are running.\n", profile.Name)
+		return nil
+	}
+
+	results := make([]ProfileTunnelResult, 0, len(tunnels))
+	var failed int
+	for _, t := range tunnels {
+		r := ProfileTunnelResult{Target: t.Target(), LocalPort: t.LocalPort, Status: ProfileStatusStopped, PID: t.PID, SessionID: t.SessionID}
+		if err := bastionSvc.StopTunnel(t); err != nil {
+			r.Status, r.Error = ProfileStatusFailed, err.Error()
+			failed++
+		}
+		results = append(results, r)
+	}
+	if err := printProfileResults(appCtx, "Tunnel Profile "+profile.Name, results, useJSON); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tunnels of profile %s could not be stopped", failed, len(results), profile.Name)
+	}
+	return nil
+}
+
+// printProfileResults prints the per-tunnel status of "bastion up" or "bastion down".
+func printProfileResults(appCtx *app.ApplicationContext, title string, results []ProfileTunnelResult, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(results)
+	}
+	headers := []string{"Target", "Local Port", "Status", "Detail"}
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		port, detail := "-", r.Error
+		if r.LocalPort != 0 {
+			port = strconv.Itoa(r.LocalPort)
+		}
+		if detail == "" && r.PID != 0 {
+			detail = "pid " + strconv.Itoa(r.PID)
+		}
+		rows = append(rows, []string{r.Target, port, r.Status, detail})
+	}
+	p.PrintTable(title, headers, rows)
+	return nil
+}
@@ -0,0 +1,35 @@
+package bastion
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpDownCommands(t *testing.T) {
+	up := NewUpCmd(&app.ApplicationContext{})
+	assert.Equal(t, "up <profile>", up.Use)
+	assert.NotNil(t, up.Flags().Lookup("skip-reachability"))
+	assert.Error(t, up.Args(up, nil), "a profile name is required")
+
+	down := NewDownCmd(&app.ApplicationContext{})
+	assert.Equal(t, "down <profile>", down.Use)
+	assert.NoError(t, down.Args(down, []string{"dev"}))
+}
+
+func TestPrintProfileResults_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	results := []ProfileTunnelResult{
+		{Target: "adb:salesdb", LocalPort: 1521, Status: ProfileStatusUp, PID: 42},
+		{Target: "cache:sessions", Status: ProfileStatusFailed, Error: "cache \"sessions\": target not found"},
+	}
+	require.NoError(t, printProfileResults(&app.ApplicationContext{Stdout: &buf}, "Tunnel Profile dev", results, true))
+
+	var got []ProfileTunnelResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, results, got)
+}
@@ -11,12 +11,19 @@ func NewBastionCmd(appCtx *app.ApplicationContext) *cobra.Command {
 		Use:           "bastion",
 		Aliases:       []string{"b"},
 		Short:         "Manage OCI Bastion",
-		Long:          "Manage Oracle Cloud Infrastructure Bastions: list existing bastions or create bastion and sessions connection.",
-		Example:       "  ocloud identity bastion list\n  ocloud identity bastion create",
+		Long:          "Manage Oracle Cloud Infrastructure Bastions: list existing bastions, create or delete bastions, and create session connections interactively or from flags, manage background tunnels, bring up tunnel profiles, generate an ssh config for managed SSH sessions, and diagnose why a bastion cannot reach a target.",
+		Example:       "  ocloud identity bastion get\n  ocloud identity bastion create\n  ocloud identity bastion delete <name|ocid>\n  ocloud identity bastion session create --bastion <name> --target instance:<name>\n  ocloud identity bastion tunnel list\n  ocloud identity bastion ssh-config\n  ocloud identity bastion diagnose --bastion <name> --target instance:<name>\n  ocloud identity bastion up <profile>",
 		SilenceUsage:  true,
 		SilenceErrors: true,
 	}
 	cmd.AddCommand(NewGetCmd(appCtx))
 	cmd.AddCommand(NewCreateCmd(appCtx))
+	cmd.AddCommand(NewDeleteCmd(appCtx))
+	cmd.AddCommand(NewSessionCmd(appCtx))
+	cmd.AddCommand(NewTunnelCmd(appCtx))
+	cmd.AddCommand(NewSSHConfigCmd(appCtx))
+	cmd.AddCommand(NewDiagnoseCmd(appCtx))
+	cmd.AddCommand(NewUpCmd(appCtx))
+	cmd.AddCommand(NewDownCmd(appCtx))
 	return cmd
 }
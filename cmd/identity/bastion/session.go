@@ -0,0 +1,21 @@
+package bastion
+
+import (
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// NewSessionCmd returns the "bastion session" command group.
+func NewSessionCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "session",
+		Aliases:       []string{"s"},
+		Short:         "Manage bastion sessions without the interactive TUI",
+		Long:          "Create bastion sessions from flags so they can be driven from scripts, CI jobs and runbooks.",
+		Example:       "  ocloud identity bastion session create --bastion prodbastion --target instance:web-1 --type port-forward --local-port 15432 --remote-port 5432 --key ~/.ssh/id_ed25519",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.AddCommand(NewSessionCreateCmd(appCtx))
+	return cmd
+}
@@ -0,0 +1,411 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	bastionFlags "github.com/rozdolsky33/ocloud/cmd/identity/bastion/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/printer"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/rozdolsky33/ocloud/internal/services/util"
+	"github.com/spf13/cobra"
+)
+
+var sessionCreateLong = `
+Create a bastion session without the interactive TUI.
+
+The bastion is resolved by name or OCID in the current compartment, and the target is given as
+<kind>:<name|ocid> where kind is one of: instance, adb, heatwave, cache, oke, lb.
+
+Port-forward sessions start an SSH tunnel in the background and return once it is listening.
+When --remote-port is omitted the target's standard port is used (22, 1521, 3306, 6379, 6443 or 443),
+and --local-port defaults to the remote port. Managed SSH sessions are supported for instances only.
+
+With --keep-alive the command stays in the foreground after the tunnel is up and supervises it:
+shortly before the bastion session expires a fresh session is created and the forwarder is restarted on the
+same local port, and a dead forwarder process is restarted. Restarts are written to the tunnel log.
+Press Ctrl+C to stop supervising; the tunnel itself keeps running until "bastion tunnel stop".
+
+Before the session is created the bastion's reach to the target is analysed as in "bastion diagnose":
+the CIDR allow-list, routes, the bastion subnet's egress rules and the target's security lists and NSGs.
+The findings are printed and the command stops when a check fails; --skip-reachability overrides this.
+
+The public key is read from <key>.pub. The command exits non-zero when the bastion or target
+cannot be resolved, so it can be used from scripts and CI jobs.
+`
+
+var sessionCreateExamples = `
+  # Forward local port 15432 to PostgreSQL on an instance
+  ocloud identity bastion session create --bastion prodbastion --target instance:web-1 --type port-forward --local-port 15432 --remote-port 5432 --key ~/.ssh/id_ed25519
+
+  # Forward the default Autonomous Database port (1521)
+  ocloud identity bastion session create --bastion prodbastion --target adb:salesdb --key ~/.ssh/id_ed25519
+
+  # Tunnel to an OKE API server and print the result as JSON
+  ocloud identity bastion session create --bastion prodbastion --target oke:prod-cluster --local-port 6443 --json
+
+  # Keep a database tunnel open across session expiry
+  ocloud identity bastion session create --bastion prodbastion --target adb:salesdb --keep-alive
+
+  # Print the managed SSH command for an instance instead of connecting
+  ocloud identity bastion session create --bastion prodbastion --target instance:web-1 --type managed-ssh --user opc --print-only
+`
+
+// NewSessionCreateCmd returns "bastion session create".
+func NewSessionCreateCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "create",
+		Aliases:       []string{"c"},
+		Short:         "Create a port-forward or managed SSH session from flags",
+		Long:          sessionCreateLong,
+		Example:       sessionCreateExamples,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSessionCreateCommand(cmd, appCtx)
+		},
+	}
+	bastionFlags.BastionFlag.Add(cmd)
+	bastionFlags.TargetFlag.Add(cmd)
+	bastionFlags.SessionTypeFlag.Add(cmd)
+	bastionFlags.LocalPortFlag.Add(cmd)
+	bastionFlags.RemotePortFlag.Add(cmd)
+	bastionFlags.SSHKeyFlag.Add(cmd)
+	bastionFlags.SSHUserFlag.Add(cmd)
+	bastionFlags.PrintOnlyFlag.Add(cmd)
+	bastionFlags.KeepAliveFlag.Add(cmd)
+	bastionFlags.ClientIPFlag.Add(cmd)
+	bastionFlags.SkipReachabilityFlag.Add(cmd)
+	_ = cmd.MarkFlagRequired(flags.FlagNameBastion)
+	_ = cmd.MarkFlagRequired(flags.FlagNameTarget)
+	return cmd
+}
+
+// sessionCreateOptions holds the parsed flags for "bastion session create".
+type sessionCreateOptions struct {
+	Bastion    string
+	TargetKind bastionSvc.TargetKind
+	TargetName string
+	Type       SessionType
+	LocalPort  int
+	RemotePort int
+	PrivateKey string
+	PublicKey  string
+	User       string
+	PrintOnly  bool
+	KeepAlive  bool
+	ClientIP   string
+	SkipReach  bool
+	JSON       bool
+	// Profile names the tunnel profile the session is started from, if any.
+	Profile string
+}
+
+// SessionResult is printed after a non-interactive session has been created.
+type SessionResult struct {
+	SessionID   string                    `json:"session_id"`
+	SessionType string                    `json:"session_type"`
+	BastionID   string                    `json:"bastion_id"`
+	BastionName string                    `json:"bastion_name"`
+	Target      bastionSvc.ResolvedTarget `json:"target"`
+	LocalPort   int                       `json:"local_port,omitempty"`
+	RemotePort  int                       `json:"remote_port"`
+	PID         int                       `json:"pid,omitempty"`
+	LogFile     string                    `json:"log_file,omitempty"`
+	Ready       bool                      `json:"ready"`
+	Command     string                    `json:"command,omitempty"`
+
+	Reachability *bastionSvc.ReachabilityReport `json:"reachability,omitempty"`
+}
+
+// parseSessionType maps the --type flag to a SessionType.
+func parseSessionType(s string) (SessionType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "port-forward", "port-forwarding", "pf":
+		return TypePortForwarding, nil
+	case "managed-ssh", "ssh":
+		return TypeManagedSSH, nil
+	default:
+		return "", fmt.Errorf("invalid session type %q: must be port-forward or managed-ssh", s)
+	}
+}
+
+// parseSessionCreateOptions reads and validates the flags for "bastion session create".
+func parseSessionCreateOptions(cmd *cobra.Command) (sessionCreateOptions, error) {
+	opts := sessionCreateOptions{
+		Bastion:    flags.GetStringFlag(cmd, flags.FlagNameBastion, ""),
+		LocalPort:  flags.GetIntFlag(cmd, flags.FlagNameLocalPort, 0),
+		RemotePort: flags.GetIntFlag(cmd, flags.FlagNameRemotePort, 0),
+		User:       flags.GetStringFlag(cmd, flags.FlagNameSSHUser, bastionFlags.SSHUserFlag.Default),
+		PrintOnly:  flags.GetBoolFlag(cmd, flags.FlagNamePrintOnly, false),
+		KeepAlive:  flags.GetBoolFlag(cmd, flags.FlagNameKeepAlive, false),
+		ClientIP:   flags.GetStringFlag(cmd, flags.FlagNameClientIP, ""),
+		SkipReach:  flags.GetBoolFlag(cmd, flags.FlagNameSkipReach, false),
+		JSON:       flags.GetBoolFlag(cmd, flags.FlagNameJSON, false),
+	}
+
+	var err error
+	opts.TargetKind, opts.TargetName, err = bastionSvc.ParseTargetSpec(flags.GetStringFlag(cmd, flags.FlagNameTarget, ""))
+	if err != nil {
+		return opts, err
+	}
+	opts.Type, err = parseSessionType(flags.GetStringFlag(cmd, flags.FlagNameSessionType, bastionFlags.SessionTypeFlag.Default))
+	if err != nil {
+		return opts, err
+	}
+	if opts.Type == TypeManagedSSH && opts.TargetKind != bastionSvc.KindInstance {
+		return opts, fmt.Errorf("managed-ssh sessions are only supported for instance targets, got %s", opts.TargetKind)
+	}
+	if opts.KeepAlive && opts.Type != TypePortForwarding {
+		return opts, fmt.Errorf("--%s is only supported for port-forward sessions", flags.FlagNameKeepAlive)
+	}
+	for name, port := range map[string]int{flags.FlagNameLocalPort: opts.LocalPort, flags.FlagNameRemotePort: opts.RemotePort} {
+		if port < 0 || port > 65535 {
+			return opts, fmt.Errorf("--%s must be between 1 and 65535, got %d", name, port)
+		}
+	}
+
+	opts.PrivateKey, err = bastionSvc.ExpandTilde(flags.GetStringFlag(cmd, flags.FlagNameSSHKey, bastionFlags.SSHKeyFlag.Default))
+	if err != nil {
+		return opts, fmt.Errorf("expand key path: %w", err)
+	}
+	opts.PublicKey = opts.PrivateKey + ".pub"
+	for _, p := range []string{opts.PrivateKey, opts.PublicKey} {
+		if _, err := os.Stat(p); err != nil {
+			return opts, fmt.Errorf("ssh key: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+// runSessionCreateCommand resolves the bastion and target from flags and creates the session.
+func runSessionCreateCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	ctx := cmd.Context()
+
+	opts, err := parseSessionCreateOptions(cmd)
+	if err != nil {
+		return err
+	}
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion session create command",
+		"bastion", opts.Bastion, "target_kind", opts.TargetKind, "target", opts.TargetName, "type", opts.Type)
+
+	svc, err := bastionSvc.NewServiceFromAppContext(appCtx)
+	if err != nil {
+		return fmt.Errorf("create bastion service: %w", err)
+	}
+
+	b, err := svc.Resolve(ctx, opts.Bastion)
+	if err != nil {
+		return err
+	}
+
+	target, err := bastionSvc.ResolveTarget(ctx, appCtx, opts.TargetKind, opts.TargetName)
+	if err != nil {
+		return err
+	}
+
+	report := svc.AnalyzeReachability(ctx, *b, target.ReachabilityTarget(opts.RemotePort, opts.ClientIP))
+	if !opts.JSON {
+		if err := bastionSvc.PrintReachabilityReport(report, appCtx, false); err != nil {
+			return err
+		}
+	}
+	if !report.Reachable {
+		if !opts.SkipReach {
+			return &bastionSvc.ResolveError{Kind: target.Kind, Name: opts.TargetName, Err: bastionSvc.ErrTargetUnreachable, Reason: report.FailureReason()}
+		}
+		logger.Logger.Info("Creating session despite failed reachability checks", "reason", report.FailureReason())
+	}
+
+	region, err := appCtx.Provider.Region()
+	if err != nil {
+		return fmt.Errorf("get region: %w", err)
+	}
+
+	var result *SessionResult
+	switch opts.Type {
+	case TypeManagedSSH:
+		result, err = createManagedSSHSession(ctx, appCtx, svc, *b, *target, opts, region)
+	case TypePortForwarding:
+		result, err = createPortForwardSession(ctx, svc, *b, *target, opts, region)
+	default:
+		err = fmt.Errorf("unsupported session type: %s", opts.Type)
+	}
+	if err != nil || result == nil {
+		return err
+	}
+	result.Reachability = report
+	if err := printSessionResult(appCtx, result, opts.JSON); err != nil {
+		return err
+	}
+	if opts.KeepAlive {
+		return superviseTunnel(ctx, svc, result, opts, region)
+	}
+	return nil
+}
+
+// createManagedSSHSession creates a managed SSH session and either runs ssh or returns the command.
+func createManagedSSHSession(ctx context.Context, appCtx *app.ApplicationContext, svc *bastionSvc.Service,
+	b bastionSvc.Bastion, target bastionSvc.ResolvedTarget, opts sessionCreateOptions, region string) (*SessionResult, error) {
+
+	port := opts.RemotePort
+	if port == 0 {
+		port = target.Port
+	}
+	sessID, err := svc.EnsureManagedSSHSession(ctx, b.OCID, target.ID, target.IP, opts.User, port, opts.PublicKey, 0)
+	if err != nil {
+		return nil, fmt.Errorf("ensure managed SSH: %w", err)
+	}
+	sshCmd := bastionSvc.BuildManagedSSHCommand(opts.PrivateKey, sessID, region, target.IP, opts.User)
+
+	if opts.PrintOnly || opts.JSON {
+		return &SessionResult{
+			SessionID:   sessID,
+			SessionType: string(TypeManagedSSH),
+			BastionID:   b.OCID,
+			BastionName: b.DisplayName,
+			Target:      target,
+			RemotePort:  port,
+			Ready:       true,
+			Command:     sshCmd,
+		}, nil
+	}
+
+	logger.Logger.Info("Executing", "command", sshCmd)
+	return nil, bastionSvc.RunShell(ctx, appCtx.Stdout, appCtx.Stderr, sshCmd)
+}
+
+// createPortForwardSession creates a port-forwarding session and starts a detached SSH tunnel.
+func createPortForwardSession(ctx context.Context, svc *bastionSvc.Service, b bastionSvc.Bastion,
+	target bastionSvc.ResolvedTarget, opts sessionCreateOptions, region string) (*SessionResult, error) {
+
+	remotePort := opts.RemotePort
+	if remotePort == 0 {
+		remotePort = target.Port
+	}
+	localPort := opts.LocalPort
+	if localPort == 0 {
+		localPort = remotePort
+	}
+	if localPort < 1024 {
+		return nil, fmt.Errorf("local port %d is privileged; choose a port >= 1024 with --local-port", localPort)
+	}
+	if util.IsLocalTCPPortInUse(localPort) {
+		return nil, fmt.Errorf("local port %d is already in use on 127.0.0.1; choose another port", localPort)
+	}
+
+	sessID, err := svc.EnsurePortForwardSession(ctx, b.OCID, target.IP, remotePort, opts.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("ensure port forward: %w", err)
+	}
+	forwarderCfg, err := bastionSvc.BuildPortForwardConfig(opts.PrivateKey, sessID, region, target.IP, localPort, remotePort)
+	if err != nil {
+		return nil, fmt.Errorf("build forwarder config: %w", err)
+	}
+
+	pid, logFile, err := bastionSvc.SpawnDetached(forwarderCfg)
+	if err != nil {
+		return nil, fmt.Errorf("spawn detached: %w", err)
+	}
+	logger.Logger.V(logger.Debug).Info("spawned tunnel", "pid", pid)
+
+	tunnelInfo := bastionSvc.TunnelInfo{
+		PID:        pid,
+		LocalPort:  localPort,
+		TargetIP:   target.IP,
+		StartedAt:  time.Now(),
+		LogFile:    logFile,
+		SessionID:  sessID,
+		BastionID:  b.OCID,
+		TargetID:   target.ID,
+		TargetName: target.Name,
+		TargetKind: string(target.Kind),
+		RemotePort: remotePort,
+		Profile:    opts.Profile,
+	}
+	if err := svc.RecordTunnel(ctx, tunnelInfo); err != nil {
+		logger.Logger.Error(err, "failed to save tunnel state")
+	}
+
+	ready := true
+	if err := bastionSvc.WaitForListen(localPort, 30*time.Second); err != nil {
+		ready = false
+		logger.Logger.Info("Tunnel verification timed out, but the tunnel may still be establishing in the background", "port", localPort)
+	}
+
+	return &SessionResult{
+		SessionID:   sessID,
+		SessionType: string(TypePortForwarding),
+		BastionID:   b.OCID,
+		BastionName: b.DisplayName,
+		Target:      target,
+		LocalPort:   localPort,
+		RemotePort:  remotePort,
+		PID:         pid,
+		LogFile:     logFile,
+		Ready:       ready,
+	}, nil
+}
+
+// superviseTunnel keeps the tunnel from a port-forward result alive until the context is cancelled
+// or the tunnel is stopped.
+func superviseTunnel(ctx context.Context, svc *bastionSvc.Service, r *SessionResult, opts sessionCreateOptions, region string) error {
+	tunnel := bastionSvc.TunnelInfo{
+		PID:        r.PID,
+		LocalPort:  r.LocalPort,
+		TargetIP:   r.Target.IP,
+		LogFile:    r.LogFile,
+		SessionID:  r.SessionID,
+		BastionID:  r.BastionID,
+		TargetID:   r.Target.ID,
+		TargetName: r.Target.Name,
+		TargetKind: string(r.Target.Kind),
+		RemotePort: r.RemotePort,
+		StartedAt:  time.Now(),
+	}
+	// Prefer the recorded state, which already carries the session expiry.
+	if existing, err := bastionSvc.FindTunnel(r.LocalPort); err == nil {
+		tunnel = *existing
+	}
+
+	logger.Logger.Info("Supervising tunnel; press Ctrl+C to stop supervising (the tunnel keeps running)", "local_port", r.LocalPort)
+	return svc.NewTunnelSupervisor(tunnel, opts.PrivateKey, opts.PublicKey, region).Run(ctx)
+}
+
+// printSessionResult prints the created session as JSON or as a key/value table.
+func printSessionResult(appCtx *app.ApplicationContext, r *SessionResult, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(r)
+	}
+
+	data := map[string]string{
+		"Session ID": r.SessionID,
+		"Type":       r.SessionType,
+		"Bastion":    r.BastionName,
+		"Target":     fmt.Sprintf("%s %s", r.Target.Kind, r.Target.Name),
+		"Remote":     fmt.Sprintf("%s:%d", r.Target.IP, r.RemotePort),
+	}
+	keys := []string{"Session ID", "Type", "Bastion", "Target", "Remote"}
+	if r.LocalPort != 0 {
+		data["Local"] = "127.0.0.1:" + strconv.Itoa(r.LocalPort)
+		data["PID"] = strconv.Itoa(r.PID)
+		data["Ready"] = strconv.FormatBool(r.Ready)
+		data["Logs"] = r.LogFile
+		keys = append(keys, "Local", "PID", "Ready", "Logs")
+	}
+	if r.Command != "" {
+		data["Command"] = r.Command
+		keys = append(keys, "Command")
+	}
+	p.PrintKeyValuesNoTruncate("Bastion Session", data, keys)
+	return nil
+}
@@ -0,0 +1,52 @@
+package bastion
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionCommand(t *testing.T) {
+	cmd := NewSessionCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "session", cmd.Use)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+
+	create, _, err := cmd.Find([]string{"create"})
+	require.NoError(t, err)
+	assert.Equal(t, "create", create.Use)
+	for _, name := range []string{"bastion", "target", "type", "local-port", "remote-port", "key", "user", "print-only", "keep-alive", "client-ip", "skip-reachability"} {
+		assert.NotNil(t, create.Flags().Lookup(name), "expected --%s flag", name)
+	}
+	assert.Equal(t, "port-forward", create.Flags().Lookup("type").DefValue)
+	assert.Equal(t, "opc", create.Flags().Lookup("user").DefValue)
+}
+
+func TestParseSessionType(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  SessionType
+		expectErr bool
+	}{
+		{input: "port-forward", expected: TypePortForwarding},
+		{input: "Port-Forwarding", expected: TypePortForwarding},
+		{input: "managed-ssh", expected: TypeManagedSSH},
+		{input: "ssh", expected: TypeManagedSSH},
+		{input: "scp", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseSessionType(tt.input)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package bastion
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	bastionFlags "github.com/rozdolsky33/ocloud/cmd/identity/bastion/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/printer"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/spf13/cobra"
+)
+
+var sshConfigLong = `
+Write an ssh config include with a Host entry for every active managed SSH session.
+
+The file ~/.ssh/config.d/ocloud is regenerated from the ACTIVE managed SSH sessions on the bastions
+in the current compartment (or only --bastion). Each entry sets HostName, User, Port, IdentityFile,
+HostKeyAlias and a ProxyCommand through the bastion, so plain ssh, scp, rsync, ansible and
+VS Code Remote can use the sessions by alias, e.g. "ssh ocloud-web-1".
+
+With --bastion, only that bastion's entries are regenerated; the entries of other bastions are kept
+until their sessions expire.
+
+The identity file is the key in ~/.ssh whose .pub matches the session's public key; --key is used
+when no match is found. Running the command again only rewrites the file when sessions changed.
+Add "Include config.d/ocloud" at the top of ~/.ssh/config to use the entries.
+`
+
+var sshConfigExamples = `
+  # Regenerate ~/.ssh/config.d/ocloud from all bastions in the compartment
+  ocloud identity bastion ssh-config
+
+  # Only sessions on one bastion, printing the file instead of writing it
+  ocloud identity bastion ssh-config --bastion prodbastion --dry-run
+
+  # Use the generated aliases
+  ssh ocloud-web-1
+  rsync -av ./build/ ocloud-web-1:/srv/app/
+`
+
+// SSHConfigResult is printed as JSON after the ssh config has been generated.
+type SSHConfigResult struct {
+	Path    string                     `json:"path"`
+	Changed bool                       `json:"changed"`
+	Hosts   []bastionSvc.SSHConfigHost `json:"hosts"`
+}
+
+// NewSSHConfigCmd returns "bastion ssh-config".
+func NewSSHConfigCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "ssh-config",
+		Short:         "Write ~/.ssh/config.d/ocloud for active managed SSH sessions",
+		Long:          sshConfigLong,
+		Example:       sshConfigExamples,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSSHConfigCommand(cmd, appCtx)
+		},
+	}
+	bastionFlags.BastionFlag.Add(cmd)
+	bastionFlags.SSHKeyFlag.Add(cmd)
+	bastionFlags.DryRunFlag.Add(cmd)
+	return cmd
+}
+
+// runSSHConfigCommand collects the managed SSH sessions and writes the ssh config include.
+func runSSHConfigCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	ctx := cmd.Context()
+	bastionName := flags.GetStringFlag(cmd, flags.FlagNameBastion, "")
+	dryRun := flags.GetBoolFlag(cmd, flags.FlagNameDryRun, false)
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion ssh-config command", "bastion", bastionName, "dry_run", dryRun)
+
+	fallbackKey, err := bastionSvc.ExpandTilde(flags.GetStringFlag(cmd, flags.FlagNameSSHKey, bastionFlags.SSHKeyFlag.Default))
+	if err != nil {
+		return fmt.Errorf("expand key path: %w", err)
+	}
+
+	svc, err := bastionSvc.NewServiceFromAppContext(appCtx)
+	if err != nil {
+		return fmt.Errorf("create bastion service: %w", err)
+	}
+
+	var bastions []bastionSvc.Bastion
+	if bastionName != "" {
+		b, err := svc.Resolve(ctx, bastionName)
+		if err != nil {
+			return err
+		}
+		bastions = append(bastions, *b)
+	} else {
+		all, err := svc.List(ctx)
+		if err != nil {
+			return fmt.Errorf("listing bastions: %w", err)
+		}
+		for _, b := range all {
+			if b.LifecycleState == "ACTIVE" {
+				bastions = append(bastions, b)
+			}
+		}
+	}
+
+	region, err := appCtx.Provider.Region()
+	if err != nil {
+		return fmt.Errorf("get region: %w", err)
+	}
+
+	hosts, err := svc.ManagedSSHHosts(ctx, bastions, region, fallbackKey)
+	if err != nil {
+		return err
+	}
+
+	path, err := bastionSvc.DefaultSSHConfigPath()
+	if err != nil {
+		return fmt.Errorf("get ssh config path: %w", err)
+	}
+	if bastionName != "" {
+		existing, err := bastionSvc.ReadSSHConfigHosts(path)
+		if err != nil {
+			return err
+		}
+		kept := slices.DeleteFunc(existing, func(h bastionSvc.SSHConfigHost) bool { return h.BastionName == bastions[0].DisplayName })
+		hosts = bastionSvc.MergeSSHConfigHosts(hosts, kept, time.Now())
+	}
+	content := bastionSvc.RenderSSHConfig(hosts)
+	result := SSHConfigResult{Path: path, Hosts: hosts}
+
+	if dryRun {
+		if useJSON {
+			return printer.New(appCtx.Stdout).MarshalToJSON(result)
+		}
+		fmt.Fprint(appCtx.Stdout, content)
+		return nil
+	}
+
+	result.Changed, err = bastionSvc.WriteSSHConfig(path, content)
+	if err != nil {
+		return err
+	}
+	if useJSON {
+		return printer.New(appCtx.Stdout).MarshalToJSON(result)
+	}
+
+	if err := bastionSvc.PrintSSHConfigHosts(hosts, appCtx); err != nil {
+		return err
+	}
+	if result.Changed {
+		logger.Logger.Info("SSH config updated", "path", path, "hosts", len(hosts))
+	} else {
+		logger.Logger.Info("SSH config already up to date", "path", path, "hosts", len(hosts))
+	}
+	if !bastionSvc.SSHConfigIncluded(path) {
+		logger.Logger.Info("Add this line at the top of ~/.ssh/config to use the entries", "line", "Include config.d/ocloud")
+	}
+	return nil
+}
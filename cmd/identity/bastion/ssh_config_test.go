@@ -0,0 +1,18 @@
+package bastion
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSHConfigCommand(t *testing.T) {
+	cmd := NewSSHConfigCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "ssh-config", cmd.Use)
+	assert.True(t, cmd.SilenceUsage)
+	for _, name := range []string{"bastion", "key", "dry-run"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "expected --%s flag", name)
+	}
+}
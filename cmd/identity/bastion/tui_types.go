@@ -16,6 +16,7 @@ import (
 	hwdbSvc "github.com/rozdolsky33/ocloud/internal/services/database/heatwavedb"
 	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
 	lbSvc "github.com/rozdolsky33/ocloud/internal/services/network/loadbalancer"
+	vcnSvc "github.com/rozdolsky33/ocloud/internal/services/network/vcn"
 )
 
 // BastionType identifies the top-level action.
@@ -510,6 +511,39 @@ func NewCacheClusterListModelFancy(clusters []cacheSvc.CacheCluster) ResourceLis
 	return newResourceList("OCI Cache Clusters", items)
 }
 
+// NewVCNListModelFancy creates a ResourceListModel populated with a list of VCNs for TUI display.
+func NewVCNListModelFancy(vcns []vcnSvc.VCN) ResourceListModel {
+	items := make([]list.Item, 0, len(vcns))
+	for _, v := range vcns {
+		var parts []string
+		if v.LifecycleState != "" {
+			parts = append(parts, v.LifecycleState)
+		}
+		if len(v.CidrBlocks) > 0 {
+			parts = append(parts, strings.Join(v.CidrBlocks, ", "))
+		}
+		if len(v.Subnets) > 0 {
+			parts = append(parts, fmt.Sprintf("%d subnets", len(v.Subnets)))
+		}
+		items = append(items, resourceItem{id: v.OCID, title: v.DisplayName, description: strings.Join(parts, " • ")})
+	}
+	return newResourceList("VCNs", items)
+}
+
+// NewSubnetListModelFancy creates a ResourceListModel populated with a list of subnets for TUI display.
+func NewSubnetListModelFancy(subnets []vcnSvc.Subnet) ResourceListModel {
+	items := make([]list.Item, 0, len(subnets))
+	for _, s := range subnets {
+		access := "Private"
+		if s.Public {
+			access = "Public"
+		}
+		desc := strings.Join(filterNonEmpty(s.LifecycleState, s.CidrBlock, access), " • ")
+		items = append(items, resourceItem{id: s.OCID, title: s.DisplayName, description: desc})
+	}
+	return newResourceList("Subnets", items)
+}
+
 //---------------------------------------SSH Keys----------------------------------------------------------------------
 
 // SSHFileItem is a list item representing a file system entry (file or directory).
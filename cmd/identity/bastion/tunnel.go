@@ -0,0 +1,36 @@
+package bastion
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// NewTunnelCmd returns the "bastion tunnel" command group.
+func NewTunnelCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "tunnel",
+		Aliases:       []string{"tunnels", "tun"},
+		Short:         "Inspect and manage background SSH tunnels",
+		Long:          "List, stop, tail the logs of, and prune the SSH tunnels started by bastion port-forwarding sessions for the current profile.",
+		Example:       "  ocloud identity bastion tunnel list\n  ocloud identity bastion tunnel stop 15432\n  ocloud identity bastion tunnel logs 15432 --follow\n  ocloud identity bastion tunnel prune --older-than 72h",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.AddCommand(NewTunnelListCmd(appCtx))
+	cmd.AddCommand(NewTunnelStopCmd(appCtx))
+	cmd.AddCommand(NewTunnelLogsCmd(appCtx))
+	cmd.AddCommand(NewTunnelPruneCmd(appCtx))
+	return cmd
+}
+
+// parseLocalPort parses a local port argument.
+func parseLocalPort(arg string) (int, error) {
+	port, err := strconv.Atoi(arg)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid local port %q", arg)
+	}
+	return port, nil
+}
@@ -0,0 +1,61 @@
+package bastion
+
+import (
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/spf13/cobra"
+)
+
+// NewTunnelListCmd returns "bastion tunnel list".
+func NewTunnelListCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "list",
+		Aliases:       []string{"ls"},
+		Short:         "List active SSH tunnels",
+		Long:          "List active SSH tunnels with their target, bastion session OCID, age and remaining session TTL.",
+		Example:       "  ocloud identity bastion tunnel list\n  ocloud identity bastion tunnel list --json",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTunnelListCommand(cmd, appCtx)
+		},
+	}
+	return cmd
+}
+
+// runTunnelListCommand lists the active tunnels. Remaining TTL is looked up from OCI for tunnels
+// whose state records a session OCID but no expiry; lookup failures are logged and ignored.
+func runTunnelListCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion tunnel list command", "json", useJSON)
+
+	tunnels, err := bastionSvc.ListTunnels()
+	if err != nil {
+		return err
+	}
+
+	var svc *bastionSvc.Service
+	for i, t := range tunnels {
+		if t.SessionID == "" || !t.ExpiresAt.IsZero() {
+			continue
+		}
+		if svc == nil {
+			if svc, err = bastionSvc.NewServiceFromAppContext(appCtx); err != nil {
+				logger.LogWithLevel(logger.CmdLogger, logger.Debug, "cannot look up session expiry", "error", err)
+				break
+			}
+		}
+		ttl, expiresAt, err := svc.SessionTTL(cmd.Context(), t.SessionID)
+		if err != nil {
+			logger.LogWithLevel(logger.CmdLogger, logger.Debug, "cannot look up session expiry", "session_id", t.SessionID, "error", err)
+			continue
+		}
+		tunnels[i].SessionTTL = ttl
+		tunnels[i].ExpiresAt = expiresAt
+	}
+
+	return bastionSvc.PrintTunnelsInfo(tunnels, appCtx, useJSON)
+}
@@ -0,0 +1,53 @@
+package bastion
+
+import (
+	bastionFlags "github.com/rozdolsky33/ocloud/cmd/identity/bastion/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/spf13/cobra"
+)
+
+// NewTunnelLogsCmd returns "bastion tunnel logs".
+func NewTunnelLogsCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "logs <local-port>",
+		Aliases:       []string{"log"},
+		Short:         "Show the log of a tunnel",
+		Long:          "Print the end of the log for the tunnel on the given local port. With --follow, keep streaming new lines until interrupted.",
+		Example:       "  ocloud identity bastion tunnel logs 15432\n  ocloud identity bastion tunnel logs 15432 --lines 200\n  ocloud identity bastion tunnel logs 15432 --follow",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTunnelLogsCommand(cmd, args, appCtx)
+		},
+	}
+	bastionFlags.LinesFlag.Add(cmd)
+	bastionFlags.FollowFlag.Add(cmd)
+	return cmd
+}
+
+// runTunnelLogsCommand tails (and optionally follows) a tunnel's log file.
+func runTunnelLogsCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	port, err := parseLocalPort(args[0])
+	if err != nil {
+		return err
+	}
+	lines := flags.GetIntFlag(cmd, flags.FlagNameLines, bastionFlags.LinesFlag.Default)
+	follow := flags.GetBoolFlag(cmd, flags.FlagNameFollow, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion tunnel logs command", "port", port, "lines", lines, "follow", follow)
+
+	logFile, err := bastionSvc.TunnelLogFile(port)
+	if err != nil {
+		return err
+	}
+	if err := bastionSvc.TailLog(appCtx.Stdout, logFile, lines); err != nil {
+		return err
+	}
+	if follow {
+		return bastionSvc.FollowLog(cmd.Context(), appCtx.Stdout, logFile)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package bastion
+
+import (
+	"fmt"
+	"time"
+
+	bastionFlags "github.com/rozdolsky33/ocloud/cmd/identity/bastion/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/printer"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/spf13/cobra"
+)
+
+// NewTunnelPruneCmd returns "bastion tunnel prune".
+func NewTunnelPruneCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "prune",
+		Short:         "Remove stale tunnel state and old tunnel logs",
+		Long:          "Remove state files whose forwarder process is no longer running and tunnel log files older than --older-than. Logs of running tunnels are kept.",
+		Example:       "  ocloud identity bastion tunnel prune\n  ocloud identity bastion tunnel prune --older-than 24h --dry-run",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTunnelPruneCommand(cmd, appCtx)
+		},
+	}
+	bastionFlags.OlderThanFlag.Add(cmd)
+	bastionFlags.DryRunFlag.Add(cmd)
+	return cmd
+}
+
+// runTunnelPruneCommand removes stale state files and old logs.
+func runTunnelPruneCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	raw := flags.GetStringFlag(cmd, flags.FlagNameOlderThan, bastionFlags.OlderThanFlag.Default)
+	olderThan, err := time.ParseDuration(raw)
+	if err != nil || olderThan < 0 {
+		return fmt.Errorf("invalid --%s value %q: use a duration such as 72h", flags.FlagNameOlderThan, raw)
+	}
+	dryRun := flags.GetBoolFlag(cmd, flags.FlagNameDryRun, false)
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion tunnel prune command", "older_than", olderThan, "dry_run", dryRun)
+
+	result, err := bastionSvc.PruneTunnels(olderThan, dryRun)
+	if err != nil {
+		return err
+	}
+
+	if useJSON {
+		return printer.New(appCtx.Stdout).MarshalToJSON(result)
+	}
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	for _, port := range result.StaleStates {
+		fmt.Fprintf(appCtx.Stdout, "%s stale state for tunnel on port %d\n", verb, port)
+	}
+	for _, path := range result.Logs {
+		fmt.Fprintf(appCtx.Stdout, "%s log %s\n", verb, path)
+	}
+	fmt.Fprintf(appCtx.Stdout, "%s %d stale state file(s) and %d log file(s)\n", verb, len(result.StaleStates), len(result.Logs))
+	return nil
+}
@@ -0,0 +1,70 @@
+package bastion
+
+import (
+	"fmt"
+
+	bastionFlags "github.com/rozdolsky33/ocloud/cmd/identity/bastion/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	"github.com/spf13/cobra"
+)
+
+// NewTunnelStopCmd returns "bastion tunnel stop".
+func NewTunnelStopCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "stop [local-port]",
+		Aliases:       []string{"kill"},
+		Short:         "Stop an SSH tunnel by local port, or all tunnels",
+		Long:          "Terminate the forwarder process behind a tunnel and remove its state file. Use --all to stop every tracked tunnel.",
+		Example:       "  ocloud identity bastion tunnel stop 15432\n  ocloud identity bastion tunnel stop --all",
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTunnelStopCommand(cmd, args, appCtx)
+		},
+	}
+	bastionFlags.AllTunnelsFlag.Add(cmd)
+	return cmd
+}
+
+// runTunnelStopCommand stops the tunnel on the given port, or all tunnels with --all.
+func runTunnelStopCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	all := flags.GetBoolFlag(cmd, flags.FlagNameAll, false)
+	if all == (len(args) == 1) {
+		return fmt.Errorf("specify either a local port or --all")
+	}
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running bastion tunnel stop command", "args", args, "all", all)
+
+	var tunnels []bastionSvc.TunnelInfo
+	if all {
+		var err error
+		if tunnels, err = bastionSvc.ListTunnels(); err != nil {
+			return err
+		}
+	} else {
+		port, err := parseLocalPort(args[0])
+		if err != nil {
+			return err
+		}
+		t, err := bastionSvc.FindTunnel(port)
+		if err != nil {
+			return err
+		}
+		tunnels = append(tunnels, *t)
+	}
+
+	if len(tunnels) == 0 {
+		fmt.Fprintln(appCtx.Stdout, "No active tunnels.")
+		return nil
+	}
+	for _, t := range tunnels {
+		if err := bastionSvc.StopTunnel(t); err != nil {
+			return err
+		}
+		logger.Logger.Info("Stopped tunnel", "local_port", t.LocalPort, "pid", t.PID, "target", t.TargetIP)
+	}
+	return nil
+}
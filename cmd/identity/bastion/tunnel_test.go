@@ -0,0 +1,44 @@
+package bastion
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTunnelCommand(t *testing.T) {
+	cmd := NewTunnelCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "tunnel", cmd.Use)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+
+	subcommands := map[string]bool{}
+	for _, sc := range cmd.Commands() {
+		subcommands[sc.Name()] = true
+	}
+	for _, name := range []string{"list", "stop", "logs", "prune"} {
+		assert.True(t, subcommands[name], "expected %s subcommand", name)
+	}
+
+	stop, _, _ := cmd.Find([]string{"stop"})
+	assert.NotNil(t, stop.Flags().Lookup("all"))
+	logs, _, _ := cmd.Find([]string{"logs"})
+	assert.NotNil(t, logs.Flags().Lookup("follow"))
+	assert.Equal(t, "50", logs.Flags().Lookup("lines").DefValue)
+	prune, _, _ := cmd.Find([]string{"prune"})
+	assert.Equal(t, "168h", prune.Flags().Lookup("older-than").DefValue)
+	assert.NotNil(t, prune.Flags().Lookup("dry-run"))
+}
+
+func TestParseLocalPort(t *testing.T) {
+	port, err := parseLocalPort("15432")
+	assert.NoError(t, err)
+	assert.Equal(t, 15432, port)
+
+	for _, bad := range []string{"abc", "0", "70000", "-1"} {
+		_, err := parseLocalPort(bad)
+		assert.Error(t, err, bad)
+	}
+}
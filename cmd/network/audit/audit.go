@@ -0,0 +1,72 @@
+package audit
+
+import (
+	networkFlags "github.com/rozdolsky33/ocloud/cmd/network/flags"
+	sharedflags "github.com/rozdolsky33/ocloud/cmd/shared/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	auditSvc "github.com/rozdolsky33/ocloud/internal/services/network/audit"
+	"github.com/spf13/cobra"
+)
+
+var auditLong = `
+Scan the security lists and network security groups of every VCN for overly permissive rules.
+
+By default the configured compartment is audited. Use --recursive to include its descendants or
+--tenancy-scope (-T) to audit every compartment of the tenancy.
+
+Findings, ranked by severity:
+
+  CRITICAL  ingress from 0.0.0.0/0 to SSH (22) or RDP (3389), or of every protocol from 0.0.0.0/0
+  HIGH      ingress from 0.0.0.0/0 to a database port (1521, 3306, 5432, 1433, 6379, 27017, ...)
+  MEDIUM    ingress of every protocol from any other source
+  MEDIUM    stateless rules without a rule in the opposite direction for their return traffic
+  LOW       NSGs with no VNIC attached and security lists not attached to any subnet
+
+Compartments and NSGs that cannot be read are reported and skipped. Use --json for the report
+or --sarif for a SARIF 2.1.0 log that security tooling can ingest.
+`
+
+var auditExamples = `
+  # Audit the VCNs of the configured compartment
+  ocloud network audit
+
+  # Audit the compartment and everything below it
+  ocloud network audit --recursive
+
+  # Audit the whole tenancy and write a SARIF log
+  ocloud network audit -T --sarif > network-audit.sarif
+`
+
+// NewAuditCmd returns "network audit".
+func NewAuditCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "audit",
+		Short:         "Find overly permissive and unused security rules",
+		Long:          auditLong,
+		Example:       auditExamples,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAuditCommand(cmd, appCtx)
+		},
+	}
+	sharedflags.TenancyScopeFlag.Add(cmd)
+	networkFlags.Recursive.Add(cmd)
+	networkFlags.SARIF.Add(cmd)
+	return cmd
+}
+
+func runAuditCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	opts := auditSvc.Options{
+		TenancyScope: flags.GetBoolFlag(cmd, flags.FlagNameTenancyScope, false),
+		Recursive:    flags.GetBoolFlag(cmd, flags.FlagNameRecursive, false),
+	}
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	useSARIF := flags.GetBoolFlag(cmd, flags.FlagNameSARIF, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running network audit command",
+		"tenancy_scope", opts.TenancyScope, "recursive", opts.Recursive, "json", useJSON, "sarif", useSARIF)
+	return auditSvc.RunAudit(appCtx, opts, useJSON, useSARIF)
+}
@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditCommand(t *testing.T) {
+	cmd := NewAuditCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "audit", cmd.Use)
+	assert.Equal(t, auditLong, cmd.Long)
+	assert.Equal(t, auditExamples, cmd.Example)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, []string{"extra"}))
+
+	for _, name := range []string{flags.FlagNameTenancyScope, flags.FlagNameRecursive, flags.FlagNameSARIF} {
+		f := cmd.Flags().Lookup(name)
+		require.NotNil(t, f, name)
+		assert.Equal(t, "false", f.DefValue, name)
+	}
+	assert.Equal(t, flags.FlagShortTenancyScope, cmd.Flags().Lookup(flags.FlagNameTenancyScope).Shorthand)
+}
@@ -0,0 +1,66 @@
+package cidr
+
+import (
+	"fmt"
+
+	networkFlags "github.com/rozdolsky33/ocloud/cmd/network/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	cidrSvc "github.com/rozdolsky33/ocloud/internal/services/network/cidr"
+	"github.com/spf13/cobra"
+)
+
+var freeLong = `
+Suggest CIDR blocks for a new VCN that do not overlap any existing VCN.
+
+The blocks of --size inside the --within range are returned in address order, skipping every range
+used by a VCN in the current tenancy and region, or in every tenancy and region of the tenancy map
+with --mapped. Only IPv4 ranges are supported.
+`
+
+var freeExamples = `
+  # Suggest /24 blocks inside 10.0.0.0/8
+  ocloud network cidr free --within 10.0.0.0/8 --size /24
+
+  # Suggest three /16 blocks that are free in every mapped tenancy and region
+  ocloud network cidr free --within 10.0.0.0/8 --size 16 --limit 3 --mapped
+`
+
+// NewFreeCmd returns "network cidr free".
+func NewFreeCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "free",
+		Short:         "Suggest CIDR blocks that no VCN uses",
+		Long:          freeLong,
+		Example:       freeExamples,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runFreeCommand(cmd, appCtx)
+		},
+	}
+	networkFlags.Within.Add(cmd)
+	networkFlags.Size.Add(cmd)
+	networkFlags.FreeLimit.Add(cmd)
+	networkFlags.Mapped.Add(cmd)
+	return cmd
+}
+
+func runFreeCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	within := flags.GetStringFlag(cmd, flags.FlagNameWithin, "")
+	size := flags.GetStringFlag(cmd, flags.FlagNameSize, networkFlags.Size.Default)
+	limit := flags.GetIntFlag(cmd, flags.FlagNameLimit, networkFlags.FreeLimit.Default)
+	mapped := flags.GetBoolFlag(cmd, flags.FlagNameMapped, false)
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	if within == "" {
+		return fmt.Errorf("--%s is required", flags.FlagNameWithin)
+	}
+	if limit < 1 {
+		return fmt.Errorf("--%s must be at least 1", flags.FlagNameLimit)
+	}
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running network cidr free command",
+		"within", within, "size", size, "limit", limit, "mapped", mapped, "json", useJSON)
+	return cidrSvc.RunFree(appCtx, within, size, limit, mapped, useJSON)
+}
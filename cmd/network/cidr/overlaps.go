@@ -0,0 +1,55 @@
+package cidr
+
+import (
+	networkFlags "github.com/rozdolsky33/ocloud/cmd/network/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	cidrSvc "github.com/rozdolsky33/ocloud/internal/services/network/cidr"
+	"github.com/spf13/cobra"
+)
+
+var overlapsLong = `
+Report VCN and subnet CIDR blocks that overlap across VCNs.
+
+Every compartment of the current tenancy is scanned in the configured region. Use --mapped to scan every
+tenancy of the tenancy map in each of its regions, so that VCNs which may one day be peered over a DRG or
+remote peering are compared too. Overlapping VCNs cannot be peered; overlapping subnets cannot route to
+each other.
+
+Compartments, tenancies and regions that cannot be read are reported and skipped.
+`
+
+var overlapsExamples = `
+  # Find overlapping ranges in the current tenancy and region
+  ocloud network cidr overlaps
+
+  # Compare every tenancy and region of the tenancy map
+  ocloud network cidr overlaps --mapped --json
+`
+
+// NewOverlapsCmd returns "network cidr overlaps".
+func NewOverlapsCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "overlaps",
+		Aliases:       []string{"overlap"},
+		Short:         "Report overlapping VCN and subnet ranges",
+		Long:          overlapsLong,
+		Example:       overlapsExamples,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runOverlapsCommand(cmd, appCtx)
+		},
+	}
+	networkFlags.Mapped.Add(cmd)
+	return cmd
+}
+
+func runOverlapsCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	mapped := flags.GetBoolFlag(cmd, flags.FlagNameMapped, false)
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running network cidr overlaps command", "mapped", mapped, "json", useJSON)
+	return cidrSvc.RunOverlaps(appCtx, mapped, useJSON)
+}
@@ -0,0 +1,22 @@
+package cidr
+
+import (
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// NewCidrCmd creates a new command group for CIDR planning
+func NewCidrCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "cidr",
+		Short:         "Plan address space: overlapping ranges, free blocks and subnet utilisation",
+		Long:          "  ocloud network cidr overlaps \n  ocloud network cidr free --within <range> --size <prefix> \n  ocloud network cidr usage",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.AddCommand(NewOverlapsCmd(appCtx))
+	cmd.AddCommand(NewFreeCmd(appCtx))
+	cmd.AddCommand(NewUsageCmd(appCtx))
+	return cmd
+}
@@ -0,0 +1,50 @@
+package cidr
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCidrCmd(t *testing.T) {
+	cmd := NewCidrCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "cidr", cmd.Use)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	var subcommands []string
+	for _, c := range cmd.Commands() {
+		subcommands = append(subcommands, c.Use)
+	}
+	assert.ElementsMatch(t, []string{"overlaps", "free", "usage"}, subcommands)
+}
+
+func TestFreeCommand(t *testing.T) {
+	cmd := NewFreeCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, freeLong, cmd.Long)
+	assert.Equal(t, freeExamples, cmd.Example)
+	assert.Equal(t, "/24", cmd.Flags().Lookup(flags.FlagNameSize).DefValue)
+	assert.Equal(t, "10", cmd.Flags().Lookup(flags.FlagNameLimit).DefValue)
+	require.NotNil(t, cmd.Flags().Lookup(flags.FlagNameMapped))
+
+	assert.ErrorContains(t, runFreeCommand(cmd, &app.ApplicationContext{}), "--within is required")
+
+	require.NoError(t, cmd.Flags().Set(flags.FlagNameWithin, "10.0.0.0/8"))
+	require.NoError(t, cmd.Flags().Set(flags.FlagNameLimit, "0"))
+	assert.ErrorContains(t, runFreeCommand(cmd, &app.ApplicationContext{}), "--limit must be at least 1")
+}
+
+func TestOverlapsAndUsageCommands(t *testing.T) {
+	overlaps := NewOverlapsCmd(&app.ApplicationContext{})
+	assert.Equal(t, overlapsLong, overlaps.Long)
+	assert.Equal(t, "false", overlaps.Flags().Lookup(flags.FlagNameMapped).DefValue)
+	assert.Error(t, overlaps.Args(overlaps, []string{"extra"}))
+
+	usage := NewUsageCmd(&app.ApplicationContext{})
+	assert.Equal(t, usageLong, usage.Long)
+	require.NotNil(t, usage.Flags().Lookup(flags.FlagNameTenancyScope))
+}
@@ -0,0 +1,51 @@
+package cidr
+
+import (
+	sharedflags "github.com/rozdolsky33/ocloud/cmd/shared/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	cidrSvc "github.com/rozdolsky33/ocloud/internal/services/network/cidr"
+	"github.com/spf13/cobra"
+)
+
+var usageLong = `
+Show how full each subnet is, based on the private IPs allocated in it.
+
+Used counts every private IP in the subnet, including those of instances, load balancers, databases and
+other services. Capacity excludes the three addresses OCI reserves in every subnet. By default the subnets
+of the configured compartment are shown; use --tenancy-scope (-T) for every compartment of the tenancy.
+`
+
+var usageExamples = `
+  # Show subnet utilisation in the configured compartment
+  ocloud network cidr usage
+
+  # Show subnet utilisation across the tenancy as JSON
+  ocloud network cidr usage -T --json
+`
+
+// NewUsageCmd returns "network cidr usage".
+func NewUsageCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "usage",
+		Short:         "Show private IP utilisation per subnet",
+		Long:          usageLong,
+		Example:       usageExamples,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runUsageCommand(cmd, appCtx)
+		},
+	}
+	sharedflags.TenancyScopeFlag.Add(cmd)
+	return cmd
+}
+
+func runUsageCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	tenancyScope := flags.GetBoolFlag(cmd, flags.FlagNameTenancyScope, false)
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running network cidr usage command", "tenancy_scope", tenancyScope, "json", useJSON)
+	return cidrSvc.RunUsage(appCtx, tenancyScope, useJSON)
+}
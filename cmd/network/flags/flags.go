@@ -33,4 +33,55 @@ var (
 		Default:   false,
 		Usage:     flags.FlagDescSecurity,
 	}
+	Port = flags.IntFlag{
+		Name:    flags.FlagNamePort,
+		Default: 0,
+		Usage:   flags.FlagDescPort,
+	}
+	Protocol = flags.StringFlag{
+		Name:    flags.FlagNameProtocol,
+		Default: "tcp",
+		Usage:   flags.FlagDescProtocol,
+	}
+	SARIF = flags.BoolFlag{
+		Name:    flags.FlagNameSARIF,
+		Default: false,
+		Usage:   flags.FlagDescSARIF,
+	}
+	Recursive = flags.BoolFlag{
+		Name:    flags.FlagNameRecursive,
+		Default: false,
+		Usage:   flags.FlagDescAuditRecursive,
+	}
+	Within = flags.StringFlag{
+		Name:    flags.FlagNameWithin,
+		Default: "",
+		Usage:   flags.FlagDescWithin,
+	}
+	Size = flags.StringFlag{
+		Name:    flags.FlagNameSize,
+		Default: "/24",
+		Usage:   flags.FlagDescSize,
+	}
+	Mapped = flags.BoolFlag{
+		Name:    flags.FlagNameMapped,
+		Default: false,
+		Usage:   flags.FlagDescMapped,
+	}
+	FreeLimit = flags.IntFlag{
+		Name:      flags.FlagNameLimit,
+		Shorthand: flags.FlagShortLimit,
+		Default:   10,
+		Usage:     flags.FlagDescFreeLimit,
+	}
+	DiagramFormat = flags.StringFlag{
+		Name:    flags.FlagNameFormat,
+		Default: "dot",
+		Usage:   flags.FlagDescDiagramFormat,
+	}
+	Resources = flags.BoolFlag{
+		Name:    flags.FlagNameResources,
+		Default: false,
+		Usage:   flags.FlagDescResources,
+	}
 )
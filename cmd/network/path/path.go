@@ -0,0 +1,140 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	networkFlags "github.com/rozdolsky33/ocloud/cmd/network/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
+	pathSvc "github.com/rozdolsky33/ocloud/internal/services/network/path"
+	"github.com/spf13/cobra"
+)
+
+var pathLong = `
+Check whether a source can talk to a destination on a port, and explain why or why not.
+
+Source and destination are raw IP addresses or resources given as <kind>:<name|ocid>
+(kinds: instance, adb, heatwave, cache, oke, lb; load balancers must be private). Resources are
+resolved to their IP, subnet and NSGs in the current compartment.
+
+The VCNs of the compartment are fetched once and the path is evaluated offline:
+
+  Source / Destination  the subnet and VCN each end is in; raw IPs outside them are external
+  Source egress         the source subnet's security lists or the source's NSGs allow the traffic
+  Routing               same subnet, same VCN (local route), or the source route table's rule for
+                        the destination: LPG/DRG for other VCNs and on-premises, IGW/NAT for the internet
+  Return route          the destination route table routes back to the source
+  Destination ingress   the destination subnet's security lists or its NSGs allow the traffic
+
+NSG rules that reference another NSG match when the other end is a member of it. The verdict is
+ALLOWED, BLOCKED (naming the failed step and the closest rule) or INCONCLUSIVE when a step could
+not be evaluated. The command exits non-zero when the path is blocked.
+`
+
+var pathExamples = `
+  # Can the app server reach the database listener?
+  ocloud network path instance:app-1 adb:salesdb --port 1521
+
+  # The destination port defaults to the destination's standard port (1521 for ADB, 3306 for HeatWave)
+  ocloud network path instance:app-1 heatwave:orders
+
+  # Check DNS from an instance to a public resolver
+  ocloud network path instance:app-1 8.8.8.8 --port 53 --protocol udp
+
+  # Can an on-premises host ping an instance? Output the report as JSON
+  ocloud network path 192.168.10.4 instance:app-1 --protocol icmp --json
+`
+
+// protocols maps the accepted --protocol values to IANA protocol numbers.
+var protocols = map[string]string{
+	"tcp":  domain.ProtocolTCP,
+	"udp":  domain.ProtocolUDP,
+	"icmp": domain.ProtocolICMP,
+}
+
+// NewPathCmd returns "network path".
+func NewPathCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "path <source> <destination>",
+		Short:         "Check whether a source can reach a destination on a port",
+		Long:          pathLong,
+		Example:       pathExamples,
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPathCommand(cmd, args, appCtx)
+		},
+	}
+	networkFlags.Port.Add(cmd)
+	networkFlags.Protocol.Add(cmd)
+	return cmd
+}
+
+// runPathCommand resolves both endpoints and prints the evaluated path.
+func runPathCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	ctx := cmd.Context()
+	port := flags.GetIntFlag(cmd, flags.FlagNamePort, 0)
+	protocolName := strings.ToLower(flags.GetStringFlag(cmd, flags.FlagNameProtocol, "tcp"))
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+
+	protocol, ok := protocols[protocolName]
+	if !ok {
+		return fmt.Errorf("--%s must be tcp, udp or icmp, got %q", flags.FlagNameProtocol, protocolName)
+	}
+	if port < 0 || port > 65535 {
+		return fmt.Errorf("--%s must be between 1 and 65535, got %d", flags.FlagNamePort, port)
+	}
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running network path command",
+		"source", args[0], "destination", args[1], "protocol", protocolName, "port", port)
+
+	source, _, err := resolveEndpoint(ctx, appCtx, args[0])
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	destination, defaultPort, err := resolveEndpoint(ctx, appCtx, args[1])
+	if err != nil {
+		return fmt.Errorf("destination: %w", err)
+	}
+	if port == 0 && protocol != domain.ProtocolICMP {
+		if defaultPort == 0 {
+			return fmt.Errorf("--%s is required when the destination is an IP address", flags.FlagNamePort)
+		}
+		port = defaultPort
+	}
+
+	q := pathSvc.Query{Source: source, Destination: destination, Protocol: protocol, Port: port}
+	return pathSvc.TracePath(ctx, appCtx, q, useJSON)
+}
+
+// resolveEndpoint turns a raw IP or a <kind>:<name|ocid> spec into an endpoint. For resources it also
+// returns their standard port.
+func resolveEndpoint(ctx context.Context, appCtx *app.ApplicationContext, spec string) (pathSvc.Endpoint, int, error) {
+	spec = strings.TrimSpace(spec)
+	if ip := net.ParseIP(spec); ip != nil {
+		return pathSvc.Endpoint{Kind: pathSvc.KindIP, Name: spec, IP: ip.String()}, 0, nil
+	}
+	kind, name, err := bastionSvc.ParseTargetSpec(spec)
+	if err != nil {
+		return pathSvc.Endpoint{}, 0, err
+	}
+	target, err := bastionSvc.ResolveTarget(ctx, appCtx, kind, name)
+	if err != nil {
+		return pathSvc.Endpoint{}, 0, err
+	}
+	return pathSvc.Endpoint{
+		Kind:     string(target.Kind),
+		ID:       target.ID,
+		Name:     target.Name,
+		IP:       target.IP,
+		VcnID:    target.VcnID,
+		SubnetID: target.SubnetID,
+		NsgIDs:   target.NsgIDs,
+	}, target.Port, nil
+}
@@ -0,0 +1,37 @@
+package path
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathCommand(t *testing.T) {
+	cmd := NewPathCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "path <source> <destination>", cmd.Use)
+	assert.Equal(t, pathLong, cmd.Long)
+	assert.Equal(t, pathExamples, cmd.Example)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, []string{"instance:app-1"}))
+	require.NotNil(t, cmd.Flags().Lookup(flags.FlagNamePort))
+	assert.Equal(t, "tcp", cmd.Flags().Lookup(flags.FlagNameProtocol).DefValue)
+}
+
+func TestPathCommand_ValidatesFlags(t *testing.T) {
+	cmd := NewPathCmd(&app.ApplicationContext{})
+	cmd.SetContext(context.Background())
+
+	require.NoError(t, cmd.Flags().Set(flags.FlagNameProtocol, "sctp"))
+	assert.ErrorContains(t, runPathCommand(cmd, []string{"10.0.0.1", "10.0.0.2"}, &app.ApplicationContext{}), "must be tcp, udp or icmp")
+
+	require.NoError(t, cmd.Flags().Set(flags.FlagNameProtocol, "TCP"))
+	assert.ErrorContains(t, runPathCommand(cmd, []string{"10.0.0.1", "10.0.0.2"}, &app.ApplicationContext{}), "--port is required")
+
+	assert.ErrorContains(t, runPathCommand(cmd, []string{"bogus", "10.0.0.2"}, &app.ApplicationContext{}), "source: invalid target")
+}
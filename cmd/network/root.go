@@ -1,8 +1,11 @@
 package network
 
 import (
+	auditcmd "github.com/rozdolsky33/ocloud/cmd/network/audit"
+	cidrcmd "github.com/rozdolsky33/ocloud/cmd/network/cidr"
 	lbcmd "github.com/rozdolsky33/ocloud/cmd/network/loadbalancer"
 	nlbcmd "github.com/rozdolsky33/ocloud/cmd/network/networklb"
+	pathcmd "github.com/rozdolsky33/ocloud/cmd/network/path"
 	"github.com/rozdolsky33/ocloud/cmd/network/subnet"
 	vcncmd "github.com/rozdolsky33/ocloud/cmd/network/vcn"
 	"github.com/rozdolsky33/ocloud/internal/app"
@@ -24,6 +27,9 @@ func NewNetworkCmd(appCtx *app.ApplicationContext) *cobra.Command {
 	cmd.AddCommand(vcncmd.NewVcnCmd(appCtx))
 	cmd.AddCommand(lbcmd.NewLoadBalancerCmd(appCtx))
 	cmd.AddCommand(nlbcmd.NewNetworkLoadBalancerCmd(appCtx))
+	cmd.AddCommand(pathcmd.NewPathCmd(appCtx))
+	cmd.AddCommand(auditcmd.NewAuditCmd(appCtx))
+	cmd.AddCommand(cidrcmd.NewCidrCmd(appCtx))
 
 	return cmd
 }
@@ -21,6 +21,9 @@ func TestNetworkRootCommand(t *testing.T) {
 	hasSubnet := false
 	hasVcn := false
 	hasLB := false
+	hasPath := false
+	hasAudit := false
+	hasCidr := false
 	for _, sc := range cmd.Commands() {
 		switch sc.Use {
 		case "subnet":
@@ -29,9 +32,18 @@ func TestNetworkRootCommand(t *testing.T) {
 			hasVcn = true
 		case "load-balancer":
 			hasLB = true
+		case "path <source> <destination>":
+			hasPath = true
+		case "audit":
+			hasAudit = true
+		case "cidr":
+			hasCidr = true
 		}
 	}
 	assert.True(t, hasSubnet, "expected subnet subcommand")
 	assert.True(t, hasVcn, "expected vcn subcommand")
 	assert.True(t, hasLB, "expected load-balancer subcommand")
+	assert.True(t, hasPath, "expected path subcommand")
+	assert.True(t, hasAudit, "expected audit subcommand")
+	assert.True(t, hasCidr, "expected cidr subcommand")
 }
@@ -0,0 +1,71 @@
+package vcn
+
+import (
+	"fmt"
+
+	networkFlags "github.com/rozdolsky33/ocloud/cmd/network/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	netvcn "github.com/rozdolsky33/ocloud/internal/services/network/vcn"
+	"github.com/spf13/cobra"
+)
+
+var diagramLong = `
+Draw the topology of a VCN, given by name or OCID, as a diagram.
+
+Subnets are drawn inside the VCN together with its internet, NAT, service, DRG and local peering gateways.
+Each subnet has an edge to every gateway its route table sends traffic to, labelled with the destinations.
+Internet and NAT gateways lead to the internet, service gateways to the Oracle Services Network and local
+peering gateways to the VCNs they peer with.
+
+Formats:
+- dot: Graphviz DOT
+- mermaid: a Mermaid flowchart for Markdown documents and wikis
+- svg-ready: Graphviz DOT with colours, fonts and OCID tooltips, to render with "dot -Tsvg"
+
+Use --resources to place the instances, load balancers and databases of the compartment in their subnets.
+Anything that cannot be fetched is left out and explained in a comment at the top of the diagram.
+`
+
+var diagramExamples = `
+  # Draw a VCN as Graphviz DOT
+  ocloud network vcn diagram prod-vcn
+
+  # Render an SVG with the workloads placed in their subnets
+  ocloud network vcn diagram prod-vcn --format svg-ready --resources | dot -Tsvg > prod-vcn.svg
+
+  # Mermaid flowchart for a README
+  ocloud network vcn diagram ocid1.vcn.oc1..example --format mermaid
+`
+
+// NewDiagramCmd returns "vcn diagram" command.
+func NewDiagramCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "diagram <vcn>",
+		Short:         "Draw a VCN as a DOT or Mermaid diagram",
+		Long:          diagramLong,
+		Example:       diagramExamples,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiagramCommand(cmd, args, appCtx)
+		},
+	}
+	networkFlags.DiagramFormat.Add(cmd)
+	networkFlags.Resources.Add(cmd)
+	return cmd
+}
+
+func runDiagramCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	format := flags.GetStringFlag(cmd, flags.FlagNameFormat, networkFlags.DiagramFormat.Default)
+	resources := flags.GetBoolFlag(cmd, flags.FlagNameResources, false)
+	switch format {
+	case netvcn.DiagramFormatDOT, netvcn.DiagramFormatMermaid, netvcn.DiagramFormatSVGReady:
+	default:
+		return fmt.Errorf("--%s must be %s, %s or %s", flags.FlagNameFormat, netvcn.DiagramFormatDOT, netvcn.DiagramFormatMermaid, netvcn.DiagramFormatSVGReady)
+	}
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running network vcn diagram", "vcn", args[0], "format", format, "resources", resources)
+	return netvcn.DiagramVCN(appCtx, args[0], format, resources)
+}
@@ -0,0 +1,31 @@
+package vcn
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagramCommand(t *testing.T) {
+	appCtx := &app.ApplicationContext{}
+	cmd := NewDiagramCmd(appCtx)
+
+	assert.Equal(t, "diagram <vcn>", cmd.Use)
+	assert.Equal(t, diagramLong, cmd.Long)
+	assert.Equal(t, diagramExamples, cmd.Example)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, nil))
+
+	format := cmd.Flag("format")
+	require.NotNil(t, format)
+	assert.Equal(t, "dot", format.DefValue)
+	resources := cmd.Flag("resources")
+	require.NotNil(t, resources)
+	assert.Equal(t, "false", resources.DefValue)
+
+	require.NoError(t, cmd.Flags().Set("format", "png"))
+	assert.ErrorContains(t, runDiagramCommand(cmd, []string{"prod"}, appCtx), "--format must be dot, mermaid or svg-ready")
+}
@@ -23,6 +23,7 @@ navigate pages using --page (-p).
 Additional Information:
 - Use --json (-j) to output the results in JSON format
 - Use flags to include related resources: gateways, subnets, NSGs, route tables, security lists
+- NSGs, route tables and security lists are followed by their rules; NSG peers and route targets are shown by name
 `
 
 // Examples for the get command
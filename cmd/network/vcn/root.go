@@ -10,7 +10,7 @@ func NewVcnCmd(appCtx *app.ApplicationContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:           "vcn",
 		Short:         "Explore OCI Virtual Cloud Networks (VCNs)",
-		Long:          "  ocloud network vcn list \n  ocloud network vcn get \n  ocloud network vcn search <value>",
+		Long:          "  ocloud network vcn list \n  ocloud network vcn get \n  ocloud network vcn search <value> \n  ocloud network vcn diagram <vcn>",
 		SilenceUsage:  true,
 		SilenceErrors: true,
 	}
@@ -18,5 +18,6 @@ func NewVcnCmd(appCtx *app.ApplicationContext) *cobra.Command {
 	cmd.AddCommand(NewGetCmd(appCtx))
 	cmd.AddCommand(NewListCmd(appCtx))
 	cmd.AddCommand(NewSearchCmd(appCtx))
+	cmd.AddCommand(NewDiagramCmd(appCtx))
 	return cmd
 }
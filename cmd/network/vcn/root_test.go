@@ -24,7 +24,7 @@ func TestNewVcnCmd(t *testing.T) {
 	assert.Equal(t, "Explore OCI Virtual Cloud Networks (VCNs)", cmd.Short)
 
 	// Check if subcommands are added
-	expectedSubcommands := []string{"get", "list", "search"}
+	expectedSubcommands := []string{"get", "list", "search", "diagram"}
 	for _, sub := range expectedSubcommands {
 		found := false
 		for _, c := range cmd.Commands() {
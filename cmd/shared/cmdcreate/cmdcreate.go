@@ -8,6 +8,7 @@ import (
 	"github.com/rozdolsky33/ocloud/cmd/configuration"
 	"github.com/rozdolsky33/ocloud/cmd/database"
 	"github.com/rozdolsky33/ocloud/cmd/identity"
+	"github.com/rozdolsky33/ocloud/cmd/identity/bastion"
 	"github.com/rozdolsky33/ocloud/cmd/network"
 	"github.com/rozdolsky33/ocloud/cmd/storage"
 	"github.com/rozdolsky33/ocloud/cmd/version"
@@ -34,6 +35,7 @@ func CreateRootCmd(appCtx *app.ApplicationContext) *cobra.Command {
 	rootCmd.AddCommand(version.NewVersionCommand())
 	version.AddVersionFlag(rootCmd, os.Stdout)
 	rootCmd.AddCommand(configuration.NewConfigCmd())
+	rootCmd.AddCommand(bastion.NewForwardCmd())
 
 	// If appCtx is not nil, add commands that need context
 	if appCtx != nil {
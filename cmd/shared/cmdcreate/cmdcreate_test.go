@@ -25,6 +25,11 @@ func TestCreateRootCmd(t *testing.T) {
 	configCmd := findSubcommand(rootCmd, "config")
 	assert.NotNil(t, configCmd, "config command should be added as a subcommand")
 
+	// Verify that the hidden port forwarder command is added without context
+	forwardCmd := findSubcommand(rootCmd, "__forward")
+	assert.NotNil(t, forwardCmd, "forwarder command should be added as a subcommand")
+	assert.True(t, forwardCmd.Hidden, "forwarder command should be hidden")
+
 	// Verify that the compute command is not added when appCtx is nil
 	computeCmd := findSubcommand(rootCmd, "compute")
 	assert.Nil(t, computeCmd, "compute command should not be added when appCtx is nil")
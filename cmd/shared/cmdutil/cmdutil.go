@@ -15,6 +15,8 @@ func IsNoContextCommand() bool {
 	noContextCommands := map[string]bool{
 		"version": true,
 		"config":  true,
+		// Detached bastion port forwarder; it only needs its own configuration.
+		"__forward": true,
 	}
 
 	// Flags that don't need context
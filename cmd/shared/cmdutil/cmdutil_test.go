@@ -21,6 +21,10 @@ func TestIsNoContextCommand(t *testing.T) {
 	os.Args = []string{"ocloud", "config"}
 	assert.True(t, IsNoContextCommand(), "should return true for 'config' command")
 
+	// Test with the hidden port forwarder command
+	os.Args = []string{"ocloud", "__forward"}
+	assert.True(t, IsNoContextCommand(), "should return true for the '__forward' command")
+
 	// Test with version flag (short)
 	os.Args = []string{"ocloud", "-v"}
 	assert.True(t, IsNoContextCommand(), "should return true for '-v' flag")
@@ -234,15 +234,13 @@ func CheckPortForwardingStatus() PortForwardingStatus {
 		}
 	}
 
-	var ports []int
-	for _, tunnel := range tunnels {
-		ports = append(ports, tunnel.LocalPort)
-	}
-	sort.Ints(ports)
+	sort.Slice(tunnels, func(i, j int) bool { return tunnels[i].LocalPort < tunnels[j].LocalPort })
 
-	portStrs := make([]string, len(ports))
-	for i, port := range ports {
-		portStrs[i] = fmt.Sprintf("%d", port)
+	ports := make([]int, len(tunnels))
+	portStrs := make([]string, len(tunnels))
+	for i, tunnel := range tunnels {
+		ports[i] = tunnel.LocalPort
+		portStrs[i] = describeTunnel(tunnel, time.Now())
 	}
 	portsDisplay := strings.Join(portStrs, ", ")
 
@@ -253,6 +251,23 @@ func CheckPortForwardingStatus() PortForwardingStatus {
 	}
 }
 
+// describeTunnel renders a tunnel as "<port>", adding the target name and remaining session time when known,
+// e.g. "1521 → prod-adb (expires in 12m00s)".
+func describeTunnel(t bastionSvc.TunnelInfo, now time.Time) string {
+	desc := fmt.Sprintf("%d", t.LocalPort)
+	if t.TargetName != "" {
+		desc += " → " + t.TargetName
+	}
+	if !t.ExpiresAt.IsZero() {
+		if remaining := t.ExpiresAt.Sub(now); remaining > 0 {
+			desc += fmt.Sprintf(" (expires in %s)", bastionSvc.FormatDuration(remaining))
+		} else {
+			desc += " (expired)"
+		}
+	}
+	return desc
+}
+
 // PrintOCIConfiguration displays the current configuration details
 func PrintOCIConfiguration() {
 	displayBanner()
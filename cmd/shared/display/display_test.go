@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	bastionSvc "github.com/rozdolsky33/ocloud/internal/services/identity/bastion"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -462,3 +463,19 @@ func TestJWTClaims(t *testing.T) {
 		})
 	}
 }
+
+func TestDescribeTunnel(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "5901", describeTunnel(bastionSvc.TunnelInfo{LocalPort: 5901}, now))
+	assert.Equal(t, "1521 → prod-adb (expires in 12m00s)", describeTunnel(bastionSvc.TunnelInfo{
+		LocalPort:  1521,
+		TargetName: "prod-adb",
+		ExpiresAt:  now.Add(12 * time.Minute),
+	}, now))
+	assert.Equal(t, "6443 → oke (expired)", describeTunnel(bastionSvc.TunnelInfo{
+		LocalPort:  6443,
+		TargetName: "oke",
+		ExpiresAt:  now.Add(-time.Minute),
+	}, now))
+}
@@ -47,4 +47,11 @@ var (
 		Default:   false,
 		Usage:     flags.FlagDescTenancyScope,
 	}
+
+	YesFlag = flags.BoolFlag{
+		Name:      flags.FlagNameYes,
+		Shorthand: flags.FlagShortYes,
+		Default:   false,
+		Usage:     flags.FlagDescYes,
+	}
 )
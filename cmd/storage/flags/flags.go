@@ -0,0 +1,186 @@
+package flags
+
+import "github.com/rozdolsky33/ocloud/internal/config/flags"
+
+var FlagDefaultParallel = 4
+
+var (
+	ResumeFlag = flags.BoolFlag{
+		Name:    flags.FlagNameResume,
+		Default: false,
+		Usage:   flags.FlagDescResume,
+	}
+
+	ParallelFlag = flags.IntFlag{
+		Name:    flags.FlagNameParallel,
+		Default: FlagDefaultParallel,
+		Usage:   flags.FlagDescParallel,
+	}
+)
+
+var (
+	RecursiveFlag = flags.BoolFlag{
+		Name:    flags.FlagNameRecursive,
+		Default: false,
+		Usage:   flags.FlagDescRecursive,
+	}
+
+	ContentTypeFlag = flags.StringFlag{
+		Name:    flags.FlagNameContentType,
+		Default: "",
+		Usage:   flags.FlagDescContentType,
+	}
+
+	MetadataFlag = flags.StringSliceFlag{
+		Name:    flags.FlagNameMetadata,
+		Default: nil,
+		Usage:   flags.FlagDescMetadata,
+	}
+
+	QuietFlag = flags.BoolFlag{
+		Name:    flags.FlagNameQuiet,
+		Default: false,
+		Usage:   flags.FlagDescQuiet,
+	}
+)
+
+var (
+	DeleteFlag = flags.BoolFlag{
+		Name:    flags.FlagNameDelete,
+		Default: false,
+		Usage:   flags.FlagDescDelete,
+	}
+
+	IncludeFlag = flags.StringSliceFlag{
+		Name:    flags.FlagNameInclude,
+		Default: nil,
+		Usage:   flags.FlagDescInclude,
+	}
+
+	ExcludeFlag = flags.StringSliceFlag{
+		Name:    flags.FlagNameExclude,
+		Default: nil,
+		Usage:   flags.FlagDescExclude,
+	}
+
+	DryRunFlag = flags.BoolFlag{
+		Name:    flags.FlagNameDryRun,
+		Default: false,
+		Usage:   flags.FlagDescDryRun,
+	}
+)
+
+// FlagDefaultExpires is how long a pre-authenticated request stays valid unless --expires is given.
+var FlagDefaultExpires = "24h"
+
+var (
+	AccessFlag = flags.StringFlag{
+		Name:    flags.FlagNameAccess,
+		Default: "read",
+		Usage:   flags.FlagDescAccess,
+	}
+
+	ExpiresFlag = flags.StringFlag{
+		Name:    flags.FlagNameExpires,
+		Default: FlagDefaultExpires,
+		Usage:   flags.FlagDescExpires,
+	}
+
+	ParNameFlag = flags.StringFlag{
+		Name:    flags.FlagNameParName,
+		Default: "",
+		Usage:   flags.FlagDescParName,
+	}
+
+	AllowListingFlag = flags.BoolFlag{
+		Name:    flags.FlagNameListing,
+		Default: false,
+		Usage:   flags.FlagDescListing,
+	}
+
+	ShareFlag = flags.BoolFlag{
+		Name:    flags.FlagNameShare,
+		Default: false,
+		Usage:   flags.FlagDescShare,
+	}
+)
+
+// FlagDefaultRestoreHours is how long restored objects stay available unless --hours is given.
+var FlagDefaultRestoreHours = 24
+
+var (
+	RemoveRecursiveFlag = flags.BoolFlag{
+		Name:    flags.FlagNameRecursive,
+		Default: false,
+		Usage:   flags.FlagDescRemoveRecursive,
+	}
+
+	RestoreRecursiveFlag = flags.BoolFlag{
+		Name:    flags.FlagNameRecursive,
+		Default: false,
+		Usage:   flags.FlagDescRestoreRecursive,
+	}
+
+	HoursFlag = flags.IntFlag{
+		Name:    flags.FlagNameHours,
+		Default: FlagDefaultRestoreHours,
+		Usage:   flags.FlagDescHours,
+	}
+
+	WaitFlag = flags.BoolFlag{
+		Name:    flags.FlagNameWait,
+		Default: false,
+		Usage:   flags.FlagDescWait,
+	}
+)
+
+var (
+	VersionIDFlag = flags.StringFlag{
+		Name:    flags.FlagNameVersionID,
+		Default: "",
+		Usage:   flags.FlagDescVersionID,
+	}
+
+	PromoteFlag = flags.StringFlag{
+		Name:    flags.FlagNamePromote,
+		Default: "",
+		Usage:   flags.FlagDescPromote,
+	}
+)
+
+var (
+	PoliciesFlag = flags.BoolFlag{
+		Name:    flags.FlagNamePolicies,
+		Default: false,
+		Usage:   flags.FlagDescPolicies,
+	}
+)
+
+// FlagDefaultTop is the number of largest buckets "usage" lists unless --top is given.
+var FlagDefaultTop = 10
+
+var (
+	TopFlag = flags.IntFlag{
+		Name:    flags.FlagNameTop,
+		Default: FlagDefaultTop,
+		Usage:   flags.FlagDescTop,
+	}
+
+	TagKeyFlag = flags.StringFlag{
+		Name:    flags.FlagNameTagKey,
+		Default: "",
+		Usage:   flags.FlagDescTagKey,
+	}
+
+	PriceSheetFlag = flags.StringFlag{
+		Name:    flags.FlagNamePriceSheet,
+		Default: "",
+		Usage:   flags.FlagDescPriceSheet,
+	}
+
+	CSVFlag = flags.BoolFlag{
+		Name:    flags.FlagNameCSV,
+		Default: false,
+		Usage:   flags.FlagDescCSV,
+	}
+)
@@ -0,0 +1,120 @@
+package objectstorage
+
+import (
+	"fmt"
+	"strings"
+
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+	"github.com/spf13/cobra"
+)
+
+var cpLong = `
+Copy files to, from and between Object Storage buckets without any prompts, for use in scripts and pipelines.
+
+Object Storage locations are written as oci://<bucket>/<object or prefix>. At least one of the source and the
+destination must be such a location:
+
+  cp <file> oci://<bucket>/<object>          upload a file
+  cp <file> oci://<bucket>/<prefix>/         upload a file under a prefix, keeping its name
+  cp <dir> oci://<bucket>/<prefix>/ -r       upload the contents of a directory under a prefix
+  cp oci://<bucket>/<object> <path>          download an object to a file or into a directory
+  cp oci://<bucket>/<prefix>/ <dir> -r       download every object under a prefix
+  cp oci://<bucket>/<object> oci://<bucket2>/<object>   copy between buckets (server-side)
+
+Use - as the source to upload from stdin, or as the destination to write an object to stdout. When writing to
+stdout, the summary is printed to stderr.
+
+Files are transferred with the same multipart upload and ranged download as upload and download. --parallel
+bounds the requests in flight: a single file uses them all for its parts, many files are transferred at once. Failed transfers do not stop the others; the command exits with an error if any
+transfer failed. With --quiet only a JSON summary of the transfers is printed.
+
+In a bucket with versioning, --version-id downloads or copies an older version of a single source object; list
+the versions with "object-storage versions".
+`
+
+var cpExamples = `
+  # Upload a file
+  ocloud storage object-storage cp ./backup.tar.gz oci://backups/2024/
+
+  # Upload a directory with a content type and metadata
+  ocloud storage object-storage cp ./site oci://web/static/ --recursive --content-type text/html --metadata owner=web
+
+  # Download everything under a prefix
+  ocloud storage object-storage cp oci://logs/2024/06/ ./logs --recursive
+
+  # Stream a database dump into a bucket
+  pg_dump mydb | ocloud stg os cp - oci://backups/mydb.sql
+
+  # Print an object
+  ocloud stg os cp oci://config/app.yaml -
+
+  # Copy between buckets and print a JSON summary
+  ocloud stg os cp oci://src/data/ oci://dst/data/ --recursive --quiet
+
+  # Download an older version of an object
+  ocloud stg os cp oci://config/app.yaml ./app.yaml.old --version-id <version-id>
+`
+
+func NewCpCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "cp <source> <destination>",
+		Short:         "Copy files and objects to, from and between buckets",
+		Long:          cpLong,
+		Example:       cpExamples,
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCpCommand(cmd, args, appCtx)
+		},
+	}
+	storageFlags.RecursiveFlag.Add(cmd)
+	storageFlags.ContentTypeFlag.Add(cmd)
+	storageFlags.MetadataFlag.Add(cmd)
+	storageFlags.ParallelFlag.Add(cmd)
+	storageFlags.QuietFlag.Add(cmd)
+	storageFlags.VersionIDFlag.Add(cmd)
+	return cmd
+}
+
+func runCpCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	metadata, err := parseMetadata(flags.GetStringSliceFlag(cmd, flags.FlagNameMetadata, nil))
+	if err != nil {
+		return err
+	}
+	opts := osSvc.CopyOptions{
+		Recursive: flags.GetBoolFlag(cmd, flags.FlagNameRecursive, false),
+		Transfer: osSvc.TransferOptions{
+			Parallelism: flags.GetIntFlag(cmd, flags.FlagNameParallel, storageFlags.FlagDefaultParallel),
+			ContentType: flags.GetStringFlag(cmd, flags.FlagNameContentType, ""),
+			Metadata:    metadata,
+			VersionID:   flags.GetStringFlag(cmd, flags.FlagNameVersionID, ""),
+		},
+	}
+	if opts.Transfer.Parallelism < 1 {
+		return fmt.Errorf("--%s must be at least 1, got %d", flags.FlagNameParallel, opts.Transfer.Parallelism)
+	}
+	quiet := flags.GetBoolFlag(cmd, flags.FlagNameQuiet, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage cp command", "source", args[0], "destination", args[1], "recursive", opts.Recursive, "parallel", opts.Transfer.Parallelism)
+	return osSvc.CopyObjects(appCtx, args[0], args[1], opts, quiet, cmd.InOrStdin())
+}
+
+// parseMetadata turns --metadata key=value pairs into a map.
+func parseMetadata(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	metadata := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --%s %q: expected key=value", flags.FlagNameMetadata, pair)
+		}
+		metadata[strings.TrimSpace(key)] = value
+	}
+	return metadata, nil
+}
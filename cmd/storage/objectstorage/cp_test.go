@@ -0,0 +1,50 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCpCommand(t *testing.T) {
+	appCtx := &app.ApplicationContext{}
+	cmd := NewCpCmd(appCtx)
+
+	assert.Equal(t, "cp <source> <destination>", cmd.Use)
+	assert.Equal(t, cpLong, cmd.Long)
+	assert.Equal(t, cpExamples, cmd.Example)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, []string{"only-one"}))
+	assert.NoError(t, cmd.Args(cmd, []string{"a", "oci://b/c"}))
+
+	for _, name := range []string{flags.FlagNameRecursive, flags.FlagNameContentType, flags.FlagNameMetadata, flags.FlagNameParallel, flags.FlagNameQuiet, flags.FlagNameVersionID} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "expected --%s", name)
+	}
+}
+
+func TestCpCommand_RejectsBadFlags(t *testing.T) {
+	cmd := NewCpCmd(&app.ApplicationContext{})
+	require.NoError(t, cmd.Flags().Set(flags.FlagNameParallel, "0"))
+	assert.ErrorContains(t, runCpCommand(cmd, []string{"a", "oci://b/c"}, &app.ApplicationContext{}), "--parallel")
+
+	cmd = NewCpCmd(&app.ApplicationContext{})
+	require.NoError(t, cmd.Flags().Set(flags.FlagNameMetadata, "novalue"))
+	assert.ErrorContains(t, runCpCommand(cmd, []string{"a", "oci://b/c"}, &app.ApplicationContext{}), "key=value")
+}
+
+func TestParseMetadata(t *testing.T) {
+	m, err := parseMetadata([]string{"owner=web", "build=42", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "web", "build": "42", "empty": ""}, m)
+
+	m, err = parseMetadata(nil)
+	require.NoError(t, err)
+	assert.Nil(t, m)
+
+	_, err = parseMetadata([]string{"=x"})
+	assert.Error(t, err)
+}
@@ -1,7 +1,12 @@
 package objectstorage
 
 import (
+	"fmt"
+
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
 	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
 	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
 	"github.com/spf13/cobra"
 )
@@ -13,12 +18,25 @@ This command launches an interactive TUI that:
 1. Shows available buckets to select the source
 2. Shows objects in the bucket to select the file to download
 3. Downloads the file to the current directory with progress reporting
+
+Objects are fetched with parallel range requests (--parallel, default 4) into <name>.part. The file is
+checked against the object's MD5 (or multipart MD5) and renamed into place only when it matches. If a
+download is interrupted, downloading the same object again continues with the missing ranges.
+
+With --share, the selected object is not downloaded: a read-only pre-authenticated request is created for it
+and its URL is printed, valid for --expires (default 24h).
 `
 
 var downloadExamples = `
   # Launch the interactive download flow
   ocloud storage object-storage download
 
+  # Download with 8 ranges in flight
+  ocloud storage object-storage download --parallel 8
+
+  # Pick an object and print a link to it that is valid for 7 days
+  ocloud stg os download --share --expires 7d
+
   # Using short aliases
   ocloud stg os download
 `
@@ -32,9 +50,27 @@ func NewDownloadCmd(appCtx *app.ApplicationContext) *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return osSvc.DownloadFile(appCtx)
+			return runDownloadCommand(cmd, appCtx)
 		},
 	}
-
+	storageFlags.ParallelFlag.Add(cmd)
+	storageFlags.ShareFlag.Add(cmd)
+	storageFlags.ExpiresFlag.Add(cmd)
 	return cmd
 }
+
+func runDownloadCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	if flags.GetBoolFlag(cmd, flags.FlagNameShare, false) {
+		expires := flags.GetStringFlag(cmd, flags.FlagNameExpires, storageFlags.FlagDefaultExpires)
+		logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage download command", "share", true, "expires", expires)
+		return osSvc.ShareFile(appCtx, osSvc.PAROptions{Expires: expires}, flags.GetBoolFlag(cmd, flags.FlagNameJSON, false))
+	}
+	opts := osSvc.TransferOptions{
+		Parallelism: flags.GetIntFlag(cmd, flags.FlagNameParallel, storageFlags.FlagDefaultParallel),
+	}
+	if opts.Parallelism < 1 {
+		return fmt.Errorf("--%s must be at least 1, got %d", flags.FlagNameParallel, opts.Parallelism)
+	}
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage download command", "parallel", opts.Parallelism)
+	return osSvc.DownloadFile(appCtx, opts)
+}
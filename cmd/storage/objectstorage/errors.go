@@ -0,0 +1,6 @@
+package objectstorage
+
+import osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+
+// ErrAborted is returned when the user declines a confirmation prompt.
+var ErrAborted = osSvc.ErrAborted
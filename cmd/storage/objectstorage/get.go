@@ -2,6 +2,7 @@ package objectstorage
 
 import (
 	osflags "github.com/rozdolsky33/ocloud/cmd/shared/flags"
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
 	"github.com/rozdolsky33/ocloud/internal/app"
 	"github.com/rozdolsky33/ocloud/internal/config/flags"
 	"github.com/rozdolsky33/ocloud/internal/logger"
@@ -13,7 +14,11 @@ var getLong = `Get Object Storage buckets in the specified compartment with pagi
 
 This command lists Object Storage buckets in the current compartment. By default, it shows a concise table
 with key fields (name, namespace, created). Use --all (-A) to include extended bucket details (tier, access,
-versioning, encryption, counts) and --json (-j) for machine-readable output.`
+versioning, encryption, counts) and --json (-j) for machine-readable output.
+
+Use --policies to also fetch and show the lifecycle rules (action, target tier, time and prefix filters),
+retention rules (duration and locked state), replication policies (destination region and bucket, status)
+and object events setting of each bucket.`
 
 var getExamples = `  # Get buckets with default pagination (20 per page)
   ocloud storage object-storage get
@@ -24,6 +29,9 @@ var getExamples = `  # Get buckets with default pagination (20 per page)
   # Get buckets and include extended details in the table
   ocloud storage object-storage get --all
 
+  # Show lifecycle, retention and replication policies
+  ocloud storage object-storage get --policies
+
   # JSON output with short aliases
   ocloud storage os get -A -j`
 
@@ -41,6 +49,7 @@ func NewGetCmd(appCtx *app.ApplicationContext) *cobra.Command {
 	}
 	osflags.LimitFlag.Add(cmd)
 	osflags.PageFlag.Add(cmd)
+	storageFlags.PoliciesFlag.Add(cmd)
 	return cmd
 }
 
@@ -48,6 +57,7 @@ func runGetCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
 	limit := flags.GetIntFlag(cmd, flags.FlagNameLimit, osflags.FlagDefaultLimit)
 	page := flags.GetIntFlag(cmd, flags.FlagNamePage, osflags.FlagDefaultPage)
 	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
-	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage get command", "compartment", appCtx.CompartmentName, "limit", limit, "page", page, "json", useJSON)
-	return osSvc.GetBuckets(appCtx, limit, page, useJSON)
+	policies := flags.GetBoolFlag(cmd, flags.FlagNamePolicies, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage get command", "compartment", appCtx.CompartmentName, "limit", limit, "page", page, "json", useJSON, "policies", policies)
+	return osSvc.GetBuckets(appCtx, limit, page, useJSON, policies)
 }
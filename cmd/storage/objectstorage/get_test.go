@@ -26,4 +26,8 @@ func TestGetCommand_ObjectStorage(t *testing.T) {
 	page := cmd.Flag("page")
 	assert.NotNil(t, page)
 	assert.Equal(t, "p", page.Shorthand)
+
+	policies := cmd.Flag("policies")
+	assert.NotNil(t, policies)
+	assert.Equal(t, "false", policies.DefValue)
 }
@@ -15,7 +15,10 @@ This command launches a terminal UI that loads available Buckets and lets you:
 - Navigate the list
 - Select a single Bucket to view its details
 
-After you pick a Bucket, the tool prints detailed information about the selected Bucket in the default table view or JSON format if specified with --json (-j).
+After you pick a Bucket, you browse its objects folder by folder: Enter opens a folder, ".." or Esc goes back up,
+and large folders are loaded one page of 1000 entries at a time ("Load more..."). The title shows how many
+folders and objects of the current folder are loaded. Pick an object to view its details in the default table
+view or JSON format if specified with --json (-j), or to download it.
 `
 
 var listExamples = `
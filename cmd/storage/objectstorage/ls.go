@@ -0,0 +1,57 @@
+package objectstorage
+
+import (
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+	"github.com/spf13/cobra"
+)
+
+var lsLong = `
+List the folders and objects under a bucket prefix.
+
+Object Storage has no real directories: folders are the prefixes of object names up to the next "/". Without
+--recursive, ls shows the folders (marked PRE) and objects directly under the prefix, with names relative to
+it. With --recursive, every object below the prefix is listed with its full name. Results are fetched and
+printed page by page, so even buckets with millions of objects start printing at once.
+`
+
+var lsExamples = `
+  # List the top level of a bucket
+  ocloud storage object-storage ls oci://logs
+
+  # List one folder
+  ocloud storage object-storage ls oci://logs/2024/06/
+
+  # List every object below a prefix
+  ocloud stg os ls oci://logs/2024/ --recursive
+
+  # Output in JSON format
+  ocloud stg os ls oci://logs/2024/ --json
+`
+
+func NewLsCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "ls <oci://bucket/prefix>",
+		Short:         "List folders and objects under a bucket prefix",
+		Long:          lsLong,
+		Example:       lsExamples,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLsCommand(cmd, args, appCtx)
+		},
+	}
+	storageFlags.RecursiveFlag.Add(cmd)
+	return cmd
+}
+
+func runLsCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	recursive := flags.GetBoolFlag(cmd, flags.FlagNameRecursive, false)
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage ls command", "path", args[0], "recursive", recursive, "json", useJSON)
+	return osSvc.ListPath(appCtx, args[0], recursive, useJSON)
+}
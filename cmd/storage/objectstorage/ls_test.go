@@ -0,0 +1,24 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLsCommand(t *testing.T) {
+	cmd := NewLsCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "ls <oci://bucket/prefix>", cmd.Use)
+	assert.Equal(t, lsLong, cmd.Long)
+	assert.Equal(t, lsExamples, cmd.Example)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, []string{}))
+	assert.NotNil(t, cmd.Flags().Lookup(flags.FlagNameRecursive))
+
+	// A local path is rejected before any client is created.
+	assert.ErrorContains(t, runLsCommand(cmd, []string{"./dir"}, &app.ApplicationContext{}), "oci://")
+}
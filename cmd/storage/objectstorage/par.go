@@ -0,0 +1,43 @@
+package objectstorage
+
+import (
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var parLong = `
+Manage pre-authenticated requests (PARs): links that give access to an object, or to every object under a
+prefix, without OCI credentials, until they expire.
+
+A location ending in "/" (or a bare bucket) shares every object under that prefix; any other location shares
+that one object. Object Storage returns the full URL only when the request is created, so save it then.
+`
+
+var parExamples = `
+  # Share a report for 7 days
+  ocloud storage object-storage par create oci://reports/2024/q1.pdf --expires 7d
+
+  # Let another team upload into a prefix until the end of the year
+  ocloud stg os par create oci://incoming/team-a/ --access write --expires 2025-12-31T23:59:59Z
+
+  # List the requests of a bucket, then delete one
+  ocloud stg os par list oci://reports
+  ocloud stg os par delete oci://reports <par-id>
+`
+
+// NewParCmd returns the "object-storage par" command group.
+func NewParCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "par",
+		Aliases:       []string{"pars"},
+		Short:         "Manage pre-authenticated requests for buckets and objects",
+		Long:          parLong,
+		Example:       parExamples,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.AddCommand(NewParCreateCmd(appCtx))
+	cmd.AddCommand(NewParListCmd(appCtx))
+	cmd.AddCommand(NewParDeleteCmd(appCtx))
+	return cmd
+}
@@ -0,0 +1,42 @@
+package objectstorage
+
+import (
+	"fmt"
+
+	sharedflags "github.com/rozdolsky33/ocloud/cmd/shared/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/services/util"
+	"github.com/spf13/cobra"
+)
+
+// NewParDeleteCmd returns "object-storage par delete".
+func NewParDeleteCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "delete <oci://bucket> <par-id>",
+		Aliases:       []string{"rm"},
+		Short:         "Delete a pre-authenticated request",
+		Long:          "Delete a pre-authenticated request of a bucket; its URL stops working at once. The command asks for confirmation; use --yes (-y) to skip the prompt in scripts.",
+		Example:       "  ocloud storage object-storage par delete oci://reports <par-id>\n  ocloud stg os par delete oci://reports <par-id> --yes",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runParDeleteCommand(cmd, args, appCtx)
+		},
+	}
+	sharedflags.YesFlag.Add(cmd)
+	return cmd
+}
+
+func runParDeleteCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	skipConfirm := flags.GetBoolFlag(cmd, flags.FlagNameYes, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage par delete command", "location", args[0], "par_id", args[1], "yes", skipConfirm)
+
+	if !skipConfirm && !util.PromptYesNo(fmt.Sprintf("Delete pre-authenticated request %s of %s?", args[1], args[0])) {
+		return ErrAborted
+	}
+	return osSvc.DeletePAR(appCtx, args[0], args[1])
+}
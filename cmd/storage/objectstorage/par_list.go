@@ -0,0 +1,33 @@
+package objectstorage
+
+import (
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+	"github.com/spf13/cobra"
+)
+
+// NewParListCmd returns "object-storage par list".
+func NewParListCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "list <oci://bucket[/prefix]>",
+		Aliases:       []string{"ls"},
+		Short:         "List the pre-authenticated requests of a bucket",
+		Long:          "List the pre-authenticated requests of a bucket, or only those for objects under a prefix. Expired requests are marked; URLs cannot be shown again after creation.",
+		Example:       "  ocloud storage object-storage par list oci://reports\n  ocloud stg os par list oci://reports/2024/ --json",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runParListCommand(cmd, args, appCtx)
+		},
+	}
+	return cmd
+}
+
+func runParListCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage par list command", "location", args[0], "json", useJSON)
+	return osSvc.ListPARs(appCtx, args[0], useJSON)
+}
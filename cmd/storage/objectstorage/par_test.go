@@ -0,0 +1,44 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParCommand(t *testing.T) {
+	cmd := NewParCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "par", cmd.Use)
+	assert.Equal(t, parLong, cmd.Long)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+
+	subcommands := map[string]bool{}
+	for _, sc := range cmd.Commands() {
+		subcommands[sc.Name()] = true
+	}
+	for _, name := range []string{"create", "list", "delete"} {
+		assert.True(t, subcommands[name], "expected %s subcommand", name)
+	}
+
+	create, _, _ := cmd.Find([]string{"create"})
+	assert.Error(t, create.Args(create, nil))
+	assert.Equal(t, "read", create.Flags().Lookup(flags.FlagNameAccess).DefValue)
+	assert.Equal(t, "24h", create.Flags().Lookup(flags.FlagNameExpires).DefValue)
+	assert.NotNil(t, create.Flags().Lookup(flags.FlagNameParName))
+	assert.NotNil(t, create.Flags().Lookup(flags.FlagNameListing))
+
+	del, _, _ := cmd.Find([]string{"delete"})
+	assert.Error(t, del.Args(del, []string{"oci://b"}))
+	assert.NotNil(t, del.Flags().Lookup(flags.FlagNameYes))
+}
+
+func TestDownloadCommand_ShareFlags(t *testing.T) {
+	cmd := NewDownloadCmd(&app.ApplicationContext{})
+
+	assert.NotNil(t, cmd.Flags().Lookup(flags.FlagNameShare))
+	assert.Equal(t, "24h", cmd.Flags().Lookup(flags.FlagNameExpires).DefValue)
+}
@@ -0,0 +1,64 @@
+package objectstorage
+
+import (
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+	"github.com/spf13/cobra"
+)
+
+var restoreLong = `
+Restore objects from the Archive storage tier so they can be downloaded.
+
+Restoring an archived object takes up to an hour. The restored copy stays available for --hours (1-240,
+default 24) and then returns to the archive. Objects that are already restoring or restored are reported with their
+state, and objects that are not in the Archive tier are skipped.
+
+With --recursive every archived object under a prefix is restored. With --wait the command checks the objects
+every minute until all of them are restored.
+`
+
+var restoreExamples = `
+  # Restore one object for a day
+  ocloud storage object-storage restore oci://archive/2019/ledger.csv
+
+  # Restore a prefix for a week and wait until it can be downloaded
+  ocloud stg os restore oci://archive/2019/ --recursive --hours 168 --wait
+
+  # JSON output for scripts
+  ocloud stg os restore oci://archive/2019/ledger.csv --json
+`
+
+// NewRestoreCmd returns "object-storage restore".
+func NewRestoreCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "restore <oci://bucket/object|prefix/|glob>",
+		Short:         "Restore archived objects",
+		Long:          restoreLong,
+		Example:       restoreExamples,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRestoreCommand(cmd, args, appCtx)
+		},
+	}
+	storageFlags.RestoreRecursiveFlag.Add(cmd)
+	storageFlags.HoursFlag.Add(cmd)
+	storageFlags.WaitFlag.Add(cmd)
+	return cmd
+}
+
+func runRestoreCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	opts := osSvc.RestoreOptions{
+		Recursive: flags.GetBoolFlag(cmd, flags.FlagNameRecursive, false),
+		Hours:     flags.GetIntFlag(cmd, flags.FlagNameHours, storageFlags.FlagDefaultRestoreHours),
+		Wait:      flags.GetBoolFlag(cmd, flags.FlagNameWait, false),
+	}
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage restore command", "location", args[0],
+		"recursive", opts.Recursive, "hours", opts.Hours, "wait", opts.Wait)
+	return osSvc.RestoreObjects(appCtx, args[0], opts, useJSON)
+}
@@ -0,0 +1,89 @@
+package objectstorage
+
+import (
+	"fmt"
+
+	sharedflags "github.com/rozdolsky33/ocloud/cmd/shared/flags"
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/services/util"
+	"github.com/spf13/cobra"
+)
+
+var rmLong = `
+Delete objects from a bucket.
+
+The location selects what is deleted:
+
+  rm oci://<bucket>/<object>                 a single object
+  rm oci://<bucket>/<prefix>/ --recursive    every object under a prefix
+  rm oci://<bucket>/<prefix>/*.log           the objects matching a glob (* and ? do not match "/")
+
+The objects are listed with their size and storage tier before the command asks for confirmation; use --yes (-y)
+to skip the prompt in scripts, or --dry-run to only list them. --json needs one of the two, so that the output is
+only the JSON summary. Objects in the Archive and Infrequent Access tiers are billed for a minimum retention period
+(90 and 31 days); deleting them sooner is flagged with a warning.
+
+Objects are deleted up to --parallel at a time. Failed deletions do not stop the others; the command exits with an
+error if any deletion failed.
+`
+
+var rmExamples = `
+  # Delete one object
+  ocloud storage object-storage rm oci://reports/2024/q1.pdf
+
+  # Show what deleting a prefix would remove
+  ocloud stg os rm oci://logs/2023/ --recursive --dry-run
+
+  # Delete the matching objects without a prompt
+  ocloud stg os rm 'oci://logs/2024/06/*.gz' --yes --parallel 16
+`
+
+// NewRmCmd returns "object-storage rm".
+func NewRmCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "rm <oci://bucket/object|prefix/|glob>",
+		Short:         "Delete an object, the objects under a prefix or the objects matching a glob",
+		Long:          rmLong,
+		Example:       rmExamples,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRmCommand(cmd, args, appCtx)
+		},
+	}
+	storageFlags.RemoveRecursiveFlag.Add(cmd)
+	storageFlags.DryRunFlag.Add(cmd)
+	storageFlags.ParallelFlag.Add(cmd)
+	sharedflags.YesFlag.Add(cmd)
+	return cmd
+}
+
+func runRmCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	opts := osSvc.RemoveOptions{
+		Recursive:   flags.GetBoolFlag(cmd, flags.FlagNameRecursive, false),
+		DryRun:      flags.GetBoolFlag(cmd, flags.FlagNameDryRun, false),
+		Parallelism: flags.GetIntFlag(cmd, flags.FlagNameParallel, storageFlags.FlagDefaultParallel),
+	}
+	if opts.Parallelism < 1 {
+		return fmt.Errorf("--%s must be at least 1, got %d", flags.FlagNameParallel, opts.Parallelism)
+	}
+	skipConfirm := flags.GetBoolFlag(cmd, flags.FlagNameYes, false)
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	if useJSON && !skipConfirm && !opts.DryRun {
+		// The preview and the prompt would be mixed into the JSON output.
+		return fmt.Errorf("--%s needs --%s or --%s", flags.FlagNameJSON, flags.FlagNameYes, flags.FlagNameDryRun)
+	}
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage rm command", "location", args[0],
+		"recursive", opts.Recursive, "dry-run", opts.DryRun, "parallel", opts.Parallelism, "yes", skipConfirm)
+
+	var confirm func(string) bool
+	if !skipConfirm {
+		confirm = util.PromptYesNo
+	}
+	return osSvc.RemoveObjects(appCtx, args[0], opts, confirm, useJSON)
+}
@@ -0,0 +1,47 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRmCommand(t *testing.T) {
+	cmd := NewRmCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "rm <oci://bucket/object|prefix/|glob>", cmd.Use)
+	assert.Equal(t, rmLong, cmd.Long)
+	assert.Equal(t, rmExamples, cmd.Example)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, nil))
+
+	for _, name := range []string{flags.FlagNameRecursive, flags.FlagNameDryRun, flags.FlagNameParallel, flags.FlagNameYes} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "expected --%s", name)
+	}
+
+	require.NoError(t, cmd.Flags().Set(flags.FlagNameParallel, "0"))
+	assert.ErrorContains(t, runRmCommand(cmd, []string{"oci://b/o"}, &app.ApplicationContext{}), "--parallel")
+
+	// --json is a global flag; the prompt would break its output.
+	cmd = NewRmCmd(&app.ApplicationContext{})
+	cmd.Flags().Bool(flags.FlagNameJSON, true, "")
+	assert.ErrorContains(t, runRmCommand(cmd, []string{"oci://b/o"}, &app.ApplicationContext{}), "--json needs --yes")
+}
+
+func TestRestoreCommand(t *testing.T) {
+	cmd := NewRestoreCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "restore <oci://bucket/object|prefix/|glob>", cmd.Use)
+	assert.Equal(t, restoreLong, cmd.Long)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, nil))
+
+	assert.NotNil(t, cmd.Flags().Lookup(flags.FlagNameRecursive))
+	assert.NotNil(t, cmd.Flags().Lookup(flags.FlagNameWait))
+	assert.Equal(t, "24", cmd.Flags().Lookup(flags.FlagNameHours).DefValue)
+}
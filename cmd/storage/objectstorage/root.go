@@ -9,17 +9,28 @@ var osLong = `
 Manage Oracle Cloud Infrastructure Object Storage buckets and objects.
 
 Commands:
-  list      - Interactive TUI to browse buckets and objects, view details or download
+  list      - Interactive TUI to browse buckets and folders of objects, view details, download or share
+  ls        - List folders and objects under a bucket prefix
   get       - Paginated listing of buckets with optional JSON output
   search    - Fuzzy search for buckets by name, tags, or other attributes
   upload    - Upload a file to a bucket (supports multipart for large files)
-  download  - Download an object from a bucket
+  download  - Download an object from a bucket, or share it with --share
+  cp        - Copy files and objects to, from and between buckets (non-interactive)
+  sync      - Synchronize a local directory with a bucket prefix
+  par       - Create, list and delete pre-authenticated requests (shareable links)
+  rm        - Delete an object, a prefix or the objects matching a glob
+  restore   - Restore objects from the Archive tier
+  versions  - List the versions of an object or make an older version current
+  usage     - Report bucket storage usage and estimated cost across a compartment tree
 `
 
 var osExamples = `
   # Browse buckets and objects interactively
   ocloud stg os list
 
+  # List the folders and objects under a prefix
+  ocloud stg os ls oci://logs/2024/
+
   # List buckets with pagination
   ocloud stg os get --limit 10 --page 1
 
@@ -31,6 +42,27 @@ var osExamples = `
 
   # Download an object from a bucket (interactive)
   ocloud stg os download
+
+  # Copy a directory to a bucket from a script
+  ocloud stg os cp ./dist oci://releases/v1.2.0/ --recursive
+
+  # Mirror a directory into a bucket prefix
+  ocloud stg os sync ./public oci://web/site/ --delete
+
+  # Create a link to an object that expires in 7 days
+  ocloud stg os par create oci://reports/q1.pdf --expires 7d
+
+  # Preview deleting everything under a prefix
+  ocloud stg os rm oci://logs/2023/ --recursive --dry-run
+
+  # Restore an archived object and wait until it can be downloaded
+  ocloud stg os restore oci://archive/2019/ledger.csv --wait
+
+  # List the versions of an object
+  ocloud stg os versions oci://config/app.yaml
+
+  # Report storage usage across the tenancy
+  ocloud stg os usage --tenancy-scope
 `
 
 func NewObjectStorageCmd(appCtx *app.ApplicationContext) *cobra.Command {
@@ -45,8 +77,16 @@ func NewObjectStorageCmd(appCtx *app.ApplicationContext) *cobra.Command {
 	}
 	cmd.AddCommand(NewGetCmd(appCtx))
 	cmd.AddCommand(NewListCmd(appCtx))
+	cmd.AddCommand(NewLsCmd(appCtx))
 	cmd.AddCommand(NewSearchCmd(appCtx))
 	cmd.AddCommand(NewUploadCmd(appCtx))
 	cmd.AddCommand(NewDownloadCmd(appCtx))
+	cmd.AddCommand(NewCpCmd(appCtx))
+	cmd.AddCommand(NewSyncCmd(appCtx))
+	cmd.AddCommand(NewParCmd(appCtx))
+	cmd.AddCommand(NewRmCmd(appCtx))
+	cmd.AddCommand(NewRestoreCmd(appCtx))
+	cmd.AddCommand(NewVersionsCmd(appCtx))
+	cmd.AddCommand(NewUsageCmd(appCtx))
 	return cmd
 }
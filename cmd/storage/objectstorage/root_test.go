@@ -24,6 +24,14 @@ func TestObjectStorageRootCommand(t *testing.T) {
 	hasSearch := false
 	hasUpload := false
 	hasDownload := false
+	hasCp := false
+	hasSync := false
+	hasLs := false
+	hasPar := false
+	hasRm := false
+	hasRestore := false
+	hasVersions := false
+	hasUsage := false
 	for _, sc := range cmd.Commands() {
 		switch sc.Use {
 		case "get":
@@ -36,6 +44,22 @@ func TestObjectStorageRootCommand(t *testing.T) {
 			hasUpload = true
 		case "download":
 			hasDownload = true
+		case "cp <source> <destination>":
+			hasCp = true
+		case "sync <source> <destination>":
+			hasSync = true
+		case "ls <oci://bucket/prefix>":
+			hasLs = true
+		case "par":
+			hasPar = true
+		case "rm <oci://bucket/object|prefix/|glob>":
+			hasRm = true
+		case "restore <oci://bucket/object|prefix/|glob>":
+			hasRestore = true
+		case "versions <oci://bucket/object>":
+			hasVersions = true
+		case "usage":
+			hasUsage = true
 		}
 	}
 	assert.True(t, hasGet, "expected get subcommand")
@@ -43,4 +67,12 @@ func TestObjectStorageRootCommand(t *testing.T) {
 	assert.True(t, hasSearch, "expected search subcommand")
 	assert.True(t, hasUpload, "expected upload subcommand")
 	assert.True(t, hasDownload, "expected download subcommand")
+	assert.True(t, hasCp, "expected cp subcommand")
+	assert.True(t, hasSync, "expected sync subcommand")
+	assert.True(t, hasLs, "expected ls subcommand")
+	assert.True(t, hasPar, "expected par subcommand")
+	assert.True(t, hasRm, "expected rm subcommand")
+	assert.True(t, hasRestore, "expected restore subcommand")
+	assert.True(t, hasVersions, "expected versions subcommand")
+	assert.True(t, hasUsage, "expected usage subcommand")
 }
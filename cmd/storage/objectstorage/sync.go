@@ -0,0 +1,82 @@
+package objectstorage
+
+import (
+	"fmt"
+
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+	"github.com/spf13/cobra"
+)
+
+var syncLong = `
+Synchronize a local directory with a bucket prefix, in either direction, transferring only what changed.
+
+  sync <dir> oci://<bucket>/<prefix>/    upload new and changed files
+  sync oci://<bucket>/<prefix>/ <dir>    download new and changed objects
+
+Files are compared by size first. Files of the same size are compared by MD5; objects uploaded in parts have
+no MD5 of their content, so they are compared by modification time instead. Sync stores the modification time
+of every file it uploads in the object's "mtime" metadata and sets it on every file it downloads.
+
+With --delete, destination files that are not in the source are removed. --include and --exclude take glob
+patterns and can be repeated: a pattern without a slash (e.g. "*.tmp", "node_modules") matches any part of the
+path, and a pattern with a slash (e.g. "assets/*.png", "build/") matches from the top of the directory. Excluded
+files are neither transferred nor deleted. Use --dry-run to see the plan without changing anything.
+`
+
+var syncExamples = `
+  # Publish a static site, removing files that were deleted locally
+  ocloud storage object-storage sync ./public oci://web/site/ --delete
+
+  # Preview the changes first
+  ocloud storage object-storage sync ./public oci://web/site/ --delete --dry-run
+
+  # Back up a directory, skipping temporary files
+  ocloud stg os sync ./data oci://backups/data/ --exclude "*.tmp" --exclude cache/
+
+  # Pull only the logs of a bucket prefix, 8 files at a time, with a JSON report
+  ocloud stg os sync oci://logs/app/ ./logs --include "*.log" --parallel 8 --json
+`
+
+func NewSyncCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "sync <source> <destination>",
+		Short:         "Synchronize a local directory with a bucket prefix",
+		Long:          syncLong,
+		Example:       syncExamples,
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSyncCommand(cmd, args, appCtx)
+		},
+	}
+	storageFlags.DeleteFlag.Add(cmd)
+	storageFlags.IncludeFlag.Add(cmd)
+	storageFlags.ExcludeFlag.Add(cmd)
+	storageFlags.DryRunFlag.Add(cmd)
+	storageFlags.ParallelFlag.Add(cmd)
+	return cmd
+}
+
+func runSyncCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	opts := osSvc.SyncOptions{
+		Delete:  flags.GetBoolFlag(cmd, flags.FlagNameDelete, false),
+		DryRun:  flags.GetBoolFlag(cmd, flags.FlagNameDryRun, false),
+		Include: flags.GetStringSliceFlag(cmd, flags.FlagNameInclude, nil),
+		Exclude: flags.GetStringSliceFlag(cmd, flags.FlagNameExclude, nil),
+		Transfer: osSvc.TransferOptions{
+			Parallelism: flags.GetIntFlag(cmd, flags.FlagNameParallel, storageFlags.FlagDefaultParallel),
+		},
+	}
+	if opts.Transfer.Parallelism < 1 {
+		return fmt.Errorf("--%s must be at least 1, got %d", flags.FlagNameParallel, opts.Transfer.Parallelism)
+	}
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage sync command", "source", args[0], "destination", args[1],
+		"delete", opts.Delete, "dry-run", opts.DryRun, "parallel", opts.Transfer.Parallelism)
+	return osSvc.SyncObjects(appCtx, args[0], args[1], opts, useJSON)
+}
@@ -0,0 +1,28 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncCommand(t *testing.T) {
+	cmd := NewSyncCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "sync <source> <destination>", cmd.Use)
+	assert.Equal(t, syncLong, cmd.Long)
+	assert.Equal(t, syncExamples, cmd.Example)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, []string{"./dir"}))
+
+	for _, name := range []string{flags.FlagNameDelete, flags.FlagNameInclude, flags.FlagNameExclude, flags.FlagNameDryRun, flags.FlagNameParallel} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "expected --%s", name)
+	}
+
+	require.NoError(t, cmd.Flags().Set(flags.FlagNameParallel, "0"))
+	assert.ErrorContains(t, runSyncCommand(cmd, []string{"./dir", "oci://b/p/"}, &app.ApplicationContext{}), "--parallel")
+}
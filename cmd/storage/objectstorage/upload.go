@@ -1,7 +1,12 @@
 package objectstorage
 
 import (
+	"fmt"
+
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
 	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
 	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
 	"github.com/spf13/cobra"
 )
@@ -14,14 +19,25 @@ This command launches an interactive TUI that:
 2. Shows a file browser to select the file to upload
 3. Uploads the file with progress reporting
 
-Files larger than 10 MiB are automatically uploaded using multipart upload
-for better performance and reliability.
+Files larger than 10 MiB are automatically uploaded using multipart upload. Parts are uploaded in
+parallel (--parallel, default 4) and each part is retried on transient failures. The part size grows
+with the file so that even very large files stay within the 10,000-part limit.
+
+An interrupted multipart upload is kept in the bucket and recorded in ~/.oci/.ocloud/uploads. Run the
+upload again with --resume and pick the same bucket and file to upload only the missing parts. Without
+--resume a new upload is started and the interrupted one is discarded.
 `
 
 var uploadExamples = `
   # Launch the interactive upload flow
   ocloud storage object-storage upload
 
+  # Upload with 8 parts in flight
+  ocloud storage object-storage upload --parallel 8
+
+  # Continue an interrupted upload
+  ocloud storage object-storage upload --resume
+
   # Using short aliases
   ocloud stg os upload
 `
@@ -35,9 +51,22 @@ func NewUploadCmd(appCtx *app.ApplicationContext) *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return osSvc.UploadFile(appCtx)
+			return runUploadCommand(cmd, appCtx)
 		},
 	}
-
+	storageFlags.ResumeFlag.Add(cmd)
+	storageFlags.ParallelFlag.Add(cmd)
 	return cmd
 }
+
+func runUploadCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	opts := osSvc.TransferOptions{
+		Resume:      flags.GetBoolFlag(cmd, flags.FlagNameResume, false),
+		Parallelism: flags.GetIntFlag(cmd, flags.FlagNameParallel, storageFlags.FlagDefaultParallel),
+	}
+	if opts.Parallelism < 1 {
+		return fmt.Errorf("--%s must be at least 1, got %d", flags.FlagNameParallel, opts.Parallelism)
+	}
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage upload command", "resume", opts.Resume, "parallel", opts.Parallelism)
+	return osSvc.UploadFile(appCtx, opts)
+}
@@ -0,0 +1,86 @@
+package objectstorage
+
+import (
+	sharedflags "github.com/rozdolsky33/ocloud/cmd/shared/flags"
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+	"github.com/spf13/cobra"
+)
+
+var usageLong = `
+Report the storage used by buckets across a compartment tree, for chargeback.
+
+The report covers the configured compartment and all of its sub-compartments, or with -T/--tenancy-scope
+every compartment of the tenancy. It aggregates the approximate size and object count of the buckets by
+compartment, by default storage tier and by tag, and lists the largest buckets (--top, default 10). Buckets are
+grouped under every "key=value" tag they carry; --tag-key groups them by the value of one tag instead.
+Compartments and buckets that cannot be read are reported and skipped.
+
+With --price-sheet the monthly cost is estimated from a YAML file giving the price of a gigabyte (2^30 bytes)
+per month in each storage tier:
+
+  currency: USD
+  tiers:
+    Standard: 0.0255
+    InfrequentAccess: 0.01
+    Archive: 0.0026
+
+Sizes are the approximate values reported by Object Storage, which gives no breakdown by tier: every object
+of a bucket counts, and is priced, in the bucket's default storage tier, even objects that lifecycle rules
+moved to Infrequent Access or Archive.
+
+Output is a set of tables, JSON with --json, or one CSV row per bucket with --csv.
+`
+
+var usageExamples = `
+  # Usage of the current compartment and its sub-compartments
+  ocloud storage object-storage usage
+
+  # Usage of the whole tenancy with an estimated monthly cost
+  ocloud stg os usage -T --price-sheet ~/prices.yaml
+
+  # Group by the cost-center tag and list the 25 largest buckets
+  ocloud stg os usage -T --tag-key Finance.CostCenter --top 25
+
+  # One CSV row per bucket for a spreadsheet
+  ocloud stg os usage -T --csv > usage.csv
+`
+
+// NewUsageCmd returns "object-storage usage".
+func NewUsageCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "usage",
+		Short:         "Report bucket storage usage and estimated cost",
+		Long:          usageLong,
+		Example:       usageExamples,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUsageCommand(cmd, appCtx)
+		},
+	}
+	sharedflags.TenancyScopeFlag.Add(cmd)
+	storageFlags.TopFlag.Add(cmd)
+	storageFlags.TagKeyFlag.Add(cmd)
+	storageFlags.PriceSheetFlag.Add(cmd)
+	storageFlags.CSVFlag.Add(cmd)
+	return cmd
+}
+
+func runUsageCommand(cmd *cobra.Command, appCtx *app.ApplicationContext) error {
+	opts := osSvc.UsageOptions{
+		TenancyScope: flags.GetBoolFlag(cmd, flags.FlagNameTenancyScope, false),
+		Top:          flags.GetIntFlag(cmd, flags.FlagNameTop, storageFlags.FlagDefaultTop),
+		TagKey:       flags.GetStringFlag(cmd, flags.FlagNameTagKey, ""),
+		PriceSheet:   flags.GetStringFlag(cmd, flags.FlagNamePriceSheet, ""),
+	}
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	useCSV := flags.GetBoolFlag(cmd, flags.FlagNameCSV, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage usage command", "tenancy_scope", opts.TenancyScope,
+		"top", opts.Top, "tag_key", opts.TagKey, "price_sheet", opts.PriceSheet, "json", useJSON, "csv", useCSV)
+	return osSvc.ReportUsage(appCtx, opts, useJSON, useCSV)
+}
@@ -0,0 +1,32 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageCommand(t *testing.T) {
+	cmd := NewUsageCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "usage", cmd.Use)
+	assert.Equal(t, usageLong, cmd.Long)
+	assert.Equal(t, usageExamples, cmd.Example)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, []string{"extra"}))
+
+	tenancy := cmd.Flags().Lookup(flags.FlagNameTenancyScope)
+	if assert.NotNil(t, tenancy) {
+		assert.Equal(t, "T", tenancy.Shorthand)
+	}
+	top := cmd.Flags().Lookup(flags.FlagNameTop)
+	if assert.NotNil(t, top) {
+		assert.Equal(t, "10", top.DefValue)
+	}
+	assert.NotNil(t, cmd.Flags().Lookup(flags.FlagNameTagKey))
+	assert.NotNil(t, cmd.Flags().Lookup(flags.FlagNamePriceSheet))
+	assert.NotNil(t, cmd.Flags().Lookup(flags.FlagNameCSV))
+}
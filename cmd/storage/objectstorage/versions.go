@@ -0,0 +1,57 @@
+package objectstorage
+
+import (
+	storageFlags "github.com/rozdolsky33/ocloud/cmd/storage/flags"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osSvc "github.com/rozdolsky33/ocloud/internal/services/storage/objectstorage"
+	"github.com/spf13/cobra"
+)
+
+var versionsLong = `
+List the versions of an object in a bucket with versioning, newest first, with their version IDs, sizes and
+modification times. A delete marker records that the object was deleted.
+
+With --promote <version-id> the version becomes the current version again: it is copied over the object, and the
+content it replaces is kept as a previous version. To download a version, use cp with --version-id.
+`
+
+var versionsExamples = `
+  # List the versions of an object
+  ocloud storage object-storage versions oci://config/app.yaml
+
+  # Download an older version
+  ocloud stg os cp oci://config/app.yaml ./app.yaml.old --version-id <version-id>
+
+  # Make an older version current again
+  ocloud stg os versions oci://config/app.yaml --promote <version-id>
+`
+
+// NewVersionsCmd returns "object-storage versions".
+func NewVersionsCmd(appCtx *app.ApplicationContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "versions <oci://bucket/object>",
+		Short:         "List the versions of an object or make an older version current",
+		Long:          versionsLong,
+		Example:       versionsExamples,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVersionsCommand(cmd, args, appCtx)
+		},
+	}
+	storageFlags.PromoteFlag.Add(cmd)
+	return cmd
+}
+
+func runVersionsCommand(cmd *cobra.Command, args []string, appCtx *app.ApplicationContext) error {
+	promote := flags.GetStringFlag(cmd, flags.FlagNamePromote, "")
+	useJSON := flags.GetBoolFlag(cmd, flags.FlagNameJSON, false)
+	logger.LogWithLevel(logger.CmdLogger, logger.Debug, "Running object storage versions command", "location", args[0], "promote", promote)
+	if promote != "" {
+		return osSvc.PromoteVersion(appCtx, args[0], promote)
+	}
+	return osSvc.ListVersions(appCtx, args[0], useJSON)
+}
@@ -0,0 +1,21 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionsCommand(t *testing.T) {
+	cmd := NewVersionsCmd(&app.ApplicationContext{})
+
+	assert.Equal(t, "versions <oci://bucket/object>", cmd.Use)
+	assert.Equal(t, versionsLong, cmd.Long)
+	assert.Equal(t, versionsExamples, cmd.Example)
+	assert.True(t, cmd.SilenceUsage)
+	assert.True(t, cmd.SilenceErrors)
+	assert.Error(t, cmd.Args(cmd, nil))
+	assert.NotNil(t, cmd.Flags().Lookup(flags.FlagNamePromote))
+}
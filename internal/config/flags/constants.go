@@ -22,6 +22,7 @@ const (
 	FlagNameFilter       = "filter"
 	FlagNameScope        = "scope"
 	FlagNameTenancyScope = "tenancy-scope"
+	FlagNameYes          = "yes"
 )
 
 // Flag Names (network toggles)
@@ -33,6 +34,84 @@ const (
 	FlagNameSecurity = "security-list"
 )
 
+// Flag Names (bastion sessions)
+const (
+	FlagNameBastion     = "bastion"
+	FlagNameTarget      = "target"
+	FlagNameSessionType = "type"
+	FlagNameLocalPort   = "local-port"
+	FlagNameRemotePort  = "remote-port"
+	FlagNameSSHKey      = "key"
+	FlagNameSSHUser     = "user"
+	FlagNamePrintOnly   = "print-only"
+	FlagNameKeepAlive   = "keep-alive"
+	FlagNameClientIP    = "client-ip"
+	FlagNameSkipReach   = "skip-reachability"
+	FlagNameLines       = "lines"
+	FlagNameFollow      = "follow"
+	FlagNameOlderThan   = "older-than"
+	FlagNameDryRun      = "dry-run"
+)
+
+// Flag Names (object storage)
+const (
+	FlagNameResume      = "resume"
+	FlagNameParallel    = "parallel"
+	FlagNameRecursive   = "recursive"
+	FlagNameContentType = "content-type"
+	FlagNameMetadata    = "metadata"
+	FlagNameQuiet       = "quiet"
+	FlagNameDelete      = "delete"
+	FlagNameInclude     = "include"
+	FlagNameExclude     = "exclude"
+	FlagNameAccess      = "access"
+	FlagNameExpires     = "expires"
+	FlagNameParName     = "name"
+	FlagNameListing     = "allow-listing"
+	FlagNameShare       = "share"
+	FlagNameHours       = "hours"
+	FlagNameWait        = "wait"
+	FlagNameVersionID   = "version-id"
+	FlagNamePromote     = "promote"
+)
+
+// Object storage bucket flags
+const (
+	FlagNamePolicies = "policies"
+)
+
+// Object storage usage flags
+const (
+	FlagNameTop        = "top"
+	FlagNameTagKey     = "tag-key"
+	FlagNamePriceSheet = "price-sheet"
+	FlagNameCSV        = "csv"
+)
+
+// Network path flags
+const (
+	FlagNamePort     = "port"
+	FlagNameProtocol = "protocol"
+)
+
+// Network audit flags
+const (
+	FlagNameSARIF = "sarif"
+)
+
+// Network CIDR planning flags
+const (
+	FlagNameWithin = "within"
+	FlagNameSize   = "size"
+	FlagNameMapped = "mapped"
+)
+
+// Network VCN diagram flags
+const (
+	FlagNameFormat    = "format"
+	FlagNameResources = "resources"
+)
+
 // ============================================================================
 // Flag Shorthands
 // ============================================================================
@@ -51,6 +130,7 @@ const (
 	FlagShortFilter       = "f"
 	FlagShortAll          = "A"
 	FlagShortTenancyScope = "T"
+	FlagShortYes          = "y"
 
 	// Network toggles (avoid collisions with common flags)
 	FlagShortGateway  = "G"
@@ -81,6 +161,7 @@ const (
 	FlagDescAll          = "Show all information"
 	FlagDescScope        = "Listing scope: compartment or tenancy"
 	FlagDescTenancyScope = "Shortcut: list at tenancy level (overrides --scope)"
+	FlagDescYes          = "Skip the confirmation prompt"
 
 	// Network
 	FlagDescGateway  = "Display gateway information"
@@ -88,6 +169,77 @@ const (
 	FlagDescNsg      = "Display network security group information"
 	FlagDescRoute    = "Display route table information"
 	FlagDescSecurity = "Display security list information"
+
+	// Bastion sessions
+	FlagDescBastion     = "Bastion name or OCID"
+	FlagDescTarget      = "Session target as <kind>:<name|ocid> (kinds: instance, adb, heatwave, cache, oke, lb)"
+	FlagDescSessionType = "Session type: port-forward or managed-ssh"
+	FlagDescLocalPort   = "Local port to listen on (defaults to the remote port)"
+	FlagDescRemotePort  = "Target port (defaults to the target's standard port)"
+	FlagDescSSHKey      = "Path to the SSH private key; the public key is read from <key>.pub"
+	FlagDescSSHUser     = "OS user for managed SSH sessions"
+	FlagDescPrintOnly   = "Print the SSH command instead of running it (managed-ssh only)"
+	FlagDescKeepAlive   = "Stay in the foreground and renew the session before it expires (port-forward only)"
+	FlagDescClientIP    = "Public IP the session is opened from, checked against the bastion CIDR allow-list"
+	FlagDescSkipReach   = "Create the session even if the reachability checks fail"
+	FlagDescAllTunnels  = "Stop all tracked tunnels"
+	FlagDescLines       = "Number of lines to show from the end of the log (0 shows everything)"
+	FlagDescFollow      = "Keep streaming new log lines until interrupted"
+	FlagDescOlderThan   = "Remove log files older than this duration (e.g., 72h)"
+	FlagDescDryRun      = "Show what would be done without making changes"
+
+	// Object storage
+	FlagDescResume      = "Continue an interrupted multipart upload of the same file"
+	FlagDescParallel    = "Number of parts transferred in parallel"
+	FlagDescRecursive   = "Copy directories and prefixes recursively"
+	FlagDescContentType = "Content type of uploaded objects (detected from the file name by default)"
+	FlagDescMetadata    = "User metadata to set on uploaded or copied objects, as key=value (repeatable)"
+	FlagDescQuiet       = "Print only a JSON summary of the transfers"
+	FlagDescDelete      = "Delete destination files that are not in the source"
+	FlagDescInclude     = "Only sync paths matching this glob (repeatable)"
+	FlagDescExclude     = "Skip paths matching this glob (repeatable)"
+	FlagDescAccess      = "Access granted by the link: read, write or readwrite"
+	FlagDescExpires     = "When the link expires: a duration from now (e.g., 90m, 12h, 7d) or an RFC3339 time"
+	FlagDescParName     = "Name of the pre-authenticated request (generated by default)"
+	FlagDescListing     = "Let holders of a bucket or prefix link list its objects"
+	FlagDescShare       = "Create a read-only link to the selected object instead of downloading it"
+
+	// Object storage rm and restore
+	FlagDescRemoveRecursive  = "Delete every object under the prefix"
+	FlagDescRestoreRecursive = "Restore every archived object under the prefix"
+	FlagDescHours            = "Hours the restored objects stay available for download (1-240)"
+	FlagDescWait             = "Wait until every object is restored"
+
+	// Object storage versions
+	FlagDescVersionID = "Version of the source object to download or copy (see object-storage versions)"
+	FlagDescPromote   = "Make this version the current version by copying it over the object"
+
+	// Object storage bucket policies
+	FlagDescPolicies = "Show lifecycle rules, retention rules, replication policies and object events settings"
+
+	// Object storage usage
+	FlagDescTop        = "Number of largest buckets to list"
+	FlagDescTagKey     = "Group usage by the value of this tag (freeform key or namespace.key) instead of every tag"
+	FlagDescPriceSheet = "YAML price sheet with the monthly price per GB of each storage tier, to estimate cost"
+	FlagDescCSV        = "Output one CSV row per bucket"
+
+	// Network path
+	FlagDescPort     = "Destination port (defaults to the destination's standard port)"
+	FlagDescProtocol = "Protocol to evaluate: tcp, udp or icmp"
+
+	// Network audit
+	FlagDescSARIF          = "Output the findings as a SARIF 2.1.0 log"
+	FlagDescAuditRecursive = "Also audit the descendants of the compartment"
+
+	// Network CIDR planning
+	FlagDescWithin    = "IPv4 range to search for free blocks, e.g. 10.0.0.0/8"
+	FlagDescSize      = "Prefix length of the blocks to suggest, e.g. /24"
+	FlagDescMapped    = "Scan every tenancy and region listed in the tenancy map"
+	FlagDescFreeLimit = "Maximum number of free blocks to suggest"
+
+	// Network VCN diagram
+	FlagDescDiagramFormat = "Diagram format: dot, mermaid or svg-ready"
+	FlagDescResources     = "Place the compartment's instances, load balancers and databases in their subnets"
 )
 
 // ============================================================================
@@ -134,11 +286,13 @@ const (
 const (
 	DefaultProfileName = "DEFAULT"
 
-	OCIConfigDirName     = ".oci"
-	OCIConfigFileName    = "config"
-	OCloudDefaultDirName = ".ocloud"
-	OCloudScriptsDirName = "scripts"
-	OCISessionsDirName   = "sessions"
-	TenancyMapFileName   = "tenancy-map.yaml"
-	OCIRefresherPIDFile  = "refresher.pid"
+	OCIConfigDirName      = ".oci"
+	OCIConfigFileName     = "config"
+	OCloudDefaultDirName  = ".ocloud"
+	OCloudScriptsDirName  = "scripts"
+	OCloudProfilesDirName = "tunnel-profiles"
+	OCloudUploadsDirName  = "uploads"
+	OCISessionsDirName    = "sessions"
+	TenancyMapFileName    = "tenancy-map.yaml"
+	OCIRefresherPIDFile   = "refresher.pid"
 )
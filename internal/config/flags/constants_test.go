@@ -25,6 +25,57 @@ func TestFlagNames(t *testing.T) {
 	assert.Equal(t, "filter", FlagNameFilter)
 	assert.Equal(t, "scope", FlagNameScope)
 	assert.Equal(t, "tenancy-scope", FlagNameTenancyScope)
+	assert.Equal(t, "yes", FlagNameYes)
+
+	// Test bastion session flag names
+	assert.Equal(t, "bastion", FlagNameBastion)
+	assert.Equal(t, "target", FlagNameTarget)
+	assert.Equal(t, "type", FlagNameSessionType)
+	assert.Equal(t, "local-port", FlagNameLocalPort)
+	assert.Equal(t, "remote-port", FlagNameRemotePort)
+	assert.Equal(t, "key", FlagNameSSHKey)
+	assert.Equal(t, "user", FlagNameSSHUser)
+	assert.Equal(t, "print-only", FlagNamePrintOnly)
+	assert.Equal(t, "keep-alive", FlagNameKeepAlive)
+	assert.Equal(t, "client-ip", FlagNameClientIP)
+	assert.Equal(t, "skip-reachability", FlagNameSkipReach)
+	assert.Equal(t, "lines", FlagNameLines)
+	assert.Equal(t, "follow", FlagNameFollow)
+	assert.Equal(t, "older-than", FlagNameOlderThan)
+	assert.Equal(t, "dry-run", FlagNameDryRun)
+
+	// Test object storage flag names
+	assert.Equal(t, "resume", FlagNameResume)
+	assert.Equal(t, "parallel", FlagNameParallel)
+	assert.Equal(t, "recursive", FlagNameRecursive)
+	assert.Equal(t, "content-type", FlagNameContentType)
+	assert.Equal(t, "metadata", FlagNameMetadata)
+	assert.Equal(t, "quiet", FlagNameQuiet)
+	assert.Equal(t, "delete", FlagNameDelete)
+	assert.Equal(t, "include", FlagNameInclude)
+	assert.Equal(t, "exclude", FlagNameExclude)
+	assert.Equal(t, "access", FlagNameAccess)
+	assert.Equal(t, "expires", FlagNameExpires)
+	assert.Equal(t, "name", FlagNameParName)
+	assert.Equal(t, "allow-listing", FlagNameListing)
+	assert.Equal(t, "share", FlagNameShare)
+	assert.Equal(t, "hours", FlagNameHours)
+	assert.Equal(t, "wait", FlagNameWait)
+	assert.Equal(t, "version-id", FlagNameVersionID)
+	assert.Equal(t, "promote", FlagNamePromote)
+	assert.Equal(t, "policies", FlagNamePolicies)
+	assert.Equal(t, "top", FlagNameTop)
+	assert.Equal(t, "tag-key", FlagNameTagKey)
+	assert.Equal(t, "price-sheet", FlagNamePriceSheet)
+	assert.Equal(t, "csv", FlagNameCSV)
+	assert.Equal(t, "port", FlagNamePort)
+	assert.Equal(t, "protocol", FlagNameProtocol)
+	assert.Equal(t, "sarif", FlagNameSARIF)
+	assert.Equal(t, "within", FlagNameWithin)
+	assert.Equal(t, "size", FlagNameSize)
+	assert.Equal(t, "mapped", FlagNameMapped)
+	assert.Equal(t, "format", FlagNameFormat)
+	assert.Equal(t, "resources", FlagNameResources)
 
 	// Test network toggle flag names
 	assert.Equal(t, "gateway", FlagNameGateway)
@@ -49,6 +100,7 @@ func TestFlagShorthands(t *testing.T) {
 	assert.Equal(t, "f", FlagShortFilter)
 	assert.Equal(t, "A", FlagShortAll)
 	assert.Equal(t, "T", FlagShortTenancyScope)
+	assert.Equal(t, "y", FlagShortYes)
 
 	// Test network toggle flag shorthands
 	assert.Equal(t, "G", FlagShortGateway)
@@ -76,6 +128,62 @@ func TestFlagDescriptions(t *testing.T) {
 	assert.NotEmpty(t, FlagDescAll)
 	assert.NotEmpty(t, FlagDescScope)
 	assert.NotEmpty(t, FlagDescTenancyScope)
+	assert.NotEmpty(t, FlagDescYes)
+
+	// Test bastion session flag descriptions
+	assert.NotEmpty(t, FlagDescBastion)
+	assert.NotEmpty(t, FlagDescTarget)
+	assert.NotEmpty(t, FlagDescSessionType)
+	assert.NotEmpty(t, FlagDescLocalPort)
+	assert.NotEmpty(t, FlagDescRemotePort)
+	assert.NotEmpty(t, FlagDescSSHKey)
+	assert.NotEmpty(t, FlagDescSSHUser)
+	assert.NotEmpty(t, FlagDescPrintOnly)
+	assert.NotEmpty(t, FlagDescKeepAlive)
+	assert.NotEmpty(t, FlagDescClientIP)
+	assert.NotEmpty(t, FlagDescSkipReach)
+	assert.NotEmpty(t, FlagDescAllTunnels)
+	assert.NotEmpty(t, FlagDescLines)
+	assert.NotEmpty(t, FlagDescFollow)
+	assert.NotEmpty(t, FlagDescOlderThan)
+	assert.NotEmpty(t, FlagDescDryRun)
+
+	// Test object storage flag descriptions
+	assert.NotEmpty(t, FlagDescResume)
+	assert.NotEmpty(t, FlagDescParallel)
+	assert.NotEmpty(t, FlagDescRecursive)
+	assert.NotEmpty(t, FlagDescContentType)
+	assert.NotEmpty(t, FlagDescMetadata)
+	assert.NotEmpty(t, FlagDescQuiet)
+	assert.NotEmpty(t, FlagDescDelete)
+	assert.NotEmpty(t, FlagDescInclude)
+	assert.NotEmpty(t, FlagDescExclude)
+	assert.NotEmpty(t, FlagDescAccess)
+	assert.NotEmpty(t, FlagDescExpires)
+	assert.NotEmpty(t, FlagDescParName)
+	assert.NotEmpty(t, FlagDescListing)
+	assert.NotEmpty(t, FlagDescShare)
+	assert.NotEmpty(t, FlagDescRemoveRecursive)
+	assert.NotEmpty(t, FlagDescRestoreRecursive)
+	assert.NotEmpty(t, FlagDescHours)
+	assert.NotEmpty(t, FlagDescWait)
+	assert.NotEmpty(t, FlagDescVersionID)
+	assert.NotEmpty(t, FlagDescPromote)
+	assert.NotEmpty(t, FlagDescPolicies)
+	assert.NotEmpty(t, FlagDescTop)
+	assert.NotEmpty(t, FlagDescTagKey)
+	assert.NotEmpty(t, FlagDescPriceSheet)
+	assert.NotEmpty(t, FlagDescCSV)
+	assert.NotEmpty(t, FlagDescPort)
+	assert.NotEmpty(t, FlagDescProtocol)
+	assert.NotEmpty(t, FlagDescSARIF)
+	assert.NotEmpty(t, FlagDescAuditRecursive)
+	assert.NotEmpty(t, FlagDescWithin)
+	assert.NotEmpty(t, FlagDescSize)
+	assert.NotEmpty(t, FlagDescMapped)
+	assert.NotEmpty(t, FlagDescFreeLimit)
+	assert.NotEmpty(t, FlagDescDiagramFormat)
+	assert.NotEmpty(t, FlagDescResources)
 
 	// Test network flag descriptions
 	assert.NotEmpty(t, FlagDescGateway)
@@ -118,6 +226,8 @@ func TestDefaults(t *testing.T) {
 	assert.Equal(t, "config", OCIConfigFileName)
 	assert.Equal(t, ".ocloud", OCloudDefaultDirName)
 	assert.Equal(t, "scripts", OCloudScriptsDirName)
+	assert.Equal(t, "tunnel-profiles", OCloudProfilesDirName)
+	assert.Equal(t, "uploads", OCloudUploadsDirName)
 	assert.Equal(t, "sessions", OCISessionsDirName)
 	assert.Equal(t, "tenancy-map.yaml", TenancyMapFileName)
 	assert.Equal(t, "refresher.pid", OCIRefresherPIDFile)
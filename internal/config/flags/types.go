@@ -1,6 +1,6 @@
 // Package flags provides a type-safe and reusable way to define and manage command-line flags
 // for CLI applications using cobra and pflag libraries. It offers structured flag types for
-// boolean, string, and integer values, along with consistent interfaces for adding these flags
+// boolean, string, string slice and integer values, along with consistent interfaces for adding these flags
 // to commands and flag sets.
 package flags
 
@@ -66,6 +66,25 @@ func (f IntFlag) Apply(flags *pflag.FlagSet) {
 	flags.IntP(f.Name, f.Shorthand, f.Default, f.Usage)
 }
 
+// StringSliceFlag represents a string slice command flag configuration with a name, optional shorthand,
+// default value, and usage description. The flag can be repeated or given comma-separated values.
+type StringSliceFlag struct {
+	Name      string
+	Shorthand string
+	Default   []string
+	Usage     string
+}
+
+// Add adds the string slice flag to the command
+func (f StringSliceFlag) Add(cmd *cobra.Command) {
+	cmd.Flags().StringSliceP(f.Name, f.Shorthand, f.Default, f.Usage)
+}
+
+// Apply adds the string slice flag to the given flag set
+func (f StringSliceFlag) Apply(flags *pflag.FlagSet) {
+	flags.StringSliceP(f.Name, f.Shorthand, f.Default, f.Usage)
+}
+
 // Flag defines the interface that all flag types must implement to be used within the CLI.
 // It provides methods for adding flags to both cobras.Command and pflag.FlagSet, allowing
 // flexible flag registration across different command contexts.
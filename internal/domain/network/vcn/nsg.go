@@ -5,4 +5,5 @@ type NSG struct {
 	OCID           string
 	DisplayName    string
 	LifecycleState string
+	Rules          []SecurityRule
 }
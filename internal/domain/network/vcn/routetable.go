@@ -1,8 +1,51 @@
 package vcn
 
+import "strings"
+
 // RouteTable represents a route table in the domain layer.
 type RouteTable struct {
 	OCID           string
 	DisplayName    string
 	LifecycleState string
+	Rules          []RouteRule
+}
+
+// Kinds of route rule targets. The gateway kinds match Gateway.Type.
+const (
+	TargetTypeInternet     = "Internet"
+	TargetTypeNAT          = "NAT"
+	TargetTypeService      = "Service"
+	TargetTypeDRG          = "DRG"
+	TargetTypeLocalPeering = "Local Peering"
+	TargetTypePrivateIP    = "Private IP"
+)
+
+// RouteRule sends traffic for a destination to a gateway or a private IP.
+type RouteRule struct {
+	// Destination is a CIDR block, or a service CIDR label when DestinationType is SERVICE_CIDR_BLOCK.
+	Destination     string
+	DestinationType string
+	TargetID        string
+	TargetType      string
+	// TargetName is the display name of the target, or its IP address for a private IP.
+	TargetName  string
+	Description string
+}
+
+// TargetTypeFromOCID returns the kind of route target an OCID identifies, or "" when it is not a known target.
+func TargetTypeFromOCID(ocid string) string {
+	prefixes := map[string]string{
+		"ocid1.internetgateway.":     TargetTypeInternet,
+		"ocid1.natgateway.":          TargetTypeNAT,
+		"ocid1.servicegateway.":      TargetTypeService,
+		"ocid1.drg.":                 TargetTypeDRG,
+		"ocid1.localpeeringgateway.": TargetTypeLocalPeering,
+		"ocid1.privateip.":           TargetTypePrivateIP,
+	}
+	for prefix, kind := range prefixes {
+		if strings.HasPrefix(ocid, prefix) {
+			return kind
+		}
+	}
+	return ""
 }
@@ -5,4 +5,6 @@ type SecurityList struct {
 	OCID           string
 	DisplayName    string
 	LifecycleState string
+	IngressRules   []SecurityRule
+	EgressRules    []SecurityRule
 }
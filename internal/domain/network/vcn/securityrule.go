@@ -0,0 +1,114 @@
+package vcn
+
+import "strconv"
+
+// Directions of a security rule.
+const (
+	DirectionIngress = "INGRESS"
+	DirectionEgress  = "EGRESS"
+)
+
+// Kinds of security rule peers and route rule destinations.
+const (
+	AddressTypeCIDR    = "CIDR_BLOCK"
+	AddressTypeService = "SERVICE_CIDR_BLOCK"
+	AddressTypeNSG     = "NETWORK_SECURITY_GROUP"
+)
+
+// IANA numbers of the protocols a security rule can name; ProtocolAll matches every protocol.
+const (
+	ProtocolAll    = "all"
+	ProtocolICMP   = "1"
+	ProtocolTCP    = "6"
+	ProtocolUDP    = "17"
+	ProtocolICMPv6 = "58"
+)
+
+// PortRange is an inclusive range of ports.
+type PortRange struct {
+	Min int
+	Max int
+}
+
+// Contains reports whether port is in the range.
+func (r PortRange) Contains(port int) bool {
+	return port >= r.Min && port <= r.Max
+}
+
+// String formats the range as "22" or "1024-65535".
+func (r PortRange) String() string {
+	if r.Min == r.Max {
+		return strconv.Itoa(r.Min)
+	}
+	return strconv.Itoa(r.Min) + "-" + strconv.Itoa(r.Max)
+}
+
+// SecurityRule allows traffic into (ingress) or out of (egress) the VNICs a security list or NSG applies to.
+type SecurityRule struct {
+	// ID is only set for the rules of an NSG.
+	ID        string
+	Direction string
+	// Protocol is ProtocolAll or an IANA protocol number.
+	Protocol string
+	// Peer is the source of an ingress rule or the destination of an egress rule: a CIDR block, a service
+	// CIDR label or an NSG OCID, as given by PeerType.
+	Peer     string
+	PeerType string
+	// PeerName is the display name of the NSG when PeerType is AddressTypeNSG.
+	PeerName string
+	// SourcePorts and DestinationPorts only apply to TCP and UDP; nil means every port.
+	SourcePorts      *PortRange
+	DestinationPorts *PortRange
+	// IcmpType and IcmpCode only apply to ICMP; nil means every type or code.
+	IcmpType    *int
+	IcmpCode    *int
+	Stateless   bool
+	Description string
+}
+
+// ProtocolName returns the protocol as "all", "TCP", "UDP", "ICMP" or "ICMPv6", or its number for others.
+func (r SecurityRule) ProtocolName() string {
+	return ProtocolName(r.Protocol)
+}
+
+// PortSummary describes the ports of a TCP/UDP rule, e.g. "22" or "all (src 1024-65535)", or the type
+// and code of an ICMP rule, e.g. "type 3 code 4". Other protocols have no ports and return "all".
+func (r SecurityRule) PortSummary() string {
+	switch r.Protocol {
+	case ProtocolTCP, ProtocolUDP:
+		ports := "all"
+		if r.DestinationPorts != nil {
+			ports = r.DestinationPorts.String()
+		}
+		if r.SourcePorts != nil {
+			ports += " (src " + r.SourcePorts.String() + ")"
+		}
+		return ports
+	case ProtocolICMP, ProtocolICMPv6:
+		if r.IcmpType == nil {
+			return "all"
+		}
+		icmp := "type " + strconv.Itoa(*r.IcmpType)
+		if r.IcmpCode != nil {
+			icmp += " code " + strconv.Itoa(*r.IcmpCode)
+		}
+		return icmp
+	}
+	return "all"
+}
+
+// ProtocolName returns an IANA protocol number as "TCP", "UDP", "ICMP" or "ICMPv6"; ProtocolAll and
+// other numbers are returned unchanged.
+func ProtocolName(protocol string) string {
+	switch protocol {
+	case ProtocolTCP:
+		return "TCP"
+	case ProtocolUDP:
+		return "UDP"
+	case ProtocolICMP:
+		return "ICMP"
+	case ProtocolICMPv6:
+		return "ICMPv6"
+	}
+	return protocol
+}
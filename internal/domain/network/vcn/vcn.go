@@ -32,3 +32,21 @@ type VCNRepository interface {
 	ListVcns(ctx context.Context, compartmentID string) ([]VCN, error)
 	ListEnrichedVcns(ctx context.Context, compartmentID string) ([]VCN, error)
 }
+
+// GatewaySummaryRepository returns the human-readable gateway summary of a VCN.
+type GatewaySummaryRepository interface {
+	GatewaysSummary(ctx context.Context, compartmentID, vcnID string) (Gateways, error)
+}
+
+// NSGUsageRepository reports whether network security groups are attached to anything.
+type NSGUsageRepository interface {
+	NSGHasVnics(ctx context.Context, nsgID string) (bool, error)
+}
+
+// AddressSpaceRepository lists the address space of VCNs without enriching them: their subnets and the
+// private IPs allocated in each subnet.
+type AddressSpaceRepository interface {
+	ListVcns(ctx context.Context, compartmentID string) ([]VCN, error)
+	ListSubnets(ctx context.Context, compartmentID, vcnID string) ([]Subnet, error)
+	CountPrivateIPs(ctx context.Context, subnetID string) (int, error)
+}
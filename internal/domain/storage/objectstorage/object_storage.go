@@ -2,26 +2,100 @@ package objectstorage
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 )
 
+// ErrChecksumMismatch is returned when a downloaded file does not match the checksum of the object.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
 type Bucket struct {
-	Name               string
-	OCID               string
-	Namespace          string
-	StorageTier        string
-	Visibility         string
-	Encryption         string
-	Versioning         string
-	ReplicationEnabled bool
-	IsReadOnly         bool
-	ApproximateSize    int64
-	ApproximateCount   int
-	TimeCreated        time.Time
-	FreeformTags       map[string]string
-	DefinedTags        map[string]map[string]interface{}
+	Name                string
+	OCID                string
+	Namespace           string
+	StorageTier         string
+	Visibility          string
+	Encryption          string
+	Versioning          string
+	ReplicationEnabled  bool
+	IsReadOnly          bool
+	ObjectEventsEnabled bool
+	ApproximateSize     int64
+	ApproximateCount    int
+	TimeCreated         time.Time
+	FreeformTags        map[string]string
+	DefinedTags         map[string]map[string]interface{}
+	// Policies holds the lifecycle, retention and replication rules; it is only filled in when requested.
+	Policies *BucketPolicies `json:",omitempty"`
+}
+
+// BucketPolicies are the rules that act on the objects of a bucket.
+type BucketPolicies struct {
+	LifecycleRules      []LifecycleRule
+	RetentionRules      []RetentionRule
+	ReplicationPolicies []ReplicationPolicy
+}
+
+// LifecycleRule archives, moves or deletes objects, or aborts multipart uploads, a given time after they
+// were last modified.
+type LifecycleRule struct {
+	Name string
+	// Action is ARCHIVE, INFREQUENT_ACCESS, DELETE or ABORT.
+	Action string
+	// Target is objects, previous-object-versions or multipart-uploads.
+	Target            string
+	TimeAmount        int64
+	TimeUnit          string
+	Enabled           bool
+	InclusionPrefixes []string
+	InclusionPatterns []string
+	ExclusionPatterns []string
+}
+
+// RetentionRule prevents objects from being changed or deleted. A rule without a duration retains objects
+// indefinitely; a locked rule can no longer be changed or removed.
+type RetentionRule struct {
+	ID             string
+	Name           string
+	DurationAmount int64
+	DurationUnit   string
+	TimeRuleLocked time.Time
+	TimeModified   time.Time
+}
+
+// IsLocked reports whether the rule is locked at now.
+func (r RetentionRule) IsLocked(now time.Time) bool {
+	return !r.TimeRuleLocked.IsZero() && !r.TimeRuleLocked.After(now)
 }
 
+// ReplicationPolicy copies the objects of a bucket to a bucket in another region.
+type ReplicationPolicy struct {
+	ID                string
+	Name              string
+	DestinationRegion string
+	DestinationBucket string
+	Status            string
+	StatusMessage     string
+	TimeCreated       time.Time
+	TimeLastSync      time.Time
+}
+
+// Storage tiers with a minimum retention period: objects deleted earlier are billed for the whole period.
+const (
+	StorageTierArchive          = "Archive"
+	StorageTierInfrequentAccess = "InfrequentAccess"
+)
+
+// Archival states of an object in the Archive tier.
+const (
+	ArchivalStateArchived  = "Archived"
+	ArchivalStateRestoring = "Restoring"
+	ArchivalStateRestored  = "Restored"
+)
+
 type Object struct {
 	Name         string
 	Size         int64
@@ -30,11 +104,76 @@ type Object struct {
 	ContentMD5   string
 	ETag         string
 	LastModified time.Time
+	// ArchivalState is Archived, Restoring or Restored for objects in the Archive tier, and empty otherwise.
+	ArchivalState string
+	// Metadata holds the opc-meta-* user metadata; it is only returned by GetObjectHead.
+	Metadata map[string]string
+	// VersionID identifies the version in a bucket with versioning; it is only returned by GetObjectHead
+	// and ListObjectVersions.
+	VersionID string
 	// For URL generation
 	BucketName string
 	Namespace  string
 }
 
+// ObjectVersion is one version of an object in a bucket with versioning. A delete marker records that the
+// object was deleted and has no content.
+type ObjectVersion struct {
+	Object
+	IsDeleteMarker bool
+	// IsLatest marks the current version of the object.
+	IsLatest bool
+}
+
+// ObjectPage is one page of an object listing. When the listing uses a delimiter, Prefixes holds the
+// pseudo-directories directly below the listed prefix. NextStart is empty on the last page.
+type ObjectPage struct {
+	Objects   []Object
+	Prefixes  []string
+	NextStart string
+}
+
+// Access levels of a pre-authenticated request.
+const (
+	PARAccessRead      = "read"
+	PARAccessWrite     = "write"
+	PARAccessReadWrite = "readwrite"
+)
+
+// PreauthenticatedRequest (PAR) is a link that grants access to an object, or to the objects under a prefix,
+// without OCI credentials.
+type PreauthenticatedRequest struct {
+	ID         string
+	Name       string
+	AccessType string
+	// ObjectName is the shared object, or the shared prefix when AccessType starts with AnyObject.
+	ObjectName          string
+	BucketListingAction string
+	TimeCreated         time.Time
+	TimeExpires         time.Time
+	// URL is the full link. Object Storage only returns it when the request is created.
+	URL        string
+	BucketName string
+	Namespace  string
+}
+
+// PreauthenticatedRequestSpec describes a pre-authenticated request to create.
+type PreauthenticatedRequestSpec struct {
+	Name string
+	// ObjectName is the object to share. An empty name, or one ending in "/", shares every object under that prefix.
+	ObjectName string
+	// Access is PARAccessRead, PARAccessWrite or PARAccessReadWrite.
+	Access      string
+	TimeExpires time.Time
+	// AllowListing lets holders of a bucket or prefix link list its objects.
+	AllowListing bool
+}
+
+// IsPrefixScope reports whether the spec shares a bucket or prefix rather than a single object.
+func (s PreauthenticatedRequestSpec) IsPrefixScope() bool {
+	return s.ObjectName == "" || strings.HasSuffix(s.ObjectName, "/")
+}
+
 // TransferProgress reports transfer progress (upload or download) to a callback function.
 type TransferProgress struct {
 	BytesTransferred int64
@@ -43,6 +182,36 @@ type TransferProgress struct {
 	TotalParts       int
 }
 
+// TransferOptions tunes how an object is transferred.
+type TransferOptions struct {
+	// Parallelism is the number of parts transferred at the same time; values below 1 use the default.
+	Parallelism int
+	// Resume continues an interrupted multipart upload of the same file instead of starting over.
+	// Interrupted downloads are always resumed from their .part file.
+	Resume bool
+	// ContentType overrides the content type detected from the object name on upload and copy.
+	ContentType string
+	// Metadata is stored as opc-meta-* user metadata on uploaded and copied objects.
+	Metadata map[string]string
+	// VersionID selects the version of the object to download or copy; empty means the current version.
+	VersionID string
+}
+
+// IncompleteUploadError is returned when a multipart upload stops before all parts are uploaded.
+// The upload is kept so that it can be resumed later.
+type IncompleteUploadError struct {
+	UploadID      string
+	PartsUploaded int
+	TotalParts    int
+	Err           error
+}
+
+func (e *IncompleteUploadError) Error() string {
+	return fmt.Sprintf("multipart upload %s incomplete (%d of %d parts uploaded): %v", e.UploadID, e.PartsUploaded, e.TotalParts, e.Err)
+}
+
+func (e *IncompleteUploadError) Unwrap() error { return e.Err }
+
 // ObjectStorageRepository defines the port for interacting with object storage.
 type ObjectStorageRepository interface {
 	GetBucketNameByOCID(ctx context.Context, compartmentID, bucketOCID string) (string, error)
@@ -51,6 +220,19 @@ type ObjectStorageRepository interface {
 	GetNamespace(ctx context.Context, compartmentID string) (string, error)
 	ListObjects(ctx context.Context, namespace, bucketName string) ([]Object, error)
 	GetObjectHead(ctx context.Context, namespace, bucketName, objectName string) (*Object, error)
-	DownloadObject(ctx context.Context, namespace, bucketName, objectName, destPath string, progressFn func(TransferProgress)) error
-	UploadObject(ctx context.Context, namespace, bucketName, objectName, filePath string, progressFn func(TransferProgress)) error
+	ListObjectsByPrefix(ctx context.Context, namespace, bucketName, prefix string) ([]Object, error)
+	ListObjectsPage(ctx context.Context, namespace, bucketName, prefix, delimiter, start string, limit int) (*ObjectPage, error)
+	ListObjectVersions(ctx context.Context, namespace, bucketName, objectName string) ([]ObjectVersion, error)
+	DownloadObject(ctx context.Context, namespace, bucketName, objectName, destPath string, opts TransferOptions, progressFn func(TransferProgress)) error
+	DownloadObjectToFile(ctx context.Context, namespace, bucketName, objectName, filePath string, opts TransferOptions, progressFn func(TransferProgress)) error
+	OpenObject(ctx context.Context, namespace, bucketName, objectName string, opts TransferOptions) (io.ReadCloser, error)
+	UploadObject(ctx context.Context, namespace, bucketName, objectName, filePath string, opts TransferOptions, progressFn func(TransferProgress)) error
+	UploadStream(ctx context.Context, namespace, bucketName, objectName string, r io.Reader, opts TransferOptions) (int64, error)
+	CopyObject(ctx context.Context, namespace, bucketName, objectName, destRegion, destBucket, destObject string, opts TransferOptions) error
+	DeleteObject(ctx context.Context, namespace, bucketName, objectName string) error
+	RestoreObject(ctx context.Context, namespace, bucketName, objectName string, hours int) error
+	CreatePreauthenticatedRequest(ctx context.Context, namespace, bucketName string, spec PreauthenticatedRequestSpec) (*PreauthenticatedRequest, error)
+	ListPreauthenticatedRequests(ctx context.Context, namespace, bucketName, prefix string) ([]PreauthenticatedRequest, error)
+	DeletePreauthenticatedRequest(ctx context.Context, namespace, bucketName, parID string) error
+	GetBucketPolicies(ctx context.Context, namespace, bucketName string) (*BucketPolicies, error)
 }
@@ -10,20 +10,21 @@ import (
 // BucketAttributes is a generic, intermediate representation of a bucket's data.
 // Each adapter is responsible for populating this struct.
 type BucketAttributes struct {
-	Name               *string
-	ID                 *string
-	Namespace          *string
-	TimeCreated        *time.Time
-	StorageTier        string
-	PublicAccessType   string
-	KmsKeyID           *string
-	Versioning         string
-	ReplicationEnabled *bool
-	IsReadOnly         *bool
-	ApproximateCount   *int64
-	ApproximateSize    *int64
-	FreeformTags       map[string]string
-	DefinedTags        map[string]map[string]interface{}
+	Name                *string
+	ID                  *string
+	Namespace           *string
+	TimeCreated         *time.Time
+	StorageTier         string
+	PublicAccessType    string
+	KmsKeyID            *string
+	Versioning          string
+	ReplicationEnabled  *bool
+	IsReadOnly          *bool
+	ObjectEventsEnabled *bool
+	ApproximateCount    *int64
+	ApproximateSize     *int64
+	FreeformTags        map[string]string
+	DefinedTags         map[string]map[string]interface{}
 }
 
 func NewBucketAttributesFromOCIBucket(bucket objectstorage.Bucket) *BucketAttributes {
@@ -33,20 +34,21 @@ func NewBucketAttributesFromOCIBucket(bucket objectstorage.Bucket) *BucketAttrib
 		tc = &t
 	}
 	return &BucketAttributes{
-		Name:               bucket.Name,
-		ID:                 bucket.Id,
-		Namespace:          bucket.Namespace,
-		TimeCreated:        tc,
-		StorageTier:        string(bucket.StorageTier),
-		PublicAccessType:   string(bucket.PublicAccessType),
-		KmsKeyID:           bucket.KmsKeyId,
-		Versioning:         string(bucket.Versioning),
-		ReplicationEnabled: bucket.ReplicationEnabled,
-		IsReadOnly:         bucket.IsReadOnly,
-		ApproximateCount:   bucket.ApproximateCount,
-		ApproximateSize:    bucket.ApproximateSize,
-		FreeformTags:       bucket.FreeformTags,
-		DefinedTags:        bucket.DefinedTags,
+		Name:                bucket.Name,
+		ID:                  bucket.Id,
+		Namespace:           bucket.Namespace,
+		TimeCreated:         tc,
+		StorageTier:         string(bucket.StorageTier),
+		PublicAccessType:    string(bucket.PublicAccessType),
+		KmsKeyID:            bucket.KmsKeyId,
+		Versioning:          string(bucket.Versioning),
+		ReplicationEnabled:  bucket.ReplicationEnabled,
+		IsReadOnly:          bucket.IsReadOnly,
+		ObjectEventsEnabled: bucket.ObjectEventsEnabled,
+		ApproximateCount:    bucket.ApproximateCount,
+		ApproximateSize:     bucket.ApproximateSize,
+		FreeformTags:        bucket.FreeformTags,
+		DefinedTags:         bucket.DefinedTags,
 	}
 }
 
@@ -71,19 +73,20 @@ func NewDomainBucketFromAttrs(attrs BucketAttributes) domain.Bucket {
 	}
 
 	db := domain.Bucket{
-		Name:               stringValue(attrs.Name),
-		OCID:               stringValue(attrs.ID),
-		Namespace:          stringValue(attrs.Namespace),
-		StorageTier:        attrs.StorageTier,
-		Visibility:         attrs.PublicAccessType,
-		Versioning:         attrs.Versioning,
-		Encryption:         encryption,
-		ReplicationEnabled: boolValue(attrs.ReplicationEnabled),
-		IsReadOnly:         boolValue(attrs.IsReadOnly),
-		ApproximateCount:   intValueFromInt64(attrs.ApproximateCount),
-		ApproximateSize:    int64Value(attrs.ApproximateSize),
-		FreeformTags:       attrs.FreeformTags,
-		DefinedTags:        attrs.DefinedTags,
+		Name:                stringValue(attrs.Name),
+		OCID:                stringValue(attrs.ID),
+		Namespace:           stringValue(attrs.Namespace),
+		StorageTier:         attrs.StorageTier,
+		Visibility:          attrs.PublicAccessType,
+		Versioning:          attrs.Versioning,
+		Encryption:          encryption,
+		ReplicationEnabled:  boolValue(attrs.ReplicationEnabled),
+		IsReadOnly:          boolValue(attrs.IsReadOnly),
+		ObjectEventsEnabled: boolValue(attrs.ObjectEventsEnabled),
+		ApproximateCount:    intValueFromInt64(attrs.ApproximateCount),
+		ApproximateSize:     int64Value(attrs.ApproximateSize),
+		FreeformTags:        attrs.FreeformTags,
+		DefinedTags:         attrs.DefinedTags,
 	}
 
 	if attrs.TimeCreated != nil {
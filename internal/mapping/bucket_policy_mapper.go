@@ -0,0 +1,145 @@
+package mapping
+
+import (
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+)
+
+// LifecycleRuleAttributes is a generic, intermediate representation of an object lifecycle rule.
+type LifecycleRuleAttributes struct {
+	Name              *string
+	Action            *string
+	Target            *string
+	TimeAmount        *int64
+	TimeUnit          string
+	IsEnabled         *bool
+	InclusionPrefixes []string
+	InclusionPatterns []string
+	ExclusionPatterns []string
+}
+
+// NewLifecycleRuleAttributesFromOCI creates attributes from an item of an OCI object lifecycle policy.
+func NewLifecycleRuleAttributesFromOCI(rule objectstorage.ObjectLifecycleRule) *LifecycleRuleAttributes {
+	attrs := &LifecycleRuleAttributes{
+		Name:       rule.Name,
+		Action:     rule.Action,
+		Target:     rule.Target,
+		TimeAmount: rule.TimeAmount,
+		TimeUnit:   string(rule.TimeUnit),
+		IsEnabled:  rule.IsEnabled,
+	}
+	if f := rule.ObjectNameFilter; f != nil {
+		attrs.InclusionPrefixes = f.InclusionPrefixes
+		attrs.InclusionPatterns = f.InclusionPatterns
+		attrs.ExclusionPatterns = f.ExclusionPatterns
+	}
+	return attrs
+}
+
+// NewDomainLifecycleRuleFromAttrs builds a domain.LifecycleRule. Rules without a target apply to objects.
+func NewDomainLifecycleRuleFromAttrs(attrs LifecycleRuleAttributes) domain.LifecycleRule {
+	rule := domain.LifecycleRule{
+		Name:              stringValue(attrs.Name),
+		Action:            stringValue(attrs.Action),
+		Target:            stringValue(attrs.Target),
+		TimeAmount:        int64Value(attrs.TimeAmount),
+		TimeUnit:          attrs.TimeUnit,
+		Enabled:           boolValue(attrs.IsEnabled),
+		InclusionPrefixes: attrs.InclusionPrefixes,
+		InclusionPatterns: attrs.InclusionPatterns,
+		ExclusionPatterns: attrs.ExclusionPatterns,
+	}
+	if rule.Target == "" {
+		rule.Target = "objects"
+	}
+	return rule
+}
+
+// RetentionRuleAttributes is a generic, intermediate representation of a retention rule.
+type RetentionRuleAttributes struct {
+	ID             *string
+	DisplayName    *string
+	DurationAmount *int64
+	DurationUnit   string
+	TimeRuleLocked *time.Time
+	TimeModified   *time.Time
+}
+
+// NewRetentionRuleAttributesFromOCISummary creates attributes from an OCI ListRetentionRules item.
+func NewRetentionRuleAttributesFromOCISummary(rule objectstorage.RetentionRuleSummary) *RetentionRuleAttributes {
+	attrs := &RetentionRuleAttributes{
+		ID:             rule.Id,
+		DisplayName:    rule.DisplayName,
+		TimeRuleLocked: sdkTime(rule.TimeRuleLocked),
+		TimeModified:   sdkTime(rule.TimeModified),
+	}
+	if d := rule.Duration; d != nil {
+		attrs.DurationAmount = d.TimeAmount
+		attrs.DurationUnit = string(d.TimeUnit)
+	}
+	return attrs
+}
+
+// NewDomainRetentionRuleFromAttrs builds a domain.RetentionRule.
+func NewDomainRetentionRuleFromAttrs(attrs RetentionRuleAttributes) domain.RetentionRule {
+	rule := domain.RetentionRule{
+		ID:             stringValue(attrs.ID),
+		Name:           stringValue(attrs.DisplayName),
+		DurationAmount: int64Value(attrs.DurationAmount),
+		DurationUnit:   attrs.DurationUnit,
+	}
+	if attrs.TimeRuleLocked != nil {
+		rule.TimeRuleLocked = *attrs.TimeRuleLocked
+	}
+	if attrs.TimeModified != nil {
+		rule.TimeModified = *attrs.TimeModified
+	}
+	return rule
+}
+
+// ReplicationPolicyAttributes is a generic, intermediate representation of a replication policy.
+type ReplicationPolicyAttributes struct {
+	ID                    *string
+	Name                  *string
+	DestinationRegionName *string
+	DestinationBucketName *string
+	Status                string
+	StatusMessage         *string
+	TimeCreated           *time.Time
+	TimeLastSync          *time.Time
+}
+
+// NewReplicationPolicyAttributesFromOCISummary creates attributes from an OCI ListReplicationPolicies item.
+func NewReplicationPolicyAttributesFromOCISummary(policy objectstorage.ReplicationPolicySummary) *ReplicationPolicyAttributes {
+	return &ReplicationPolicyAttributes{
+		ID:                    policy.Id,
+		Name:                  policy.Name,
+		DestinationRegionName: policy.DestinationRegionName,
+		DestinationBucketName: policy.DestinationBucketName,
+		Status:                string(policy.Status),
+		StatusMessage:         policy.StatusMessage,
+		TimeCreated:           sdkTime(policy.TimeCreated),
+		TimeLastSync:          sdkTime(policy.TimeLastSync),
+	}
+}
+
+// NewDomainReplicationPolicyFromAttrs builds a domain.ReplicationPolicy.
+func NewDomainReplicationPolicyFromAttrs(attrs ReplicationPolicyAttributes) domain.ReplicationPolicy {
+	policy := domain.ReplicationPolicy{
+		ID:                stringValue(attrs.ID),
+		Name:              stringValue(attrs.Name),
+		DestinationRegion: stringValue(attrs.DestinationRegionName),
+		DestinationBucket: stringValue(attrs.DestinationBucketName),
+		Status:            attrs.Status,
+		StatusMessage:     stringValue(attrs.StatusMessage),
+	}
+	if attrs.TimeCreated != nil {
+		policy.TimeCreated = *attrs.TimeCreated
+	}
+	if attrs.TimeLastSync != nil {
+		policy.TimeLastSync = *attrs.TimeLastSync
+	}
+	return policy
+}
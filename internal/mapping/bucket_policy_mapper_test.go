@@ -0,0 +1,82 @@
+package mapping
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDomainLifecycleRuleFromAttrs(t *testing.T) {
+	rule := objectstorage.ObjectLifecycleRule{
+		Name:       common.String("archive-logs"),
+		Action:     common.String("ARCHIVE"),
+		TimeAmount: common.Int64(30),
+		TimeUnit:   objectstorage.ObjectLifecycleRuleTimeUnitDays,
+		IsEnabled:  common.Bool(true),
+		ObjectNameFilter: &objectstorage.ObjectNameFilter{
+			InclusionPrefixes: []string{"logs/"},
+			ExclusionPatterns: []string{"*.keep"},
+		},
+	}
+
+	got := NewDomainLifecycleRuleFromAttrs(*NewLifecycleRuleAttributesFromOCI(rule))
+
+	assert.Equal(t, "archive-logs", got.Name)
+	assert.Equal(t, "ARCHIVE", got.Action)
+	assert.Equal(t, "objects", got.Target, "rules without a target apply to objects")
+	assert.Equal(t, int64(30), got.TimeAmount)
+	assert.Equal(t, "DAYS", got.TimeUnit)
+	assert.True(t, got.Enabled)
+	assert.Equal(t, []string{"logs/"}, got.InclusionPrefixes)
+	assert.Empty(t, got.InclusionPatterns)
+	assert.Equal(t, []string{"*.keep"}, got.ExclusionPatterns)
+}
+
+func TestNewDomainRetentionRuleFromAttrs(t *testing.T) {
+	locked := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	summary := objectstorage.RetentionRuleSummary{
+		Id:             common.String("rule-id"),
+		DisplayName:    common.String("legal"),
+		Duration:       &objectstorage.Duration{TimeAmount: common.Int64(7), TimeUnit: objectstorage.DurationTimeUnitYears},
+		TimeRuleLocked: &common.SDKTime{Time: locked},
+	}
+
+	got := NewDomainRetentionRuleFromAttrs(*NewRetentionRuleAttributesFromOCISummary(summary))
+
+	assert.Equal(t, "rule-id", got.ID)
+	assert.Equal(t, "legal", got.Name)
+	assert.Equal(t, int64(7), got.DurationAmount)
+	assert.Equal(t, "YEARS", got.DurationUnit)
+	assert.Equal(t, locked, got.TimeRuleLocked)
+	assert.True(t, got.IsLocked(locked.Add(time.Hour)))
+	assert.False(t, got.IsLocked(locked.Add(-time.Hour)), "a rule is not locked before its lock time")
+
+	indefinite := NewDomainRetentionRuleFromAttrs(*NewRetentionRuleAttributesFromOCISummary(objectstorage.RetentionRuleSummary{}))
+	assert.Zero(t, indefinite.DurationAmount)
+	assert.False(t, indefinite.IsLocked(locked))
+}
+
+func TestNewDomainReplicationPolicyFromAttrs(t *testing.T) {
+	synced := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	summary := objectstorage.ReplicationPolicySummary{
+		Id:                    common.String("policy-id"),
+		Name:                  common.String("dr"),
+		DestinationRegionName: common.String("us-phoenix-1"),
+		DestinationBucketName: common.String("backup"),
+		Status:                objectstorage.ReplicationPolicySummaryStatusActive,
+		TimeLastSync:          &common.SDKTime{Time: synced},
+	}
+
+	got := NewDomainReplicationPolicyFromAttrs(*NewReplicationPolicyAttributesFromOCISummary(summary))
+
+	assert.Equal(t, "policy-id", got.ID)
+	assert.Equal(t, "dr", got.Name)
+	assert.Equal(t, "us-phoenix-1", got.DestinationRegion)
+	assert.Equal(t, "backup", got.DestinationBucket)
+	assert.Equal(t, "ACTIVE", got.Status)
+	assert.Equal(t, synced, got.TimeLastSync)
+	assert.True(t, got.TimeCreated.IsZero())
+}
@@ -9,15 +9,20 @@ import (
 
 // ObjectAttributes is a generic, intermediate representation of an object's data.
 type ObjectAttributes struct {
-	Name         *string
-	Size         *int64
-	StorageTier  string
-	ContentType  *string
-	ContentMD5   *string
-	ETag         *string
-	LastModified *time.Time
-	BucketName   string
-	Namespace    string
+	Name          *string
+	Size          *int64
+	StorageTier   string
+	ArchivalState string
+	ContentType   *string
+	ContentMD5    *string
+	ETag          *string
+	LastModified  *time.Time
+	Metadata      map[string]string
+	VersionID     *string
+	// IsDeleteMarker is only set for object versions.
+	IsDeleteMarker *bool
+	BucketName     string
+	Namespace      string
 }
 
 // NewObjectAttributesFromOCIObjectSummary creates ObjectAttributes from an OCI ListObjects response item.
@@ -28,13 +33,15 @@ func NewObjectAttributesFromOCIObjectSummary(obj objectstorage.ObjectSummary, bu
 		lm = &t
 	}
 	return &ObjectAttributes{
-		Name:         obj.Name,
-		Size:         obj.Size,
-		StorageTier:  string(obj.StorageTier),
-		ETag:         obj.Etag,
-		LastModified: lm,
-		BucketName:   bucketName,
-		Namespace:    namespace,
+		Name:          obj.Name,
+		Size:          obj.Size,
+		StorageTier:   string(obj.StorageTier),
+		ArchivalState: string(obj.ArchivalState),
+		ContentMD5:    obj.Md5,
+		ETag:          obj.Etag,
+		LastModified:  lm,
+		BucketName:    bucketName,
+		Namespace:     namespace,
 	}
 }
 
@@ -46,27 +53,55 @@ func NewObjectAttributesFromOCIHeadObject(resp objectstorage.HeadObjectResponse,
 		lm = &t
 	}
 	return &ObjectAttributes{
-		StorageTier:  string(resp.StorageTier),
-		ContentType:  resp.ContentType,
-		ContentMD5:   resp.ContentMd5,
-		ETag:         resp.ETag,
-		LastModified: lm,
-		BucketName:   bucketName,
-		Namespace:    namespace,
+		StorageTier:   string(resp.StorageTier),
+		ArchivalState: string(resp.ArchivalState),
+		ContentType:   resp.ContentType,
+		ContentMD5:    resp.ContentMd5,
+		ETag:          resp.ETag,
+		LastModified:  lm,
+		Metadata:      resp.OpcMeta,
+		VersionID:     resp.VersionId,
+		BucketName:    bucketName,
+		Namespace:     namespace,
+	}
+}
+
+// NewObjectAttributesFromOCIObjectVersionSummary creates ObjectAttributes from an OCI ListObjectVersions response item.
+func NewObjectAttributesFromOCIObjectVersionSummary(v objectstorage.ObjectVersionSummary, bucketName, namespace string) *ObjectAttributes {
+	var lm *time.Time
+	if v.TimeModified != nil {
+		t := v.TimeModified.Time
+		lm = &t
+	}
+	return &ObjectAttributes{
+		Name:           v.Name,
+		Size:           v.Size,
+		StorageTier:    string(v.StorageTier),
+		ArchivalState:  string(v.ArchivalState),
+		ContentMD5:     v.Md5,
+		ETag:           v.Etag,
+		LastModified:   lm,
+		VersionID:      v.VersionId,
+		IsDeleteMarker: v.IsDeleteMarker,
+		BucketName:     bucketName,
+		Namespace:      namespace,
 	}
 }
 
 // NewDomainObjectFromAttrs builds a domain.Object from provider-agnostic attributes.
 func NewDomainObjectFromAttrs(attrs ObjectAttributes) domain.Object {
 	obj := domain.Object{
-		Name:        stringValue(attrs.Name),
-		Size:        int64Value(attrs.Size),
-		StorageTier: attrs.StorageTier,
-		ContentType: stringValue(attrs.ContentType),
-		ContentMD5:  stringValue(attrs.ContentMD5),
-		ETag:        stringValue(attrs.ETag),
-		BucketName:  attrs.BucketName,
-		Namespace:   attrs.Namespace,
+		Name:          stringValue(attrs.Name),
+		Size:          int64Value(attrs.Size),
+		StorageTier:   attrs.StorageTier,
+		ArchivalState: attrs.ArchivalState,
+		ContentType:   stringValue(attrs.ContentType),
+		ContentMD5:    stringValue(attrs.ContentMD5),
+		ETag:          stringValue(attrs.ETag),
+		Metadata:      attrs.Metadata,
+		VersionID:     stringValue(attrs.VersionID),
+		BucketName:    attrs.BucketName,
+		Namespace:     attrs.Namespace,
 	}
 
 	if attrs.LastModified != nil {
@@ -75,3 +110,8 @@ func NewDomainObjectFromAttrs(attrs ObjectAttributes) domain.Object {
 
 	return obj
 }
+
+// NewDomainObjectVersionFromAttrs builds a domain.ObjectVersion from provider-agnostic attributes.
+func NewDomainObjectVersionFromAttrs(attrs ObjectAttributes) domain.ObjectVersion {
+	return domain.ObjectVersion{Object: NewDomainObjectFromAttrs(attrs), IsDeleteMarker: boolValue(attrs.IsDeleteMarker)}
+}
@@ -0,0 +1,91 @@
+package mapping
+
+import (
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+)
+
+// PreauthenticatedRequestAttributes is a generic, intermediate representation of a pre-authenticated request.
+type PreauthenticatedRequestAttributes struct {
+	ID                  *string
+	Name                *string
+	AccessType          string
+	ObjectName          *string
+	BucketListingAction string
+	TimeCreated         *time.Time
+	TimeExpires         *time.Time
+	AccessURI           *string
+	FullPath            *string
+	BucketName          string
+	Namespace           string
+}
+
+// NewPreauthenticatedRequestAttributesFromOCI creates attributes from an OCI CreatePreauthenticatedRequest response.
+func NewPreauthenticatedRequestAttributesFromOCI(par objectstorage.PreauthenticatedRequest, bucketName, namespace string) *PreauthenticatedRequestAttributes {
+	return &PreauthenticatedRequestAttributes{
+		ID:                  par.Id,
+		Name:                par.Name,
+		AccessType:          string(par.AccessType),
+		ObjectName:          par.ObjectName,
+		BucketListingAction: string(par.BucketListingAction),
+		TimeCreated:         sdkTime(par.TimeCreated),
+		TimeExpires:         sdkTime(par.TimeExpires),
+		AccessURI:           par.AccessUri,
+		FullPath:            par.FullPath,
+		BucketName:          bucketName,
+		Namespace:           namespace,
+	}
+}
+
+// NewPreauthenticatedRequestAttributesFromOCISummary creates attributes from an OCI ListPreauthenticatedRequests item.
+func NewPreauthenticatedRequestAttributesFromOCISummary(par objectstorage.PreauthenticatedRequestSummary, bucketName, namespace string) *PreauthenticatedRequestAttributes {
+	return &PreauthenticatedRequestAttributes{
+		ID:                  par.Id,
+		Name:                par.Name,
+		AccessType:          string(par.AccessType),
+		ObjectName:          par.ObjectName,
+		BucketListingAction: string(par.BucketListingAction),
+		TimeCreated:         sdkTime(par.TimeCreated),
+		TimeExpires:         sdkTime(par.TimeExpires),
+		BucketName:          bucketName,
+		Namespace:           namespace,
+	}
+}
+
+// NewDomainPreauthenticatedRequestFromAttrs builds a domain.PreauthenticatedRequest. The URL is the full path
+// when Object Storage returns one, otherwise the access URI joined to endpoint, the regional endpoint.
+func NewDomainPreauthenticatedRequestFromAttrs(attrs PreauthenticatedRequestAttributes, endpoint string) domain.PreauthenticatedRequest {
+	par := domain.PreauthenticatedRequest{
+		ID:                  stringValue(attrs.ID),
+		Name:                stringValue(attrs.Name),
+		AccessType:          attrs.AccessType,
+		ObjectName:          stringValue(attrs.ObjectName),
+		BucketListingAction: attrs.BucketListingAction,
+		BucketName:          attrs.BucketName,
+		Namespace:           attrs.Namespace,
+	}
+	if attrs.TimeCreated != nil {
+		par.TimeCreated = *attrs.TimeCreated
+	}
+	if attrs.TimeExpires != nil {
+		par.TimeExpires = *attrs.TimeExpires
+	}
+	if full := stringValue(attrs.FullPath); strings.HasPrefix(full, "https://") {
+		par.URL = full
+	} else if uri := stringValue(attrs.AccessURI); uri != "" {
+		par.URL = endpoint + uri
+	}
+	return par
+}
+
+func sdkTime(t *common.SDKTime) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
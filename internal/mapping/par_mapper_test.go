@@ -0,0 +1,54 @@
+package mapping
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDomainPreauthenticatedRequestFromAttrs(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	expires := created.Add(24 * time.Hour)
+	par := objectstorage.PreauthenticatedRequest{
+		Id:          common.String("par-id"),
+		Name:        common.String("share-report"),
+		AccessUri:   common.String("/p/token/n/ns/b/reports/o/q1.pdf"),
+		ObjectName:  common.String("q1.pdf"),
+		AccessType:  objectstorage.PreauthenticatedRequestAccessTypeObjectread,
+		TimeCreated: &common.SDKTime{Time: created},
+		TimeExpires: &common.SDKTime{Time: expires},
+	}
+
+	got := NewDomainPreauthenticatedRequestFromAttrs(*NewPreauthenticatedRequestAttributesFromOCI(par, "reports", "ns"),
+		"https://objectstorage.us-ashburn-1.oraclecloud.com")
+
+	assert.Equal(t, "par-id", got.ID)
+	assert.Equal(t, "share-report", got.Name)
+	assert.Equal(t, "ObjectRead", got.AccessType)
+	assert.Equal(t, "q1.pdf", got.ObjectName)
+	assert.Equal(t, created, got.TimeCreated)
+	assert.Equal(t, expires, got.TimeExpires)
+	assert.Equal(t, "https://objectstorage.us-ashburn-1.oraclecloud.com/p/token/n/ns/b/reports/o/q1.pdf", got.URL)
+	assert.Equal(t, "reports", got.BucketName)
+	assert.Equal(t, "ns", got.Namespace)
+}
+
+func TestNewDomainPreauthenticatedRequestFromAttrs_Summary(t *testing.T) {
+	summary := objectstorage.PreauthenticatedRequestSummary{
+		Id:                  common.String("par-id"),
+		Name:                common.String("uploads"),
+		AccessType:          objectstorage.PreauthenticatedRequestSummaryAccessTypeAnyobjectwrite,
+		BucketListingAction: objectstorage.PreauthenticatedRequestBucketListingActionDeny,
+	}
+
+	got := NewDomainPreauthenticatedRequestFromAttrs(*NewPreauthenticatedRequestAttributesFromOCISummary(summary, "b", "ns"), "https://endpoint")
+
+	assert.Equal(t, "AnyObjectWrite", got.AccessType)
+	assert.Equal(t, "Deny", got.BucketListingAction)
+	assert.Empty(t, got.ObjectName)
+	assert.Empty(t, got.URL, "listed requests carry no URL")
+	assert.True(t, got.TimeCreated.IsZero())
+}
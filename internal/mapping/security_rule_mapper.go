@@ -0,0 +1,142 @@
+package mapping
+
+import (
+	"github.com/oracle/oci-go-sdk/v65/core"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+)
+
+// SecurityRuleAttributes is a generic, intermediate representation of a security list or NSG rule.
+type SecurityRuleAttributes struct {
+	ID          *string
+	Direction   string
+	Protocol    *string
+	Peer        *string
+	PeerType    string
+	TcpOptions  *core.TcpOptions
+	UdpOptions  *core.UdpOptions
+	IcmpOptions *core.IcmpOptions
+	IsStateless *bool
+	Description *string
+}
+
+// NewSecurityRuleAttributesFromOCIIngressRule creates attributes from an ingress rule of a security list.
+func NewSecurityRuleAttributesFromOCIIngressRule(r core.IngressSecurityRule) *SecurityRuleAttributes {
+	return &SecurityRuleAttributes{
+		Direction:   domain.DirectionIngress,
+		Protocol:    r.Protocol,
+		Peer:        r.Source,
+		PeerType:    string(r.SourceType),
+		TcpOptions:  r.TcpOptions,
+		UdpOptions:  r.UdpOptions,
+		IcmpOptions: r.IcmpOptions,
+		IsStateless: r.IsStateless,
+		Description: r.Description,
+	}
+}
+
+// NewSecurityRuleAttributesFromOCIEgressRule creates attributes from an egress rule of a security list.
+func NewSecurityRuleAttributesFromOCIEgressRule(r core.EgressSecurityRule) *SecurityRuleAttributes {
+	return &SecurityRuleAttributes{
+		Direction:   domain.DirectionEgress,
+		Protocol:    r.Protocol,
+		Peer:        r.Destination,
+		PeerType:    string(r.DestinationType),
+		TcpOptions:  r.TcpOptions,
+		UdpOptions:  r.UdpOptions,
+		IcmpOptions: r.IcmpOptions,
+		IsStateless: r.IsStateless,
+		Description: r.Description,
+	}
+}
+
+// NewSecurityRuleAttributesFromOCINSGRule creates attributes from a rule of a network security group.
+func NewSecurityRuleAttributesFromOCINSGRule(r core.SecurityRule) *SecurityRuleAttributes {
+	attrs := &SecurityRuleAttributes{
+		ID:          r.Id,
+		Direction:   string(r.Direction),
+		Protocol:    r.Protocol,
+		TcpOptions:  r.TcpOptions,
+		UdpOptions:  r.UdpOptions,
+		IcmpOptions: r.IcmpOptions,
+		IsStateless: r.IsStateless,
+		Description: r.Description,
+	}
+	if r.Direction == core.SecurityRuleDirectionEgress {
+		attrs.Peer, attrs.PeerType = r.Destination, string(r.DestinationType)
+	} else {
+		attrs.Peer, attrs.PeerType = r.Source, string(r.SourceType)
+	}
+	return attrs
+}
+
+// NewDomainSecurityRuleFromAttrs builds a domain.SecurityRule. A peer without a type is a CIDR block.
+func NewDomainSecurityRuleFromAttrs(attrs *SecurityRuleAttributes) domain.SecurityRule {
+	rule := domain.SecurityRule{
+		ID:          stringValue(attrs.ID),
+		Direction:   attrs.Direction,
+		Protocol:    stringValue(attrs.Protocol),
+		Peer:        stringValue(attrs.Peer),
+		PeerType:    attrs.PeerType,
+		Stateless:   boolValue(attrs.IsStateless),
+		Description: stringValue(attrs.Description),
+	}
+	if rule.PeerType == "" {
+		rule.PeerType = domain.AddressTypeCIDR
+	}
+	if o := attrs.TcpOptions; o != nil {
+		rule.SourcePorts, rule.DestinationPorts = portRange(o.SourcePortRange), portRange(o.DestinationPortRange)
+	}
+	if o := attrs.UdpOptions; o != nil {
+		rule.SourcePorts, rule.DestinationPorts = portRange(o.SourcePortRange), portRange(o.DestinationPortRange)
+	}
+	if o := attrs.IcmpOptions; o != nil {
+		rule.IcmpType, rule.IcmpCode = o.Type, o.Code
+	}
+	return rule
+}
+
+// RouteRuleAttributes is a generic, intermediate representation of a route rule.
+type RouteRuleAttributes struct {
+	Destination     *string
+	CidrBlock       *string
+	DestinationType string
+	NetworkEntityID *string
+	Description     *string
+}
+
+// NewRouteRuleAttributesFromOCIRouteRule creates attributes from a rule of a route table.
+func NewRouteRuleAttributesFromOCIRouteRule(r core.RouteRule) *RouteRuleAttributes {
+	return &RouteRuleAttributes{
+		Destination:     r.Destination,
+		CidrBlock:       r.CidrBlock,
+		DestinationType: string(r.DestinationType),
+		NetworkEntityID: r.NetworkEntityId,
+		Description:     r.Description,
+	}
+}
+
+// NewDomainRouteRuleFromAttrs builds a domain.RouteRule. The target name is left for the adapter to resolve.
+func NewDomainRouteRuleFromAttrs(attrs *RouteRuleAttributes) domain.RouteRule {
+	rule := domain.RouteRule{
+		Destination:     stringValue(attrs.Destination),
+		DestinationType: attrs.DestinationType,
+		TargetID:        stringValue(attrs.NetworkEntityID),
+		Description:     stringValue(attrs.Description),
+	}
+	// Older rules only carry the deprecated CIDR block.
+	if rule.Destination == "" {
+		rule.Destination = stringValue(attrs.CidrBlock)
+	}
+	if rule.DestinationType == "" {
+		rule.DestinationType = domain.AddressTypeCIDR
+	}
+	rule.TargetType = domain.TargetTypeFromOCID(rule.TargetID)
+	return rule
+}
+
+func portRange(r *core.PortRange) *domain.PortRange {
+	if r == nil || r.Min == nil || r.Max == nil {
+		return nil
+	}
+	return &domain.PortRange{Min: *r.Min, Max: *r.Max}
+}
@@ -0,0 +1,120 @@
+package mapping
+
+import (
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSecurityListAttributes_Rules(t *testing.T) {
+	sl := core.SecurityList{
+		Id:          common.String("ocid1.securitylist.oc1..sl"),
+		DisplayName: common.String("default"),
+		IngressSecurityRules: []core.IngressSecurityRule{{
+			Protocol:   common.String(domain.ProtocolTCP),
+			Source:     common.String("0.0.0.0/0"),
+			SourceType: core.IngressSecurityRuleSourceTypeCidrBlock,
+			TcpOptions: &core.TcpOptions{DestinationPortRange: &core.PortRange{Min: common.Int(22), Max: common.Int(22)}},
+		}, {
+			Protocol:    common.String(domain.ProtocolICMP),
+			Source:      common.String("10.0.0.0/16"),
+			IcmpOptions: &core.IcmpOptions{Type: common.Int(3), Code: common.Int(4)},
+			IsStateless: common.Bool(true),
+		}},
+		EgressSecurityRules: []core.EgressSecurityRule{{
+			Protocol:        common.String(domain.ProtocolAll),
+			Destination:     common.String("all-iad-services-in-oracle-services-network"),
+			DestinationType: core.EgressSecurityRuleDestinationTypeServiceCidrBlock,
+			Description:     common.String("services"),
+		}},
+	}
+
+	list := NewDomainSecurityListFromAttrs(NewSecurityListAttributesFromOCISecurityList(sl))
+
+	assert.Len(t, list.IngressRules, 2)
+	ssh := list.IngressRules[0]
+	assert.Equal(t, domain.DirectionIngress, ssh.Direction)
+	assert.Equal(t, "TCP", ssh.ProtocolName())
+	assert.Equal(t, domain.AddressTypeCIDR, ssh.PeerType)
+	assert.Equal(t, &domain.PortRange{Min: 22, Max: 22}, ssh.DestinationPorts)
+	assert.Nil(t, ssh.SourcePorts)
+
+	icmp := list.IngressRules[1]
+	assert.Equal(t, domain.AddressTypeCIDR, icmp.PeerType, "a rule without a source type is a CIDR rule")
+	assert.Equal(t, 3, *icmp.IcmpType)
+	assert.Equal(t, 4, *icmp.IcmpCode)
+	assert.True(t, icmp.Stateless)
+
+	assert.Len(t, list.EgressRules, 1)
+	assert.Equal(t, domain.SecurityRule{
+		Direction:   domain.DirectionEgress,
+		Protocol:    domain.ProtocolAll,
+		Peer:        "all-iad-services-in-oracle-services-network",
+		PeerType:    domain.AddressTypeService,
+		Description: "services",
+	}, list.EgressRules[0])
+}
+
+func TestNewSecurityRuleAttributesFromOCINSGRule(t *testing.T) {
+	ingress := NewDomainSecurityRuleFromAttrs(NewSecurityRuleAttributesFromOCINSGRule(core.SecurityRule{
+		Id:          common.String("rule-1"),
+		Direction:   core.SecurityRuleDirectionIngress,
+		Protocol:    common.String(domain.ProtocolUDP),
+		Source:      common.String("ocid1.networksecuritygroup.oc1..web"),
+		SourceType:  core.SecurityRuleSourceTypeNetworkSecurityGroup,
+		Destination: common.String("ignored"),
+		UdpOptions: &core.UdpOptions{
+			SourcePortRange:      &core.PortRange{Min: common.Int(1024), Max: common.Int(65535)},
+			DestinationPortRange: &core.PortRange{Min: common.Int(53), Max: common.Int(53)},
+		},
+	}))
+	assert.Equal(t, "rule-1", ingress.ID)
+	assert.Equal(t, "ocid1.networksecuritygroup.oc1..web", ingress.Peer)
+	assert.Equal(t, domain.AddressTypeNSG, ingress.PeerType)
+	assert.Equal(t, "1024-65535", ingress.SourcePorts.String())
+	assert.Equal(t, "53", ingress.DestinationPorts.String())
+
+	egress := NewDomainSecurityRuleFromAttrs(NewSecurityRuleAttributesFromOCINSGRule(core.SecurityRule{
+		Direction:       core.SecurityRuleDirectionEgress,
+		Protocol:        common.String(domain.ProtocolAll),
+		Source:          common.String("ignored"),
+		Destination:     common.String("0.0.0.0/0"),
+		DestinationType: core.SecurityRuleDestinationTypeCidrBlock,
+	}))
+	assert.Equal(t, domain.DirectionEgress, egress.Direction)
+	assert.Equal(t, "0.0.0.0/0", egress.Peer)
+}
+
+func TestNewRouteTableAttributes_Rules(t *testing.T) {
+	rt := core.RouteTable{
+		Id:          common.String("ocid1.routetable.oc1..rt"),
+		DisplayName: common.String("private"),
+		RouteRules: []core.RouteRule{{
+			Destination:     common.String("0.0.0.0/0"),
+			DestinationType: core.RouteRuleDestinationTypeCidrBlock,
+			NetworkEntityId: common.String("ocid1.natgateway.oc1..nat"),
+			Description:     common.String("egress"),
+		}, {
+			CidrBlock:       common.String("192.168.0.0/16"),
+			NetworkEntityId: common.String("ocid1.drg.oc1..drg"),
+		}},
+	}
+
+	table := NewDomainRouteTableFromAttrs(NewRouteTableAttributesFromOCIRouteTable(rt))
+
+	assert.Equal(t, []domain.RouteRule{{
+		Destination:     "0.0.0.0/0",
+		DestinationType: domain.AddressTypeCIDR,
+		TargetID:        "ocid1.natgateway.oc1..nat",
+		TargetType:      domain.TargetTypeNAT,
+		Description:     "egress",
+	}, {
+		Destination:     "192.168.0.0/16",
+		DestinationType: domain.AddressTypeCIDR,
+		TargetID:        "ocid1.drg.oc1..drg",
+		TargetType:      domain.TargetTypeDRG,
+	}}, table.Rules)
+}
@@ -91,14 +91,19 @@ type RouteTableAttributes struct {
 	OCID           *string
 	DisplayName    *string
 	LifecycleState core.RouteTableLifecycleStateEnum
+	Rules          []*RouteRuleAttributes
 }
 
 func NewRouteTableAttributesFromOCIRouteTable(rt core.RouteTable) *RouteTableAttributes {
-	return &RouteTableAttributes{
+	attrs := &RouteTableAttributes{
 		OCID:           rt.Id,
 		DisplayName:    rt.DisplayName,
 		LifecycleState: rt.LifecycleState,
 	}
+	for _, r := range rt.RouteRules {
+		attrs.Rules = append(attrs.Rules, NewRouteRuleAttributesFromOCIRouteRule(r))
+	}
+	return attrs
 }
 
 func NewDomainRouteTableFromAttrs(rt *RouteTableAttributes) *domain_vcn.RouteTable {
@@ -114,11 +119,15 @@ func NewDomainRouteTableFromAttrs(rt *RouteTableAttributes) *domain_vcn.RouteTab
 		lifecycleState = string(rt.LifecycleState)
 	}
 
-	return &domain_vcn.RouteTable{
+	table := &domain_vcn.RouteTable{
 		OCID:           ocid,
 		DisplayName:    displayName,
 		LifecycleState: lifecycleState,
 	}
+	for _, r := range rt.Rules {
+		table.Rules = append(table.Rules, NewDomainRouteRuleFromAttrs(r))
+	}
+	return table
 }
 
 type VcnAttributes struct {
@@ -165,14 +165,23 @@ type SecurityListAttributes struct {
 	OCID           *string
 	DisplayName    *string
 	LifecycleState core.SecurityListLifecycleStateEnum
+	IngressRules   []*SecurityRuleAttributes
+	EgressRules    []*SecurityRuleAttributes
 }
 
 func NewSecurityListAttributesFromOCISecurityList(sl core.SecurityList) *SecurityListAttributes {
-	return &SecurityListAttributes{
+	attrs := &SecurityListAttributes{
 		OCID:           sl.Id,
 		DisplayName:    sl.DisplayName,
 		LifecycleState: sl.LifecycleState,
 	}
+	for _, r := range sl.IngressSecurityRules {
+		attrs.IngressRules = append(attrs.IngressRules, NewSecurityRuleAttributesFromOCIIngressRule(r))
+	}
+	for _, r := range sl.EgressSecurityRules {
+		attrs.EgressRules = append(attrs.EgressRules, NewSecurityRuleAttributesFromOCIEgressRule(r))
+	}
+	return attrs
 }
 
 func NewDomainSecurityListFromAttrs(sl *SecurityListAttributes) *domain.SecurityList {
@@ -188,11 +197,18 @@ func NewDomainSecurityListFromAttrs(sl *SecurityListAttributes) *domain.Security
 		lifecycleState = string(sl.LifecycleState)
 	}
 
-	return &domain.SecurityList{
+	list := &domain.SecurityList{
 		OCID:           ocid,
 		DisplayName:    displayName,
 		LifecycleState: lifecycleState,
 	}
+	for _, r := range sl.IngressRules {
+		list.IngressRules = append(list.IngressRules, NewDomainSecurityRuleFromAttrs(r))
+	}
+	for _, r := range sl.EgressRules {
+		list.EgressRules = append(list.EgressRules, NewDomainSecurityRuleFromAttrs(r))
+	}
+	return list
 }
 
 type NSGAttributes struct {
@@ -17,19 +17,30 @@ const (
 	defaultMaxRetries     = 5
 	defaultInitialBackoff = 1 * time.Second
 	defaultMaxBackoff     = 32 * time.Second
+
+	// nsgRulesParallelism bounds the concurrent security rule listings of a VCN's NSGs.
+	nsgRulesParallelism = 4
 )
 
 // Adapter provides access to VCN-related OCI APIs.
 // It is infra-layer and should be used by the service layer.
 type Adapter struct {
-	client core.VirtualNetworkClient
+	client    core.VirtualNetworkClient
+	withRules bool
 }
 
-// NewAdapter creates a new adapter instance.
+// NewAdapter creates a new adapter instance. Enriched VCNs carry their NSGs without rules, and route
+// targets outside the VCN's gateways are left unnamed.
 func NewAdapter(client core.VirtualNetworkClient) *Adapter {
 	return &Adapter{client: client}
 }
 
+// NewAdapterWithRules creates an adapter whose enriched VCNs also carry the security rules of their NSGs
+// and the names of every route target. This costs extra API calls per NSG and per DRG or private IP target.
+func NewAdapterWithRules(client core.VirtualNetworkClient) *Adapter {
+	return &Adapter{client: client, withRules: true}
+}
+
 func (a *Adapter) GetEnrichedVcn(ctx context.Context, vcnID string) (domain.VCN, error) {
 	var resp core.GetVcnResponse
 	err := retryOnRateLimit(ctx, defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff, func() error {
@@ -169,7 +180,7 @@ func (a *Adapter) enrichVCN(ctx context.Context, vcn *domain.VCN) error {
 	go func() {
 		defer wg.Done()
 		var err error
-		vcn.Subnets, err = a.listSubnets(ctx, vcn.CompartmentID, vcn.OCID)
+		vcn.Subnets, err = a.ListSubnets(ctx, vcn.CompartmentID, vcn.OCID)
 		if err != nil {
 			errCh <- err
 		}
@@ -219,6 +230,7 @@ func (a *Adapter) enrichVCN(ctx context.Context, vcn *domain.VCN) error {
 		}
 	}
 
+	a.resolveRuleNames(ctx, vcn)
 	return nil
 }
 
@@ -363,40 +375,216 @@ func (a *Adapter) listNetworkSecurityGroups(ctx context.Context, compartmentID,
 	for _, item := range resp.Items {
 		nsgs = append(nsgs, *mapping.NewDomainNSGFromAttrs(mapping.NewNSGAttributesFromOCINSG(item)))
 	}
+	if !a.withRules {
+		return nsgs, nil
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, nsgRulesParallelism)
+	errCh := make(chan error, len(nsgs))
+	for i := range nsgs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			rules, err := a.listNSGSecurityRules(ctx, nsgs[i].OCID)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			nsgs[i].Rules = rules
+		}(i)
+	}
+	wg.Wait()
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return nil, err
+		}
+	}
 	return nsgs, nil
 }
 
-func (a *Adapter) GetDhcpOptions(ctx context.Context, dhcpID string) (domain.DhcpOptions, error) {
-	var resp core.GetDhcpOptionsResponse
+// NSGHasVnics reports whether any VNIC, including those of load balancers and databases, uses the NSG.
+func (a *Adapter) NSGHasVnics(ctx context.Context, nsgID string) (bool, error) {
+	req := core.ListNetworkSecurityGroupVnicsRequest{NetworkSecurityGroupId: &nsgID, Limit: common.Int(1)}
+	var resp core.ListNetworkSecurityGroupVnicsResponse
 	err := retryOnRateLimit(ctx, defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff, func() error {
 		var e error
-		resp, e = a.client.GetDhcpOptions(ctx, core.GetDhcpOptionsRequest{DhcpId: &dhcpID})
+		resp, e = a.client.ListNetworkSecurityGroupVnics(ctx, req)
 		return e
 	})
 	if err != nil {
-		return domain.DhcpOptions{}, err
+		return false, fmt.Errorf("listing VNICs of NSG %s: %w", nsgID, err)
 	}
-	return *mapping.NewDomainDhcpOptionsFromAttrs(mapping.NewDhcpOptionsAttributesFromOCIDhcpOptions(resp.DhcpOptions)), nil
+	return len(resp.Items) > 0, nil
 }
 
-func (a *Adapter) listSubnets(ctx context.Context, compartmentID, vcnID string) ([]domain.Subnet, error) {
-	req := core.ListSubnetsRequest{CompartmentId: &compartmentID, VcnId: &vcnID}
-	var resp core.ListSubnetsResponse
+// listNSGSecurityRules lists every ingress and egress rule of a network security group.
+func (a *Adapter) listNSGSecurityRules(ctx context.Context, nsgID string) ([]domain.SecurityRule, error) {
+	req := core.ListNetworkSecurityGroupSecurityRulesRequest{NetworkSecurityGroupId: &nsgID}
+	var rules []domain.SecurityRule
+	for {
+		var resp core.ListNetworkSecurityGroupSecurityRulesResponse
+		err := retryOnRateLimit(ctx, defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff, func() error {
+			var e error
+			resp, e = a.client.ListNetworkSecurityGroupSecurityRules(ctx, req)
+			return e
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing security rules of NSG %s: %w", nsgID, err)
+		}
+		for _, item := range resp.Items {
+			rules = append(rules, mapping.NewDomainSecurityRuleFromAttrs(mapping.NewSecurityRuleAttributesFromOCINSGRule(item)))
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return rules, nil
+}
+
+// resolveRuleNames fills in the NSG names referenced by security rules and the target names of route rules.
+// With rules, targets outside the VCN's own gateways (DRGs, private IPs) are looked up once each; a failed
+// lookup leaves the name empty rather than failing the whole VCN.
+func (a *Adapter) resolveRuleNames(ctx context.Context, vcn *domain.VCN) {
+	names := ruleNames(vcn)
+	lookup := func(id, targetType string) string {
+		if name, ok := names[id]; ok || !a.withRules {
+			return name
+		}
+		names[id] = a.lookupTargetName(ctx, id, targetType)
+		return names[id]
+	}
+	for i := range vcn.SecurityLists {
+		resolvePeerNames(vcn.SecurityLists[i].IngressRules, names)
+		resolvePeerNames(vcn.SecurityLists[i].EgressRules, names)
+	}
+	for i := range vcn.NSGs {
+		resolvePeerNames(vcn.NSGs[i].Rules, names)
+	}
+	for i := range vcn.RouteTables {
+		rules := vcn.RouteTables[i].Rules
+		for j := range rules {
+			if rules[j].TargetID != "" {
+				rules[j].TargetName = lookup(rules[j].TargetID, rules[j].TargetType)
+			}
+		}
+	}
+}
+
+// ruleNames indexes the display names of the NSGs and gateways of a VCN by OCID.
+func ruleNames(vcn *domain.VCN) map[string]string {
+	names := make(map[string]string, len(vcn.NSGs)+len(vcn.Gateways))
+	for _, nsg := range vcn.NSGs {
+		names[nsg.OCID] = nsg.DisplayName
+	}
+	for _, g := range vcn.Gateways {
+		names[g.OCID] = g.DisplayName
+	}
+	return names
+}
+
+func resolvePeerNames(rules []domain.SecurityRule, names map[string]string) {
+	for i := range rules {
+		if rules[i].PeerType == domain.AddressTypeNSG {
+			rules[i].PeerName = names[rules[i].Peer]
+		}
+	}
+}
+
+// lookupTargetName fetches the name of a route target that is not one of the VCN's gateways.
+// Private IPs are named by their address.
+func (a *Adapter) lookupTargetName(ctx context.Context, id, targetType string) string {
+	switch targetType {
+	case domain.TargetTypeDRG:
+		var resp core.GetDrgResponse
+		err := retryOnRateLimit(ctx, defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff, func() error {
+			var e error
+			resp, e = a.client.GetDrg(ctx, core.GetDrgRequest{DrgId: &id})
+			return e
+		})
+		if err == nil && resp.DisplayName != nil {
+			return *resp.DisplayName
+		}
+	case domain.TargetTypePrivateIP:
+		var resp core.GetPrivateIpResponse
+		err := retryOnRateLimit(ctx, defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff, func() error {
+			var e error
+			resp, e = a.client.GetPrivateIp(ctx, core.GetPrivateIpRequest{PrivateIpId: &id})
+			return e
+		})
+		if err == nil && resp.IpAddress != nil {
+			return *resp.IpAddress
+		}
+	}
+	return ""
+}
+
+func (a *Adapter) GetDhcpOptions(ctx context.Context, dhcpID string) (domain.DhcpOptions, error) {
+	var resp core.GetDhcpOptionsResponse
 	err := retryOnRateLimit(ctx, defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff, func() error {
 		var e error
-		resp, e = a.client.ListSubnets(ctx, req)
+		resp, e = a.client.GetDhcpOptions(ctx, core.GetDhcpOptionsRequest{DhcpId: &dhcpID})
 		return e
 	})
 	if err != nil {
-		return nil, err
+		return domain.DhcpOptions{}, err
 	}
+	return *mapping.NewDomainDhcpOptionsFromAttrs(mapping.NewDhcpOptionsAttributesFromOCIDhcpOptions(resp.DhcpOptions)), nil
+}
+
+// ListSubnets lists every subnet of a VCN in a compartment.
+func (a *Adapter) ListSubnets(ctx context.Context, compartmentID, vcnID string) ([]domain.Subnet, error) {
+	req := core.ListSubnetsRequest{CompartmentId: &compartmentID, VcnId: &vcnID}
 	var subnets []domain.Subnet
-	for _, item := range resp.Items {
-		subnets = append(subnets, *mapping.NewDomainSubnetFromAttrs(mapping.NewSubnetAttributesFromOCISubnet(item)))
+	for {
+		var resp core.ListSubnetsResponse
+		err := retryOnRateLimit(ctx, defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff, func() error {
+			var e error
+			resp, e = a.client.ListSubnets(ctx, req)
+			return e
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Items {
+			subnets = append(subnets, *mapping.NewDomainSubnetFromAttrs(mapping.NewSubnetAttributesFromOCISubnet(item)))
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
 	}
 	return subnets, nil
 }
 
+// CountPrivateIPs returns the number of private IPs allocated in a subnet, including those of
+// load balancers, databases and other services.
+func (a *Adapter) CountPrivateIPs(ctx context.Context, subnetID string) (int, error) {
+	req := core.ListPrivateIpsRequest{SubnetId: &subnetID, Limit: common.Int(1000)}
+	count := 0
+	for {
+		var resp core.ListPrivateIpsResponse
+		err := retryOnRateLimit(ctx, defaultMaxRetries, defaultInitialBackoff, defaultMaxBackoff, func() error {
+			var e error
+			resp, e = a.client.ListPrivateIps(ctx, req)
+			return e
+		})
+		if err != nil {
+			return 0, fmt.Errorf("listing private IPs of subnet %s: %w", subnetID, err)
+		}
+		count += len(resp.Items)
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return count, nil
+}
+
 // retryOnRateLimit retries the provided operation when OCI responds with HTTP 429 rate limited.
 // It applies exponential backoff between retries and preserves the original behavior and error messages.
 func retryOnRateLimit(ctx context.Context, maxRetries int, initialBackoff, maxBackoff time.Duration, op func() error) error {
@@ -3,7 +3,6 @@ package objectstorage
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 
@@ -142,36 +141,100 @@ func (a *Adapter) GetNamespace(ctx context.Context, compartmentID string) (strin
 
 // ListObjects retrieves all objects in a bucket.
 func (a *Adapter) ListObjects(ctx context.Context, namespace, bucketName string) ([]domain.Object, error) {
-	var allObjects []domain.Object
-	var nextStart *string
-	// Request additional fields: size, etag, timeModified, storageTier
-	fields := "name,size,etag,timeCreated,timeModified,storageTier"
+	return a.ListObjectsByPrefix(ctx, namespace, bucketName, "")
+}
 
+// ListObjectsByPrefix retrieves all objects in a bucket whose names start with prefix.
+func (a *Adapter) ListObjectsByPrefix(ctx context.Context, namespace, bucketName, prefix string) ([]domain.Object, error) {
+	var allObjects []domain.Object
+	start := ""
 	for {
-		resp, err := a.client.ListObjects(ctx, objectstorage.ListObjectsRequest{
-			NamespaceName: &namespace,
-			BucketName:    &bucketName,
-			Start:         nextStart,
-			Fields:        &fields,
-		})
+		page, err := a.ListObjectsPage(ctx, namespace, bucketName, prefix, "", start, 0)
 		if err != nil {
-			return nil, fmt.Errorf("listing objects: %w", err)
+			return nil, err
 		}
-
-		for _, item := range resp.Objects {
-			obj := mapping.NewDomainObjectFromAttrs(*mapping.NewObjectAttributesFromOCIObjectSummary(item, bucketName, namespace))
-			allObjects = append(allObjects, obj)
-		}
-
-		if resp.NextStartWith == nil || *resp.NextStartWith == "" {
+		allObjects = append(allObjects, page.Objects...)
+		if page.NextStart == "" {
 			break
 		}
-		nextStart = resp.NextStartWith
+		start = page.NextStart
 	}
 
 	return allObjects, nil
 }
 
+// ListObjectsPage retrieves one page of the objects whose names start with prefix, beginning at start.
+// With a delimiter, objects below the next delimiter are returned as prefixes instead, like folders.
+// A limit of 0 uses the service default of 1000.
+func (a *Adapter) ListObjectsPage(ctx context.Context, namespace, bucketName, prefix, delimiter, start string, limit int) (*domain.ObjectPage, error) {
+	// Request additional fields: size, etag, md5, timeModified, storageTier, archivalState
+	fields := "name,size,etag,md5,timeCreated,timeModified,storageTier,archivalState"
+	req := objectstorage.ListObjectsRequest{
+		NamespaceName: &namespace,
+		BucketName:    &bucketName,
+		Fields:        &fields,
+	}
+	if prefix != "" {
+		req.Prefix = &prefix
+	}
+	if delimiter != "" {
+		req.Delimiter = &delimiter
+	}
+	if start != "" {
+		req.Start = &start
+	}
+	if limit > 0 {
+		req.Limit = &limit
+	}
+	resp, err := a.client.ListObjects(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("listing objects: %w", err)
+	}
+
+	page := &domain.ObjectPage{Prefixes: resp.Prefixes}
+	for _, item := range resp.Objects {
+		obj := mapping.NewDomainObjectFromAttrs(*mapping.NewObjectAttributesFromOCIObjectSummary(item, bucketName, namespace))
+		page.Objects = append(page.Objects, obj)
+	}
+	if resp.NextStartWith != nil {
+		page.NextStart = *resp.NextStartWith
+	}
+	return page, nil
+}
+
+// ListObjectVersions retrieves the versions of an object, newest first. The first version is the current one,
+// unless the object was deleted; then it is a delete marker.
+func (a *Adapter) ListObjectVersions(ctx context.Context, namespace, bucketName, objectName string) ([]domain.ObjectVersion, error) {
+	// The SDK accepts a single extra field; the version ID, modification time and delete marker are always returned.
+	req := objectstorage.ListObjectVersionsRequest{
+		NamespaceName: &namespace,
+		BucketName:    &bucketName,
+		Prefix:        &objectName,
+		Fields:        objectstorage.ListObjectVersionsFieldsSize,
+	}
+	var versions []domain.ObjectVersion
+	for {
+		resp, err := a.client.ListObjectVersions(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("listing object versions: %w", err)
+		}
+		for _, item := range resp.Items {
+			// The prefix also matches longer names; only the versions of the object itself are kept.
+			if item.Name == nil || *item.Name != objectName {
+				continue
+			}
+			v := mapping.NewDomainObjectVersionFromAttrs(*mapping.NewObjectAttributesFromOCIObjectVersionSummary(item, bucketName, namespace))
+			v.IsLatest = len(versions) == 0
+			versions = append(versions, v)
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return versions, nil
+}
+
 // GetObjectHead retrieves object metadata using HeadObject.
 func (a *Adapter) GetObjectHead(ctx context.Context, namespace, bucketName, objectName string) (*domain.Object, error) {
 	resp, err := a.client.HeadObject(ctx, objectstorage.HeadObjectRequest{
@@ -195,87 +258,61 @@ func (a *Adapter) GetObjectHead(ctx context.Context, namespace, bucketName, obje
 	return &obj, nil
 }
 
-// DownloadObject downloads an object to the specified destination path with progress reporting.
-func (a *Adapter) DownloadObject(ctx context.Context, namespace, bucketName, objectName, destPath string, progressFn func(domain.TransferProgress)) error {
-	resp, err := a.client.GetObject(ctx, objectstorage.GetObjectRequest{
+// DeleteObject deletes an object.
+func (a *Adapter) DeleteObject(ctx context.Context, namespace, bucketName, objectName string) error {
+	_, err := a.client.DeleteObject(ctx, objectstorage.DeleteObjectRequest{
 		NamespaceName: &namespace,
 		BucketName:    &bucketName,
 		ObjectName:    &objectName,
 	})
 	if err != nil {
-		return fmt.Errorf("get object: %w", err)
-	}
-	defer resp.Content.Close()
-
-	// Use only the base name from the object name for the destination file
-	baseName := filepath.Base(objectName)
-	fullPath := filepath.Join(destPath, baseName)
-
-	file, err := os.Create(fullPath)
-	if err != nil {
-		return fmt.Errorf("create file %s: %w", fullPath, err)
-	}
-	defer file.Close()
-
-	totalSize := int64(0)
-	if resp.ContentLength != nil {
-		totalSize = *resp.ContentLength
+		return fmt.Errorf("delete object: %w", err)
 	}
+	return nil
+}
 
-	// If we have a progress callback and know the total size, use progress writer
-	if progressFn != nil && totalSize > 0 {
-		pw := &progressWriter{
-			total:      totalSize,
-			downloaded: 0,
-			onProgress: func(downloaded int64) {
-				progressFn(domain.TransferProgress{
-					BytesTransferred: downloaded,
-					TotalBytes:       totalSize,
-					PartNumber:       1,
-					TotalParts:       1,
-				})
-			},
-		}
-		_, err = io.Copy(file, io.TeeReader(resp.Content, pw))
-	} else {
-		_, err = io.Copy(file, resp.Content)
+// RestoreObject starts restoring an object from the Archive tier. The restored copy can be downloaded for the
+// given number of hours; 0 uses the service default of 24.
+func (a *Adapter) RestoreObject(ctx context.Context, namespace, bucketName, objectName string, hours int) error {
+	details := objectstorage.RestoreObjectsDetails{ObjectName: &objectName}
+	if hours > 0 {
+		details.Hours = &hours
 	}
-
+	_, err := a.client.RestoreObjects(ctx, objectstorage.RestoreObjectsRequest{
+		NamespaceName:         &namespace,
+		BucketName:            &bucketName,
+		RestoreObjectsDetails: details,
+	})
 	if err != nil {
-		return fmt.Errorf("write file %s: %w", fullPath, err)
+		return fmt.Errorf("restore object: %w", err)
 	}
-
 	return nil
 }
 
-// progressWriter tracks download progress.
-type progressWriter struct {
-	total      int64
-	downloaded int64
-	onProgress func(int64)
-}
-
-func (pw *progressWriter) Write(p []byte) (int, error) {
-	n := len(p)
-	pw.downloaded += int64(n)
-	if pw.onProgress != nil {
-		pw.onProgress(pw.downloaded)
+// versionParam turns a version ID into a request parameter; the current version is selected by leaving it out.
+func versionParam(versionID string) *string {
+	if versionID == "" {
+		return nil
 	}
-	return n, nil
+	return &versionID
 }
 
 // Multipart upload constants
 const (
 	// MinPartSize is the minimum part size for multipart upload (10 MiB)
 	MinPartSize = 10 * 1024 * 1024
-	// MaxPartSize is the maximum part size (50 MiB for better parallelism)
-	MaxPartSize = 50 * 1024 * 1024
+	// DefaultPartSize is the part size used for large files (50 MiB)
+	DefaultPartSize = 50 * 1024 * 1024
+	// MaxUploadParts is the maximum number of parts Object Storage accepts for one upload
+	MaxUploadParts = 10000
 	// MultipartThreshold - files larger than this use multipart upload (10 MiB)
 	MultipartThreshold = 10 * 1024 * 1024
+	// DefaultParallelism is the number of parts uploaded at the same time
+	DefaultParallelism = 4
 )
 
 // UploadObject uploads a file to object storage using multipart upload for large files.
-func (a *Adapter) UploadObject(ctx context.Context, namespace, bucketName, objectName, filePath string, progressFn func(domain.TransferProgress)) error {
+func (a *Adapter) UploadObject(ctx context.Context, namespace, bucketName, objectName, filePath string, opts domain.TransferOptions, progressFn func(domain.TransferProgress)) error {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return fmt.Errorf("open file: %w", err)
@@ -291,16 +328,16 @@ func (a *Adapter) UploadObject(ctx context.Context, namespace, bucketName, objec
 
 	// Use simple upload for small files
 	if fileSize <= MultipartThreshold {
-		return a.simpleUpload(ctx, namespace, bucketName, objectName, file, fileSize, progressFn)
+		return a.simpleUpload(ctx, namespace, bucketName, objectName, file, fileSize, opts, progressFn)
 	}
 
 	// Use multipart upload for large files
-	return a.multipartUpload(ctx, namespace, bucketName, objectName, file, fileSize, progressFn)
+	return a.multipartUpload(ctx, namespace, bucketName, objectName, file, stat, opts, progressFn)
 }
 
 // simpleUpload performs a single PUT request for small files.
-func (a *Adapter) simpleUpload(ctx context.Context, namespace, bucketName, objectName string, file *os.File, fileSize int64, progressFn func(domain.TransferProgress)) error {
-	contentType := detectContentType(file.Name())
+func (a *Adapter) simpleUpload(ctx context.Context, namespace, bucketName, objectName string, file *os.File, fileSize int64, opts domain.TransferOptions, progressFn func(domain.TransferProgress)) error {
+	contentType := contentTypeFor(file.Name(), opts)
 
 	_, err := a.client.PutObject(ctx, objectstorage.PutObjectRequest{
 		NamespaceName: &namespace,
@@ -309,6 +346,7 @@ func (a *Adapter) simpleUpload(ctx context.Context, namespace, bucketName, objec
 		ContentLength: &fileSize,
 		PutObjectBody: file,
 		ContentType:   &contentType,
+		OpcMeta:       opts.Metadata,
 	})
 	if err != nil {
 		return fmt.Errorf("put object: %w", err)
@@ -326,95 +364,12 @@ func (a *Adapter) simpleUpload(ctx context.Context, namespace, bucketName, objec
 	return nil
 }
 
-// multipartUpload performs a multipart upload for large files.
-func (a *Adapter) multipartUpload(ctx context.Context, namespace, bucketName, objectName string, file *os.File, fileSize int64, progressFn func(domain.TransferProgress)) error {
-	// Calculate part size and count
-	partSize := int64(MaxPartSize)
-	partCount := int((fileSize + partSize - 1) / partSize)
-
-	contentType := detectContentType(file.Name())
-
-	// Create multipart upload
-	createResp, err := a.client.CreateMultipartUpload(ctx, objectstorage.CreateMultipartUploadRequest{
-		NamespaceName: &namespace,
-		BucketName:    &bucketName,
-		CreateMultipartUploadDetails: objectstorage.CreateMultipartUploadDetails{
-			Object:      &objectName,
-			ContentType: &contentType,
-		},
-	})
-	if err != nil {
-		return fmt.Errorf("create multipart upload: %w", err)
+// contentTypeFor returns the content type override from opts, or the type detected from name.
+func contentTypeFor(name string, opts domain.TransferOptions) string {
+	if opts.ContentType != "" {
+		return opts.ContentType
 	}
-
-	uploadID := *createResp.UploadId
-
-	// Upload parts
-	var committedParts []objectstorage.CommitMultipartUploadPartDetails
-	var bytesUploaded int64
-
-	for partNum := 1; partNum <= partCount; partNum++ {
-		offset := int64(partNum-1) * partSize
-		size := partSize
-		if offset+size > fileSize {
-			size = fileSize - offset
-		}
-
-		// Create a section reader for this part wrapped as ReadCloser
-		sectionReader := io.NewSectionReader(file, offset, size)
-		partReader := io.NopCloser(sectionReader)
-
-		resp, err := a.client.UploadPart(ctx, objectstorage.UploadPartRequest{
-			NamespaceName:  &namespace,
-			BucketName:     &bucketName,
-			ObjectName:     &objectName,
-			UploadId:       &uploadID,
-			UploadPartNum:  &partNum,
-			UploadPartBody: partReader,
-			ContentLength:  &size,
-		})
-		if err != nil {
-			// Abort on failure
-			_, _ = a.client.AbortMultipartUpload(ctx, objectstorage.AbortMultipartUploadRequest{
-				NamespaceName: &namespace,
-				BucketName:    &bucketName,
-				ObjectName:    &objectName,
-				UploadId:      &uploadID,
-			})
-			return fmt.Errorf("upload part %d: %w", partNum, err)
-		}
-
-		committedParts = append(committedParts, objectstorage.CommitMultipartUploadPartDetails{
-			PartNum: &partNum,
-			Etag:    resp.ETag,
-		})
-
-		bytesUploaded += size
-		if progressFn != nil {
-			progressFn(domain.TransferProgress{
-				BytesTransferred: bytesUploaded,
-				TotalBytes:       fileSize,
-				PartNumber:       partNum,
-				TotalParts:       partCount,
-			})
-		}
-	}
-
-	// Commit multipart upload
-	_, err = a.client.CommitMultipartUpload(ctx, objectstorage.CommitMultipartUploadRequest{
-		NamespaceName: &namespace,
-		BucketName:    &bucketName,
-		ObjectName:    &objectName,
-		UploadId:      &uploadID,
-		CommitMultipartUploadDetails: objectstorage.CommitMultipartUploadDetails{
-			PartsToCommit: committedParts,
-		},
-	})
-	if err != nil {
-		return fmt.Errorf("commit multipart upload: %w", err)
-	}
-
-	return nil
+	return detectContentType(name)
 }
 
 // detectContentType returns the MIME type based on file extension.
@@ -0,0 +1,148 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListObjectsByPrefix(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	fake.listPage = 2
+	for _, name := range []string{"logs/a", "logs/b", "logs/c", "other"} {
+		fake.putObject("b", name, []byte(name))
+	}
+
+	objects, err := a.ListObjectsByPrefix(context.Background(), "ns", "b", "logs/")
+	require.NoError(t, err)
+	var names []string
+	for _, o := range objects {
+		names = append(names, o.Name)
+	}
+	assert.Equal(t, []string{"logs/a", "logs/b", "logs/c"}, names, "all pages are read")
+	assert.Equal(t, int64(6), objects[0].Size)
+	assert.Equal(t, fake.objects["b/logs/a"].md5, objects[0].ContentMD5)
+}
+
+func TestGetObjectHead_Metadata(t *testing.T) {
+	a, _ := newTestAdapter(t)
+	_, err := a.UploadStream(context.Background(), "ns", "b", "x", bytes.NewReader([]byte("x")),
+		domain.TransferOptions{Metadata: map[string]string{"mtime": "2024-05-01T10:00:00Z"}})
+	require.NoError(t, err)
+
+	obj, err := a.GetObjectHead(context.Background(), "ns", "b", "x")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"mtime": "2024-05-01T10:00:00Z"}, obj.Metadata)
+}
+
+func TestDeleteObject(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	fake.putObject("b", "old.txt", []byte("x"))
+
+	require.NoError(t, a.DeleteObject(context.Background(), "ns", "b", "old.txt"))
+	assert.Nil(t, fake.object("b", "old.txt"))
+	assert.Error(t, a.DeleteObject(context.Background(), "ns", "b", "old.txt"))
+}
+
+func TestRestoreObject(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	ctx := context.Background()
+	o := fake.putObject("b", "old.tar", []byte("archived"))
+	o.tier, o.archival = objectstorage.StorageTierArchive, objectstorage.ArchivalStateArchived
+	fake.putObject("b", "hot.txt", []byte("x"))
+
+	objects, err := a.ListObjectsByPrefix(ctx, "ns", "b", "old")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, domain.StorageTierArchive, objects[0].StorageTier)
+	assert.Equal(t, domain.ArchivalStateArchived, objects[0].ArchivalState)
+
+	require.NoError(t, a.RestoreObject(ctx, "ns", "b", "old.tar", 48))
+	assert.Equal(t, 48, o.restoreHours)
+	head, err := a.GetObjectHead(ctx, "ns", "b", "old.tar")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StorageTierArchive, head.StorageTier)
+	assert.Equal(t, domain.ArchivalStateRestoring, head.ArchivalState)
+
+	assert.Error(t, a.RestoreObject(ctx, "ns", "b", "hot.txt", 0), "only archived objects can be restored")
+}
+
+func TestListObjectsPage_Delimiter(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	for _, name := range []string{"logs/2024/01.log", "logs/2024/02.log", "logs/2025/01.log", "logs/app.log", "logs/web.log", "readme.txt"} {
+		fake.putObject("b", name, []byte(name))
+	}
+
+	page, err := a.ListObjectsPage(context.Background(), "ns", "b", "", "/", "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs/"}, page.Prefixes)
+	require.Len(t, page.Objects, 1)
+	assert.Equal(t, "readme.txt", page.Objects[0].Name)
+	assert.Empty(t, page.NextStart)
+
+	page, err = a.ListObjectsPage(context.Background(), "ns", "b", "logs/", "/", "", 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs/2024/", "logs/2025/"}, page.Prefixes)
+	require.Len(t, page.Objects, 1)
+	assert.Equal(t, "logs/app.log", page.Objects[0].Name)
+	assert.Equal(t, "logs/web.log", page.NextStart)
+
+	page, err = a.ListObjectsPage(context.Background(), "ns", "b", "logs/", "/", page.NextStart, 3)
+	require.NoError(t, err)
+	assert.Empty(t, page.Prefixes)
+	require.Len(t, page.Objects, 1)
+	assert.Equal(t, "logs/web.log", page.Objects[0].Name)
+	assert.Empty(t, page.NextStart)
+}
+
+func TestObjectVersions(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	fake.versioning = true
+	first := fake.putObject("b", "app.yaml", []byte("replicas: 2"))
+	fake.putObject("b", "app.yaml", []byte("replicas: 0"))
+	fake.putObject("b", "app.yaml.bak", []byte("other"))
+	ctx := context.Background()
+
+	versions, err := a.ListObjectVersions(ctx, "ns", "b", "app.yaml")
+	require.NoError(t, err)
+	require.Len(t, versions, 2, "versions of other objects with the name as prefix are left out")
+	assert.True(t, versions[0].IsLatest)
+	assert.False(t, versions[1].IsLatest)
+	assert.Equal(t, first.versionID, versions[1].VersionID)
+	assert.Equal(t, int64(11), versions[1].Size)
+	assert.True(t, versions[0].LastModified.After(versions[1].LastModified))
+
+	dest := filepath.Join(t.TempDir(), "app.yaml")
+	opts := domain.TransferOptions{VersionID: first.versionID}
+	require.NoError(t, a.DownloadObjectToFile(ctx, "ns", "b", "app.yaml", dest, opts, nil))
+	data, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "replicas: 2", string(data))
+
+	r, err := a.OpenObject(ctx, "ns", "b", "app.yaml", opts)
+	require.NoError(t, err)
+	data, err = io.ReadAll(r)
+	require.NoError(t, r.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "replicas: 2", string(data))
+
+	// Copying an old version over the object makes it current again.
+	fastCopyPoll(t)
+	require.NoError(t, a.CopyObject(ctx, "ns", "b", "app.yaml", "us-ashburn-1", "b", "app.yaml", opts))
+	assert.Equal(t, "replicas: 2", string(fake.object("b", "app.yaml")))
+	versions, err = a.ListObjectVersions(ctx, "ns", "b", "app.yaml")
+	require.NoError(t, err)
+	assert.Len(t, versions, 3)
+
+	head, err := a.GetObjectHead(ctx, "ns", "b", "app.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, versions[0].VersionID, head.VersionID)
+}
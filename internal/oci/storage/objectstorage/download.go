@@ -0,0 +1,411 @@
+package objectstorage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+)
+
+// downloadSuffix is appended to the destination while a download is in progress.
+const downloadSuffix = ".part"
+
+// commonPartSizes are part sizes used by ocloud and the OCI tools, tried when verifying multipart objects.
+var commonPartSizes = []int64{MinPartSize, DefaultPartSize, 128 * 1024 * 1024}
+
+// downloadState records which chunks of a .part file are complete so an interrupted download can be resumed.
+type downloadState struct {
+	ETag      string `json:"etag"`
+	Size      int64  `json:"size"`
+	ChunkSize int64  `json:"chunk_size"`
+	Done      []int  `json:"done"`
+}
+
+// DownloadObject downloads an object into the directory destPath, named after the last element of the object name.
+func (a *Adapter) DownloadObject(ctx context.Context, namespace, bucketName, objectName, destPath string, opts domain.TransferOptions, progressFn func(domain.TransferProgress)) error {
+	// Use only the base name from the object name for the destination file
+	fullPath := filepath.Join(destPath, filepath.Base(objectName))
+	return a.DownloadObjectToFile(ctx, namespace, bucketName, objectName, fullPath, opts, progressFn)
+}
+
+// DownloadObjectToFile downloads an object, or the version in opts.VersionID, to fullPath using parallel range
+// requests. Data is written to <fullPath>.part, which is verified against the object's MD5 and renamed into place
+// on success. If the download is interrupted, the next download of the same object continues with the chunks that
+// are still missing.
+func (a *Adapter) DownloadObjectToFile(ctx context.Context, namespace, bucketName, objectName, fullPath string, opts domain.TransferOptions, progressFn func(domain.TransferProgress)) error {
+	version := versionParam(opts.VersionID)
+	head, err := a.client.HeadObject(ctx, objectstorage.HeadObjectRequest{
+		NamespaceName: &namespace,
+		BucketName:    &bucketName,
+		ObjectName:    &objectName,
+		VersionId:     version,
+	})
+	if err != nil {
+		return fmt.Errorf("head object: %w", err)
+	}
+	var size int64
+	if head.ContentLength != nil {
+		size = *head.ContentLength
+	}
+	etag := stringValue(head.ETag)
+
+	partPath := fullPath + downloadSuffix
+	statePath := partPath + ".json"
+
+	state := loadDownloadState(statePath)
+	if state == nil || state.ETag != etag || state.Size != size || !fileHasSize(partPath, size) {
+		state = &downloadState{ETag: etag, Size: size, ChunkSize: PartSize(size)}
+	}
+	file, err := os.OpenFile(partPath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("create file %s: %w", partPath, err)
+	}
+	defer file.Close()
+	if err := file.Truncate(size); err != nil {
+		return fmt.Errorf("allocate %s: %w", partPath, err)
+	}
+
+	chunks := splitParts(size, state.ChunkSize)
+	done := make(map[int]bool, len(state.Done))
+	for _, n := range state.Done {
+		done[n] = true
+	}
+	var pending []filePart
+	var bytesDone int64
+	for _, c := range chunks {
+		if done[c.num] {
+			bytesDone += c.size
+			continue
+		}
+		pending = append(pending, c)
+	}
+
+	var mu sync.Mutex
+	report := func() {
+		if progressFn != nil && size > 0 {
+			progressFn(domain.TransferProgress{
+				BytesTransferred: bytesDone,
+				TotalBytes:       size,
+				PartNumber:       len(state.Done),
+				TotalParts:       len(chunks),
+			})
+		}
+	}
+	if bytesDone > 0 {
+		report()
+	}
+	// onWrite adds streamed bytes to the progress; a retried chunk subtracts what it had written before.
+	onWrite := func(n int64) {
+		mu.Lock()
+		bytesDone += n
+		report()
+		mu.Unlock()
+	}
+
+	workers := opts.Parallelism
+	if workers < 1 {
+		workers = DefaultParallelism
+	}
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	dlCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs := make(chan filePart)
+	var firstErr error
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range jobs {
+				err := a.downloadChunk(dlCtx, namespace, bucketName, objectName, version, etag, file, c, onWrite)
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("download bytes %d-%d: %w", c.offset, c.offset+c.size-1, err)
+						cancel()
+					}
+				} else {
+					state.Done = append(state.Done, c.num)
+					// The state is only a resume hint; failing to save it costs a re-download of the chunk.
+					_ = state.save(statePath)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for _, c := range pending {
+		select {
+		case jobs <- c:
+		case <-dlCtx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return fmt.Errorf("%w (%d of %d chunks downloaded to %s)", firstErr, len(state.Done), len(chunks), partPath)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("write file %s: %w", partPath, err)
+	}
+
+	if err := verifyDownload(partPath, size, stringValue(head.ContentMd5), stringValue(head.OpcMultipartMd5), recordedPartSize(head.OpcMeta)); err != nil {
+		// The data cannot be trusted, so the next attempt starts from scratch.
+		_ = os.Remove(partPath)
+		_ = os.Remove(statePath)
+		return fmt.Errorf("verify %s: %w", objectName, err)
+	}
+	if err := os.Rename(partPath, fullPath); err != nil {
+		return fmt.Errorf("rename %s: %w", partPath, err)
+	}
+	_ = os.Remove(statePath)
+	return nil
+}
+
+// downloadChunk fetches one byte range into the file at its offset, retrying transient failures. The
+// request is conditional on the ETag so a chunk of a newer version of the object is never mixed in.
+func (a *Adapter) downloadChunk(ctx context.Context, namespace, bucketName, objectName string, version *string, etag string, file *os.File, c filePart, onWrite func(int64)) error {
+	noRetry := common.NoRetryPolicy()
+	var err error
+	for attempt := 1; attempt <= partRetryAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * partRetryBackoff):
+			}
+		}
+
+		var written int64
+		err = func() error {
+			byteRange := fmt.Sprintf("bytes=%d-%d", c.offset, c.offset+c.size-1)
+			req := objectstorage.GetObjectRequest{
+				NamespaceName:   &namespace,
+				BucketName:      &bucketName,
+				ObjectName:      &objectName,
+				VersionId:       version,
+				Range:           &byteRange,
+				RequestMetadata: common.RequestMetadata{RetryPolicy: &noRetry},
+			}
+			if etag != "" {
+				req.IfMatch = &etag
+			}
+			resp, err := a.client.GetObject(ctx, req)
+			if err != nil {
+				return err
+			}
+			defer resp.Content.Close()
+
+			w := &chunkWriter{w: io.NewOffsetWriter(file, c.offset), onWrite: onWrite}
+			n, err := io.Copy(w, io.LimitReader(resp.Content, c.size))
+			written = n
+			if err != nil {
+				return err
+			}
+			if n != c.size {
+				return fmt.Errorf("short read: got %d of %d bytes", n, c.size)
+			}
+			return nil
+		}()
+		if err == nil {
+			return nil
+		}
+		onWrite(-written)
+		if ctx.Err() != nil || !isRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// chunkWriter reports every write to onWrite.
+type chunkWriter struct {
+	w       io.Writer
+	onWrite func(int64)
+}
+
+func (cw *chunkWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.onWrite(int64(n))
+	return n, err
+}
+
+// loadDownloadState reads the resume state of a download; nil means start from scratch.
+func loadDownloadState(path string) *downloadState {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var s downloadState
+	if err := json.Unmarshal(data, &s); err != nil || s.ChunkSize <= 0 {
+		return nil
+	}
+	return &s
+}
+
+// save writes the download state next to the .part file.
+func (s *downloadState) save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func fileHasSize(path string, size int64) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Size() == size
+}
+
+// verifyDownload compares a downloaded file with the object's checksum. Objects uploaded in one request carry
+// a Content-MD5; multipart objects carry an opc-multipart-md5 of "<md5 of the part MD5s>-<part count>", which
+// depends on the part size. ocloud records the part size in the object's metadata; for other objects it is
+// guessed from the sizes ocloud and the OCI tools use. A guess that does not match proves nothing, so the
+// file is then accepted as unverifiable rather than rejected.
+func verifyDownload(path string, size int64, contentMD5, multipartMD5 string, partSize int64) error {
+	var hashers []*partHasher
+	var parts int64
+	if contentMD5 == "" {
+		idx := strings.LastIndex(multipartMD5, "-")
+		if idx < 0 {
+			return nil
+		}
+		var err error
+		parts, err = strconv.ParseInt(multipartMD5[idx+1:], 10, 64)
+		if err != nil || parts < 1 {
+			return nil
+		}
+		candidates := append([]int64{PartSize(size), size}, commonPartSizes...)
+		if partSize > 0 {
+			candidates = []int64{partSize}
+		}
+		seen := make(map[int64]bool)
+		for _, ps := range candidates {
+			if ps > 0 && !seen[ps] && (size+ps-1)/ps == parts {
+				seen[ps] = true
+				hashers = append(hashers, newPartHasher(ps))
+			}
+		}
+		if len(hashers) == 0 {
+			logger.Logger.V(logger.Debug).Info("cannot verify multipart download: no known part size fits the part count", "path", path, "parts", parts)
+			return nil
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	whole := md5.New()
+	writers := []io.Writer{whole}
+	for _, h := range hashers {
+		writers = append(writers, h)
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), f); err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	if contentMD5 != "" {
+		if got := base64.StdEncoding.EncodeToString(whole.Sum(nil)); got != contentMD5 {
+			return fmt.Errorf("%w: MD5 is %s, object has %s", domain.ErrChecksumMismatch, got, contentMD5)
+		}
+		return nil
+	}
+	for _, h := range hashers {
+		if h.sum() == multipartMD5 {
+			return nil
+		}
+	}
+	// A single part hashes the same at any part size, so only then is a guess conclusive.
+	if partSize <= 0 && parts > 1 {
+		logger.Logger.V(logger.Debug).Info("cannot verify multipart download: part size unknown", "path", path, "parts", parts)
+		return nil
+	}
+	return fmt.Errorf("%w: multipart MD5 does not match %s", domain.ErrChecksumMismatch, multipartMD5)
+}
+
+// partHasher computes the multipart MD5 of a stream split into parts of a fixed size.
+type partHasher struct {
+	partSize int64
+	written  int64
+	parts    int
+	cur      hash.Hash
+	sums     []byte
+}
+
+func newPartHasher(partSize int64) *partHasher {
+	return &partHasher{partSize: partSize, cur: md5.New()}
+}
+
+func (h *partHasher) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		chunk := p
+		if room := h.partSize - h.written; int64(len(chunk)) > room {
+			chunk = p[:room]
+		}
+		h.cur.Write(chunk)
+		h.written += int64(len(chunk))
+		p = p[len(chunk):]
+		if h.written == h.partSize {
+			h.finishPart()
+		}
+	}
+	return n, nil
+}
+
+func (h *partHasher) finishPart() {
+	h.sums = h.cur.Sum(h.sums)
+	h.parts++
+	h.cur.Reset()
+	h.written = 0
+}
+
+// sum returns the multipart MD5 in the opc-multipart-md5 format.
+func (h *partHasher) sum() string {
+	if h.written > 0 {
+		h.finishPart()
+	}
+	total := md5.Sum(h.sums)
+	return base64.StdEncoding.EncodeToString(total[:]) + "-" + strconv.Itoa(h.parts)
+}
+
+// recordedPartSize returns the part size a multipart upload recorded in the object's metadata, or 0.
+func recordedPartSize(meta map[string]string) int64 {
+	size, err := strconv.ParseInt(meta[partSizeMetadataKey], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
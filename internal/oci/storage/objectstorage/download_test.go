@@ -0,0 +1,178 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testContent(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i*31 + i/1024)
+	}
+	return data
+}
+
+func TestDownloadObject_ParallelRanges(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	data := testContent(2*MinPartSize + 4321)
+	fake.putObject("exports", "db/export.dmp", data)
+	dest := t.TempDir()
+
+	var last domain.TransferProgress
+	err := a.DownloadObject(context.Background(), "ns", "exports", "db/export.dmp", dest, domain.TransferOptions{Parallelism: 3},
+		func(p domain.TransferProgress) { last = p })
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dest, "export.dmp"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, got))
+	assert.ElementsMatch(t, []string{"bytes=0-10485759", "bytes=10485760-20971519", "bytes=20971520-20975840"}, fake.gets)
+	assert.Equal(t, int64(len(data)), last.BytesTransferred)
+	assert.Equal(t, int64(len(data)), last.TotalBytes)
+	assert.NoFileExists(t, filepath.Join(dest, "export.dmp"+downloadSuffix))
+	assert.NoFileExists(t, filepath.Join(dest, "export.dmp"+downloadSuffix+".json"))
+}
+
+func TestDownloadObject_VerifiesMultipartUpload(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	path, data := writeUploadFile(t, 3*MinPartSize+10)
+	require.NoError(t, a.UploadObject(context.Background(), "ns", "exports", "export.dmp", path, domain.TransferOptions{}, nil))
+	assert.Equal(t, strconv.Itoa(MinPartSize), fake.objects["exports/export.dmp"].meta[partSizeMetadataKey])
+
+	// Corrupt the stored object; the multipart MD5 computed at commit time no longer matches.
+	fake.objects["exports/export.dmp"].data[5] ^= 0xff
+	dest := t.TempDir()
+	err := a.DownloadObject(context.Background(), "ns", "exports", "export.dmp", dest, domain.TransferOptions{}, nil)
+	require.ErrorIs(t, err, domain.ErrChecksumMismatch)
+	assert.NoFileExists(t, filepath.Join(dest, "export.dmp"))
+	assert.NoFileExists(t, filepath.Join(dest, "export.dmp"+downloadSuffix), "a corrupt download is not kept for resuming")
+
+	fake.objects["exports/export.dmp"].data[5] ^= 0xff
+	require.NoError(t, a.DownloadObject(context.Background(), "ns", "exports", "export.dmp", dest, domain.TransferOptions{}, nil))
+	got, err := os.ReadFile(filepath.Join(dest, "export.dmp"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, got))
+}
+
+func TestDownloadObject_ChecksumMismatch(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	obj := fake.putObject("b", "notes.txt", []byte("hello object storage"))
+	obj.md5 = "AAAAAAAAAAAAAAAAAAAAAA=="
+
+	err := a.DownloadObject(context.Background(), "ns", "b", "notes.txt", t.TempDir(), domain.TransferOptions{}, nil)
+	assert.True(t, errors.Is(err, domain.ErrChecksumMismatch), "got %v", err)
+}
+
+func TestDownloadObject_RetriesFailedRange(t *testing.T) {
+	fastPartRetry(t)
+	a, fake := newTestAdapter(t)
+	data := testContent(MinPartSize + 10)
+	fake.putObject("b", "data.bin", data)
+	fake.failGets = partRetryAttempts - 1
+
+	dest := t.TempDir()
+	require.NoError(t, a.DownloadObject(context.Background(), "ns", "b", "data.bin", dest, domain.TransferOptions{Parallelism: 1}, nil))
+	got, err := os.ReadFile(filepath.Join(dest, "data.bin"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, got))
+}
+
+func TestDownloadObject_ResumesPartFile(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	data := testContent(2*MinPartSize + 100)
+	obj := fake.putObject("b", "data.bin", data)
+	dest := t.TempDir()
+
+	// An earlier run finished the first two chunks before it was interrupted.
+	partPath := filepath.Join(dest, "data.bin"+downloadSuffix)
+	partial := make([]byte, len(data))
+	copy(partial, data[:2*MinPartSize])
+	require.NoError(t, os.WriteFile(partPath, partial, 0o644))
+	state := &downloadState{ETag: obj.etag, Size: int64(len(data)), ChunkSize: MinPartSize, Done: []int{1, 2}}
+	require.NoError(t, state.save(partPath+".json"))
+
+	var first domain.TransferProgress
+	err := a.DownloadObject(context.Background(), "ns", "b", "data.bin", dest, domain.TransferOptions{},
+		func(p domain.TransferProgress) {
+			if first.TotalBytes == 0 {
+				first = p
+			}
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bytes=20971520-20971619"}, fake.gets, "only the missing chunk is fetched")
+	assert.Equal(t, int64(2*MinPartSize), first.BytesTransferred)
+	got, err := os.ReadFile(filepath.Join(dest, "data.bin"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, got))
+
+	// A state from another version of the object is ignored.
+	fake.gets = nil
+	require.NoError(t, os.WriteFile(partPath, partial, 0o644))
+	state.ETag = "etag-old"
+	require.NoError(t, state.save(partPath+".json"))
+	require.NoError(t, a.DownloadObject(context.Background(), "ns", "b", "data.bin", dest, domain.TransferOptions{}, nil))
+	assert.Len(t, fake.gets, 3)
+}
+
+func TestDownloadObject_EmptyObject(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	fake.putObject("b", "empty", nil)
+	dest := t.TempDir()
+
+	require.NoError(t, a.DownloadObject(context.Background(), "ns", "b", "empty", dest, domain.TransferOptions{}, nil))
+	info, err := os.Stat(filepath.Join(dest, "empty"))
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+	assert.Empty(t, fake.gets)
+}
+
+func TestVerifyDownload_UnknownPartSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "f")
+	require.NoError(t, os.WriteFile(path, testContent(1000), 0o644))
+
+	// 1000 bytes cannot have been uploaded in 7 parts of any size ocloud uses, so there is nothing to compare.
+	assert.NoError(t, verifyDownload(path, 1000, "", "abc=-7", 0))
+	assert.NoError(t, verifyDownload(path, 1000, "", "", 0))
+	assert.ErrorIs(t, verifyDownload(path, 1000, "", "abc=-1", 0), domain.ErrChecksumMismatch)
+}
+
+func TestVerifyDownload_UnlistedPartSize(t *testing.T) {
+	// Parts of 12 MiB split 40 MiB into four, as the guessed 10 MiB parts do, but hash differently.
+	const size, partSize = 4 * MinPartSize, 12 * 1024 * 1024
+	data := testContent(size)
+	path := filepath.Join(t.TempDir(), "f")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	h := newPartHasher(partSize)
+	_, _ = h.Write(data)
+	multipartMD5 := h.sum()
+
+	assert.NoError(t, verifyDownload(path, size, "", multipartMD5, 0), "a wrong guess leaves the file unverified, not corrupt")
+	assert.NoError(t, verifyDownload(path, size, "", multipartMD5, partSize))
+
+	data[5] ^= 0xff
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	assert.NoError(t, verifyDownload(path, size, "", multipartMD5, 0))
+	assert.ErrorIs(t, verifyDownload(path, size, "", multipartMD5, partSize), domain.ErrChecksumMismatch,
+		"the part size recorded at upload makes the check conclusive")
+}
+
+func TestPartHasher(t *testing.T) {
+	h := newPartHasher(4)
+	_, _ = h.Write([]byte("abcdefghij"))
+	whole := newPartHasher(4)
+	for _, p := range []string{"ab", "cdefg", "hij"} {
+		_, _ = whole.Write([]byte(p))
+	}
+	assert.Equal(t, h.sum(), whole.sum(), "the result does not depend on how writes are split")
+	assert.Equal(t, 3, h.parts)
+}
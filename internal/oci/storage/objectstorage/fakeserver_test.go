@@ -0,0 +1,638 @@
+package objectstorage
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKeyOnce sync.Once
+	testKeyPEM  string
+)
+
+// fakeObjectStorage is an in-memory stand-in for the Object Storage API, just enough for the adapter tests.
+type fakeObjectStorage struct {
+	mu       sync.Mutex
+	objects  map[string]*fakeObject // bucket/object -> object
+	uploads  map[string]*fakeUpload
+	nextID   int
+	failPart map[int]int // part number -> remaining failures
+	partPuts []int
+	aborted  []string
+	inFlight int
+	maxPar   int
+	failGets int      // ranged GETs that fail with a 500 before succeeding
+	gets     []string // Range headers of the GETs served
+	listPage int      // objects per ListObjects page; 0 returns everything
+	work     map[string]*fakeWorkRequest
+	pars     map[string]objectstorage.PreauthenticatedRequest // bucket/id -> request
+	// versioning keeps the replaced versions of objects in history, newest first.
+	versioning bool
+	history    map[string][]*fakeObject
+	// Bucket policies, by bucket. A bucket without lifecycle rules has no lifecycle policy.
+	lifecycle   map[string][]objectstorage.ObjectLifecycleRule
+	retention   map[string][]objectstorage.RetentionRuleSummary
+	replication map[string][]objectstorage.ReplicationPolicySummary
+}
+
+// fakeWorkRequest is a server-side copy; it reports IN_PROGRESS once before its final status.
+type fakeWorkRequest struct {
+	polled bool
+	err    string
+}
+
+type fakeObject struct {
+	data         []byte
+	etag         string
+	md5          string
+	multipartMD5 string
+	contentType  string
+	meta         map[string]string
+	tier         objectstorage.StorageTierEnum
+	archival     objectstorage.ArchivalStateEnum
+	restoreHours int
+	versionID    string
+	modified     time.Time
+}
+
+type fakeUpload struct {
+	bucket, object string
+	parts          map[int][]byte
+	contentType    string
+	meta           map[string]string
+}
+
+// newTestAdapter returns an adapter whose client talks to a fresh fake Object Storage server.
+func newTestAdapter(t *testing.T) (*Adapter, *fakeObjectStorage) {
+	t.Helper()
+	testKeyOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(err)
+		}
+		testKeyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	})
+
+	fake := &fakeObjectStorage{objects: map[string]*fakeObject{}, uploads: map[string]*fakeUpload{}, failPart: map[int]int{}, work: map[string]*fakeWorkRequest{},
+		pars: map[string]objectstorage.PreauthenticatedRequest{}, history: map[string][]*fakeObject{},
+		lifecycle: map[string][]objectstorage.ObjectLifecycleRule{}, retention: map[string][]objectstorage.RetentionRuleSummary{},
+		replication: map[string][]objectstorage.ReplicationPolicySummary{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	provider := common.NewRawConfigurationProvider("ocid1.tenancy.oc1..test", "ocid1.user.oc1..test", "us-ashburn-1",
+		"11:22:33:44", testKeyPEM, nil)
+	client, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(provider)
+	require.NoError(t, err)
+	client.Host = srv.URL
+	return NewAdapter(client), fake
+}
+
+func (f *fakeObjectStorage) object(bucket, object string) []byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if o, ok := f.objects[bucket+"/"+object]; ok {
+		return o.data
+	}
+	return nil
+}
+
+// putObject stores an object as a single-request upload, with a Content-MD5.
+func (f *fakeObjectStorage) putObject(bucket, object string, data []byte) *fakeObject {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	sum := md5.Sum(data)
+	o := &fakeObject{data: data, etag: "etag-" + strconv.Itoa(f.nextID), md5: base64.StdEncoding.EncodeToString(sum[:])}
+	f.store(bucket+"/"+object, o)
+	return o
+}
+
+// store makes o the current version of an object, keeping the replaced one in history when versioning is on.
+// The caller holds f.mu.
+func (f *fakeObjectStorage) store(key string, o *fakeObject) {
+	f.nextID++
+	o.versionID = "version-" + strconv.Itoa(f.nextID)
+	o.modified = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(f.nextID) * time.Minute)
+	if old, ok := f.objects[key]; ok && f.versioning {
+		f.history[key] = append([]*fakeObject{old}, f.history[key]...)
+	}
+	f.objects[key] = o
+}
+
+// version returns the current object, or the version with the given ID. The caller holds f.mu.
+func (f *fakeObjectStorage) version(key, versionID string) (*fakeObject, bool) {
+	o, ok := f.objects[key]
+	if versionID == "" || (ok && o.versionID == versionID) {
+		return o, ok
+	}
+	for _, old := range f.history[key] {
+		if old.versionID == versionID {
+			return old, true
+		}
+	}
+	return nil, false
+}
+
+func writeServiceError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"code": code, "message": code})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// ServeHTTP routes /n/{ns}/b/{bucket}/o[/{object}], /n/{ns}/b/{bucket}/u[/{object}], /n/{ns}/b/{bucket}/p[/{id}],
+// /n/{ns}/b/{bucket}/objectversions, /n/{ns}/b/{bucket}/actions/{copyObject,restoreObjects} and
+// /workRequests/{id}[/errors] requests.
+func (f *fakeObjectStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/workRequests/") {
+		f.serveWorkRequest(w, strings.TrimPrefix(r.URL.Path, "/workRequests/"))
+		return
+	}
+	segs := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 6)
+	if len(segs) < 5 || segs[0] != "n" || segs[2] != "b" {
+		writeServiceError(w, http.StatusNotFound, "NotFound")
+		return
+	}
+	bucket, kind, object := segs[3], segs[4], ""
+	if len(segs) == 6 {
+		object = segs[5]
+	}
+	q := r.URL.Query()
+
+	switch {
+	case kind == "u" && object == "" && r.Method == http.MethodPost:
+		var details objectstorage.CreateMultipartUploadDetails
+		_ = json.NewDecoder(r.Body).Decode(&details)
+		f.mu.Lock()
+		f.nextID++
+		id := "upload-" + strconv.Itoa(f.nextID)
+		f.uploads[id] = &fakeUpload{bucket: bucket, object: *details.Object, parts: map[int][]byte{},
+			contentType: stringValue(details.ContentType), meta: details.Metadata}
+		f.mu.Unlock()
+		writeJSON(w, map[string]string{"uploadId": id, "namespace": segs[1], "bucket": bucket, "object": *details.Object})
+
+	case kind == "u":
+		f.serveUpload(w, r, q.Get("uploadId"))
+
+	case kind == "o" && r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		o := f.putObject(bucket, object, data)
+		o.contentType = r.Header.Get("Content-Type")
+		for k := range r.Header {
+			if strings.HasPrefix(strings.ToLower(k), "opc-meta-") {
+				if o.meta == nil {
+					o.meta = map[string]string{}
+				}
+				o.meta[strings.TrimPrefix(strings.ToLower(k), "opc-meta-")] = r.Header.Get(k)
+			}
+		}
+		w.Header().Set("ETag", o.etag)
+
+	case kind == "o" && r.Method == http.MethodDelete:
+		f.mu.Lock()
+		_, ok := f.objects[bucket+"/"+object]
+		delete(f.objects, bucket+"/"+object)
+		f.mu.Unlock()
+		if !ok {
+			writeServiceError(w, http.StatusNotFound, "ObjectNotFound")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case kind == "o" && object == "" && r.Method == http.MethodGet:
+		f.serveList(w, q, bucket)
+
+	case kind == "actions" && object == "copyObject" && r.Method == http.MethodPost:
+		f.serveCopy(w, r, bucket)
+
+	case kind == "actions" && object == "restoreObjects" && r.Method == http.MethodPost:
+		f.serveRestore(w, r, bucket)
+
+	case kind == "p":
+		f.servePAR(w, r, segs[1], bucket, object)
+
+	case kind == "objectversions" && r.Method == http.MethodGet:
+		f.serveVersions(w, q, bucket)
+
+	case (kind == "l" || kind == "retentionRules" || kind == "replicationPolicies") && r.Method == http.MethodGet:
+		f.servePolicies(w, q, bucket, kind)
+
+	case kind == "o" && (r.Method == http.MethodHead || r.Method == http.MethodGet):
+		f.serveObject(w, r, bucket+"/"+object, q.Get("versionId"))
+
+	default:
+		writeServiceError(w, http.StatusNotFound, "NotFound")
+	}
+}
+
+func (f *fakeObjectStorage) serveUpload(w http.ResponseWriter, r *http.Request, id string) {
+	f.mu.Lock()
+	up, ok := f.uploads[id]
+	f.mu.Unlock()
+	if !ok {
+		writeServiceError(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		num, _ := strconv.Atoi(r.URL.Query().Get("uploadPartNum"))
+		f.mu.Lock()
+		f.partPuts = append(f.partPuts, num)
+		f.inFlight++
+		if f.inFlight > f.maxPar {
+			f.maxPar = f.inFlight
+		}
+		fail := f.failPart[num] > 0
+		if fail {
+			f.failPart[num]--
+		}
+		f.mu.Unlock()
+		defer func() {
+			f.mu.Lock()
+			f.inFlight--
+			f.mu.Unlock()
+		}()
+
+		data, _ := io.ReadAll(r.Body)
+		if fail {
+			writeServiceError(w, http.StatusInternalServerError, "InternalServerError")
+			return
+		}
+		f.mu.Lock()
+		up.parts[num] = data
+		f.mu.Unlock()
+		w.Header().Set("ETag", fmt.Sprintf("etag-%s-%d", id, num))
+
+	case http.MethodGet:
+		f.mu.Lock()
+		var items []objectstorage.MultipartUploadPartSummary
+		for num, data := range up.parts {
+			num, size, etag := num, int64(len(data)), fmt.Sprintf("etag-%s-%d", id, num)
+			items = append(items, objectstorage.MultipartUploadPartSummary{PartNumber: &num, Size: &size, Etag: &etag, Md5: common.String("md5")})
+		}
+		f.mu.Unlock()
+		sort.Slice(items, func(i, j int) bool { return *items[i].PartNumber < *items[j].PartNumber })
+		writeJSON(w, items)
+
+	case http.MethodPost:
+		var details objectstorage.CommitMultipartUploadDetails
+		_ = json.NewDecoder(r.Body).Decode(&details)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var buf bytes.Buffer
+		var sums []byte
+		for _, p := range details.PartsToCommit {
+			if *p.Etag != fmt.Sprintf("etag-%s-%d", id, *p.PartNum) {
+				writeServiceError(w, http.StatusBadRequest, "InvalidPart")
+				return
+			}
+			part := up.parts[*p.PartNum]
+			buf.Write(part)
+			sum := md5.Sum(part)
+			sums = append(sums, sum[:]...)
+		}
+		total := md5.Sum(sums)
+		f.store(up.bucket+"/"+up.object, &fakeObject{data: buf.Bytes(), etag: "etag-" + id,
+			multipartMD5: base64.StdEncoding.EncodeToString(total[:]) + "-" + strconv.Itoa(len(details.PartsToCommit)),
+			contentType:  up.contentType, meta: up.meta})
+		delete(f.uploads, id)
+
+	case http.MethodDelete:
+		f.mu.Lock()
+		delete(f.uploads, id)
+		f.aborted = append(f.aborted, id)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// serveObject answers HeadObject and GetObject for the current object or a version, honouring Range and If-Match.
+func (f *fakeObjectStorage) serveObject(w http.ResponseWriter, r *http.Request, key, versionID string) {
+	f.mu.Lock()
+	o, ok := f.version(key, versionID)
+	fail := false
+	if ok && r.Method == http.MethodGet {
+		f.gets = append(f.gets, r.Header.Get("Range"))
+		if f.failGets > 0 {
+			f.failGets--
+			fail = true
+		}
+	}
+	f.mu.Unlock()
+	if !ok {
+		writeServiceError(w, http.StatusNotFound, "ObjectNotFound")
+		return
+	}
+	if m := r.Header.Get("If-Match"); m != "" && m != o.etag {
+		writeServiceError(w, http.StatusPreconditionFailed, "IfMatchFailed")
+		return
+	}
+	if fail {
+		writeServiceError(w, http.StatusInternalServerError, "InternalServerError")
+		return
+	}
+
+	w.Header().Set("ETag", o.etag)
+	w.Header().Set("version-id", o.versionID)
+	if o.md5 != "" {
+		w.Header().Set("Content-MD5", o.md5)
+	}
+	if o.multipartMD5 != "" {
+		w.Header().Set("opc-multipart-md5", o.multipartMD5)
+	}
+	for k, v := range o.meta {
+		w.Header().Set("opc-meta-"+k, v)
+	}
+	if o.tier != "" {
+		w.Header().Set("storage-tier", string(o.tier))
+	}
+	if o.archival != "" {
+		w.Header().Set("archival-state", string(o.archival))
+	}
+	data := o.data
+	if rng := r.Header.Get("Range"); rng != "" {
+		var start, end int
+		if _, err := fmt.Sscanf(rng, "bytes=%d-%d", &start, &end); err != nil || start > end || end >= len(data) {
+			writeServiceError(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+			return
+		}
+		data = data[start : end+1]
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(o.data)))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(http.StatusPartialContent)
+	} else {
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	}
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(data)
+	}
+}
+
+// serveList answers ListObjects with the objects of a bucket under prefix, rolling names below the next
+// delimiter into prefixes. Pages hold limit entries, or listPage when no limit is requested.
+func (f *fakeObjectStorage) serveList(w http.ResponseWriter, q url.Values, bucket string) {
+	prefix, delimiter, start := q.Get("prefix"), q.Get("delimiter"), q.Get("start")
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit == 0 {
+		limit = f.listPage
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	seen := map[string]bool{}
+	var entries []string
+	for key := range f.objects {
+		name := strings.TrimPrefix(key, bucket+"/")
+		if name == key || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(name[len(prefix):], delimiter); i >= 0 {
+				name = name[:len(prefix)+i+len(delimiter)]
+			}
+		}
+		if name >= start && !seen[name] {
+			seen[name] = true
+			entries = append(entries, name)
+		}
+	}
+	sort.Strings(entries)
+	var next string
+	if limit > 0 && len(entries) > limit {
+		next = entries[limit]
+		entries = entries[:limit]
+	}
+
+	var resp objectstorage.ListObjects
+	for _, name := range entries {
+		o, ok := f.objects[bucket+"/"+name]
+		if !ok || (delimiter != "" && strings.HasSuffix(name, delimiter) && name != prefix) {
+			resp.Prefixes = append(resp.Prefixes, name)
+			continue
+		}
+		name, size, sum := name, int64(len(o.data)), o.md5
+		if sum == "" {
+			sum = o.multipartMD5
+		}
+		resp.Objects = append(resp.Objects, objectstorage.ObjectSummary{Name: &name, Size: &size, Md5: &sum,
+			StorageTier: o.tier, ArchivalState: o.archival})
+	}
+	if next != "" {
+		resp.NextStartWith = &next
+	}
+	writeJSON(w, resp)
+}
+
+// serveVersions answers ListObjectVersions with every version of the objects under prefix, newest first.
+func (f *fakeObjectStorage) serveVersions(w http.ResponseWriter, q url.Values, bucket string) {
+	prefix := q.Get("prefix")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var names []string
+	for key := range f.objects {
+		if name := strings.TrimPrefix(key, bucket+"/"); name != key && strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var resp objectstorage.ObjectVersionCollection
+	for _, name := range names {
+		key := bucket + "/" + name
+		for _, o := range append([]*fakeObject{f.objects[key]}, f.history[key]...) {
+			name, size, sum, id := name, int64(len(o.data)), o.md5, o.versionID
+			resp.Items = append(resp.Items, objectstorage.ObjectVersionSummary{Name: &name, Size: &size, Md5: &sum, VersionId: &id,
+				Etag: common.String(o.etag), TimeModified: &common.SDKTime{Time: o.modified}, IsDeleteMarker: common.Bool(false),
+				StorageTier: o.tier})
+		}
+	}
+	writeJSON(w, resp)
+}
+
+// servePolicies answers GetObjectLifecyclePolicy, with a 404 when the bucket has no lifecycle rules, and
+// ListRetentionRules and ListReplicationPolicies one item per page.
+func (f *fakeObjectStorage) servePolicies(w http.ResponseWriter, q url.Values, bucket, kind string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if kind == "l" {
+		rules, ok := f.lifecycle[bucket]
+		if !ok {
+			writeServiceError(w, http.StatusNotFound, "LifecyclePolicyNotFound")
+			return
+		}
+		writeJSON(w, objectstorage.ObjectLifecyclePolicy{Items: rules})
+		return
+	}
+
+	idx, _ := strconv.Atoi(q.Get("page"))
+	n := len(f.retention[bucket])
+	if kind == "replicationPolicies" {
+		n = len(f.replication[bucket])
+	}
+	if idx+1 < n {
+		w.Header().Set("opc-next-page", strconv.Itoa(idx+1))
+	}
+	switch {
+	case idx >= n && kind == "retentionRules":
+		writeJSON(w, objectstorage.RetentionRuleCollection{Items: []objectstorage.RetentionRuleSummary{}})
+	case idx >= n:
+		writeJSON(w, []objectstorage.ReplicationPolicySummary{})
+	case kind == "retentionRules":
+		writeJSON(w, objectstorage.RetentionRuleCollection{Items: f.retention[bucket][idx : idx+1]})
+	default:
+		writeJSON(w, f.replication[bucket][idx:idx+1])
+	}
+}
+
+// serveCopy starts a server-side copy. The copy is done at once; a missing source fails the work request.
+func (f *fakeObjectStorage) serveCopy(w http.ResponseWriter, r *http.Request, bucket string) {
+	var details objectstorage.CopyObjectDetails
+	_ = json.NewDecoder(r.Body).Decode(&details)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	id := "work-" + strconv.Itoa(f.nextID)
+	wr := &fakeWorkRequest{}
+	f.work[id] = wr
+
+	src, ok := f.version(bucket+"/"+*details.SourceObjectName, stringValue(details.SourceVersionId))
+	if !ok {
+		wr.err = "The source object was not found"
+	} else {
+		dst := *src
+		if details.DestinationObjectMetadata != nil {
+			dst.meta = map[string]string{}
+			for k, v := range details.DestinationObjectMetadata {
+				dst.meta[strings.TrimPrefix(k, "opc-meta-")] = v
+			}
+		}
+		f.store(*details.DestinationBucket+"/"+*details.DestinationObjectName, &dst)
+	}
+	w.Header().Set("opc-work-request-id", id)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// serveRestore starts restoring an archived object; it stays Restoring until a test changes it.
+func (f *fakeObjectStorage) serveRestore(w http.ResponseWriter, r *http.Request, bucket string) {
+	var details objectstorage.RestoreObjectsDetails
+	_ = json.NewDecoder(r.Body).Decode(&details)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	o, ok := f.objects[bucket+"/"+*details.ObjectName]
+	switch {
+	case !ok:
+		writeServiceError(w, http.StatusNotFound, "ObjectNotFound")
+	case o.tier != objectstorage.StorageTierArchive:
+		writeServiceError(w, http.StatusBadRequest, "NotArchived")
+	default:
+		o.archival = objectstorage.ArchivalStateRestoring
+		o.restoreHours = 24
+		if details.Hours != nil {
+			o.restoreHours = *details.Hours
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (f *fakeObjectStorage) serveWorkRequest(w http.ResponseWriter, path string) {
+	id, errorsPath := strings.CutSuffix(path, "/errors")
+	f.mu.Lock()
+	wr, ok := f.work[id]
+	f.mu.Unlock()
+	if !ok {
+		writeServiceError(w, http.StatusNotFound, "NotFound")
+		return
+	}
+	if errorsPath {
+		writeJSON(w, []objectstorage.WorkRequestError{{Code: common.String("Failed"), Message: common.String(wr.err)}})
+		return
+	}
+
+	f.mu.Lock()
+	status := objectstorage.WorkRequestStatusInProgress
+	if wr.polled {
+		status = objectstorage.WorkRequestStatusCompleted
+		if wr.err != "" {
+			status = objectstorage.WorkRequestStatusFailed
+		}
+	}
+	wr.polled = true
+	f.mu.Unlock()
+	writeJSON(w, objectstorage.WorkRequest{Id: &id, Status: status})
+}
+
+// servePAR creates, lists and deletes pre-authenticated requests. Created requests get an access URI like
+// Object Storage's, /p/{token}/n/{ns}/b/{bucket}/o/[{object}].
+func (f *fakeObjectStorage) servePAR(w http.ResponseWriter, r *http.Request, ns, bucket, id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var details objectstorage.CreatePreauthenticatedRequestDetails
+		_ = json.NewDecoder(r.Body).Decode(&details)
+		f.nextID++
+		id := "par-" + strconv.Itoa(f.nextID)
+		uri := "/p/token-" + strconv.Itoa(f.nextID) + "/n/" + ns + "/b/" + bucket + "/o/" + stringValue(details.ObjectName)
+		par := objectstorage.PreauthenticatedRequest{
+			Id: &id, Name: details.Name, AccessUri: &uri, ObjectName: details.ObjectName,
+			AccessType:          objectstorage.PreauthenticatedRequestAccessTypeEnum(details.AccessType),
+			BucketListingAction: details.BucketListingAction,
+			TimeExpires:         details.TimeExpires,
+			TimeCreated:         &common.SDKTime{Time: details.TimeExpires.Add(-time.Hour)},
+		}
+		f.pars[bucket+"/"+id] = par
+		writeJSON(w, par)
+
+	case id == "" && r.Method == http.MethodGet:
+		prefix := r.URL.Query().Get("objectNamePrefix")
+		items := []objectstorage.PreauthenticatedRequestSummary{}
+		for key, par := range f.pars {
+			if strings.HasPrefix(key, bucket+"/") && strings.HasPrefix(stringValue(par.ObjectName), prefix) {
+				items = append(items, objectstorage.PreauthenticatedRequestSummary{
+					Id: par.Id, Name: par.Name, ObjectName: par.ObjectName, TimeExpires: par.TimeExpires, TimeCreated: par.TimeCreated,
+					AccessType:          objectstorage.PreauthenticatedRequestSummaryAccessTypeEnum(par.AccessType),
+					BucketListingAction: par.BucketListingAction,
+				})
+			}
+		}
+		sort.Slice(items, func(i, j int) bool { return *items[i].Id < *items[j].Id })
+		writeJSON(w, items)
+
+	case r.Method == http.MethodDelete:
+		if _, ok := f.pars[bucket+"/"+id]; !ok {
+			writeServiceError(w, http.StatusNotFound, "NotFound")
+			return
+		}
+		delete(f.pars, bucket+"/"+id)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeServiceError(w, http.StatusNotFound, "NotFound")
+	}
+}
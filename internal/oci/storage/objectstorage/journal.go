@@ -0,0 +1,87 @@
+package objectstorage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+)
+
+// uploadJournal records an in-progress multipart upload so that it can be resumed after an interruption.
+// The uploaded parts themselves are not journalled; they are listed from Object Storage on resume.
+type uploadJournal struct {
+	UploadID  string    `json:"upload_id"`
+	Namespace string    `json:"namespace"`
+	Bucket    string    `json:"bucket"`
+	Object    string    `json:"object"`
+	FilePath  string    `json:"file_path"`
+	FileSize  int64     `json:"file_size"`
+	ModTime   time.Time `json:"mod_time"`
+	PartSize  int64     `json:"part_size"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// UploadJournalDir returns the directory holding upload journals, ~/.oci/.ocloud/uploads.
+func UploadJournalDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, flags.OCIConfigDirName, flags.OCloudDefaultDirName, flags.OCloudUploadsDirName), nil
+}
+
+// uploadJournalPath returns the journal file for uploading filePath to namespace/bucket/object.
+func uploadJournalPath(namespace, bucket, object, filePath string) (string, error) {
+	dir, err := UploadJournalDir()
+	if err != nil {
+		return "", fmt.Errorf("get upload journal dir: %w", err)
+	}
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", filePath, err)
+	}
+	sum := sha256.Sum256([]byte(namespace + "\x00" + bucket + "\x00" + object + "\x00" + abs))
+	return filepath.Join(dir, hex.EncodeToString(sum[:16])+".json"), nil
+}
+
+// loadUploadJournal reads a journal; it returns nil without an error when none exists.
+func loadUploadJournal(path string) (*uploadJournal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read upload journal: %w", err)
+	}
+	var j uploadJournal
+	if err := json.Unmarshal(data, &j); err != nil || j.UploadID == "" {
+		// A corrupt journal cannot be resumed; treat it as absent.
+		return nil, nil
+	}
+	return &j, nil
+}
+
+// save writes the journal to path.
+func (j *uploadJournal) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create upload journal dir: %w", err)
+	}
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal upload journal: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write upload journal: %w", err)
+	}
+	return nil
+}
+
+// matches reports whether the journalled upload was started from the same version of the file.
+func (j *uploadJournal) matches(stat os.FileInfo) bool {
+	return j.FileSize == stat.Size() && j.ModTime.Equal(stat.ModTime())
+}
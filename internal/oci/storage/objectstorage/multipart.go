@@ -0,0 +1,355 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+)
+
+// Per-part retry settings; variables so tests can shorten the backoff.
+var (
+	partRetryAttempts = 3
+	partRetryBackoff  = 2 * time.Second
+)
+
+// partSizeMetadataKey is the user metadata in which multipart uploads record their part size.
+const partSizeMetadataKey = "ocloud-part-size"
+
+// PartSize returns the multipart part size for a file. Files too small to keep every worker busy with
+// DefaultPartSize parts use MinPartSize, and very large files use parts just big enough (in whole MiB)
+// to stay within MaxUploadParts.
+func PartSize(fileSize int64) int64 {
+	size := int64(DefaultPartSize)
+	if fileSize < DefaultPartSize*DefaultParallelism {
+		size = MinPartSize
+	}
+	if fileSize > size*MaxUploadParts {
+		const mib = 1024 * 1024
+		perPart := (fileSize + MaxUploadParts - 1) / MaxUploadParts
+		size = (perPart + mib - 1) / mib * mib
+	}
+	return size
+}
+
+// filePart is one part of a multipart upload.
+type filePart struct {
+	num    int
+	offset int64
+	size   int64
+}
+
+// splitParts divides a file into parts of partSize; the last part holds the remainder.
+func splitParts(fileSize, partSize int64) []filePart {
+	var parts []filePart
+	for offset, num := int64(0), 1; offset < fileSize; offset, num = offset+partSize, num+1 {
+		size := partSize
+		if offset+size > fileSize {
+			size = fileSize - offset
+		}
+		parts = append(parts, filePart{num: num, offset: offset, size: size})
+	}
+	return parts
+}
+
+// multipartUpload uploads a large file in parts using a bounded pool of workers. The upload is journalled
+// locally; if it is interrupted the upload is kept and can be continued with opts.Resume.
+func (a *Adapter) multipartUpload(ctx context.Context, namespace, bucketName, objectName string, file *os.File, stat os.FileInfo,
+	opts domain.TransferOptions, progressFn func(domain.TransferProgress)) error {
+
+	fileSize := stat.Size()
+	journalPath, err := uploadJournalPath(namespace, bucketName, objectName, file.Name())
+	if err != nil {
+		return err
+	}
+	journal, uploaded, err := a.resumableUpload(ctx, journalPath, stat, opts.Resume)
+	if err != nil {
+		return err
+	}
+
+	if journal == nil {
+		partSize := PartSize(fileSize)
+		uploadID, err := a.createMultipartUpload(ctx, namespace, bucketName, objectName, contentTypeFor(file.Name(), opts), opts.Metadata, partSize)
+		if err != nil {
+			return err
+		}
+		journal = &uploadJournal{
+			UploadID:  uploadID,
+			Namespace: namespace,
+			Bucket:    bucketName,
+			Object:    objectName,
+			FilePath:  file.Name(),
+			FileSize:  fileSize,
+			ModTime:   stat.ModTime(),
+			PartSize:  partSize,
+			StartedAt: time.Now(),
+		}
+		if err := journal.save(journalPath); err != nil {
+			a.abortMultipartUpload(ctx, journal)
+			return err
+		}
+	}
+
+	parts := splitParts(fileSize, journal.PartSize)
+	etags := make(map[int]string, len(parts))
+	var pending []filePart
+	var bytesUploaded int64
+	for _, p := range parts {
+		// A part with an unexpected size belongs to a different split and is uploaded again.
+		if summary, ok := uploaded[p.num]; ok && summary.Size != nil && *summary.Size == p.size && summary.Etag != nil {
+			etags[p.num] = *summary.Etag
+			bytesUploaded += p.size
+			continue
+		}
+		pending = append(pending, p)
+	}
+
+	var mu sync.Mutex
+	report := func() {
+		if progressFn != nil {
+			progressFn(domain.TransferProgress{
+				BytesTransferred: bytesUploaded,
+				TotalBytes:       fileSize,
+				PartNumber:       len(etags),
+				TotalParts:       len(parts),
+			})
+		}
+	}
+	if len(etags) > 0 {
+		report()
+	}
+
+	workers := opts.Parallelism
+	if workers < 1 {
+		workers = DefaultParallelism
+	}
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	uploadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs := make(chan filePart)
+	var firstErr error
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				etag, err := a.uploadPart(uploadCtx, journal, file, p)
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("upload part %d: %w", p.num, err)
+						cancel()
+					}
+				} else {
+					etags[p.num] = etag
+					bytesUploaded += p.size
+					report()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for _, p := range pending {
+		select {
+		case jobs <- p:
+		case <-uploadCtx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return &domain.IncompleteUploadError{UploadID: journal.UploadID, PartsUploaded: len(etags), TotalParts: len(parts), Err: firstErr}
+	}
+
+	if err := a.commitMultipartUpload(ctx, journal, etags, len(parts)); err != nil {
+		return &domain.IncompleteUploadError{UploadID: journal.UploadID, PartsUploaded: len(etags), TotalParts: len(parts), Err: err}
+	}
+
+	_ = os.Remove(journalPath)
+	return nil
+}
+
+// commitMultipartUpload assembles parts 1..count into the object.
+func (a *Adapter) commitMultipartUpload(ctx context.Context, journal *uploadJournal, etags map[int]string, count int) error {
+	committed := make([]objectstorage.CommitMultipartUploadPartDetails, 0, count)
+	for num := 1; num <= count; num++ {
+		num, etag := num, etags[num]
+		committed = append(committed, objectstorage.CommitMultipartUploadPartDetails{PartNum: &num, Etag: &etag})
+	}
+	_, err := a.client.CommitMultipartUpload(ctx, objectstorage.CommitMultipartUploadRequest{
+		NamespaceName: &journal.Namespace,
+		BucketName:    &journal.Bucket,
+		ObjectName:    &journal.Object,
+		UploadId:      &journal.UploadID,
+		CommitMultipartUploadDetails: objectstorage.CommitMultipartUploadDetails{
+			PartsToCommit: committed,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("commit multipart upload: %w", err)
+	}
+	return nil
+}
+
+// createMultipartUpload starts a multipart upload and returns its ID. The part size is recorded in the
+// object's metadata so that downloads can verify the multipart MD5.
+func (a *Adapter) createMultipartUpload(ctx context.Context, namespace, bucketName, objectName, contentType string, metadata map[string]string, partSize int64) (string, error) {
+	withPartSize := make(map[string]string, len(metadata)+1)
+	for k, v := range metadata {
+		withPartSize[k] = v
+	}
+	withPartSize[partSizeMetadataKey] = strconv.FormatInt(partSize, 10)
+	resp, err := a.client.CreateMultipartUpload(ctx, objectstorage.CreateMultipartUploadRequest{
+		NamespaceName: &namespace,
+		BucketName:    &bucketName,
+		CreateMultipartUploadDetails: objectstorage.CreateMultipartUploadDetails{
+			Object:      &objectName,
+			ContentType: &contentType,
+			Metadata:    withPartSize,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("create multipart upload: %w", err)
+	}
+	return *resp.UploadId, nil
+}
+
+// resumableUpload returns the journalled upload to continue and the parts it already has. When resume is
+// not requested, the file has changed, or the upload no longer exists, the previous upload is aborted and
+// its journal removed so that a new upload is started.
+func (a *Adapter) resumableUpload(ctx context.Context, journalPath string, stat os.FileInfo, resume bool) (*uploadJournal, map[int]objectstorage.MultipartUploadPartSummary, error) {
+	journal, err := loadUploadJournal(journalPath)
+	if err != nil || journal == nil {
+		return nil, nil, err
+	}
+
+	if resume && journal.matches(stat) {
+		parts, err := a.listUploadedParts(ctx, journal)
+		if err == nil {
+			return journal, parts, nil
+		}
+		if !isNotFound(err) {
+			return nil, nil, fmt.Errorf("list uploaded parts: %w", err)
+		}
+	} else {
+		a.abortMultipartUpload(ctx, journal)
+	}
+
+	if err := os.Remove(journalPath); err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("remove upload journal: %w", err)
+	}
+	return nil, nil, nil
+}
+
+// listUploadedParts returns the parts already uploaded to a multipart upload, keyed by part number.
+func (a *Adapter) listUploadedParts(ctx context.Context, journal *uploadJournal) (map[int]objectstorage.MultipartUploadPartSummary, error) {
+	parts := make(map[int]objectstorage.MultipartUploadPartSummary)
+	var page *string
+	for {
+		resp, err := a.client.ListMultipartUploadParts(ctx, objectstorage.ListMultipartUploadPartsRequest{
+			NamespaceName: &journal.Namespace,
+			BucketName:    &journal.Bucket,
+			ObjectName:    &journal.Object,
+			UploadId:      &journal.UploadID,
+			Page:          page,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range resp.Items {
+			if p.PartNumber != nil {
+				parts[*p.PartNumber] = p
+			}
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+	return parts, nil
+}
+
+// uploadPart uploads one part read from src, retrying transient failures, and returns its ETag.
+func (a *Adapter) uploadPart(ctx context.Context, journal *uploadJournal, src io.ReaderAt, p filePart) (string, error) {
+	// Retries are handled here so that every attempt gets a fresh reader for the part.
+	noRetry := common.NoRetryPolicy()
+	var err error
+	for attempt := 1; attempt <= partRetryAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * partRetryBackoff):
+			}
+		}
+
+		num, size := p.num, p.size
+		var resp objectstorage.UploadPartResponse
+		resp, err = a.client.UploadPart(ctx, objectstorage.UploadPartRequest{
+			NamespaceName:   &journal.Namespace,
+			BucketName:      &journal.Bucket,
+			ObjectName:      &journal.Object,
+			UploadId:        &journal.UploadID,
+			UploadPartNum:   &num,
+			UploadPartBody:  io.NopCloser(io.NewSectionReader(src, p.offset, p.size)),
+			ContentLength:   &size,
+			RequestMetadata: common.RequestMetadata{RetryPolicy: &noRetry},
+		})
+		if err == nil {
+			if resp.ETag == nil {
+				return "", fmt.Errorf("no ETag returned")
+			}
+			return *resp.ETag, nil
+		}
+		if ctx.Err() != nil || !isRetryable(err) {
+			return "", err
+		}
+	}
+	return "", err
+}
+
+// abortMultipartUpload discards an unfinished upload; failures are ignored because the upload may already be gone.
+func (a *Adapter) abortMultipartUpload(ctx context.Context, journal *uploadJournal) {
+	_, _ = a.client.AbortMultipartUpload(ctx, objectstorage.AbortMultipartUploadRequest{
+		NamespaceName: &journal.Namespace,
+		BucketName:    &journal.Bucket,
+		ObjectName:    &journal.Object,
+		UploadId:      &journal.UploadID,
+	})
+}
+
+// isNotFound reports whether err is a 404 from the service.
+func isNotFound(err error) bool {
+	var serviceErr common.ServiceError
+	return errors.As(err, &serviceErr) && serviceErr.GetHTTPStatusCode() == http.StatusNotFound
+}
+
+// isRetryable reports whether a failed request may succeed when repeated: network errors, throttling,
+// timeouts and server errors are retried, other client errors are not.
+func isRetryable(err error) bool {
+	var serviceErr common.ServiceError
+	if !errors.As(err, &serviceErr) {
+		return true
+	}
+	code := serviceErr.GetHTTPStatusCode()
+	return code == http.StatusTooManyRequests || code == http.StatusRequestTimeout || code >= http.StatusInternalServerError
+}
@@ -0,0 +1,151 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeUploadFile creates a file of the given size with non-repeating content in a fresh HOME,
+// so upload journals stay inside the test.
+func writeUploadFile(t *testing.T, size int) (string, []byte) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i * 7 / 3)
+	}
+	path := filepath.Join(t.TempDir(), "export.dmp")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path, data
+}
+
+func fastPartRetry(t *testing.T) {
+	backoff := partRetryBackoff
+	partRetryBackoff = time.Millisecond
+	t.Cleanup(func() { partRetryBackoff = backoff })
+}
+
+func journalCount(t *testing.T) int {
+	dir, err := UploadJournalDir()
+	require.NoError(t, err)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+	return len(entries)
+}
+
+func TestPartSize(t *testing.T) {
+	const mib, gib = int64(1024 * 1024), int64(1024 * 1024 * 1024)
+	assert.Equal(t, int64(MinPartSize), PartSize(60*mib), "small files use small parts so all workers are busy")
+	assert.Equal(t, int64(DefaultPartSize), PartSize(40*gib))
+
+	for _, size := range []int64{600 * gib, 4 * 1024 * gib, 10*1024*gib - 1} {
+		part := PartSize(size)
+		assert.Zero(t, part%mib, "part size is a whole number of MiB")
+		assert.LessOrEqual(t, (size+part-1)/part, int64(MaxUploadParts))
+	}
+}
+
+func TestSplitParts(t *testing.T) {
+	parts := splitParts(25, 10)
+	require.Len(t, parts, 3)
+	assert.Equal(t, filePart{num: 3, offset: 20, size: 5}, parts[2])
+	assert.Len(t, splitParts(20, 10), 2)
+	assert.Empty(t, splitParts(0, 10))
+}
+
+func TestUploadObject_ParallelMultipart(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	path, data := writeUploadFile(t, 3*MinPartSize+1234)
+
+	var last domain.TransferProgress
+	err := a.UploadObject(context.Background(), "ns", "exports", "db/export.dmp", path, domain.TransferOptions{Parallelism: 4},
+		func(p domain.TransferProgress) { last = p })
+	require.NoError(t, err)
+
+	assert.True(t, bytes.Equal(data, fake.object("exports", "db/export.dmp")))
+	assert.Len(t, fake.partPuts, 4)
+	assert.Greater(t, fake.maxPar, 1, "parts are uploaded in parallel")
+	assert.Equal(t, domain.TransferProgress{BytesTransferred: int64(len(data)), TotalBytes: int64(len(data)), PartNumber: 4, TotalParts: 4}, last)
+	assert.Zero(t, journalCount(t), "the journal is removed after a successful upload")
+}
+
+func TestUploadObject_RetriesFailedPart(t *testing.T) {
+	fastPartRetry(t)
+	a, fake := newTestAdapter(t)
+	path, data := writeUploadFile(t, 2*MinPartSize+10)
+	fake.failPart[2] = partRetryAttempts - 1
+
+	err := a.UploadObject(context.Background(), "ns", "exports", "export.dmp", path, domain.TransferOptions{}, nil)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, fake.object("exports", "export.dmp")))
+}
+
+func TestUploadObject_Resume(t *testing.T) {
+	fastPartRetry(t)
+	a, fake := newTestAdapter(t)
+	path, data := writeUploadFile(t, 3*MinPartSize+10)
+	fake.failPart[3] = partRetryAttempts
+
+	err := a.UploadObject(context.Background(), "ns", "exports", "export.dmp", path, domain.TransferOptions{Parallelism: 1}, nil)
+	var incomplete *domain.IncompleteUploadError
+	require.True(t, errors.As(err, &incomplete), "got %v", err)
+	assert.Equal(t, 4, incomplete.TotalParts)
+	assert.Empty(t, fake.aborted, "an interrupted upload is kept for resuming")
+	assert.Equal(t, 1, journalCount(t))
+
+	fake.partPuts = nil
+	var first domain.TransferProgress
+	err = a.UploadObject(context.Background(), "ns", "exports", "export.dmp", path, domain.TransferOptions{Resume: true},
+		func(p domain.TransferProgress) {
+			if first.TotalParts == 0 {
+				first = p
+			}
+		})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{3, 4}, fake.partPuts, "only the missing parts are uploaded")
+	assert.Equal(t, 2, first.PartNumber, "progress starts from the parts already uploaded")
+	assert.True(t, bytes.Equal(data, fake.object("exports", "export.dmp")))
+	assert.Zero(t, journalCount(t))
+}
+
+func TestUploadObject_WithoutResumeDiscardsPreviousUpload(t *testing.T) {
+	fastPartRetry(t)
+	a, fake := newTestAdapter(t)
+	path, data := writeUploadFile(t, 2*MinPartSize+10)
+	fake.failPart[2] = partRetryAttempts
+
+	err := a.UploadObject(context.Background(), "ns", "exports", "export.dmp", path, domain.TransferOptions{}, nil)
+	require.Error(t, err)
+
+	fake.partPuts = nil
+	require.NoError(t, a.UploadObject(context.Background(), "ns", "exports", "export.dmp", path, domain.TransferOptions{}, nil))
+	assert.Equal(t, []string{"upload-1"}, fake.aborted)
+	assert.Len(t, fake.partPuts, 3, "all parts are uploaded again")
+	assert.True(t, bytes.Equal(data, fake.object("exports", "export.dmp")))
+}
+
+func TestUploadObject_ResumeAfterFileChangeStartsOver(t *testing.T) {
+	fastPartRetry(t)
+	a, fake := newTestAdapter(t)
+	path, _ := writeUploadFile(t, 2*MinPartSize+10)
+	fake.failPart[2] = partRetryAttempts
+	require.Error(t, a.UploadObject(context.Background(), "ns", "exports", "export.dmp", path, domain.TransferOptions{}, nil))
+
+	changed := bytes.Repeat([]byte("x"), 2*MinPartSize+20)
+	require.NoError(t, os.WriteFile(path, changed, 0o644))
+	require.NoError(t, a.UploadObject(context.Background(), "ns", "exports", "export.dmp", path, domain.TransferOptions{Resume: true}, nil))
+	assert.Equal(t, []string{"upload-1"}, fake.aborted)
+	assert.True(t, bytes.Equal(changed, fake.object("exports", "export.dmp")))
+}
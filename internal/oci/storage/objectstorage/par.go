@@ -0,0 +1,123 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/mapping"
+)
+
+// CreatePreauthenticatedRequest creates a pre-authenticated request and returns it with its full URL.
+func (a *Adapter) CreatePreauthenticatedRequest(ctx context.Context, namespace, bucketName string, spec domain.PreauthenticatedRequestSpec) (*domain.PreauthenticatedRequest, error) {
+	accessType, err := parAccessType(spec)
+	if err != nil {
+		return nil, err
+	}
+	details := objectstorage.CreatePreauthenticatedRequestDetails{
+		Name:        &spec.Name,
+		AccessType:  accessType,
+		TimeExpires: &common.SDKTime{Time: spec.TimeExpires},
+	}
+	if spec.ObjectName != "" {
+		details.ObjectName = &spec.ObjectName
+	}
+	if spec.IsPrefixScope() {
+		details.BucketListingAction = objectstorage.PreauthenticatedRequestBucketListingActionDeny
+		if spec.AllowListing {
+			details.BucketListingAction = objectstorage.PreauthenticatedRequestBucketListingActionListobjects
+		}
+	}
+
+	resp, err := a.client.CreatePreauthenticatedRequest(ctx, objectstorage.CreatePreauthenticatedRequestRequest{
+		NamespaceName:                        &namespace,
+		BucketName:                           &bucketName,
+		CreatePreauthenticatedRequestDetails: details,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create pre-authenticated request: %w", err)
+	}
+	attrs := mapping.NewPreauthenticatedRequestAttributesFromOCI(resp.PreauthenticatedRequest, bucketName, namespace)
+	par := mapping.NewDomainPreauthenticatedRequestFromAttrs(*attrs, a.endpoint())
+	return &par, nil
+}
+
+// ListPreauthenticatedRequests lists the pre-authenticated requests of a bucket whose object name starts with prefix.
+func (a *Adapter) ListPreauthenticatedRequests(ctx context.Context, namespace, bucketName, prefix string) ([]domain.PreauthenticatedRequest, error) {
+	var pars []domain.PreauthenticatedRequest
+	var page *string
+	for {
+		req := objectstorage.ListPreauthenticatedRequestsRequest{
+			NamespaceName: &namespace,
+			BucketName:    &bucketName,
+			Page:          page,
+		}
+		if prefix != "" {
+			req.ObjectNamePrefix = &prefix
+		}
+		resp, err := a.client.ListPreauthenticatedRequests(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("list pre-authenticated requests: %w", err)
+		}
+		for _, item := range resp.Items {
+			attrs := mapping.NewPreauthenticatedRequestAttributesFromOCISummary(item, bucketName, namespace)
+			pars = append(pars, mapping.NewDomainPreauthenticatedRequestFromAttrs(*attrs, a.endpoint()))
+		}
+		if resp.OpcNextPage == nil {
+			return pars, nil
+		}
+		page = resp.OpcNextPage
+	}
+}
+
+// DeletePreauthenticatedRequest deletes a pre-authenticated request; its link stops working at once.
+func (a *Adapter) DeletePreauthenticatedRequest(ctx context.Context, namespace, bucketName, parID string) error {
+	_, err := a.client.DeletePreauthenticatedRequest(ctx, objectstorage.DeletePreauthenticatedRequestRequest{
+		NamespaceName: &namespace,
+		BucketName:    &bucketName,
+		ParId:         &parID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete pre-authenticated request: %w", err)
+	}
+	return nil
+}
+
+// parAccessType maps the requested access to an OCI access type: Object* for a single object and AnyObject*
+// for a bucket or prefix. Write-only access to a single object is allowed; listing is not.
+func parAccessType(spec domain.PreauthenticatedRequestSpec) (objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeEnum, error) {
+	prefix := spec.IsPrefixScope()
+	if spec.AllowListing && !prefix {
+		return "", fmt.Errorf("listing can only be allowed for a bucket or prefix, not for object %q", spec.ObjectName)
+	}
+	switch spec.Access {
+	case domain.PARAccessRead:
+		if prefix {
+			return objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeAnyobjectread, nil
+		}
+		return objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeObjectread, nil
+	case domain.PARAccessWrite:
+		if prefix {
+			return objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeAnyobjectwrite, nil
+		}
+		return objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeObjectwrite, nil
+	case domain.PARAccessReadWrite:
+		if prefix {
+			return objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeAnyobjectreadwrite, nil
+		}
+		return objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeObjectreadwrite, nil
+	}
+	return "", fmt.Errorf("unknown access %q: use %s, %s or %s", spec.Access, domain.PARAccessRead, domain.PARAccessWrite, domain.PARAccessReadWrite)
+}
+
+// endpoint returns the regional Object Storage endpoint the client talks to, with its scheme.
+func (a *Adapter) endpoint() string {
+	host := strings.TrimSuffix(a.client.Host, "/")
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	return host
+}
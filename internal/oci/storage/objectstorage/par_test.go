@@ -0,0 +1,92 @@
+package objectstorage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePreauthenticatedRequest_Object(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	par, err := a.CreatePreauthenticatedRequest(context.Background(), "ns", "reports", domain.PreauthenticatedRequestSpec{
+		Name: "q1", ObjectName: "2024/q1.pdf", Access: domain.PARAccessRead, TimeExpires: expires,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ObjectRead", par.AccessType)
+	assert.Equal(t, "2024/q1.pdf", par.ObjectName)
+	assert.Empty(t, par.BucketListingAction, "listing does not apply to a single object")
+	assert.True(t, par.TimeExpires.Equal(expires))
+	assert.Equal(t, a.endpoint()+"/p/token-1/n/ns/b/reports/o/2024/q1.pdf", par.URL)
+	assert.True(t, strings.HasPrefix(par.URL, "http://127.0.0.1"), par.URL)
+	assert.Len(t, fake.pars, 1)
+}
+
+func TestCreatePreauthenticatedRequest_Prefix(t *testing.T) {
+	a, _ := newTestAdapter(t)
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour)
+
+	par, err := a.CreatePreauthenticatedRequest(ctx, "ns", "b", domain.PreauthenticatedRequestSpec{
+		Name: "drop", ObjectName: "incoming/", Access: domain.PARAccessWrite, TimeExpires: expires,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "AnyObjectWrite", par.AccessType)
+	assert.Equal(t, "Deny", par.BucketListingAction)
+
+	par, err = a.CreatePreauthenticatedRequest(ctx, "ns", "b", domain.PreauthenticatedRequestSpec{
+		Name: "all", Access: domain.PARAccessReadWrite, TimeExpires: expires, AllowListing: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "AnyObjectReadWrite", par.AccessType)
+	assert.Equal(t, "ListObjects", par.BucketListingAction)
+	assert.Empty(t, par.ObjectName)
+}
+
+func TestCreatePreauthenticatedRequest_Invalid(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	ctx := context.Background()
+
+	_, err := a.CreatePreauthenticatedRequest(ctx, "ns", "b", domain.PreauthenticatedRequestSpec{
+		Name: "x", ObjectName: "a.txt", Access: domain.PARAccessRead, AllowListing: true,
+	})
+	assert.ErrorContains(t, err, "listing can only be allowed")
+
+	_, err = a.CreatePreauthenticatedRequest(ctx, "ns", "b", domain.PreauthenticatedRequestSpec{
+		Name: "x", ObjectName: "a.txt", Access: "admin",
+	})
+	assert.ErrorContains(t, err, `unknown access "admin"`)
+	assert.Empty(t, fake.pars)
+}
+
+func TestListAndDeletePreauthenticatedRequests(t *testing.T) {
+	a, _ := newTestAdapter(t)
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour)
+	for _, name := range []string{"logs/a.log", "logs/b.log", "img/c.png"} {
+		_, err := a.CreatePreauthenticatedRequest(ctx, "ns", "b", domain.PreauthenticatedRequestSpec{
+			Name: name, ObjectName: name, Access: domain.PARAccessRead, TimeExpires: expires,
+		})
+		require.NoError(t, err)
+	}
+
+	pars, err := a.ListPreauthenticatedRequests(ctx, "ns", "b", "logs/")
+	require.NoError(t, err)
+	require.Len(t, pars, 2)
+	assert.Equal(t, "logs/a.log", pars[0].ObjectName)
+	assert.Equal(t, "b", pars[0].BucketName)
+	assert.Empty(t, pars[0].URL)
+
+	require.NoError(t, a.DeletePreauthenticatedRequest(ctx, "ns", "b", pars[0].ID))
+	pars, err = a.ListPreauthenticatedRequests(ctx, "ns", "b", "")
+	require.NoError(t, err)
+	assert.Len(t, pars, 2)
+
+	assert.Error(t, a.DeletePreauthenticatedRequest(ctx, "ns", "b", "par-missing"))
+}
@@ -0,0 +1,65 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/mapping"
+)
+
+// GetBucketPolicies returns the lifecycle rules, retention rules and replication policies of a bucket.
+func (a *Adapter) GetBucketPolicies(ctx context.Context, namespace, bucketName string) (*domain.BucketPolicies, error) {
+	policies := &domain.BucketPolicies{}
+
+	// A bucket without lifecycle rules has no lifecycle policy at all.
+	lifecycle, err := a.client.GetObjectLifecyclePolicy(ctx, objectstorage.GetObjectLifecyclePolicyRequest{
+		NamespaceName: &namespace,
+		BucketName:    &bucketName,
+	})
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("get object lifecycle policy: %w", err)
+	}
+	for _, item := range lifecycle.Items {
+		policies.LifecycleRules = append(policies.LifecycleRules, mapping.NewDomainLifecycleRuleFromAttrs(*mapping.NewLifecycleRuleAttributesFromOCI(item)))
+	}
+
+	var page *string
+	for {
+		resp, err := a.client.ListRetentionRules(ctx, objectstorage.ListRetentionRulesRequest{
+			NamespaceName: &namespace,
+			BucketName:    &bucketName,
+			Page:          page,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list retention rules: %w", err)
+		}
+		for _, item := range resp.Items {
+			policies.RetentionRules = append(policies.RetentionRules, mapping.NewDomainRetentionRuleFromAttrs(*mapping.NewRetentionRuleAttributesFromOCISummary(item)))
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	page = nil
+	for {
+		resp, err := a.client.ListReplicationPolicies(ctx, objectstorage.ListReplicationPoliciesRequest{
+			NamespaceName: &namespace,
+			BucketName:    &bucketName,
+			Page:          page,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list replication policies: %w", err)
+		}
+		for _, item := range resp.Items {
+			policies.ReplicationPolicies = append(policies.ReplicationPolicies, mapping.NewDomainReplicationPolicyFromAttrs(*mapping.NewReplicationPolicyAttributesFromOCISummary(item)))
+		}
+		if resp.OpcNextPage == nil {
+			return policies, nil
+		}
+		page = resp.OpcNextPage
+	}
+}
@@ -0,0 +1,46 @@
+package objectstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBucketPolicies(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	fake.lifecycle["logs"] = []objectstorage.ObjectLifecycleRule{{
+		Name: common.String("archive"), Action: common.String("ARCHIVE"), TimeAmount: common.Int64(30),
+		TimeUnit: objectstorage.ObjectLifecycleRuleTimeUnitDays, IsEnabled: common.Bool(true),
+	}}
+	fake.retention["logs"] = []objectstorage.RetentionRuleSummary{
+		{Id: common.String("r1"), DisplayName: common.String("legal")},
+		{Id: common.String("r2"), DisplayName: common.String("audit")},
+	}
+	fake.replication["logs"] = []objectstorage.ReplicationPolicySummary{{
+		Id: common.String("p1"), Name: common.String("dr"), DestinationRegionName: common.String("us-phoenix-1"),
+		DestinationBucketName: common.String("logs-dr"), Status: objectstorage.ReplicationPolicySummaryStatusActive,
+	}}
+
+	policies, err := a.GetBucketPolicies(context.Background(), "ns", "logs")
+	require.NoError(t, err)
+	require.Len(t, policies.LifecycleRules, 1)
+	assert.Equal(t, "archive", policies.LifecycleRules[0].Name)
+	require.Len(t, policies.RetentionRules, 2, "every page of retention rules is read")
+	assert.Equal(t, "audit", policies.RetentionRules[1].Name)
+	require.Len(t, policies.ReplicationPolicies, 1)
+	assert.Equal(t, "logs-dr", policies.ReplicationPolicies[0].DestinationBucket)
+}
+
+func TestGetBucketPolicies_None(t *testing.T) {
+	a, _ := newTestAdapter(t)
+
+	policies, err := a.GetBucketPolicies(context.Background(), "ns", "empty")
+	require.NoError(t, err, "a bucket without a lifecycle policy is not an error")
+	assert.Empty(t, policies.LifecycleRules)
+	assert.Empty(t, policies.RetentionRules)
+	assert.Empty(t, policies.ReplicationPolicies)
+}
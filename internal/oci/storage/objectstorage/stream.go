@@ -0,0 +1,212 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+)
+
+// copyPollInterval is how often the work request of a server-side copy is polled; a variable so tests can shorten it.
+var copyPollInterval = 2 * time.Second
+
+// OpenObject returns a reader for the content of an object, or of the version in opts.VersionID. The caller
+// must close it.
+func (a *Adapter) OpenObject(ctx context.Context, namespace, bucketName, objectName string, opts domain.TransferOptions) (io.ReadCloser, error) {
+	resp, err := a.client.GetObject(ctx, objectstorage.GetObjectRequest{
+		NamespaceName: &namespace,
+		BucketName:    &bucketName,
+		ObjectName:    &objectName,
+		VersionId:     versionParam(opts.VersionID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get object: %w", err)
+	}
+	return resp.Content, nil
+}
+
+// UploadStream uploads data of unknown length, such as stdin, and returns the number of bytes uploaded. Input
+// that fits in one part is sent with a single PUT; longer input is read into DefaultPartSize buffers that are
+// uploaded as a multipart upload with up to opts.Parallelism parts in flight. A stream cannot be read again, so
+// a failed stream upload is aborted rather than kept for resuming.
+func (a *Adapter) UploadStream(ctx context.Context, namespace, bucketName, objectName string, r io.Reader, opts domain.TransferOptions) (int64, error) {
+	contentType := contentTypeFor(objectName, opts)
+	buf := make([]byte, DefaultPartSize)
+	n, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		size := int64(n)
+		_, err := a.client.PutObject(ctx, objectstorage.PutObjectRequest{
+			NamespaceName: &namespace,
+			BucketName:    &bucketName,
+			ObjectName:    &objectName,
+			ContentLength: &size,
+			PutObjectBody: io.NopCloser(bytes.NewReader(buf[:n])),
+			ContentType:   &contentType,
+			OpcMeta:       opts.Metadata,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("put object: %w", err)
+		}
+		return size, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read input: %w", err)
+	}
+
+	uploadID, err := a.createMultipartUpload(ctx, namespace, bucketName, objectName, contentType, opts.Metadata, DefaultPartSize)
+	if err != nil {
+		return 0, err
+	}
+	// The journal type only identifies the upload here; stream uploads are not journalled.
+	upload := &uploadJournal{UploadID: uploadID, Namespace: namespace, Bucket: bucketName, Object: objectName}
+
+	workers := opts.Parallelism
+	if workers < 1 {
+		workers = DefaultParallelism
+	}
+	// One buffer per worker bounds memory to workers * DefaultPartSize.
+	buffers := make(chan []byte, workers)
+	for i := 1; i < workers; i++ {
+		buffers <- make([]byte, DefaultPartSize)
+	}
+
+	uploadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	etags := make(map[int]string)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+		mu.Unlock()
+	}
+
+	var total int64
+	parts, last := 0, false
+	for {
+		parts++
+		if parts > MaxUploadParts {
+			fail(fmt.Errorf("input is larger than %d parts of %d bytes", MaxUploadParts, DefaultPartSize))
+			break
+		}
+		total += int64(n)
+		wg.Add(1)
+		go func(num int, data, full []byte) {
+			defer wg.Done()
+			etag, err := a.uploadPart(uploadCtx, upload, bytes.NewReader(data), filePart{num: num, size: int64(len(data))})
+			if err != nil {
+				fail(fmt.Errorf("upload part %d: %w", num, err))
+			} else {
+				mu.Lock()
+				etags[num] = etag
+				mu.Unlock()
+			}
+			buffers <- full
+		}(parts, buf[:n], buf)
+		if last {
+			break
+		}
+
+		select {
+		case buf = <-buffers:
+		case <-uploadCtx.Done():
+		}
+		if uploadCtx.Err() != nil {
+			break
+		}
+		n, err = io.ReadFull(r, buf)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			last = true
+		} else if err != nil {
+			fail(fmt.Errorf("read input: %w", err))
+			break
+		}
+	}
+	wg.Wait()
+
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr == nil {
+		firstErr = a.commitMultipartUpload(ctx, upload, etags, parts)
+	}
+	if firstErr != nil {
+		a.abortMultipartUpload(context.WithoutCancel(ctx), upload)
+		return 0, firstErr
+	}
+	return total, nil
+}
+
+// CopyObject copies an object inside Object Storage to destBucket/destObject in destRegion and waits for the
+// copy to finish. The destination keeps the source metadata unless opts.Metadata is set. opts.VersionID copies an
+// older version of the source.
+func (a *Adapter) CopyObject(ctx context.Context, namespace, bucketName, objectName, destRegion, destBucket, destObject string, opts domain.TransferOptions) error {
+	details := objectstorage.CopyObjectDetails{
+		SourceObjectName:      &objectName,
+		DestinationRegion:     &destRegion,
+		DestinationNamespace:  &namespace,
+		DestinationBucket:     &destBucket,
+		DestinationObjectName: &destObject,
+		SourceVersionId:       versionParam(opts.VersionID),
+	}
+	if len(opts.Metadata) > 0 {
+		// CopyObject takes the user metadata with its opc-meta- header prefix.
+		details.DestinationObjectMetadata = make(map[string]string, len(opts.Metadata))
+		for k, v := range opts.Metadata {
+			details.DestinationObjectMetadata["opc-meta-"+k] = v
+		}
+	}
+	resp, err := a.client.CopyObject(ctx, objectstorage.CopyObjectRequest{
+		NamespaceName:     &namespace,
+		BucketName:        &bucketName,
+		CopyObjectDetails: details,
+	})
+	if err != nil {
+		return fmt.Errorf("copy object: %w", err)
+	}
+	if resp.OpcWorkRequestId == nil {
+		return nil
+	}
+	return a.waitForWorkRequest(ctx, *resp.OpcWorkRequestId)
+}
+
+// waitForWorkRequest polls an Object Storage work request until it completes, and returns its first error if it fails.
+func (a *Adapter) waitForWorkRequest(ctx context.Context, id string) error {
+	for {
+		resp, err := a.client.GetWorkRequest(ctx, objectstorage.GetWorkRequestRequest{WorkRequestId: &id})
+		if err != nil {
+			return fmt.Errorf("get work request: %w", err)
+		}
+		switch resp.Status {
+		case objectstorage.WorkRequestStatusCompleted:
+			return nil
+		case objectstorage.WorkRequestStatusFailed, objectstorage.WorkRequestStatusCanceled:
+			reason := strings.ToLower(string(resp.Status))
+			errs, err := a.client.ListWorkRequestErrors(ctx, objectstorage.ListWorkRequestErrorsRequest{WorkRequestId: &id})
+			if err == nil && len(errs.Items) > 0 && errs.Items[0].Message != nil {
+				reason += ": " + *errs.Items[0].Message
+			}
+			return fmt.Errorf("work request %s %s", id, reason)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(copyPollInterval):
+		}
+	}
+}
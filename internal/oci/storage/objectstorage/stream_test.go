@@ -0,0 +1,114 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fastCopyPoll(t *testing.T) {
+	t.Helper()
+	prev := copyPollInterval
+	copyPollInterval = time.Millisecond
+	t.Cleanup(func() { copyPollInterval = prev })
+}
+
+func TestUploadStream_SingleRequest(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	opts := domain.TransferOptions{ContentType: "application/sql", Metadata: map[string]string{"owner": "dba"}}
+
+	n, err := a.UploadStream(context.Background(), "ns", "b", "dump.sql", bytes.NewReader([]byte("select 1;")), opts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), n)
+	assert.Equal(t, []byte("select 1;"), fake.object("b", "dump.sql"))
+	o := fake.objects["b/dump.sql"]
+	assert.Equal(t, "application/sql", o.contentType)
+	assert.Equal(t, map[string]string{"owner": "dba"}, o.meta)
+	assert.Empty(t, fake.partPuts)
+}
+
+func TestUploadStream_Multipart(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	data := testContent(2*DefaultPartSize + 1234)
+
+	// io.MultiReader hides the length, as a pipe would.
+	n, err := a.UploadStream(context.Background(), "ns", "b", "big.bin", io.MultiReader(bytes.NewReader(data)), domain.TransferOptions{Parallelism: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), n)
+	assert.True(t, bytes.Equal(data, fake.object("b", "big.bin")))
+	assert.ElementsMatch(t, []int{1, 2, 3}, fake.partPuts)
+	assert.LessOrEqual(t, fake.maxPar, 2)
+	assert.Equal(t, "application/octet-stream", fake.objects["b/big.bin"].contentType)
+}
+
+func TestUploadStream_ExactPartSize(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	data := testContent(DefaultPartSize)
+
+	n, err := a.UploadStream(context.Background(), "ns", "b", "exact.bin", bytes.NewReader(data), domain.TransferOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), n)
+	assert.True(t, bytes.Equal(data, fake.object("b", "exact.bin")))
+	assert.Equal(t, []int{1}, fake.partPuts)
+}
+
+func TestUploadStream_FailureAbortsUpload(t *testing.T) {
+	fastPartRetry(t)
+	a, fake := newTestAdapter(t)
+	fake.failPart[2] = partRetryAttempts
+
+	_, err := a.UploadStream(context.Background(), "ns", "b", "big.bin", bytes.NewReader(testContent(2*DefaultPartSize+1)), domain.TransferOptions{})
+	require.Error(t, err)
+	assert.Len(t, fake.aborted, 1, "a stream cannot be resumed, so the upload is aborted")
+	assert.Nil(t, fake.object("b", "big.bin"))
+}
+
+func TestUploadStream_ReadError(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	r := io.MultiReader(bytes.NewReader(testContent(DefaultPartSize+10)), errReader{})
+
+	_, err := a.UploadStream(context.Background(), "ns", "b", "x", r, domain.TransferOptions{})
+	assert.ErrorContains(t, err, "read input")
+	assert.Len(t, fake.aborted, 1)
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func TestOpenObject(t *testing.T) {
+	a, fake := newTestAdapter(t)
+	fake.putObject("b", "notes.txt", []byte("hello"))
+
+	r, err := a.OpenObject(context.Background(), "ns", "b", "notes.txt", domain.TransferOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(got))
+
+	_, err = a.OpenObject(context.Background(), "ns", "b", "missing", domain.TransferOptions{})
+	assert.Error(t, err)
+}
+
+func TestCopyObject(t *testing.T) {
+	fastCopyPoll(t)
+	a, fake := newTestAdapter(t)
+	fake.putObject("src", "data/a.bin", []byte("abc"))
+
+	err := a.CopyObject(context.Background(), "ns", "src", "data/a.bin", "us-ashburn-1", "dst", "backup/a.bin",
+		domain.TransferOptions{Metadata: map[string]string{"copied": "yes"}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("abc"), fake.object("dst", "backup/a.bin"))
+	assert.Equal(t, map[string]string{"copied": "yes"}, fake.objects["dst/backup/a.bin"].meta)
+
+	err = a.CopyObject(context.Background(), "ns", "src", "missing", "us-ashburn-1", "dst", "x", domain.TransferOptions{})
+	assert.ErrorContains(t, err, "failed: The source object was not found")
+}
@@ -20,16 +20,48 @@ func NewBucketListModel(b []domain.Bucket) tui.Model {
 	})
 }
 
-// NewObjectListModel builds a TUI list for Objects in a bucket.
-func NewObjectListModel(objects []domain.Object, bucketName string) tui.Model {
-	title := fmt.Sprintf("Objects in %s", bucketName)
-	return tui.NewModel(title, objects, func(o domain.Object) tui.ResourceItemData {
-		return tui.ResourceItemData{
-			ID:          o.Name, // Use name as ID since objects don't have OCIDs
-			Title:       o.Name,
-			Description: describeObject(o),
-		}
-	})
+// IDs of the object browser entries that are not folders or objects; object names cannot contain NUL.
+const (
+	BrowseParentID   = "\x00parent"
+	BrowseLoadMoreID = "\x00more"
+)
+
+// ObjectListing is the part of a bucket prefix that the object browser has loaded so far.
+type ObjectListing struct {
+	Bucket   string
+	Prefix   string
+	Prefixes []string
+	Objects  []domain.Object
+	HasMore  bool
+}
+
+// NewObjectBrowserModel builds a TUI list of the folders and objects directly under a prefix. Folders are
+// selected by their prefix, which ends in "/", and objects by their name. The list starts with a ".." entry
+// below the top of the bucket and ends with a "Load more" entry while there are more pages.
+func NewObjectBrowserModel(l ObjectListing) tui.Model {
+	entries := make([]tui.ResourceItemData, 0, len(l.Prefixes)+len(l.Objects)+2)
+	if l.Prefix != "" {
+		entries = append(entries, tui.ResourceItemData{ID: BrowseParentID, Title: "..", Description: "Parent folder"})
+	}
+	for _, p := range l.Prefixes {
+		entries = append(entries, tui.ResourceItemData{ID: p, Title: strings.TrimPrefix(p, l.Prefix), Description: "Folder"})
+	}
+	for _, o := range l.Objects {
+		entries = append(entries, tui.ResourceItemData{ID: o.Name, Title: strings.TrimPrefix(o.Name, l.Prefix), Description: describeObject(o)})
+	}
+	more := ""
+	if l.HasMore {
+		more = "+"
+		entries = append(entries, tui.ResourceItemData{
+			ID:          BrowseLoadMoreID,
+			Title:       "Load more...",
+			Description: fmt.Sprintf("%d entries loaded, list the next page", len(l.Prefixes)+len(l.Objects)),
+		})
+	}
+
+	title := fmt.Sprintf("oci://%s/%s • %s%s folders • %s%s objects", l.Bucket, l.Prefix,
+		humanCount(len(l.Prefixes)), more, humanCount(len(l.Objects)), more)
+	return tui.NewModel(title, entries, func(e tui.ResourceItemData) tui.ResourceItemData { return e })
 }
 
 // describeObject formats a single-line description for an object.
@@ -43,16 +75,56 @@ func describeObject(o domain.Object) string {
 	return join(" • ", size, tier, modified)
 }
 
-// NewActionPickerModel builds a radio-button style picker for action selection.
-func NewActionPickerModel(objectName string) tui.PickerModel {
+// NewActionPickerModel builds a radio-button style picker for action selection. Objects in a bucket with
+// versioning also offer their versions.
+func NewActionPickerModel(objectName string, versioned bool) tui.PickerModel {
 	options := []tui.PickerOption{
 		{ID: "view", Label: "View object details", Description: "Display object metadata and properties"},
 		{ID: "download", Label: "Download", Description: "Download object to current directory"},
+		{ID: "share", Label: "Share link", Description: "Create a read-only pre-authenticated URL valid for 24 hours"},
+	}
+	if versioned {
+		options = append(options, tui.PickerOption{ID: "versions", Label: "Versions", Description: "List previous versions to download or restore one"})
 	}
 	title := fmt.Sprintf("Action for %s", objectName)
 	return tui.NewPickerModel(title, options)
 }
 
+// NewObjectVersionListModel builds a TUI list of the versions of an object, newest first, selected by version ID.
+func NewObjectVersionListModel(objectName string, versions []domain.ObjectVersion) tui.Model {
+	return tui.NewModel(fmt.Sprintf("Versions of %s", objectName), versions, func(v domain.ObjectVersion) tui.ResourceItemData {
+		return tui.ResourceItemData{
+			ID:          v.VersionID,
+			Title:       v.LastModified.Local().Format("2006-01-02 15:04:05"),
+			Description: describeVersion(v),
+		}
+	})
+}
+
+// NewVersionActionPickerModel builds a picker for what to do with an object version. The current version cannot
+// be made current again.
+func NewVersionActionPickerModel(v domain.ObjectVersion) tui.PickerModel {
+	options := []tui.PickerOption{
+		{ID: "download", Label: "Download", Description: "Download this version to current directory"},
+	}
+	if !v.IsLatest {
+		options = append(options, tui.PickerOption{ID: "promote", Label: "Make current", Description: "Copy this version over the object; the current content is kept as a version"})
+	}
+	return tui.NewPickerModel(fmt.Sprintf("Version %s of %s", v.VersionID, v.Name), options)
+}
+
+// describeVersion formats a single-line description for an object version.
+func describeVersion(v domain.ObjectVersion) string {
+	if v.IsDeleteMarker {
+		return join(" • ", "Delete marker", v.VersionID)
+	}
+	status := ""
+	if v.IsLatest {
+		status = "current"
+	}
+	return join(" • ", util.HumanizeBytesIEC(v.Size), status, v.VersionID)
+}
+
 func describeBucket(b domain.Bucket) string {
 	size := ""
 	if b.ApproximateSize > 0 {
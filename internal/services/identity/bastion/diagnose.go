@@ -0,0 +1,319 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/core"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/mapping"
+	"github.com/rozdolsky33/ocloud/internal/services/network/path"
+)
+
+// FindingStatus is the outcome of a single reachability check.
+type FindingStatus string
+
+const (
+	FindingPass FindingStatus = "PASS"
+	FindingFail FindingStatus = "FAIL"
+	FindingWarn FindingStatus = "WARN"
+	FindingSkip FindingStatus = "SKIP"
+)
+
+// Reachability check names, in the order they are reported.
+const (
+	CheckClientAllowList = "Client CIDR allow-list"
+	CheckTargetVcn       = "Target VCN"
+	CheckRouting         = "Routing"
+	CheckBastionEgress   = "Bastion egress"
+	CheckTargetIngress   = "Target ingress"
+)
+
+// Finding is the result of one reachability check.
+type Finding struct {
+	Check  string        `json:"check"`
+	Status FindingStatus `json:"status"`
+	Detail string        `json:"detail"`
+}
+
+// ReachabilityTarget describes the network endpoint a bastion session would connect to.
+type ReachabilityTarget struct {
+	IP       string
+	Port     int
+	VcnID    string
+	SubnetID string
+	NsgIDs   []string
+	// ClientIP is the public IP the session is opened from; the allow-list check is skipped when empty.
+	ClientIP string
+}
+
+// ReachabilityReport is the list of findings for a bastion and target pair.
+type ReachabilityReport struct {
+	BastionID   string    `json:"bastion_id"`
+	BastionName string    `json:"bastion_name"`
+	BastionIP   string    `json:"bastion_ip,omitempty"`
+	TargetIP    string    `json:"target_ip"`
+	TargetPort  int       `json:"target_port"`
+	Reachable   bool      `json:"reachable"`
+	Findings    []Finding `json:"findings"`
+}
+
+// FailureReason joins the details of all failed checks, or returns "" when nothing failed.
+func (r *ReachabilityReport) FailureReason() string {
+	var reasons []string
+	for _, f := range r.Findings {
+		if f.Status == FindingFail {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", f.Check, f.Detail))
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func (r *ReachabilityReport) add(check string, status FindingStatus, format string, args ...any) {
+	r.Findings = append(r.Findings, Finding{Check: check, Status: status, Detail: fmt.Sprintf(format, args...)})
+	if status == FindingFail {
+		r.Reachable = false
+	}
+}
+
+// AnalyzeReachability checks whether the bastion's private endpoint can open a TCP connection to target.
+// It evaluates the client CIDR allow-list, the VCN and route tables, the egress rules of the bastion subnet
+// and the ingress rules of the target subnet's security lists and the target's NSGs.
+// Checks that cannot be evaluated, e.g. because an API call is not permitted, are reported as WARN or SKIP.
+func (s *Service) AnalyzeReachability(ctx context.Context, b Bastion, target ReachabilityTarget) *ReachabilityReport {
+	r := &ReachabilityReport{
+		BastionID:   b.OCID,
+		BastionName: b.DisplayName,
+		BastionIP:   b.PrivateEndpointIpAddress,
+		TargetIP:    target.IP,
+		TargetPort:  target.Port,
+		Reachable:   true,
+	}
+
+	checkClientAllowList(r, b.ClientCidrBlockAllowList, target.ClientIP)
+
+	var targetSubnet *core.Subnet
+	if target.SubnetID != "" {
+		subnet, err := s.getSubnetDetails(ctx, target.SubnetID)
+		if err != nil {
+			r.add(CheckTargetVcn, FindingWarn, "could not read target subnet: %v", err)
+		} else {
+			targetSubnet = subnet
+			target.VcnID = safeVcnID(subnet)
+		}
+	}
+	var bastionSubnet *core.Subnet
+	if b.TargetSubnetID != "" {
+		subnet, err := s.getSubnetDetails(ctx, b.TargetSubnetID)
+		if err != nil {
+			r.add(CheckRouting, FindingWarn, "could not read bastion subnet: %v", err)
+		} else {
+			bastionSubnet = subnet
+		}
+	}
+
+	sameVcn := b.TargetVcnID != "" && b.TargetVcnID == target.VcnID
+	switch {
+	case b.TargetVcnID == "":
+		r.add(CheckTargetVcn, FindingFail, "bastion is not configured with a target VCN")
+	case target.VcnID == "":
+		r.add(CheckTargetVcn, FindingSkip, "target VCN is unknown")
+	case sameVcn:
+		r.add(CheckTargetVcn, FindingPass, "bastion and target are in VCN %s", target.VcnID)
+	default:
+		r.add(CheckTargetVcn, FindingWarn, "target is in VCN %s, bastion in VCN %s; traffic depends on peering routes", target.VcnID, b.TargetVcnID)
+	}
+
+	s.checkRouting(ctx, r, sameVcn, b.PrivateEndpointIpAddress, target.IP, bastionSubnet, targetSubnet)
+
+	if b.PrivateEndpointIpAddress == "" {
+		r.add(CheckBastionEgress, FindingSkip, "bastion has no private endpoint IP yet")
+		r.add(CheckTargetIngress, FindingSkip, "bastion has no private endpoint IP yet")
+		return r
+	}
+
+	if bastionSubnet == nil {
+		r.add(CheckBastionEgress, FindingSkip, "bastion subnet is unknown")
+	} else {
+		rules, warnings := s.securityListRules(ctx, bastionSubnet.SecurityListIds, true)
+		evaluateRules(r, CheckBastionEgress, rules, warnings, target.IP, target.Port,
+			fmt.Sprintf("no egress rule in the bastion subnet's security lists allows TCP %s:%d", target.IP, target.Port))
+	}
+
+	if targetSubnet == nil && len(target.NsgIDs) == 0 {
+		r.add(CheckTargetIngress, FindingSkip, "target subnet and NSGs are unknown")
+		return r
+	}
+	var rules []path.NamedRule
+	var warnings []string
+	if targetSubnet != nil {
+		rules, warnings = s.securityListRules(ctx, targetSubnet.SecurityListIds, false)
+	}
+	nsgRules, nsgWarnings := s.nsgRules(ctx, target.NsgIDs, false)
+	rules = append(rules, nsgRules...)
+	warnings = append(warnings, nsgWarnings...)
+	evaluateRules(r, CheckTargetIngress, rules, warnings, b.PrivateEndpointIpAddress, target.Port,
+		fmt.Sprintf("no security list or NSG ingress rule allows TCP port %d from %s", target.Port, b.PrivateEndpointIpAddress))
+	return r
+}
+
+// checkClientAllowList reports whether clientIP is inside the bastion's client CIDR allow-list.
+func checkClientAllowList(r *ReachabilityReport, allowList []string, clientIP string) {
+	if len(allowList) == 0 {
+		r.add(CheckClientAllowList, FindingFail, "bastion allow-list is empty; no client can connect")
+		return
+	}
+	if clientIP == "" {
+		r.add(CheckClientAllowList, FindingSkip, "client IP unknown; bastion allows %s", strings.Join(allowList, ", "))
+		return
+	}
+	for _, cidr := range allowList {
+		if path.CIDRContains(cidr, clientIP) {
+			r.add(CheckClientAllowList, FindingPass, "client IP %s is allowed by %s", clientIP, cidr)
+			return
+		}
+	}
+	r.add(CheckClientAllowList, FindingFail, "client IP %s is not in the allow-list (%s)", clientIP, strings.Join(allowList, ", "))
+}
+
+// checkRouting verifies that the bastion subnet routes to the target and the target subnet routes back.
+// Traffic within a VCN uses the implicit local route and needs no route rule.
+func (s *Service) checkRouting(ctx context.Context, r *ReachabilityReport, sameVcn bool, bastionIP, targetIP string, bastionSubnet, targetSubnet *core.Subnet) {
+	if sameVcn {
+		r.add(CheckRouting, FindingPass, "traffic stays inside the VCN (local route)")
+		return
+	}
+	if bastionSubnet == nil || targetSubnet == nil || bastionIP == "" {
+		r.add(CheckRouting, FindingSkip, "cross-VCN routing needs both subnets and the bastion private endpoint IP")
+		return
+	}
+
+	for _, leg := range []struct {
+		name   string
+		subnet *core.Subnet
+		dest   string
+	}{
+		{"bastion subnet", bastionSubnet, targetIP},
+		{"target subnet", targetSubnet, bastionIP},
+	} {
+		if leg.subnet.RouteTableId == nil {
+			r.add(CheckRouting, FindingSkip, "%s has no route table", leg.name)
+			continue
+		}
+		resp, err := s.networkClient.GetRouteTable(ctx, core.GetRouteTableRequest{RtId: leg.subnet.RouteTableId})
+		if err != nil {
+			r.add(CheckRouting, FindingWarn, "could not read %s route table: %v", leg.name, err)
+			continue
+		}
+		rules := make([]domain.RouteRule, 0, len(resp.RouteRules))
+		for _, rule := range resp.RouteRules {
+			rules = append(rules, mapping.NewDomainRouteRuleFromAttrs(mapping.NewRouteRuleAttributesFromOCIRouteRule(rule)))
+		}
+		if rule, ok := path.RouteFor(rules, leg.dest); ok {
+			r.add(CheckRouting, FindingPass, "%s routes %s via %s", leg.name, leg.dest, rule.TargetID)
+		} else {
+			r.add(CheckRouting, FindingFail, "%s route table has no route to %s", leg.name, leg.dest)
+		}
+	}
+}
+
+// evaluateRules adds a finding for check depending on whether any rule allows TCP to ip:port.
+// For egress rules ip is the destination, for ingress rules it is the source.
+func evaluateRules(r *ReachabilityReport, check string, rules []path.NamedRule, warnings []string, ip string, port int, failDetail string) {
+	for _, w := range warnings {
+		r.add(check, FindingWarn, "%s", w)
+	}
+	allowed, _ := path.MatchRule(rules, path.Endpoint{IP: ip}, domain.ProtocolTCP, port)
+	switch {
+	case allowed != nil && allowed.Rule.Stateless:
+		r.add(check, FindingWarn, "allowed by stateless %s; return traffic needs a matching rule", allowed.Name)
+	case allowed != nil:
+		r.add(check, FindingPass, "allowed by %s", allowed.Name)
+	case len(warnings) > 0:
+		r.add(check, FindingWarn, "%s among the rules that could be read", failDetail)
+	default:
+		r.add(check, FindingFail, "%s", failDetail)
+	}
+}
+
+// securityListRules loads the ingress or egress rules of the given security lists.
+// Lists that cannot be read are returned as warnings.
+func (s *Service) securityListRules(ctx context.Context, ids []string, egress bool) ([]path.NamedRule, []string) {
+	var rules []path.NamedRule
+	var warnings []string
+	for _, id := range ids {
+		resp, err := s.networkClient.GetSecurityList(ctx, core.GetSecurityListRequest{SecurityListId: &id})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not read security list %s: %v", id, err))
+			continue
+		}
+		name := stringValue(resp.DisplayName)
+		if name == "" {
+			name = id
+		}
+		if egress {
+			for i, rule := range resp.EgressSecurityRules {
+				rules = append(rules, path.NamedRule{
+					Name: fmt.Sprintf("security list %s egress rule #%d", name, i+1),
+					Rule: mapping.NewDomainSecurityRuleFromAttrs(mapping.NewSecurityRuleAttributesFromOCIEgressRule(rule)),
+				})
+			}
+			continue
+		}
+		for i, rule := range resp.IngressSecurityRules {
+			rules = append(rules, path.NamedRule{
+				Name: fmt.Sprintf("security list %s ingress rule #%d", name, i+1),
+				Rule: mapping.NewDomainSecurityRuleFromAttrs(mapping.NewSecurityRuleAttributesFromOCIIngressRule(rule)),
+			})
+		}
+	}
+	return rules, warnings
+}
+
+// nsgRules loads the ingress or egress rules of the given network security groups. Rules that reference
+// other NSGs never match: a bastion private endpoint is never an NSG member.
+func (s *Service) nsgRules(ctx context.Context, ids []string, egress bool) ([]path.NamedRule, []string) {
+	direction := core.ListNetworkSecurityGroupSecurityRulesDirectionIngress
+	if egress {
+		direction = core.ListNetworkSecurityGroupSecurityRulesDirectionEgress
+	}
+	var rules []path.NamedRule
+	var warnings []string
+	for _, id := range ids {
+		name := id
+		if resp, err := s.networkClient.GetNetworkSecurityGroup(ctx, core.GetNetworkSecurityGroupRequest{NetworkSecurityGroupId: &id}); err == nil && resp.DisplayName != nil {
+			name = *resp.DisplayName
+		}
+		req := core.ListNetworkSecurityGroupSecurityRulesRequest{NetworkSecurityGroupId: &id, Direction: direction}
+		var page *string
+		index := 0
+		for {
+			req.Page = page
+			resp, err := s.networkClient.ListNetworkSecurityGroupSecurityRules(ctx, req)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("could not read NSG %s rules: %v", name, err))
+				break
+			}
+			for _, rule := range resp.Items {
+				index++
+				rules = append(rules, path.NamedRule{
+					Name: fmt.Sprintf("NSG %s %s rule #%d", name, strings.ToLower(string(direction)), index),
+					Rule: mapping.NewDomainSecurityRuleFromAttrs(mapping.NewSecurityRuleAttributesFromOCINSGRule(rule)),
+				})
+			}
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+	}
+	return rules, warnings
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,77 @@
+package bastion
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/services/network/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckClientAllowList(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowList []string
+		clientIP  string
+		want      FindingStatus
+	}{
+		{"empty allow-list", nil, "203.0.113.10", FindingFail},
+		{"unknown client", []string{"0.0.0.0/0"}, "", FindingSkip},
+		{"client allowed", []string{"198.51.100.0/24", "203.0.113.0/24"}, "203.0.113.10", FindingPass},
+		{"client blocked", []string{"198.51.100.0/24"}, "203.0.113.10", FindingFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ReachabilityReport{Reachable: true}
+			checkClientAllowList(r, tt.allowList, tt.clientIP)
+			require.Len(t, r.Findings, 1)
+			assert.Equal(t, tt.want, r.Findings[0].Status)
+			assert.Equal(t, tt.want != FindingFail, r.Reachable)
+		})
+	}
+}
+
+func TestEvaluateRules(t *testing.T) {
+	allowSSH := []path.NamedRule{{Name: "security list default ingress rule #1", Rule: domain.SecurityRule{
+		Protocol: domain.ProtocolTCP, Peer: "10.0.0.0/16", PeerType: domain.AddressTypeCIDR, DestinationPorts: &domain.PortRange{Min: 22, Max: 22}}}}
+
+	r := &ReachabilityReport{Reachable: true}
+	evaluateRules(r, CheckTargetIngress, allowSSH, nil, "10.0.0.9", 22, "blocked")
+	require.Len(t, r.Findings, 1)
+	assert.Equal(t, FindingPass, r.Findings[0].Status)
+	assert.Contains(t, r.Findings[0].Detail, "ingress rule #1")
+
+	r = &ReachabilityReport{Reachable: true}
+	evaluateRules(r, CheckTargetIngress, allowSSH, nil, "10.0.0.9", 5432, "blocked")
+	assert.False(t, r.Reachable)
+	assert.Equal(t, "Target ingress: blocked", r.FailureReason())
+
+	// A rule that could not be read may be the one that allows the traffic, so this is only a warning.
+	r = &ReachabilityReport{Reachable: true}
+	evaluateRules(r, CheckTargetIngress, allowSSH, []string{"could not read NSG"}, "10.0.0.9", 5432, "blocked")
+	assert.True(t, r.Reachable)
+	require.Len(t, r.Findings, 2)
+	assert.Equal(t, FindingWarn, r.Findings[1].Status)
+}
+
+func TestAnalyzeReachability_WithoutNetworkDetails(t *testing.T) {
+	s := &Service{}
+	b := Bastion{OCID: "ocid1.bastion.oc1..x", DisplayName: "prod", TargetVcnID: "vcn-a", ClientCidrBlockAllowList: []string{"0.0.0.0/0"}}
+
+	r := s.AnalyzeReachability(context.Background(), b, ReachabilityTarget{IP: "10.0.0.9", Port: 22, VcnID: "vcn-a"})
+	assert.True(t, r.Reachable)
+	statuses := make(map[string]FindingStatus)
+	for _, f := range r.Findings {
+		statuses[f.Check] = f.Status
+	}
+	assert.Equal(t, FindingPass, statuses[CheckTargetVcn])
+	assert.Equal(t, FindingPass, statuses[CheckRouting])
+	assert.Equal(t, FindingSkip, statuses[CheckTargetIngress])
+
+	b.TargetVcnID = ""
+	r = s.AnalyzeReachability(context.Background(), b, ReachabilityTarget{IP: "10.0.0.9", Port: 22})
+	assert.False(t, r.Reachable)
+	assert.Contains(t, r.FailureReason(), "not configured with a target VCN")
+}
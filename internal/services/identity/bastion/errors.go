@@ -0,0 +1,37 @@
+package bastion
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by the session target resolution.
+var (
+	// ErrInvalidTarget is returned when a --target value cannot be parsed.
+	ErrInvalidTarget = errors.New("invalid target")
+	// ErrTargetNotFound is returned when no resource matches the requested target.
+	ErrTargetNotFound = errors.New("target not found")
+	// ErrAmbiguousTarget is returned when more than one resource matches the requested target.
+	ErrAmbiguousTarget = errors.New("target is ambiguous")
+	// ErrTargetUnreachable is returned when a resource was found but has no address the bastion can use.
+	ErrTargetUnreachable = errors.New("target unreachable")
+)
+
+// ResolveError describes a failure to resolve a session target.
+// It wraps one of the sentinel errors so callers can use errors.Is.
+type ResolveError struct {
+	Kind   TargetKind
+	Name   string
+	Reason string
+	Err    error
+}
+
+func (e *ResolveError) Error() string {
+	msg := fmt.Sprintf("%s %q: %v", e.Kind, e.Name, e.Err)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func (e *ResolveError) Unwrap() error { return e.Err }
@@ -0,0 +1,21 @@
+package bastion
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveError(t *testing.T) {
+	err := fmt.Errorf("resolve: %w", &ResolveError{Kind: KindInstance, Name: "web-1", Reason: "2 matches", Err: ErrAmbiguousTarget})
+
+	assert.True(t, errors.Is(err, ErrAmbiguousTarget))
+	assert.False(t, errors.Is(err, ErrTargetNotFound))
+	assert.Equal(t, `resolve: instance "web-1": target is ambiguous: 2 matches`, err.Error())
+
+	var re *ResolveError
+	assert.True(t, errors.As(err, &re))
+	assert.Equal(t, KindInstance, re.Kind)
+}
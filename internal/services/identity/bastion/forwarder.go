@@ -0,0 +1,473 @@
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// ForwarderCommand is the hidden ocloud command that runs a detached port forwarder.
+const ForwarderCommand = "__forward"
+
+// forwarderConfigEnv carries the JSON-encoded ForwarderConfig to the detached forwarder process.
+const forwarderConfigEnv = "OCLOUD_FORWARDER_CONFIG"
+
+// Forwarder timing. A connection that misses forwarderMaxMissedPings keep-alives is re-established,
+// and the forwarder gives up after forwarderReconnectAttempts failed reconnects (e.g. once the session expired).
+var (
+	forwarderDialTimeout       = 15 * time.Second
+	forwarderKeepAliveInterval = 30 * time.Second
+	forwarderKeepAliveTimeout  = 15 * time.Second
+	forwarderMaxMissedPings    = 3
+	forwarderReconnectAttempts = 3
+	forwarderReconnectBackoff  = 2 * time.Second
+	forwarderStatsInterval     = 5 * time.Second
+)
+
+// ForwarderConfig describes a local port forward through a bastion port-forwarding session.
+type ForwarderConfig struct {
+	PrivateKeyPath string `json:"private_key_path"`
+	SessionID      string `json:"session_id"`
+	Region         string `json:"region"`
+	TargetIP       string `json:"target_ip"`
+	LocalPort      int    `json:"local_port"`
+	RemotePort     int    `json:"remote_port"`
+	// BastionAddr overrides the bastion host:port derived from the session and region.
+	BastionAddr string `json:"bastion_addr,omitempty"`
+}
+
+// bastionAddr returns the SSH address of the bastion host.
+func (c ForwarderConfig) bastionAddr() string {
+	if c.BastionAddr != "" {
+		return c.BastionAddr
+	}
+	return net.JoinHostPort(bastionHost(c.SessionID, c.Region), "22")
+}
+
+// targetAddr returns the host:port the bastion forwards connections to.
+func (c ForwarderConfig) targetAddr() string {
+	return net.JoinHostPort(c.TargetIP, strconv.Itoa(c.RemotePort))
+}
+
+// ForwarderStats reports the traffic handled by a forwarder.
+type ForwarderStats struct {
+	ActiveConnections int64     `json:"active_connections"`
+	TotalConnections  int64     `json:"total_connections"`
+	BytesSent         int64     `json:"bytes_sent"`
+	BytesReceived     int64     `json:"bytes_received"`
+	UpdatedAt         time.Time `json:"updated_at,omitzero"`
+}
+
+// BuildPortForwardConfig returns the forwarder configuration for a port-forwarding session,
+// expanding "~" in the private key path.
+func BuildPortForwardConfig(privateKeyPath, sessionID, region, targetIP string, localPort, remotePort int) (ForwarderConfig, error) {
+	key, err := ExpandTilde(privateKeyPath)
+	if err != nil {
+		return ForwarderConfig{}, fmt.Errorf("expand key path: %w", err)
+	}
+	return ForwarderConfig{
+		PrivateKeyPath: key,
+		SessionID:      sessionID,
+		Region:         region,
+		TargetIP:       targetIP,
+		LocalPort:      localPort,
+		RemotePort:     remotePort,
+	}, nil
+}
+
+// Forwarder listens on a local port and proxies each connection to the target through the bastion
+// over a single SSH connection, using golang.org/x/crypto/ssh instead of an external ssh binary.
+type Forwarder struct {
+	cfg    ForwarderConfig
+	signer ssh.Signer
+	log    io.Writer
+
+	mu     sync.Mutex
+	client *ssh.Client
+	// reconnectMu serialises reconnects so concurrent callers replace a dead client only once.
+	reconnectMu sync.Mutex
+
+	active   atomic.Int64
+	total    atomic.Int64
+	sent     atomic.Int64
+	received atomic.Int64
+}
+
+// NewForwarder loads the private key and returns a forwarder that logs to w.
+func NewForwarder(cfg ForwarderConfig, w io.Writer) (*Forwarder, error) {
+	signer, err := loadSigner(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		w = io.Discard
+	}
+	return &Forwarder{cfg: cfg, signer: signer, log: w}, nil
+}
+
+// loadSigner parses the private key at path. Passphrase-protected keys are looked up in ssh-agent.
+func loadSigner(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	if err == nil {
+		return signer, nil
+	}
+	var missing *ssh.PassphraseMissingError
+	if !errors.As(err, &missing) {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" || missing.PublicKey == nil {
+		return nil, fmt.Errorf("private key %s is passphrase protected; add it to ssh-agent", path)
+	}
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to ssh-agent: %w", err)
+	}
+	signers, err := agent.NewClient(conn).Signers()
+	if err != nil {
+		return nil, fmt.Errorf("listing ssh-agent keys: %w", err)
+	}
+	want := missing.PublicKey.Marshal()
+	for _, s := range signers {
+		if string(s.PublicKey().Marshal()) == string(want) {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("private key %s is passphrase protected and not loaded in ssh-agent", path)
+}
+
+// Stats returns a snapshot of the forwarder's connection and traffic counters.
+func (f *Forwarder) Stats() ForwarderStats {
+	return ForwarderStats{
+		ActiveConnections: f.active.Load(),
+		TotalConnections:  f.total.Load(),
+		BytesSent:         f.sent.Load(),
+		BytesReceived:     f.received.Load(),
+		UpdatedAt:         time.Now(),
+	}
+}
+
+// Run connects to the bastion, listens on 127.0.0.1:LocalPort and forwards connections until the
+// context is cancelled. The local port only starts listening once the bastion connection is up.
+// It returns an error if the bastion connection is lost and cannot be re-established.
+func (f *Forwarder) Run(ctx context.Context) error {
+	if err := f.connect(); err != nil {
+		return err
+	}
+	defer f.closeClient()
+
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(f.cfg.LocalPort)))
+	if err != nil {
+		return fmt.Errorf("listen on local port %d: %w", f.cfg.LocalPort, err)
+	}
+	f.logf("listening on %s, forwarding to %s via %s", ln.Addr(), f.cfg.targetAddr(), f.cfg.bastionAddr())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		if err := f.keepAlive(ctx); err != nil {
+			errCh <- err
+		}
+		cancel()
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			select {
+			case err := <-errCh:
+				return err
+			default:
+			}
+			if ctx.Err() != nil {
+				f.logf("forwarder stopped")
+				return nil
+			}
+			return fmt.Errorf("accept: %w", err)
+		}
+		go f.handle(ctx, conn)
+	}
+}
+
+// connect establishes the SSH connection to the bastion, authenticating as the session OCID.
+func (f *Forwarder) connect() error {
+	config := &ssh.ClientConfig{
+		User: f.cfg.SessionID,
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(f.signer)},
+		// OCI Bastion presents a different host key for every session, so there is nothing stable to pin.
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         forwarderDialTimeout,
+	}
+	client, err := ssh.Dial("tcp", f.cfg.bastionAddr(), config)
+	if err != nil {
+		return fmt.Errorf("connect to bastion %s: %w", f.cfg.bastionAddr(), err)
+	}
+	f.mu.Lock()
+	old := f.client
+	f.client = client
+	f.mu.Unlock()
+	if old != nil {
+		_ = old.Close()
+	}
+	f.logf("connected to bastion %s", f.cfg.bastionAddr())
+	return nil
+}
+
+// reconnect re-establishes the bastion connection with a linear backoff.
+func (f *Forwarder) reconnect(ctx context.Context) error {
+	var err error
+	for attempt := 1; attempt <= forwarderReconnectAttempts; attempt++ {
+		if err = f.connect(); err == nil {
+			return nil
+		}
+		f.logf("reconnect attempt %d/%d failed: %v", attempt, forwarderReconnectAttempts, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * forwarderReconnectBackoff):
+		}
+	}
+	return fmt.Errorf("bastion connection lost: %w", err)
+}
+
+// reconnectFrom re-establishes the bastion connection unless another caller already replaced stale.
+func (f *Forwarder) reconnectFrom(ctx context.Context, stale *ssh.Client) error {
+	f.reconnectMu.Lock()
+	defer f.reconnectMu.Unlock()
+	if current := f.currentClient(); current != nil && current != stale {
+		return nil
+	}
+	return f.reconnect(ctx)
+}
+
+// currentClient returns the active SSH client.
+func (f *Forwarder) currentClient() *ssh.Client {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.client
+}
+
+// closeClient closes the active SSH client, if any.
+func (f *Forwarder) closeClient() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.client != nil {
+		_ = f.client.Close()
+		f.client = nil
+	}
+}
+
+// keepAlive pings the bastion and reconnects when it stops answering.
+// It returns an error once the connection cannot be re-established.
+func (f *Forwarder) keepAlive(ctx context.Context) error {
+	missed := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(forwarderKeepAliveInterval):
+		}
+		client := f.currentClient()
+		if client == nil {
+			return nil
+		}
+		if err := ping(client); err == nil {
+			missed = 0
+			continue
+		}
+		missed++
+		if missed < forwarderMaxMissedPings {
+			continue
+		}
+		f.logf("bastion stopped responding, reconnecting")
+		if err := f.reconnectFrom(ctx, client); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			f.logf("%v", err)
+			return err
+		}
+		missed = 0
+	}
+}
+
+// ping sends a keep-alive request to the bastion. A bastion that does not answer within
+// forwarderKeepAliveTimeout is treated as dead and its connection is closed.
+func ping(client *ssh.Client) error {
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(forwarderKeepAliveTimeout):
+		_ = client.Close()
+		return errors.New("keep-alive timed out")
+	}
+}
+
+// dialTarget opens a channel to the target through the bastion, reconnecting once if the connection dropped.
+// A target that refuses the channel is reported as is: the bastion connection itself is still fine.
+func (f *Forwarder) dialTarget(ctx context.Context) (net.Conn, error) {
+	client := f.currentClient()
+	if client != nil {
+		conn, err := client.Dial("tcp", f.cfg.targetAddr())
+		if err == nil {
+			return conn, nil
+		}
+		var openErr *ssh.OpenChannelError
+		if errors.As(err, &openErr) {
+			return nil, err
+		}
+	}
+	if err := f.reconnectFrom(ctx, client); err != nil {
+		return nil, err
+	}
+	client = f.currentClient()
+	if client == nil {
+		return nil, errors.New("bastion connection closed")
+	}
+	return client.Dial("tcp", f.cfg.targetAddr())
+}
+
+// handle proxies a single local connection to the target.
+func (f *Forwarder) handle(ctx context.Context, local net.Conn) {
+	defer local.Close()
+
+	remote, err := f.dialTarget(ctx)
+	if err != nil {
+		f.logf("connection from %s: dial %s: %v", local.RemoteAddr(), f.cfg.targetAddr(), err)
+		return
+	}
+	defer remote.Close()
+
+	f.active.Add(1)
+	f.total.Add(1)
+	defer f.active.Add(-1)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		n, _ := io.Copy(remote, local)
+		f.sent.Add(n)
+		closeWrite(remote)
+	}()
+	go func() {
+		defer wg.Done()
+		n, _ := io.Copy(local, remote)
+		f.received.Add(n)
+		closeWrite(local)
+	}()
+	wg.Wait()
+}
+
+// closeWrite half-closes conn when supported so the peer sees EOF while replies can still arrive.
+func closeWrite(conn net.Conn) {
+	if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+		_ = cw.CloseWrite()
+		return
+	}
+	_ = conn.Close()
+}
+
+// logf writes a timestamped line to the forwarder log.
+func (f *Forwarder) logf(format string, args ...any) {
+	_, _ = fmt.Fprintf(f.log, "%s %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
+
+// statsFile returns the path of the stats file written by the forwarder on localPort.
+func statsFile(localPort int) (string, error) {
+	tunnelsDir, err := getTunnelsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(tunnelsDir, fmt.Sprintf("tunnel-%d.stats", localPort)), nil
+}
+
+// writeStats records the forwarder's counters next to the tunnel state file.
+func writeStats(localPort int, stats ForwarderStats) error {
+	path, err := statsFile(localPort)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ReadTunnelStats returns the most recent stats reported by the forwarder on localPort.
+func ReadTunnelStats(localPort int) (ForwarderStats, error) {
+	var stats ForwarderStats
+	path, err := statsFile(localPort)
+	if err != nil {
+		return stats, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return stats, err
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return stats, fmt.Errorf("parse tunnel stats: %w", err)
+	}
+	return stats, nil
+}
+
+// ServeForwarder runs the forwarder described by the environment of a process started with SpawnDetached.
+// Output goes to stdout, which SpawnDetached points at the tunnel log.
+func ServeForwarder(ctx context.Context) error {
+	var cfg ForwarderConfig
+	if err := json.Unmarshal([]byte(os.Getenv(forwarderConfigEnv)), &cfg); err != nil {
+		return fmt.Errorf("read forwarder config: %w", err)
+	}
+	f, err := NewForwarder(cfg, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(forwarderStatsInterval):
+				_ = writeStats(cfg.LocalPort, f.Stats())
+			}
+		}
+	}()
+
+	err = f.Run(ctx)
+	_ = writeStats(cfg.LocalPort, f.Stats())
+	return err
+}
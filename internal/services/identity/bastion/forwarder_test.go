@@ -0,0 +1,304 @@
+package bastion
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const testSessionID = "ocid1.bastionsession.oc1.iad.test"
+
+// writeTestKey generates an ed25519 key pair, writes the private key in OpenSSH format and returns its path and public key.
+func writeTestKey(t *testing.T) (string, ssh.PublicKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	return path, sshPub
+}
+
+// startEchoServer starts a TCP server that echoes everything it receives.
+func startEchoServer(t *testing.T) *net.TCPAddr {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				_, _ = io.Copy(c, c)
+			}()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr)
+}
+
+// startBastionStandIn starts an SSH server that accepts the session OCID with the given key
+// and serves direct-tcpip channels like the OCI bastion does.
+func startBastionStandIn(t *testing.T, authorized ssh.PublicKey) string {
+	t.Helper()
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if meta.User() == testSessionID && string(key.Marshal()) == string(authorized.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unauthorized")
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveBastionConn(c, config)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func serveBastionConn(c net.Conn, config *ssh.ServerConfig) {
+	sconn, chans, reqs, err := ssh.NewServerConn(c, config)
+	if err != nil {
+		return
+	}
+	defer sconn.Close()
+	go ssh.DiscardRequests(reqs)
+	for newCh := range chans {
+		if newCh.ChannelType() != "direct-tcpip" {
+			_ = newCh.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		var payload struct {
+			Host     string
+			Port     uint32
+			OrigHost string
+			OrigPort uint32
+		}
+		if err := ssh.Unmarshal(newCh.ExtraData(), &payload); err != nil {
+			_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+		if err != nil {
+			_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		ch, chReqs, err := newCh.Accept()
+		if err != nil {
+			_ = target.Close()
+			continue
+		}
+		go ssh.DiscardRequests(chReqs)
+		go func() {
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() { defer wg.Done(); _, _ = io.Copy(ch, target); _ = ch.CloseWrite() }()
+			go func() { defer wg.Done(); _, _ = io.Copy(target, ch); _ = target.(*net.TCPConn).CloseWrite() }()
+			wg.Wait()
+			_ = ch.Close()
+			_ = target.Close()
+		}()
+	}
+}
+
+// freePort returns a local TCP port that is currently unused.
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+	return port
+}
+
+func TestForwarder_ProxiesThroughBastion(t *testing.T) {
+	keyPath, pub := writeTestKey(t)
+	target := startEchoServer(t)
+	cfg := ForwarderConfig{
+		PrivateKeyPath: keyPath,
+		SessionID:      testSessionID,
+		TargetIP:       target.IP.String(),
+		RemotePort:     target.Port,
+		LocalPort:      freePort(t),
+		BastionAddr:    startBastionStandIn(t, pub),
+	}
+
+	f, err := NewForwarder(cfg, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- f.Run(ctx) }()
+	require.NoError(t, WaitForListen(cfg.LocalPort, 5*time.Second))
+
+	for i := 0; i < 2; i++ {
+		conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(cfg.LocalPort)))
+		require.NoError(t, err)
+		_, err = conn.Write([]byte("hello"))
+		require.NoError(t, err)
+		require.NoError(t, conn.(*net.TCPConn).CloseWrite())
+		got, err := io.ReadAll(conn)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(got))
+		require.NoError(t, conn.Close())
+	}
+
+	require.Eventually(t, func() bool { return f.Stats().ActiveConnections == 0 }, 5*time.Second, 10*time.Millisecond)
+	stats := f.Stats()
+	assert.Equal(t, int64(3), stats.TotalConnections, "two clients plus the WaitForListen probe")
+	assert.Equal(t, int64(10), stats.BytesSent)
+	assert.Equal(t, int64(10), stats.BytesReceived)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("forwarder did not stop after cancel")
+	}
+}
+
+func TestForwarder_DialTarget(t *testing.T) {
+	keyPath, pub := writeTestKey(t)
+	target := startEchoServer(t)
+	cfg := ForwarderConfig{
+		PrivateKeyPath: keyPath,
+		SessionID:      testSessionID,
+		TargetIP:       target.IP.String(),
+		RemotePort:     freePort(t),
+		BastionAddr:    startBastionStandIn(t, pub),
+	}
+	f, err := NewForwarder(cfg, nil)
+	require.NoError(t, err)
+	require.NoError(t, f.connect())
+	defer f.closeClient()
+	ctx := context.Background()
+
+	t.Run("refused target keeps the bastion connection", func(t *testing.T) {
+		client := f.currentClient()
+		_, err := f.dialTarget(ctx)
+		var openErr *ssh.OpenChannelError
+		require.ErrorAs(t, err, &openErr)
+		assert.Same(t, client, f.currentClient())
+	})
+
+	t.Run("stale client is replaced once", func(t *testing.T) {
+		stale := f.currentClient()
+		require.NoError(t, f.reconnectFrom(ctx, stale))
+		replaced := f.currentClient()
+		assert.NotSame(t, stale, replaced)
+		require.NoError(t, f.reconnectFrom(ctx, stale))
+		assert.Same(t, replaced, f.currentClient())
+	})
+
+	t.Run("dead client is reconnected", func(t *testing.T) {
+		f.cfg.RemotePort = target.Port
+		dead := f.currentClient()
+		require.NoError(t, dead.Close())
+		conn, err := f.dialTarget(ctx)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+		assert.NotSame(t, dead, f.currentClient())
+	})
+}
+
+func TestForwarder_RejectedKey(t *testing.T) {
+	keyPath, _ := writeTestKey(t)
+	_, otherPub := writeTestKey(t)
+	cfg := ForwarderConfig{
+		PrivateKeyPath: keyPath,
+		SessionID:      testSessionID,
+		TargetIP:       "127.0.0.1",
+		RemotePort:     1,
+		LocalPort:      freePort(t),
+		BastionAddr:    startBastionStandIn(t, otherPub),
+	}
+
+	f, err := NewForwarder(cfg, nil)
+	require.NoError(t, err)
+	err = f.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connect to bastion")
+}
+
+func TestNewForwarder_InvalidKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "id_rsa")
+	require.NoError(t, os.WriteFile(path, []byte("not a key"), 0o600))
+	_, err := NewForwarder(ForwarderConfig{PrivateKeyPath: path}, nil)
+	assert.Error(t, err)
+}
+
+func TestBuildPortForwardConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg, err := BuildPortForwardConfig("~/.ssh/id_ed25519", "ocid1.bastionsession.oc1.iad.x", "us-ashburn-1", "10.0.0.5", 15432, 5432)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".ssh/id_ed25519"), cfg.PrivateKeyPath)
+	assert.Equal(t, "host.bastion.us-ashburn-1.oci.oraclecloud.com:22", cfg.bastionAddr())
+	assert.Equal(t, "10.0.0.5:5432", cfg.targetAddr())
+}
+
+func TestTunnelStats_RoundTrip(t *testing.T) {
+	setupTunnelHome(t)
+	_, err := ReadTunnelStats(1521)
+	assert.Error(t, err)
+
+	require.NoError(t, writeStats(1521, ForwarderStats{ActiveConnections: 1, TotalConnections: 3, BytesSent: 42, BytesReceived: 7}))
+	stats, err := ReadTunnelStats(1521)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stats.TotalConnections)
+	assert.Equal(t, int64(42), stats.BytesSent)
+
+	require.NoError(t, SaveTunnelState(TunnelInfo{PID: os.Getpid(), LocalPort: 1521}))
+	tunnels, err := GetActiveTunnels()
+	require.NoError(t, err)
+	require.Len(t, tunnels, 1)
+	require.NotNil(t, tunnels[0].Stats)
+	assert.Equal(t, int64(7), tunnels[0].Stats.BytesReceived)
+
+	require.NoError(t, RemoveTunnelState(1521))
+	_, err = ReadTunnelStats(1521)
+	assert.Error(t, err)
+}
+
+func TestIsProcessRunning(t *testing.T) {
+	assert.True(t, isProcessRunning(os.Getpid()))
+	assert.False(t, isProcessRunning(deadPID))
+	assert.False(t, isProcessRunning(0))
+}
@@ -0,0 +1,213 @@
+package bastion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/rozdolsky33/ocloud/internal/logger"
+)
+
+// Keep-alive timing. keepAliveRenewBefore must stay below minReuseRemaining so that
+// EnsurePortForwardSession hands out a fresh session when the supervisor renews.
+var (
+	keepAliveCheckInterval = 30 * time.Second
+	keepAliveRenewBefore   = 5 * time.Minute
+	keepAliveListenTimeout = 30 * time.Second
+)
+
+// TunnelSupervisor keeps a port-forwarding tunnel alive across bastion session expiry.
+// Shortly before the session expires it creates a fresh session, restarts the forwarder on the
+// same local port and records the restart in the tunnel log. It also restarts the forwarder if it dies.
+type TunnelSupervisor struct {
+	tunnel     TunnelInfo
+	privateKey string
+	publicKey  string
+	region     string
+
+	// Hooks, replaced in tests.
+	now           func() time.Time
+	ensureSession func(ctx context.Context) (string, error)
+	sessionTTL    func(ctx context.Context, sessionID string) (int, time.Time, error)
+	spawn         func(cfg ForwarderConfig, logFile string) (int, error)
+	stop          func(pid int) error
+	running       func(pid int) bool
+	waitListen    func(localPort int, timeout time.Duration) error
+	saveState     func(TunnelInfo) error
+	stateExists   func(localPort int) bool
+}
+
+// NewTunnelSupervisor returns a supervisor for a tunnel that has already been started and recorded.
+func (s *Service) NewTunnelSupervisor(tunnel TunnelInfo, privateKeyPath, publicKeyPath, region string) *TunnelSupervisor {
+	ts := &TunnelSupervisor{
+		tunnel:     tunnel,
+		privateKey: privateKeyPath,
+		publicKey:  publicKeyPath,
+		region:     region,
+		now:        time.Now,
+		sessionTTL: s.SessionTTL,
+		spawn:      SpawnDetachedToLog,
+		stop:       terminateProcess,
+		running:    isProcessRunning,
+		waitListen: WaitForListen,
+		saveState:  SaveTunnelState,
+		stateExists: func(localPort int) bool {
+			dir, err := getTunnelsDir()
+			if err != nil {
+				return false
+			}
+			_, err = os.Stat(filepath.Join(dir, fmt.Sprintf("tunnel-%d.json", localPort)))
+			return err == nil
+		},
+	}
+	ts.ensureSession = func(ctx context.Context) (string, error) {
+		return s.EnsurePortForwardSession(ctx, ts.tunnel.BastionID, ts.tunnel.TargetIP, ts.tunnel.RemotePort, ts.publicKey)
+	}
+	return ts
+}
+
+// Tunnel returns the current state of the supervised tunnel.
+func (ts *TunnelSupervisor) Tunnel() TunnelInfo { return ts.tunnel }
+
+// Run supervises the tunnel until the context is cancelled or the tunnel is stopped
+// (its state file removed, e.g. by "bastion tunnel stop"). The forwarder is left running on cancel.
+func (ts *TunnelSupervisor) Run(ctx context.Context) error {
+	if ts.tunnel.BastionID == "" || ts.tunnel.RemotePort == 0 {
+		return fmt.Errorf("tunnel on port %d is missing bastion or remote port information", ts.tunnel.LocalPort)
+	}
+	ts.resolveExpiry(ctx)
+	ts.logEvent("keep-alive supervisor started (pid %d), session expires %s", os.Getpid(), ts.tunnel.ExpiresAt.Format(time.RFC3339))
+
+	for {
+		select {
+		case <-ctx.Done():
+			ts.logEvent("keep-alive supervisor stopped; tunnel left running")
+			return nil
+		case <-time.After(keepAliveCheckInterval):
+		}
+
+		if err := ts.check(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, errTunnelStopped) {
+				ts.logEvent("tunnel state removed; keep-alive supervisor exiting")
+				return nil
+			}
+			// Keep trying on the next tick; the session may still have time left.
+			ts.logEvent("renewal failed: %v", err)
+			logger.Logger.Error(err, "keep-alive renewal failed", "local_port", ts.tunnel.LocalPort)
+		}
+	}
+}
+
+// errTunnelStopped signals that the tunnel was stopped externally.
+var errTunnelStopped = errors.New("tunnel stopped")
+
+// check performs one supervision step: renew the session when it is about to expire,
+// or restart the forwarder when its process has died.
+func (ts *TunnelSupervisor) check(ctx context.Context) error {
+	if !ts.stateExists(ts.tunnel.LocalPort) {
+		return errTunnelStopped
+	}
+
+	now := ts.now()
+	if !ts.tunnel.ExpiresAt.IsZero() && ts.tunnel.ExpiresAt.Sub(now) <= keepAliveRenewBefore {
+		return ts.renew(ctx, "session expires at "+ts.tunnel.ExpiresAt.Format(time.RFC3339))
+	}
+	if !ts.running(ts.tunnel.PID) {
+		return ts.restart(ctx, ts.tunnel.SessionID, fmt.Sprintf("forwarder process %d exited", ts.tunnel.PID))
+	}
+	return nil
+}
+
+// renew obtains a fresh session and restarts the forwarder with it.
+func (ts *TunnelSupervisor) renew(ctx context.Context, reason string) error {
+	sessID, err := ts.ensureSession(ctx)
+	if err != nil {
+		return fmt.Errorf("ensure port forward: %w", err)
+	}
+	return ts.restart(ctx, sessID, reason)
+}
+
+// restart replaces the forwarder process with one using sessionID on the same local port.
+// The old process must release the port first, so it is stopped just before the new one is spawned;
+// renew obtains the new session beforehand to keep the gap short. A forwarder that does not start
+// listening is stopped and reported, so the next check starts another one.
+func (ts *TunnelSupervisor) restart(ctx context.Context, sessionID, reason string) error {
+	cfg, err := BuildPortForwardConfig(ts.privateKey, sessionID, ts.region, ts.tunnel.TargetIP, ts.tunnel.LocalPort, ts.tunnel.RemotePort)
+	if err != nil {
+		return fmt.Errorf("build forwarder config: %w", err)
+	}
+
+	oldPID := ts.tunnel.PID
+	if ts.running(oldPID) {
+		if err := ts.stop(oldPID); err != nil {
+			return fmt.Errorf("stop forwarder process %d: %w", oldPID, err)
+		}
+	}
+	pid, err := ts.spawn(cfg, ts.tunnel.LogFile)
+	if err != nil {
+		return fmt.Errorf("spawn detached: %w", err)
+	}
+
+	sessionChanged := sessionID != ts.tunnel.SessionID
+	ts.tunnel.PID = pid
+	ts.tunnel.SessionID = sessionID
+	if sessionChanged {
+		ts.tunnel.ExpiresAt = time.Time{}
+		ts.resolveExpiry(ctx)
+	}
+	if err := ts.saveState(ts.tunnel); err != nil {
+		logger.Logger.Error(err, "failed to save tunnel state")
+	}
+
+	if err := ts.waitListen(ts.tunnel.LocalPort, keepAliveListenTimeout); err != nil {
+		if stopErr := ts.stop(pid); stopErr != nil {
+			logger.Logger.Error(stopErr, "failed to stop forwarder process", "pid", pid)
+		}
+		return fmt.Errorf("forwarder process %d is not listening on port %d: %w", pid, ts.tunnel.LocalPort, err)
+	}
+	ts.logEvent("restarted tunnel (%s): pid %d -> %d, session %s, expires %s",
+		reason, oldPID, pid, sessionID, ts.tunnel.ExpiresAt.Format(time.RFC3339))
+	logger.Logger.Info("Tunnel restarted", "local_port", ts.tunnel.LocalPort, "reason", reason, "pid", pid, "session_id", sessionID)
+	return nil
+}
+
+// resolveExpiry fills in the session TTL and expiry, falling back to the default TTL from the start time.
+func (ts *TunnelSupervisor) resolveExpiry(ctx context.Context) {
+	if !ts.tunnel.ExpiresAt.IsZero() {
+		return
+	}
+	if ttl, expiresAt, err := ts.sessionTTL(ctx, ts.tunnel.SessionID); err == nil {
+		ts.tunnel.SessionTTL = ttl
+		ts.tunnel.ExpiresAt = expiresAt
+		return
+	}
+	ts.tunnel.ExpiresAt = ts.now().Add(time.Duration(defaultTTL) * time.Second)
+}
+
+// logEvent appends a timestamped keep-alive line to the tunnel log.
+func (ts *TunnelSupervisor) logEvent(format string, args ...any) {
+	if ts.tunnel.LogFile == "" {
+		return
+	}
+	f, err := os.OpenFile(ts.tunnel.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = fmt.Fprintf(f, "=== [keep-alive] %s %s ===\n", ts.now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
+
+// terminateProcess sends SIGTERM to pid, ignoring processes that already exited.
+func terminateProcess(pid int) error {
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package bastion
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/bastion"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionReusable(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := func(created time.Time, ttl int) bastion.Session {
+		return bastion.Session{TimeCreated: &common.SDKTime{Time: created}, SessionTtlInSeconds: common.Int(ttl)}
+	}
+
+	assert.True(t, sessionReusable(session(now.Add(-time.Hour), 10800), now))
+	assert.False(t, sessionReusable(session(now.Add(-3*time.Hour+5*time.Minute), 10800), now))
+	assert.True(t, sessionReusable(bastion.Session{}, now), "sessions without a TTL are reused")
+}
+
+// fakeSupervisor returns a supervisor whose side effects are recorded instead of executed.
+type fakeSupervisor struct {
+	*TunnelSupervisor
+	now       time.Time
+	alive     map[int]bool
+	stopped   []int
+	nextPID   int
+	sessions  []string
+	saved     []TunnelInfo
+	stateGone bool
+}
+
+func newFakeSupervisor(t *testing.T, tunnel TunnelInfo) *fakeSupervisor {
+	t.Helper()
+	f := &fakeSupervisor{
+		now:     time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		alive:   map[int]bool{tunnel.PID: true},
+		nextPID: 200,
+	}
+	f.TunnelSupervisor = &TunnelSupervisor{
+		tunnel:     tunnel,
+		privateKey: "/tmp/id_ed25519",
+		publicKey:  "/tmp/id_ed25519.pub",
+		region:     "us-ashburn-1",
+		now:        func() time.Time { return f.now },
+		ensureSession: func(context.Context) (string, error) {
+			id := "ocid1.bastionsession.oc1..new"
+			f.sessions = append(f.sessions, id)
+			return id, nil
+		},
+		sessionTTL: func(context.Context, string) (int, time.Time, error) {
+			return 10800, f.now.Add(3 * time.Hour), nil
+		},
+		spawn: func(ForwarderConfig, string) (int, error) {
+			f.nextPID++
+			f.alive[f.nextPID] = true
+			return f.nextPID, nil
+		},
+		stop: func(pid int) error {
+			f.stopped = append(f.stopped, pid)
+			f.alive[pid] = false
+			return nil
+		},
+		running:     func(pid int) bool { return f.alive[pid] },
+		waitListen:  func(int, time.Duration) error { return nil },
+		saveState:   func(ti TunnelInfo) error { f.saved = append(f.saved, ti); return nil },
+		stateExists: func(int) bool { return !f.stateGone },
+	}
+	return f
+}
+
+func TestTunnelSupervisorCheck(t *testing.T) {
+	base := TunnelInfo{
+		PID: 100, LocalPort: 1521, TargetIP: "10.0.0.5", RemotePort: 1521,
+		SessionID: "ocid1.bastionsession.oc1..old", BastionID: "ocid1.bastion.oc1..b",
+	}
+
+	t.Run("healthy tunnel is left alone", func(t *testing.T) {
+		tunnel := base
+		tunnel.ExpiresAt = time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)
+		f := newFakeSupervisor(t, tunnel)
+
+		require.NoError(t, f.check(context.Background()))
+		assert.Empty(t, f.sessions)
+		assert.Empty(t, f.stopped)
+		assert.Equal(t, 100, f.Tunnel().PID)
+	})
+
+	t.Run("renews before expiry", func(t *testing.T) {
+		tunnel := base
+		tunnel.ExpiresAt = time.Date(2024, 1, 1, 12, 3, 0, 0, time.UTC)
+		f := newFakeSupervisor(t, tunnel)
+
+		require.NoError(t, f.check(context.Background()))
+		assert.Len(t, f.sessions, 1)
+		assert.Equal(t, []int{100}, f.stopped)
+		got := f.Tunnel()
+		assert.Equal(t, 201, got.PID)
+		assert.Equal(t, "ocid1.bastionsession.oc1..new", got.SessionID)
+		assert.True(t, f.now.Add(3*time.Hour).Equal(got.ExpiresAt))
+		require.Len(t, f.saved, 1)
+		assert.Equal(t, 201, f.saved[0].PID)
+	})
+
+	t.Run("restarts a dead forwarder process with the same session", func(t *testing.T) {
+		tunnel := base
+		tunnel.ExpiresAt = time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC)
+		f := newFakeSupervisor(t, tunnel)
+		f.alive[100] = false
+
+		require.NoError(t, f.check(context.Background()))
+		assert.Empty(t, f.sessions)
+		assert.Empty(t, f.stopped)
+		got := f.Tunnel()
+		assert.Equal(t, 201, got.PID)
+		assert.Equal(t, base.SessionID, got.SessionID)
+		assert.True(t, tunnel.ExpiresAt.Equal(got.ExpiresAt))
+	})
+
+	t.Run("a forwarder that does not listen is stopped and restarted on the next check", func(t *testing.T) {
+		tunnel := base
+		tunnel.ExpiresAt = time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC)
+		f := newFakeSupervisor(t, tunnel)
+		f.alive[100] = false
+		f.waitListen = func(int, time.Duration) error { return assert.AnError }
+
+		require.ErrorIs(t, f.check(context.Background()), assert.AnError)
+		assert.Equal(t, []int{201}, f.stopped)
+
+		f.waitListen = func(int, time.Duration) error { return nil }
+		require.NoError(t, f.check(context.Background()))
+		assert.Equal(t, 202, f.Tunnel().PID)
+	})
+
+	t.Run("stops when the tunnel state is removed", func(t *testing.T) {
+		f := newFakeSupervisor(t, base)
+		f.stateGone = true
+		assert.ErrorIs(t, f.check(context.Background()), errTunnelStopped)
+	})
+}
+
+func TestTunnelSupervisorRun_LogsToTunnelLog(t *testing.T) {
+	oldInterval := keepAliveCheckInterval
+	keepAliveCheckInterval = time.Millisecond
+	t.Cleanup(func() { keepAliveCheckInterval = oldInterval })
+
+	logFile := filepath.Join(t.TempDir(), "ssh-tunnel-1521.log")
+	f := newFakeSupervisor(t, TunnelInfo{
+		PID: 100, LocalPort: 1521, TargetIP: "10.0.0.5", RemotePort: 1521, LogFile: logFile,
+		SessionID: "ocid1.bastionsession.oc1..old", BastionID: "ocid1.bastion.oc1..b",
+		ExpiresAt: time.Date(2024, 1, 1, 12, 1, 0, 0, time.UTC),
+	})
+	// Stop after the first renewal.
+	f.saveState = func(ti TunnelInfo) error {
+		f.saved = append(f.saved, ti)
+		f.stateGone = true
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, f.Run(ctx))
+
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	log := string(data)
+	assert.Contains(t, log, "keep-alive supervisor started")
+	assert.Contains(t, log, "restarted tunnel (session expires at")
+	assert.Contains(t, log, "pid 100 -> 201")
+	assert.Contains(t, log, "tunnel state removed")
+}
+
+func TestTunnelSupervisorRun_RequiresBastion(t *testing.T) {
+	f := newFakeSupervisor(t, TunnelInfo{PID: 100, LocalPort: 1521})
+	assert.Error(t, f.Run(context.Background()))
+}
@@ -0,0 +1,134 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/identity"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+)
+
+// Bastion lifecycle states and limits used by the create/delete flows.
+const (
+	BastionTypeStandard = "STANDARD"
+
+	stateActive  = "ACTIVE"
+	stateFailed  = "FAILED"
+	stateDeleted = "DELETED"
+
+	// MinSessionTTL and MaxSessionTTL are the bounds OCI accepts for a bastion's max session TTL (seconds).
+	MinSessionTTL = 1800
+	MaxSessionTTL = 10800
+)
+
+// Create creates a new bastion through the repository and returns the bastion as reported by OCI.
+// The returned bastion is usually still CREATING; use WaitForActive to block until it is usable.
+func (s *Service) Create(ctx context.Context, req domain.CreateBastionRequest) (*Bastion, error) {
+	logger.LogWithLevel(s.logger, logger.Debug, "Creating Bastion", "name", req.DisplayName, "subnetID", req.TargetSubnetID)
+
+	if req.CompartmentID == "" {
+		req.CompartmentID = s.compartmentID
+	}
+	if req.BastionType == "" {
+		req.BastionType = BastionTypeStandard
+	}
+	if req.TargetSubnetID == "" {
+		return nil, fmt.Errorf("target subnet is required")
+	}
+	if req.MaxSessionTTL != 0 && (req.MaxSessionTTL < MinSessionTTL || req.MaxSessionTTL > MaxSessionTTL) {
+		return nil, fmt.Errorf("max session TTL must be between %d and %d seconds, got %d", MinSessionTTL, MaxSessionTTL, req.MaxSessionTTL)
+	}
+
+	b, err := s.bastionRepo.CreateBastion(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bastion: %w", err)
+	}
+
+	logger.Logger.V(logger.Debug).Info("Bastion creation requested.", "bastionID", b.OCID, "state", b.LifecycleState)
+	return b, nil
+}
+
+// WaitForActive polls the bastion until it reaches ACTIVE, fails, or the context is cancelled.
+func (s *Service) WaitForActive(ctx context.Context, bastionID string) (*Bastion, error) {
+	for {
+		b, err := s.bastionRepo.GetBastion(ctx, bastionID)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for bastion ACTIVE: %w", err)
+		}
+		switch b.LifecycleState {
+		case stateActive:
+			return b, nil
+		case stateFailed, stateDeleted:
+			return b, fmt.Errorf("bastion %s entered state %s", b.DisplayName, b.LifecycleState)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
+
+// Delete deletes the bastion with the given OCID.
+func (s *Service) Delete(ctx context.Context, bastionID string) error {
+	logger.LogWithLevel(s.logger, logger.Debug, "Deleting Bastion", "bastionID", bastionID)
+
+	if err := s.bastionRepo.DeleteBastion(ctx, bastionID); err != nil {
+		return fmt.Errorf("failed to delete bastion: %w", err)
+	}
+	return nil
+}
+
+// Resolve finds a bastion by OCID or by exact display name within the service compartment.
+// Deleted bastions are ignored when matching by name.
+func (s *Service) Resolve(ctx context.Context, nameOrID string) (*Bastion, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+	if nameOrID == "" {
+		return nil, fmt.Errorf("bastion name or OCID is required")
+	}
+	if strings.HasPrefix(nameOrID, "ocid1.bastion.") {
+		return s.Get(ctx, nameOrID)
+	}
+
+	bastions, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Bastion
+	for _, b := range bastions {
+		if b.DisplayName == nameOrID && b.LifecycleState != stateDeleted {
+			matches = append(matches, b)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("bastion %q not found in compartment", nameOrID)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("bastion name %q is ambiguous (%d matches); use the OCID instead", nameOrID, len(matches))
+	}
+}
+
+// ParseCIDRList splits a comma-separated list of CIDR blocks and validates each entry.
+func ParseCIDRList(raw string) ([]string, error) {
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		cidr := strings.TrimSpace(part)
+		if cidr == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("invalid CIDR block %q: %w", cidr, err)
+		}
+		out = append(out, cidr)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("at least one CIDR block is required")
+	}
+	return out, nil
+}
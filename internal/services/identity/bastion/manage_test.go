@@ -0,0 +1,198 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/identity"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testCompartmentID = "ocid1.compartment.oc1..test"
+
+// TestCreate tests the Create method defaults and validation
+func TestCreate(t *testing.T) {
+	t.Run("fills defaults from service", func(t *testing.T) {
+		mockRepo := new(MockBastionRepository)
+		expected := domain.CreateBastionRequest{
+			DisplayName:    "prodbastion",
+			CompartmentID:  testCompartmentID,
+			TargetSubnetID: "ocid1.subnet.oc1..test",
+			BastionType:    BastionTypeStandard,
+			ClientCIDRList: []string{"10.0.0.0/8"},
+			MaxSessionTTL:  3600,
+		}
+		mockRepo.On("CreateBastion", mock.Anything, expected).Return(&domain.Bastion{OCID: "ocid1.bastion.oc1..new", LifecycleState: "CREATING"}, nil)
+
+		service := NewService(mockRepo, logger.NewTestLogger(), testCompartmentID)
+		b, err := service.Create(context.Background(), domain.CreateBastionRequest{
+			DisplayName:    "prodbastion",
+			TargetSubnetID: "ocid1.subnet.oc1..test",
+			ClientCIDRList: []string{"10.0.0.0/8"},
+			MaxSessionTTL:  3600,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ocid1.bastion.oc1..new", b.OCID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects missing subnet", func(t *testing.T) {
+		service := NewService(new(MockBastionRepository), logger.NewTestLogger(), testCompartmentID)
+		_, err := service.Create(context.Background(), domain.CreateBastionRequest{DisplayName: "b"})
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects out of range TTL", func(t *testing.T) {
+		service := NewService(new(MockBastionRepository), logger.NewTestLogger(), testCompartmentID)
+		_, err := service.Create(context.Background(), domain.CreateBastionRequest{
+			DisplayName:    "b",
+			TargetSubnetID: "ocid1.subnet.oc1..test",
+			MaxSessionTTL:  60,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockBastionRepository)
+		mockRepo.On("CreateBastion", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("quota exceeded"))
+
+		service := NewService(mockRepo, logger.NewTestLogger(), testCompartmentID)
+		_, err := service.Create(context.Background(), domain.CreateBastionRequest{TargetSubnetID: "ocid1.subnet.oc1..test"})
+		assert.ErrorContains(t, err, "quota exceeded")
+	})
+}
+
+// TestWaitForActive tests polling until the bastion becomes ACTIVE or fails
+func TestWaitForActive(t *testing.T) {
+	orig := waitPollInterval
+	waitPollInterval = time.Millisecond
+	defer func() { waitPollInterval = orig }()
+
+	t.Run("becomes active", func(t *testing.T) {
+		mockRepo := new(MockBastionRepository)
+		mockRepo.On("GetBastion", mock.Anything, "id").Return(&domain.Bastion{OCID: "id", LifecycleState: "CREATING"}, nil).Twice()
+		mockRepo.On("GetBastion", mock.Anything, "id").Return(&domain.Bastion{OCID: "id", LifecycleState: "ACTIVE"}, nil).Once()
+
+		service := NewService(mockRepo, logger.NewTestLogger(), testCompartmentID)
+		b, err := service.WaitForActive(context.Background(), "id")
+
+		require.NoError(t, err)
+		assert.Equal(t, "ACTIVE", b.LifecycleState)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("fails", func(t *testing.T) {
+		mockRepo := new(MockBastionRepository)
+		mockRepo.On("GetBastion", mock.Anything, "id").Return(&domain.Bastion{OCID: "id", LifecycleState: "FAILED"}, nil)
+
+		service := NewService(mockRepo, logger.NewTestLogger(), testCompartmentID)
+		_, err := service.WaitForActive(context.Background(), "id")
+		assert.ErrorContains(t, err, "FAILED")
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		mockRepo := new(MockBastionRepository)
+		mockRepo.On("GetBastion", mock.Anything, "id").Return(&domain.Bastion{OCID: "id", LifecycleState: "CREATING"}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		service := NewService(mockRepo, logger.NewTestLogger(), testCompartmentID)
+		_, err := service.WaitForActive(ctx, "id")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+// TestDelete tests the Delete method
+func TestDelete(t *testing.T) {
+	mockRepo := new(MockBastionRepository)
+	mockRepo.On("DeleteBastion", mock.Anything, "ok").Return(nil)
+	mockRepo.On("DeleteBastion", mock.Anything, "bad").Return(fmt.Errorf("conflict"))
+
+	service := NewService(mockRepo, logger.NewTestLogger(), testCompartmentID)
+	assert.NoError(t, service.Delete(context.Background(), "ok"))
+	assert.ErrorContains(t, service.Delete(context.Background(), "bad"), "conflict")
+	mockRepo.AssertExpectations(t)
+}
+
+// TestResolve tests resolving a bastion by name or OCID
+func TestResolve(t *testing.T) {
+	bastions := []domain.Bastion{
+		{OCID: "ocid1.bastion.oc1..a", DisplayName: "alpha", LifecycleState: "ACTIVE"},
+		{OCID: "ocid1.bastion.oc1..b", DisplayName: "beta", LifecycleState: "ACTIVE"},
+		{OCID: "ocid1.bastion.oc1..c", DisplayName: "beta", LifecycleState: "ACTIVE"},
+		{OCID: "ocid1.bastion.oc1..d", DisplayName: "gamma", LifecycleState: "DELETED"},
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		expectID  string
+		expectErr string
+	}{
+		{name: "by name", input: "alpha", expectID: "ocid1.bastion.oc1..a"},
+		{name: "ambiguous name", input: "beta", expectErr: "ambiguous"},
+		{name: "deleted ignored", input: "gamma", expectErr: "not found"},
+		{name: "unknown", input: "delta", expectErr: "not found"},
+		{name: "empty", input: "  ", expectErr: "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockBastionRepository)
+			mockRepo.On("ListBastions", mock.Anything, testCompartmentID).Return(bastions, nil).Maybe()
+
+			service := NewService(mockRepo, logger.NewTestLogger(), testCompartmentID)
+			b, err := service.Resolve(context.Background(), tt.input)
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectID, b.OCID)
+		})
+	}
+
+	t.Run("by OCID", func(t *testing.T) {
+		mockRepo := new(MockBastionRepository)
+		mockRepo.On("GetBastion", mock.Anything, "ocid1.bastion.oc1..b").Return(&bastions[1], nil)
+
+		service := NewService(mockRepo, logger.NewTestLogger(), testCompartmentID)
+		b, err := service.Resolve(context.Background(), "ocid1.bastion.oc1..b")
+		require.NoError(t, err)
+		assert.Equal(t, "beta", b.DisplayName)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+// TestParseCIDRList tests parsing and validation of the client CIDR allow-list
+func TestParseCIDRList(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  []string
+		expectErr bool
+	}{
+		{name: "single", input: "0.0.0.0/0", expected: []string{"0.0.0.0/0"}},
+		{name: "multiple with spaces", input: " 10.0.0.0/8, 192.168.1.0/24 ,", expected: []string{"10.0.0.0/8", "192.168.1.0/24"}},
+		{name: "invalid", input: "10.0.0.0/8,not-a-cidr", expectErr: true},
+		{name: "empty", input: " , ", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCIDRList(tt.input)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -2,7 +2,9 @@ package bastion
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rozdolsky33/ocloud/internal/app"
 	"github.com/rozdolsky33/ocloud/internal/printer"
@@ -56,3 +58,113 @@ func PrintBastionInfo(bastions []Bastion, appCtx *app.ApplicationContext, useJSO
 
 	return nil
 }
+
+// PrintTunnelsInfo displays tracked SSH tunnels in a table or JSON format.
+func PrintTunnelsInfo(tunnels []TunnelInfo, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		if len(tunnels) == 0 {
+			return p.MarshalToJSON([]TunnelInfo{})
+		}
+		return p.MarshalToJSON(tunnels)
+	}
+
+	if len(tunnels) == 0 {
+		fmt.Fprintln(appCtx.Stdout, "No active tunnels.")
+		return nil
+	}
+
+	now := time.Now()
+	headers := []string{"Local Port", "PID", "Target", "Session", "Age", "TTL Left", "Conns", "Sent", "Received"}
+	rows := make([][]string, 0, len(tunnels))
+	for _, t := range tunnels {
+		age, ttlLeft := "-", "-"
+		if !t.StartedAt.IsZero() {
+			age = FormatDuration(now.Sub(t.StartedAt))
+		}
+		if !t.ExpiresAt.IsZero() {
+			ttlLeft = FormatDuration(t.ExpiresAt.Sub(now))
+		}
+		session := t.SessionID
+		if session == "" {
+			session = "-"
+		}
+		conns, sent, received := "-", "-", "-"
+		if t.Stats != nil {
+			conns = fmt.Sprintf("%d/%d", t.Stats.ActiveConnections, t.Stats.TotalConnections)
+			sent = util.HumanizeBytesIEC(t.Stats.BytesSent)
+			received = util.HumanizeBytesIEC(t.Stats.BytesReceived)
+		}
+		rows = append(rows, []string{strconv.Itoa(t.LocalPort), strconv.Itoa(t.PID), t.Target(), session, age, ttlLeft, conns, sent, received})
+	}
+
+	p.PrintTable("SSH Tunnels", headers, rows)
+	return nil
+}
+
+// PrintSSHConfigHosts displays the Host entries written to the managed ssh config.
+func PrintSSHConfigHosts(hosts []SSHConfigHost, appCtx *app.ApplicationContext) error {
+	if len(hosts) == 0 {
+		fmt.Fprintln(appCtx.Stdout, "No active managed SSH sessions.")
+		return nil
+	}
+
+	now := time.Now()
+	headers := []string{"Host", "Target", "User", "Bastion", "TTL Left"}
+	rows := make([][]string, 0, len(hosts))
+	for _, h := range hosts {
+		target := h.TargetName
+		if target == "" {
+			target = h.HostName
+		}
+		ttlLeft := "-"
+		if !h.ExpiresAt.IsZero() {
+			ttlLeft = FormatDuration(h.ExpiresAt.Sub(now))
+		}
+		rows = append(rows, []string{h.Alias, fmt.Sprintf("%s (%s)", target, h.HostName), h.User, h.BastionName, ttlLeft})
+	}
+
+	printer.New(appCtx.Stdout).PrintTable("SSH Config Hosts", headers, rows)
+	return nil
+}
+
+// PrintReachabilityReport displays the findings of a reachability analysis in a table or JSON format.
+func PrintReachabilityReport(report *ReachabilityReport, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(report)
+	}
+
+	headers := []string{"Check", "Status", "Detail"}
+	rows := make([][]string, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		rows = append(rows, []string{f.Check, string(f.Status), f.Detail})
+	}
+	bastionAddr := report.BastionIP
+	if bastionAddr == "" {
+		bastionAddr = "unknown IP"
+	}
+	title := fmt.Sprintf("Reachability: %s (%s) -> %s:%d", report.BastionName, bastionAddr, report.TargetIP, report.TargetPort)
+	p.PrintTable(title, headers, rows)
+	return nil
+}
+
+// FormatDuration renders a duration rounded to the most significant units, e.g. "2h15m" or "45s".
+// Negative durations are reported as "expired".
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return "expired"
+	}
+	d = d.Round(time.Second)
+	h := int(d / time.Hour)
+	m := int((d % time.Hour) / time.Minute)
+	s := int((d % time.Minute) / time.Second)
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh%02dm", h, m)
+	case m > 0:
+		return fmt.Sprintf("%dm%02ds", m, s)
+	default:
+		return fmt.Sprintf("%ds", s)
+	}
+}
@@ -0,0 +1,194 @@
+package bastion
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrProfileNotFound is returned when no tunnel profile file exists for the requested name.
+var ErrProfileNotFound = errors.New("tunnel profile not found")
+
+// TunnelProfile is a set of port-forward tunnels that are brought up and down together.
+//
+//	bastion: prodbastion
+//	key: ~/.ssh/id_ed25519
+//	tunnels:
+//	  - target: adb:salesdb
+//	    local_port: 1521
+//	  - target: oke:prod-cluster
+//	    local_port: 6443
+type TunnelProfile struct {
+	Name    string          `yaml:"-" json:"name"`
+	Path    string          `yaml:"-" json:"path"`
+	Bastion string          `yaml:"bastion" json:"bastion"`
+	Key     string          `yaml:"key,omitempty" json:"key,omitempty"`
+	Tunnels []ProfileTunnel `yaml:"tunnels" json:"tunnels"`
+}
+
+// ProfileTunnel is one tunnel in a profile. The target uses the same "<kind>:<name|ocid>" form as
+// "bastion session create"; RemotePort 0 means the target's standard port and LocalPort 0 means the remote port.
+type ProfileTunnel struct {
+	Target     string `yaml:"target" json:"target"`
+	LocalPort  int    `yaml:"local_port,omitempty" json:"local_port,omitempty"`
+	RemotePort int    `yaml:"remote_port,omitempty" json:"remote_port,omitempty"`
+	// Bastion overrides the profile's bastion for this tunnel.
+	Bastion string `yaml:"bastion,omitempty" json:"bastion,omitempty"`
+}
+
+// BastionName returns the bastion the tunnel uses: its own override or the profile's default.
+func (t ProfileTunnel) BastionName(p *TunnelProfile) string {
+	if t.Bastion != "" {
+		return t.Bastion
+	}
+	return p.Bastion
+}
+
+// Ports returns the local and remote port of the tunnel to target, applying the defaults.
+func (t ProfileTunnel) Ports(target ResolvedTarget) (local, remote int) {
+	remote = t.RemotePort
+	if remote == 0 {
+		remote = target.Port
+	}
+	local = t.LocalPort
+	if local == 0 {
+		local = remote
+	}
+	return local, remote
+}
+
+// TunnelProfilesDir returns the directory holding tunnel profiles, ~/.oci/.ocloud/tunnel-profiles.
+func TunnelProfilesDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, flags.OCIConfigDirName, flags.OCloudDefaultDirName, flags.OCloudProfilesDirName), nil
+}
+
+// LoadTunnelProfile reads a profile by name from the profiles directory, or from a path when nameOrPath
+// contains a path separator or a .yaml/.yml extension.
+func LoadTunnelProfile(nameOrPath string) (*TunnelProfile, error) {
+	path, err := tunnelProfilePath(nameOrPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read tunnel profile: %w", err)
+	}
+
+	var p TunnelProfile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse tunnel profile %s: %w", path, err)
+	}
+	p.Path = path
+	p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("tunnel profile %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// tunnelProfilePath resolves a profile name to <profiles dir>/<name>.yaml (or .yml).
+func tunnelProfilePath(nameOrPath string) (string, error) {
+	nameOrPath = strings.TrimSpace(nameOrPath)
+	if nameOrPath == "" {
+		return "", fmt.Errorf("tunnel profile name is required")
+	}
+	ext := filepath.Ext(nameOrPath)
+	if strings.ContainsRune(nameOrPath, filepath.Separator) || ext == ".yaml" || ext == ".yml" {
+		return ExpandTilde(nameOrPath)
+	}
+
+	dir, err := TunnelProfilesDir()
+	if err != nil {
+		return "", fmt.Errorf("get tunnel profiles dir: %w", err)
+	}
+	for _, ext := range []string{".yaml", ".yml"} {
+		path := filepath.Join(dir, nameOrPath+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s (looked for %s.yaml in %s)", ErrProfileNotFound, nameOrPath, nameOrPath, dir)
+}
+
+// Validate checks that every tunnel has a target and a bastion and that local ports are valid and unique.
+// Default local ports depend on the resolved target, so they are checked against each other by the caller.
+func (p *TunnelProfile) Validate() error {
+	if len(p.Tunnels) == 0 {
+		return fmt.Errorf("no tunnels defined")
+	}
+	seen := make(map[int]string)
+	for i, t := range p.Tunnels {
+		if strings.TrimSpace(t.Target) == "" {
+			return fmt.Errorf("tunnel #%d: target is required", i+1)
+		}
+		if t.BastionName(p) == "" {
+			return fmt.Errorf("tunnel %s: bastion is required (set it for the profile or the tunnel)", t.Target)
+		}
+		for name, port := range map[string]int{"local_port": t.LocalPort, "remote_port": t.RemotePort} {
+			if port < 0 || port > 65535 {
+				return fmt.Errorf("tunnel %s: %s must be between 1 and 65535, got %d", t.Target, name, port)
+			}
+		}
+		if t.LocalPort != 0 && t.LocalPort < 1024 {
+			return fmt.Errorf("tunnel %s: local_port %d is privileged; choose a port >= 1024", t.Target, t.LocalPort)
+		}
+		if t.LocalPort == 0 {
+			continue
+		}
+		if other, ok := seen[t.LocalPort]; ok {
+			return fmt.Errorf("tunnels %s and %s both use local_port %d", other, t.Target, t.LocalPort)
+		}
+		seen[t.LocalPort] = t.Target
+	}
+	return nil
+}
+
+// ListTunnelProfiles returns the names of the profiles in the profiles directory, sorted.
+func ListTunnelProfiles() ([]string, error) {
+	dir, err := TunnelProfilesDir()
+	if err != nil {
+		return nil, fmt.Errorf("get tunnel profiles dir: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read tunnel profiles dir: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ext))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ProfileTunnels returns the active tunnels that were started from the named profile.
+func ProfileTunnels(profile string) ([]TunnelInfo, error) {
+	tunnels, err := ListTunnels()
+	if err != nil {
+		return nil, err
+	}
+	var matched []TunnelInfo
+	for _, t := range tunnels {
+		if t.Profile == profile {
+			matched = append(matched, t)
+		}
+	}
+	return matched, nil
+}
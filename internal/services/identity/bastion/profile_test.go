@@ -0,0 +1,113 @@
+package bastion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const morningProfile = `
+bastion: prodbastion
+key: ~/.ssh/id_ed25519
+tunnels:
+  - target: adb:salesdb
+    local_port: 1521
+  - target: heatwave:reporting
+    local_port: 3306
+  - target: cache:sessions
+    local_port: 16379
+    remote_port: 6379
+    bastion: cachebastion
+`
+
+// writeProfile writes a tunnel profile into the profiles directory under a temporary home.
+func writeProfile(t *testing.T, name, content string) string {
+	t.Helper()
+	dir, err := TunnelProfilesDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadTunnelProfile(t *testing.T) {
+	setupTunnelHome(t)
+	path := writeProfile(t, "morning.yaml", morningProfile)
+
+	p, err := LoadTunnelProfile("morning")
+	require.NoError(t, err)
+	assert.Equal(t, "morning", p.Name)
+	assert.Equal(t, path, p.Path)
+	assert.Equal(t, "prodbastion", p.Bastion)
+	require.Len(t, p.Tunnels, 3)
+	assert.Equal(t, "adb:salesdb", p.Tunnels[0].Target)
+	assert.Equal(t, 1521, p.Tunnels[0].LocalPort)
+	assert.Equal(t, "prodbastion", p.Tunnels[0].BastionName(p))
+	assert.Equal(t, "cachebastion", p.Tunnels[2].BastionName(p))
+	assert.Equal(t, 6379, p.Tunnels[2].RemotePort)
+
+	byPath, err := LoadTunnelProfile(path)
+	require.NoError(t, err)
+	assert.Equal(t, p.Tunnels, byPath.Tunnels)
+
+	_, err = LoadTunnelProfile("evening")
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+
+	names, err := ListTunnelProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"morning"}, names)
+}
+
+func TestTunnelProfileValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile TunnelProfile
+		wantErr string
+	}{
+		{"valid", TunnelProfile{Bastion: "b", Tunnels: []ProfileTunnel{{Target: "adb:x", LocalPort: 1521}, {Target: "oke:y"}}}, ""},
+		{"no tunnels", TunnelProfile{Bastion: "b"}, "no tunnels"},
+		{"missing target", TunnelProfile{Bastion: "b", Tunnels: []ProfileTunnel{{LocalPort: 1521}}}, "target is required"},
+		{"missing bastion", TunnelProfile{Tunnels: []ProfileTunnel{{Target: "adb:x"}}}, "bastion is required"},
+		{"privileged port", TunnelProfile{Bastion: "b", Tunnels: []ProfileTunnel{{Target: "lb:x", LocalPort: 443}}}, "privileged"},
+		{"port out of range", TunnelProfile{Bastion: "b", Tunnels: []ProfileTunnel{{Target: "adb:x", RemotePort: 70000}}}, "between 1 and 65535"},
+		{"duplicate local port", TunnelProfile{Bastion: "b", Tunnels: []ProfileTunnel{{Target: "adb:x", LocalPort: 1521}, {Target: "adb:y", LocalPort: 1521}}}, "both use local_port 1521"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestProfileTunnelPorts(t *testing.T) {
+	target := ResolvedTarget{Kind: KindADB, Port: 1521}
+
+	local, remote := ProfileTunnel{Target: "adb:x"}.Ports(target)
+	assert.Equal(t, []int{1521, 1521}, []int{local, remote}, "both default to the target's port")
+	local, remote = ProfileTunnel{Target: "adb:x", RemotePort: 1522}.Ports(target)
+	assert.Equal(t, []int{1522, 1522}, []int{local, remote}, "the local port defaults to the remote port")
+	local, remote = ProfileTunnel{Target: "adb:x", LocalPort: 15210}.Ports(target)
+	assert.Equal(t, []int{15210, 1521}, []int{local, remote})
+}
+
+func TestProfileTunnels(t *testing.T) {
+	setupTunnelHome(t)
+	require.NoError(t, SaveTunnelState(TunnelInfo{PID: os.Getpid(), LocalPort: 1521, Profile: "morning"}))
+	require.NoError(t, SaveTunnelState(TunnelInfo{PID: os.Getpid(), LocalPort: 3306}))
+	require.NoError(t, SaveTunnelState(TunnelInfo{PID: deadPID, LocalPort: 6443, Profile: "morning"}))
+
+	tunnels, err := ProfileTunnels("morning")
+	require.NoError(t, err)
+	require.Len(t, tunnels, 1)
+	assert.Equal(t, 1521, tunnels[0].LocalPort)
+}
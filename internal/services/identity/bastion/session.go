@@ -3,13 +3,13 @@ package bastion
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -23,15 +23,47 @@ import (
 var (
 	waitPollInterval = 3 * time.Second
 	defaultTTL       = 10800 // seconds (3 hours)
+
+	// minReuseRemaining is the minimum lifetime an ACTIVE session must have left to be reused.
+	// It is longer than keepAliveRenewBefore so a renewal always gets a fresh session.
+	minReuseRemaining = 10 * time.Minute
 )
 
 // TunnelInfo stores information about an active SSH tunnel
 type TunnelInfo struct {
-	PID       int       `json:"pid"`
-	LocalPort int       `json:"local_port"`
-	TargetIP  string    `json:"target_ip"`
-	StartedAt time.Time `json:"started_at"`
-	LogFile   string    `json:"log_file"`
+	PID        int       `json:"pid"`
+	LocalPort  int       `json:"local_port"`
+	TargetIP   string    `json:"target_ip"`
+	StartedAt  time.Time `json:"started_at"`
+	LogFile    string    `json:"log_file"`
+	SessionID  string    `json:"session_id,omitempty"`
+	BastionID  string    `json:"bastion_id,omitempty"`
+	TargetID   string    `json:"target_id,omitempty"`
+	TargetName string    `json:"target_name,omitempty"`
+	TargetKind string    `json:"target_kind,omitempty"`
+	RemotePort int       `json:"remote_port,omitempty"`
+	SessionTTL int       `json:"session_ttl_seconds,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at,omitzero"`
+	Profile    string    `json:"profile,omitempty"`
+
+	// Stats is read from the forwarder's stats file when tunnels are listed; it is not persisted.
+	Stats *ForwarderStats `json:"stats,omitempty"`
+}
+
+// Target returns a short human-readable description of the tunnel target,
+// preferring the resource name over the bare IP address.
+func (t TunnelInfo) Target() string {
+	target := t.TargetIP
+	if t.TargetName != "" {
+		target = t.TargetName
+		if t.TargetKind != "" {
+			target = t.TargetKind + " " + target
+		}
+	}
+	if t.RemotePort > 0 {
+		target = fmt.Sprintf("%s:%d", target, t.RemotePort)
+	}
+	return target
 }
 
 // getTunnelsDir returns the directory where tunnel state files are stored
@@ -69,118 +101,36 @@ func SaveTunnelState(tunnel TunnelInfo) error {
 	return nil
 }
 
-// GetActiveTunnels returns a list of currently active SSH tunnels
-// It checks both state files and running processes for backward compatibility
+// GetActiveTunnels returns the tracked tunnels whose forwarder process is still running.
+// State files of tunnels that are no longer running are removed.
 func GetActiveTunnels() ([]TunnelInfo, error) {
-	tunnelsMap := make(map[int]TunnelInfo)
-
-	// 1. First, load tunnels from state files
-	tunnelsDir, err := getTunnelsDir()
-	if err == nil {
-		if _, err := os.Stat(tunnelsDir); err == nil {
-			entries, err := os.ReadDir(tunnelsDir)
-			if err == nil {
-				for _, entry := range entries {
-					if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
-						continue
-					}
-
-					stateFile := filepath.Join(tunnelsDir, entry.Name())
-					data, err := os.ReadFile(stateFile)
-					if err != nil {
-						continue
-					}
-
-					var tunnel TunnelInfo
-					if err := json.Unmarshal(data, &tunnel); err != nil {
-						_ = os.Remove(stateFile)
-						continue
-					}
-
-					if isProcessRunning(tunnel.PID) {
-						tunnelsMap[tunnel.LocalPort] = tunnel
-					} else {
-						_ = os.Remove(stateFile)
-					}
-				}
-			}
-		}
-	}
-
-	// 2.Detect running SSH tunnels from a process list
-	// This catches tunnels created before state file tracking was implemented
-	cmd := exec.Command("pgrep", "-fl", "ssh")
-	out, err := cmd.CombinedOutput()
-	if err == nil {
-		lines := strings.Split(string(out), "\n")
-		for _, line := range lines {
-			// Look for SSH port forwarding: "ssh ... -N ... -L port:target:port ..."
-			if !strings.Contains(line, "-N") || !strings.Contains(line, "-L") {
-				continue
-			}
-			// Parse PID and local port
-			fields := strings.Fields(line)
-			if len(fields) < 2 {
-				continue
-			}
-
-			pid, err := strconv.Atoi(fields[0])
-			if err != nil {
-				continue
-			}
-
-			localPort := extractLocalPortFromSSHCommand(line)
-			if localPort == 0 {
-				continue
-			}
-
-			if _, exists := tunnelsMap[localPort]; !exists {
-				tunnelsMap[localPort] = TunnelInfo{
-					PID:       pid,
-					LocalPort: localPort,
-					TargetIP:  "unknown",
-					StartedAt: time.Time{},
-					LogFile:   "",
-				}
-			}
-		}
+	tunnels, err := loadTunnelStates()
+	if err != nil {
+		return nil, err
 	}
 
-	// Convert map to slice
 	var activeTunnels []TunnelInfo
-	for _, tunnel := range tunnelsMap {
+	for _, tunnel := range tunnels {
+		if !isProcessRunning(tunnel.PID) {
+			_ = RemoveTunnelState(tunnel.LocalPort)
+			continue
+		}
+		if stats, err := ReadTunnelStats(tunnel.LocalPort); err == nil {
+			tunnel.Stats = &stats
+		}
 		activeTunnels = append(activeTunnels, tunnel)
 	}
-
 	return activeTunnels, nil
 }
 
-// extractLocalPortFromSSHCommand parses an SSH command line to extract the local port from -L flag
-// Example: "-L 3306:10.0.0.156:3306" returns 3306
-func extractLocalPortFromSSHCommand(cmdLine string) int {
-	// Find the -L flag and extract the port
-	parts := strings.Fields(cmdLine)
-	for i, part := range parts {
-		if part == "-L" && i+1 < len(parts) {
-			// The next field should be "localport:host:remoteport"
-			portMapping := parts[i+1]
-			colonIdx := strings.Index(portMapping, ":")
-			if colonIdx > 0 {
-				portStr := portMapping[:colonIdx]
-				port, err := strconv.Atoi(portStr)
-				if err == nil {
-					return port
-				}
-			}
-		}
-	}
-	return 0
-}
-
 // isProcessRunning checks if a process with the given PID is running
 func isProcessRunning(pid int) bool {
-	cmd := exec.Command("ps", "-p", strconv.Itoa(pid), "-o", "pid=")
-	return cmd.Run() == nil
+	if pid <= 0 {
+		return false
+	}
+	// Signal 0 performs the existence and permission checks without delivering a signal.
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
 }
 
 // RemoveTunnelState removes the state file for a tunnel on a given port
@@ -190,6 +140,7 @@ func RemoveTunnelState(localPort int) error {
 		return err
 	}
 
+	_ = os.Remove(filepath.Join(tunnelsDir, fmt.Sprintf("tunnel-%d.stats", localPort)))
 	stateFile := filepath.Join(tunnelsDir, fmt.Sprintf("tunnel-%d.json", localPort))
 	return os.Remove(stateFile)
 }
@@ -227,6 +178,16 @@ func (s *Service) waitForSessionActive(ctx context.Context, sessionID string) er
 	}
 }
 
+// sessionReusable reports whether a session has at least minReuseRemaining of its TTL left.
+// Sessions that do not report their creation time or TTL are assumed reusable.
+func sessionReusable(sess bastion.Session, now time.Time) bool {
+	if sess.TimeCreated == nil || sess.SessionTtlInSeconds == nil {
+		return true
+	}
+	expiresAt := sess.TimeCreated.Add(time.Duration(*sess.SessionTtlInSeconds) * time.Second)
+	return expiresAt.Sub(now) > minReuseRemaining
+}
+
 // readPublicKey reads and returns the public key content from the given path.
 func readPublicKey(publicKeyPath string) (string, error) {
 	data, err := os.ReadFile(publicKeyPath)
@@ -272,7 +233,8 @@ func (s *Service) EnsurePortForwardSession(ctx context.Context, bastionID, targe
 				if err != nil {
 					return "", fmt.Errorf("getting bastion session: %w", err)
 				}
-				if getResp.KeyDetails != nil && getResp.KeyDetails.PublicKeyContent != nil && *getResp.KeyDetails.PublicKeyContent == pubKey {
+				if getResp.KeyDetails != nil && getResp.KeyDetails.PublicKeyContent != nil && *getResp.KeyDetails.PublicKeyContent == pubKey &&
+					sessionReusable(getResp.Session, time.Now()) {
 					return *item.Id, nil // Reuse
 				}
 			}
@@ -385,15 +347,6 @@ func bastionHost(sessionID, region string) string {
 	return fmt.Sprintf("host.bastion.%s.oci.%s.com", region, bastionRealm(sessionID))
 }
 
-// BuildSCPCommand constructs an SCP command that tunnels through the bastion Managed SSH session.
-// It uses the same ProxyCommand technique as BuildManagedSSHCommand.
-func BuildSCPCommand(privateKeyPath, sessionID, region, targetIP, targetUser, localFile, remotePath string) string {
-	host := bastionHost(sessionID, region)
-	proxy := fmt.Sprintf("ssh -i %s %s -W %%h:%%p -p 22 %s@%s", privateKeyPath, bastionHostKeyOpts, sessionID, host)
-	return fmt.Sprintf("scp -i %s %s -o ProxyCommand=\"%s\" -P 22 %s %s@%s:%s",
-		privateKeyPath, bastionHostKeyOpts, proxy, localFile, targetUser, targetIP, remotePath)
-}
-
 // BuildSCPDownloadCommand constructs an SCP command that downloads a file or directory
 // from a remote instance through the bastion Managed SSH session.
 // The -r flag enables recursive copy so both files and directories work.
@@ -414,33 +367,8 @@ func BuildManagedSSHCommand(privateKeyPath, sessionID, region, targetIP, targetU
 		privateKeyPath, bastionHostKeyOpts, proxy, targetUser, targetIP)
 }
 
-// BuildPortForwardArgs constructs SSH command arguments for establishing a secure port-forwarding tunnel.
-// It handles path expansion for the private key, determines the correct realm domain, and formats connection options.
-func BuildPortForwardArgs(privateKeyPath, sessionID, region, targetIP string, localPort, remotePort int) ([]string, error) {
-	key, err := expandTilde(privateKeyPath)
-	if err != nil {
-		return nil, fmt.Errorf("expand key path: %w", err)
-	}
-
-	host := bastionHost(sessionID, region)
-	bastionUser := fmt.Sprintf("%s@%s", sessionID, host)
-
-	args := []string{
-		"-i", key,
-		"-o", "StrictHostKeyChecking=no",
-		"-o", "UserKnownHostsFile=/dev/null",
-		"-o", "ServerAliveInterval=30",
-		"-o", "ServerAliveCountMax=3",
-		"-N",
-		"-L", fmt.Sprintf("%d:%s:%d", localPort, targetIP, remotePort),
-		"-p", "22",
-		bastionUser,
-	}
-	return args, nil
-}
-
-// expandTilde resolves paths beginning with "~" to the current user's home directory, returning the expanded path or an error.
-func expandTilde(p string) (string, error) {
+// ExpandTilde resolves paths beginning with "~" to the current user's home directory, returning the expanded path or an error.
+func ExpandTilde(p string) (string, error) {
 	if strings.HasPrefix(p, "~") {
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -451,59 +379,71 @@ func expandTilde(p string) (string, error) {
 	return p, nil
 }
 
-// SpawnDetached starts ssh in the background, detaches from your process, and returns its PID and log file path.
-// localPort is used to generate a unique log file name.
-func SpawnDetached(args []string, localPort int, targetIP string) (int, string, error) {
-	sshPath, err := exec.LookPath("ssh")
-	if err != nil {
-		return 0, "", fmt.Errorf("ssh not found in PATH: %w", err)
-	}
-
+// SpawnDetached starts the port forwarder in the background, detaches it from your process, and returns its PID and log file path.
+// cfg.LocalPort is used to generate a unique log file name.
+func SpawnDetached(cfg ForwarderConfig) (int, string, error) {
 	// Create a log directory
-	homeDir, err := os.UserHomeDir()
+	logDir, err := getLogsDir()
 	if err != nil {
 		return 0, "", fmt.Errorf("get home dir: %w", err)
 	}
-	profile := os.Getenv(flags.EnvKeyProfile)
-	logDir := filepath.Join(homeDir, flags.OCIConfigDirName, flags.OCISessionsDirName, profile, "logs")
 	if err := os.MkdirAll(logDir, 0o755); err != nil {
 		return 0, "", fmt.Errorf("create log dir: %w", err)
 	}
 
 	// Create a unique log file with a timestamp
 	timestamp := time.Now().Format("20060102-150405")
-	logfile := filepath.Join(logDir, fmt.Sprintf("ssh-tunnel-%d-%s.log", localPort, timestamp))
+	logfile := filepath.Join(logDir, fmt.Sprintf("ssh-tunnel-%d-%s.log", cfg.LocalPort, timestamp))
+
+	pid, err := SpawnDetachedToLog(cfg, logfile)
+	if err != nil {
+		return 0, "", err
+	}
+	return pid, logfile, nil
+}
+
+// SpawnDetachedToLog starts the port forwarder in the background like SpawnDetached, appending its output to an existing log file.
+// It is used when a tunnel is restarted so that all of its history stays in one log.
+// The forwarder runs in a copy of the current executable, so no external ssh binary is needed.
+func SpawnDetachedToLog(cfg ForwarderConfig, logfile string) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("locate ocloud executable: %w", err)
+	}
+	config, err := json.Marshal(cfg)
+	if err != nil {
+		return 0, fmt.Errorf("marshal forwarder config: %w", err)
+	}
 
 	f, err := os.OpenFile(logfile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
 	if err != nil {
-		return 0, "", fmt.Errorf("open log file: %w", err)
+		return 0, fmt.Errorf("open log file: %w", err)
 	}
 	defer f.Close()
 
 	// Write header to a log file
-	header := fmt.Sprintf("=== SSH Tunnel Started ===\nTimestamp: %s\nLocal Port: %d\nTarget: %s\nSSH Command: %s %s\n\n",
+	header := fmt.Sprintf("=== SSH Tunnel Started ===\nTimestamp: %s\nLocal Port: %d\nTarget: %s\nBastion: %s\nSession: %s\n\n",
 		time.Now().Format(time.RFC3339),
-		localPort,
-		targetIP,
-		sshPath,
-		strings.Join(args, " "))
+		cfg.LocalPort,
+		cfg.targetAddr(),
+		cfg.bastionAddr(),
+		cfg.SessionID)
 	_, _ = f.WriteString(header)
 
-	verboseArgs := append([]string{"-v"}, args...)
-
-	cmd := exec.Command(sshPath, verboseArgs...)
+	cmd := exec.Command(exe, ForwarderCommand)
+	cmd.Env = append(os.Environ(), forwarderConfigEnv+"="+string(config))
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true} // detach from our session/TTY
 	cmd.Stdout = f
 	cmd.Stderr = f
 	cmd.Stdin = nil
 
 	if err := cmd.Start(); err != nil {
-		return 0, "", fmt.Errorf("start ssh: %w", err)
+		return 0, fmt.Errorf("start forwarder: %w", err)
 	}
 	pid := cmd.Process.Pid
 	_ = cmd.Process.Release()
 
-	return pid, logfile, nil
+	return pid, nil
 }
 
 // WaitForListen wait until the localPort is listening (nice UX).
@@ -521,82 +461,3 @@ func WaitForListen(localPort int, timeout time.Duration) error {
 	}
 	return fmt.Errorf("tunnel not up on %s after %s", addr, timeout)
 }
-
-// ValidateSudoPassword validates the sudo password by running `sudo -S -v`.
-func ValidateSudoPassword(password string) error {
-	// Run a simple sudo command to validate credentials
-	cmd := exec.Command("sudo", "-S", "-v")
-	cmd.Stdin = strings.NewReader(password + "\n")
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("sudo authentication failed: %w", err)
-	}
-	return nil
-}
-
-// SpawnDetachedWithSudo starts ssh with sudo in the background using the provided password.
-func SpawnDetachedWithSudo(args []string, localPort int, targetIP string, sudoPassword string) (int, string, error) {
-	sudoPath, err := exec.LookPath("sudo")
-	if err != nil {
-		return 0, "", fmt.Errorf("sudo not found in PATH: %w", err)
-	}
-
-	sshPath, err := exec.LookPath("ssh")
-	if err != nil {
-		return 0, "", fmt.Errorf("ssh not found in PATH: %w", err)
-	}
-
-	// Create a log directory
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return 0, "", fmt.Errorf("get home dir: %w", err)
-	}
-	profile := os.Getenv(flags.EnvKeyProfile)
-	logDir := filepath.Join(homeDir, flags.OCIConfigDirName, flags.OCISessionsDirName, profile, "logs")
-	if err := os.MkdirAll(logDir, 0o755); err != nil {
-		return 0, "", fmt.Errorf("create log dir: %w", err)
-	}
-
-	// Create a unique log file with a timestamp
-	timestamp := time.Now().Format("20060102-150405")
-	logfile := filepath.Join(logDir, fmt.Sprintf("ssh-tunnel-%d-%s.log", localPort, timestamp))
-
-	f, err := os.OpenFile(logfile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
-	if err != nil {
-		return 0, "", fmt.Errorf("open log file: %w", err)
-	}
-	defer f.Close()
-
-	// Write header to a log file
-	header := fmt.Sprintf("=== SSH Tunnel Started (SUDO) ===\nTimestamp: %s\nLocal Port: %d\nTarget: %s\nSSH Command: sudo %s %s\n\n",
-		time.Now().Format(time.RFC3339),
-		localPort,
-		targetIP,
-		sshPath,
-		strings.Join(args, " "))
-	_, _ = f.WriteString(header)
-
-	// Add -v to args
-	verboseArgs := append([]string{"-v"}, args...)
-
-	// Construct sudo command: sudo -S -p '' ssh -v ...
-	// -S: read password from stdin
-	// -p '': no prompt
-	sudoArgs := append([]string{"-S", "-p", "", sshPath}, verboseArgs...)
-
-	cmd := exec.Command(sudoPath, sudoArgs...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true} // detach from our session/TTY
-	cmd.Stdout = f
-	cmd.Stderr = f
-
-	// Pass password via stdin
-	cmd.Stdin = strings.NewReader(sudoPassword + "\n")
-
-	if err := cmd.Start(); err != nil {
-		return 0, "", fmt.Errorf("start sudo ssh: %w", err)
-	}
-	pid := cmd.Process.Pid
-	_ = cmd.Process.Release()
-
-	return pid, logfile, nil
-}
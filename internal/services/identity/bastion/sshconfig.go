@@ -0,0 +1,405 @@
+package bastion
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/bastion"
+)
+
+// sshConfigHeader starts every generated ssh config file. The content after it is fully
+// determined by the active sessions, so regenerating with no session changes is a no-op.
+const sshConfigHeader = `# Managed by ocloud (ocloud identity bastion ssh-config). Do not edit: changes are overwritten.
+# Include it from ~/.ssh/config with: Include config.d/ocloud
+`
+
+// SSHConfigHost is one Host entry in the generated ssh config.
+type SSHConfigHost struct {
+	Alias        string    `json:"alias"`
+	HostName     string    `json:"host_name"`
+	User         string    `json:"user"`
+	Port         int       `json:"port"`
+	IdentityFile string    `json:"identity_file"`
+	HostKeyAlias string    `json:"host_key_alias"`
+	ProxyCommand string    `json:"proxy_command"`
+	SessionID    string    `json:"session_id"`
+	BastionName  string    `json:"bastion_name"`
+	TargetName   string    `json:"target_name"`
+	ExpiresAt    time.Time `json:"expires_at,omitzero"`
+}
+
+// managedSSHSession is the subset of an active managed SSH session needed for an ssh config entry.
+type managedSSHSession struct {
+	SessionID   string
+	BastionName string
+	TargetID    string
+	TargetName  string
+	TargetIP    string
+	User        string
+	Port        int
+	PublicKey   string
+	ExpiresAt   time.Time
+}
+
+// DefaultSSHConfigPath returns the path of the managed ssh config include, ~/.ssh/config.d/ocloud.
+func DefaultSSHConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ssh", "config.d", "ocloud"), nil
+}
+
+// ManagedSSHHosts returns an ssh config entry for every ACTIVE managed SSH session on the given bastions.
+// The identity file is the local private key whose .pub matches the session's public key, or fallbackKey.
+func (s *Service) ManagedSSHHosts(ctx context.Context, bastions []Bastion, region, fallbackKey string) ([]SSHConfigHost, error) {
+	var sessions []managedSSHSession
+	for _, b := range bastions {
+		items, err := s.listActiveSessions(ctx, b.OCID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			trd, ok := item.TargetResourceDetails.(bastion.ManagedSshSessionTargetResourceDetails)
+			if !ok || item.Id == nil || trd.TargetResourcePrivateIpAddress == nil || trd.TargetResourceOperatingSystemUserName == nil {
+				continue
+			}
+			resp, err := s.bastionClient.GetSession(ctx, bastion.GetSessionRequest{SessionId: item.Id})
+			if err != nil {
+				return nil, fmt.Errorf("getting bastion session: %w", err)
+			}
+			sess := managedSSHSession{
+				SessionID:   *item.Id,
+				BastionName: b.DisplayName,
+				TargetIP:    *trd.TargetResourcePrivateIpAddress,
+				User:        *trd.TargetResourceOperatingSystemUserName,
+				Port:        22,
+			}
+			if trd.TargetResourceId != nil {
+				sess.TargetID = *trd.TargetResourceId
+			}
+			if trd.TargetResourceDisplayName != nil {
+				sess.TargetName = *trd.TargetResourceDisplayName
+			}
+			if trd.TargetResourcePort != nil {
+				sess.Port = *trd.TargetResourcePort
+			}
+			if resp.KeyDetails != nil && resp.KeyDetails.PublicKeyContent != nil {
+				sess.PublicKey = *resp.KeyDetails.PublicKeyContent
+			}
+			if item.TimeCreated != nil && item.SessionTtlInSeconds != nil {
+				sess.ExpiresAt = item.TimeCreated.Add(time.Duration(*item.SessionTtlInSeconds) * time.Second)
+			}
+			sessions = append(sessions, sess)
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("get home dir: %w", err)
+	}
+	sshDir := filepath.Join(home, ".ssh")
+	return newSSHConfigHosts(sessions, region, func(pub string) string {
+		if key := findPrivateKeyFor(sshDir, pub); key != "" {
+			return key
+		}
+		return fallbackKey
+	}), nil
+}
+
+// newSSHConfigHosts turns sessions into ssh config entries, one per target and user, sorted by alias.
+// When several sessions cover the same target and user, the one that expires last is used.
+func newSSHConfigHosts(sessions []managedSSHSession, region string, identityFor func(publicKey string) string) []SSHConfigHost {
+	targetOf := func(sess managedSSHSession) string {
+		if sess.TargetID != "" {
+			return sess.TargetID
+		}
+		return sess.TargetIP
+	}
+	nameOf := func(sess managedSSHSession) string {
+		if sess.TargetName != "" {
+			return sess.TargetName
+		}
+		return sess.TargetIP
+	}
+
+	latest := make(map[string]managedSSHSession)
+	for _, sess := range sessions {
+		key := targetOf(sess) + "|" + sess.User
+		if cur, ok := latest[key]; !ok || sess.ExpiresAt.After(cur.ExpiresAt) {
+			latest[key] = sess
+		}
+	}
+	unique := make([]managedSSHSession, 0, len(latest))
+	for _, sess := range latest {
+		unique = append(unique, sess)
+	}
+	// Assign aliases in a stable order so that renewed sessions keep their Host names.
+	sort.Slice(unique, func(i, j int) bool {
+		a, b := unique[i], unique[j]
+		if nameOf(a) != nameOf(b) {
+			return nameOf(a) < nameOf(b)
+		}
+		if a.User != b.User {
+			return a.User < b.User
+		}
+		return targetOf(a) < targetOf(b)
+	})
+
+	aliases := make(map[string]bool)
+	hosts := make([]SSHConfigHost, 0, len(unique))
+	for _, sess := range unique {
+		name := nameOf(sess)
+		alias := sshHostAlias(name)
+		if aliases[alias] {
+			alias = sshHostAlias(name + "-" + sess.User)
+		}
+		for i := 2; aliases[alias]; i++ {
+			alias = sshHostAlias(fmt.Sprintf("%s-%s-%d", name, sess.User, i))
+		}
+		aliases[alias] = true
+
+		identity := identityFor(sess.PublicKey)
+		hosts = append(hosts, SSHConfigHost{
+			Alias:        alias,
+			HostName:     sess.TargetIP,
+			User:         sess.User,
+			Port:         sess.Port,
+			IdentityFile: identity,
+			HostKeyAlias: targetOf(sess),
+			ProxyCommand: buildProxyCommand(identity, sess.SessionID, region),
+			SessionID:    sess.SessionID,
+			BastionName:  sess.BastionName,
+			TargetName:   sess.TargetName,
+			ExpiresAt:    sess.ExpiresAt,
+		})
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Alias < hosts[j].Alias })
+	return hosts
+}
+
+var nonAliasChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// sshHostAlias returns an ssh Host pattern for name, e.g. "ocloud-web-1".
+func sshHostAlias(name string) string {
+	clean := strings.Trim(nonAliasChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	return "ocloud-" + clean
+}
+
+// buildProxyCommand returns the ProxyCommand that opens a direct-tcpip channel through the bastion session.
+func buildProxyCommand(identityFile, sessionID, region string) string {
+	return fmt.Sprintf("ssh -i %s %s -W %%h:%%p -p 22 %s@%s",
+		quoteSSHConfigValue(identityFile), bastionHostKeyOpts, sessionID, bastionHost(sessionID, region))
+}
+
+// quoteSSHConfigValue wraps values containing spaces in double quotes.
+func quoteSSHConfigValue(v string) string {
+	if strings.ContainsAny(v, " \t") {
+		return `"` + v + `"`
+	}
+	return v
+}
+
+// findPrivateKeyFor returns the private key in dir whose ".pub" file holds publicKey, ignoring comments.
+func findPrivateKeyFor(dir, publicKey string) string {
+	want := authorizedKeyBody(publicKey)
+	if want == "" {
+		return ""
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.pub"))
+	if err != nil {
+		return ""
+	}
+	sort.Strings(matches)
+	for _, pubPath := range matches {
+		data, err := os.ReadFile(pubPath)
+		if err != nil || authorizedKeyBody(string(data)) != want {
+			continue
+		}
+		priv := strings.TrimSuffix(pubPath, ".pub")
+		if _, err := os.Stat(priv); err == nil {
+			return priv
+		}
+	}
+	return ""
+}
+
+// authorizedKeyBody returns the "type base64" part of an authorized_keys line.
+func authorizedKeyBody(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return ""
+	}
+	return fields[0] + " " + fields[1]
+}
+
+// RenderSSHConfig renders the managed ssh config file for hosts.
+func RenderSSHConfig(hosts []SSHConfigHost) string {
+	var b strings.Builder
+	b.WriteString(sshConfigHeader)
+	for _, h := range hosts {
+		b.WriteString("\n")
+		target := h.TargetName
+		if target == "" {
+			target = h.HostName
+		}
+		fmt.Fprintf(&b, "# %s via %s, session %s", target, h.BastionName, h.SessionID)
+		if !h.ExpiresAt.IsZero() {
+			fmt.Fprintf(&b, ", expires %s", h.ExpiresAt.UTC().Format(time.RFC3339))
+		}
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "Host %s\n", h.Alias)
+		fmt.Fprintf(&b, "  HostName %s\n", h.HostName)
+		fmt.Fprintf(&b, "  User %s\n", h.User)
+		fmt.Fprintf(&b, "  Port %d\n", h.Port)
+		if h.IdentityFile != "" {
+			fmt.Fprintf(&b, "  IdentityFile %s\n", quoteSSHConfigValue(h.IdentityFile))
+			b.WriteString("  IdentitiesOnly yes\n")
+		}
+		fmt.Fprintf(&b, "  HostKeyAlias %s\n", h.HostKeyAlias)
+		fmt.Fprintf(&b, "  ProxyCommand %s\n", h.ProxyCommand)
+	}
+	return b.String()
+}
+
+// sshConfigComment matches the comment RenderSSHConfig writes above every Host entry. Bastion names have no spaces.
+var sshConfigComment = regexp.MustCompile(`^# (.*) via (\S+), session (\S+?)(?:, expires (\S+))?$`)
+
+// ParseSSHConfig reads back the Host entries of a file written by RenderSSHConfig. Lines it does not know are ignored.
+func ParseSSHConfig(content string) []SSHConfigHost {
+	var hosts []SSHConfigHost
+	var cur *SSHConfigHost
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if m := sshConfigComment.FindStringSubmatch(line); m != nil {
+			hosts = append(hosts, SSHConfigHost{TargetName: m[1], BastionName: m[2], SessionID: m[3]})
+			cur = &hosts[len(hosts)-1]
+			if t, err := time.Parse(time.RFC3339, m[4]); err == nil {
+				cur.ExpiresAt = t
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, " ")
+		if !ok || cur == nil {
+			continue
+		}
+		switch key {
+		case "Host":
+			cur.Alias = value
+		case "HostName":
+			cur.HostName = value
+		case "User":
+			cur.User = value
+		case "Port":
+			cur.Port, _ = strconv.Atoi(value)
+		case "IdentityFile":
+			cur.IdentityFile = strings.Trim(value, `"`)
+		case "HostKeyAlias":
+			cur.HostKeyAlias = value
+		case "ProxyCommand":
+			cur.ProxyCommand = value
+		}
+	}
+	return slices.DeleteFunc(hosts, func(h SSHConfigHost) bool { return h.Alias == "" })
+}
+
+// ReadSSHConfigHosts returns the Host entries of the managed ssh config at path, or none when it does not exist.
+func ReadSSHConfigHosts(path string) ([]SSHConfigHost, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read ssh config: %w", err)
+	}
+	return ParseSSHConfig(string(data)), nil
+}
+
+// MergeSSHConfigHosts adds the entries of kept that have not expired at now to hosts, sorted by alias. Kept
+// entries keep their alias; a host whose alias is already taken gets a numbered one.
+func MergeSSHConfigHosts(hosts, kept []SSHConfigHost, now time.Time) []SSHConfigHost {
+	aliases := make(map[string]bool)
+	merged := make([]SSHConfigHost, 0, len(hosts)+len(kept))
+	for _, h := range kept {
+		if (!h.ExpiresAt.IsZero() && !h.ExpiresAt.After(now)) || aliases[h.Alias] {
+			continue
+		}
+		aliases[h.Alias] = true
+		merged = append(merged, h)
+	}
+	for _, h := range hosts {
+		alias := h.Alias
+		for i := 2; aliases[alias]; i++ {
+			alias = fmt.Sprintf("%s-%d", h.Alias, i)
+		}
+		aliases[alias] = true
+		h.Alias = alias
+		merged = append(merged, h)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Alias < merged[j].Alias })
+	return merged
+}
+
+// WriteSSHConfig writes content to path if it differs from what is already there and reports whether it changed.
+// The file is replaced atomically so ssh never reads a partially written config.
+func WriteSSHConfig(path, content string) (bool, error) {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, []byte(content)) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return false, fmt.Errorf("create ssh config dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".ocloud-ssh-config-*")
+	if err != nil {
+		return false, fmt.Errorf("create temp ssh config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(content); err != nil {
+		_ = tmp.Close()
+		return false, fmt.Errorf("write ssh config: %w", err)
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return false, fmt.Errorf("chmod ssh config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return false, fmt.Errorf("close ssh config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, fmt.Errorf("replace ssh config: %w", err)
+	}
+	return true, nil
+}
+
+// SSHConfigIncluded reports whether the user's ~/.ssh/config includes the managed file at managedPath.
+func SSHConfigIncluded(managedPath string) bool {
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(managedPath)), "config"))
+	if err != nil {
+		return false
+	}
+	rel := filepath.Join(filepath.Base(filepath.Dir(managedPath)), filepath.Base(managedPath))
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "Include") {
+			continue
+		}
+		for _, pattern := range fields[1:] {
+			pattern = strings.TrimPrefix(pattern, "~/.ssh/")
+			if ok, _ := filepath.Match(pattern, rel); ok || pattern == managedPath {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package bastion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSSHConfigHosts(t *testing.T) {
+	expires := time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)
+	sessions := []managedSSHSession{
+		{SessionID: "ocid1.bastionsession.oc1.iad.old", BastionName: "prod", TargetID: "ocid1.instance.oc1..web1", TargetName: "Web 1", TargetIP: "10.0.0.5", User: "opc", Port: 22, ExpiresAt: expires.Add(-time.Hour)},
+		{SessionID: "ocid1.bastionsession.oc1.iad.new", BastionName: "prod", TargetID: "ocid1.instance.oc1..web1", TargetName: "Web 1", TargetIP: "10.0.0.5", User: "opc", Port: 22, ExpiresAt: expires},
+		{SessionID: "ocid1.bastionsession.oc1.iad.root", BastionName: "prod", TargetID: "ocid1.instance.oc1..web1", TargetName: "Web 1", TargetIP: "10.0.0.5", User: "root", Port: 22, ExpiresAt: expires},
+		{SessionID: "ocid1.bastionsession.oc1.iad.db", BastionName: "prod", TargetID: "ocid1.instance.oc1..db", TargetName: "db", TargetIP: "10.0.1.7", User: "opc", Port: 2222, ExpiresAt: expires},
+	}
+	identity := func(string) string { return "/home/u/.ssh/id_ed25519" }
+
+	hosts := newSSHConfigHosts(sessions, "us-ashburn-1", identity)
+	require.Len(t, hosts, 3)
+	assert.Equal(t, []string{"ocloud-db", "ocloud-web-1", "ocloud-web-1-root"}, []string{hosts[0].Alias, hosts[1].Alias, hosts[2].Alias})
+
+	web := hosts[1]
+	assert.Equal(t, "ocid1.bastionsession.oc1.iad.new", web.SessionID, "latest-expiring session wins")
+	assert.Equal(t, "ocid1.instance.oc1..web1", web.HostKeyAlias)
+	assert.Equal(t, "ssh -i /home/u/.ssh/id_ed25519 -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null -W %h:%p -p 22 ocid1.bastionsession.oc1.iad.new@host.bastion.us-ashburn-1.oci.oraclecloud.com", web.ProxyCommand)
+	assert.Equal(t, 2222, hosts[0].Port)
+
+	// Order of the input does not change the result.
+	reversed := []managedSSHSession{sessions[3], sessions[2], sessions[1], sessions[0]}
+	assert.Equal(t, hosts, newSSHConfigHosts(reversed, "us-ashburn-1", identity))
+}
+
+func TestRenderSSHConfig(t *testing.T) {
+	hosts := []SSHConfigHost{{
+		Alias: "ocloud-web-1", HostName: "10.0.0.5", User: "opc", Port: 22,
+		IdentityFile: "/home/u/my keys/id_ed25519", HostKeyAlias: "ocid1.instance.oc1..web1",
+		ProxyCommand: "ssh -W %h:%p bastion", SessionID: "ocid1.bastionsession.oc1..s",
+		BastionName: "prod", TargetName: "web-1", ExpiresAt: time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC),
+	}}
+
+	want := sshConfigHeader + `
+# web-1 via prod, session ocid1.bastionsession.oc1..s, expires 2024-01-01T15:00:00Z
+Host ocloud-web-1
+  HostName 10.0.0.5
+  User opc
+  Port 22
+  IdentityFile "/home/u/my keys/id_ed25519"
+  IdentitiesOnly yes
+  HostKeyAlias ocid1.instance.oc1..web1
+  ProxyCommand ssh -W %h:%p bastion
+`
+	assert.Equal(t, want, RenderSSHConfig(hosts))
+	assert.Equal(t, sshConfigHeader, RenderSSHConfig(nil))
+}
+
+func TestParseSSHConfig_RoundTrip(t *testing.T) {
+	hosts := []SSHConfigHost{
+		{Alias: "ocloud-db", HostName: "10.0.1.7", User: "opc", Port: 2222, HostKeyAlias: "10.0.1.7",
+			ProxyCommand: "ssh -W %h:%p b", SessionID: "ocid1.bastionsession.oc1..db", BastionName: "dev", TargetName: "db"},
+		{Alias: "ocloud-web-1", HostName: "10.0.0.5", User: "opc", Port: 22, IdentityFile: "/home/u/my keys/id_ed25519",
+			HostKeyAlias: "ocid1.instance.oc1..web1", ProxyCommand: "ssh -W %h:%p a", SessionID: "ocid1.bastionsession.oc1..s",
+			BastionName: "prod", TargetName: "web-1 via proxy", ExpiresAt: time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)},
+	}
+	assert.Equal(t, hosts, ParseSSHConfig(RenderSSHConfig(hosts)))
+	assert.Empty(t, ParseSSHConfig(sshConfigHeader))
+}
+
+func TestMergeSSHConfigHosts(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	kept := []SSHConfigHost{
+		{Alias: "ocloud-web-1", BastionName: "dev", ExpiresAt: now.Add(time.Hour)},
+		{Alias: "ocloud-old", BastionName: "dev", ExpiresAt: now.Add(-time.Minute)},
+	}
+	hosts := []SSHConfigHost{
+		{Alias: "ocloud-db", BastionName: "prod"},
+		{Alias: "ocloud-web-1", BastionName: "prod"},
+	}
+
+	merged := MergeSSHConfigHosts(hosts, kept, now)
+	var got []string
+	for _, h := range merged {
+		got = append(got, h.BastionName+":"+h.Alias)
+	}
+	assert.Equal(t, []string{"prod:ocloud-db", "dev:ocloud-web-1", "prod:ocloud-web-1-2"}, got, "expired entries are dropped, kept aliases are stable")
+}
+
+func TestReadSSHConfigHosts_Missing(t *testing.T) {
+	hosts, err := ReadSSHConfigHosts(filepath.Join(t.TempDir(), "ocloud"))
+	require.NoError(t, err)
+	assert.Empty(t, hosts)
+}
+
+func TestWriteSSHConfig_Idempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ssh", "config.d", "ocloud")
+
+	changed, err := WriteSSHConfig(path, "Host a\n")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = WriteSSHConfig(path, "Host a\n")
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = WriteSSHConfig(path, "Host b\n")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Host b\n", string(data))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temp files left behind")
+}
+
+func TestFindPrivateKeyFor(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "id_rsa.pub"), []byte("ssh-rsa AAAArsa user@host\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "id_rsa"), []byte("private"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "orphan.pub"), []byte("ssh-ed25519 AAAAorphan\n"), 0o644))
+
+	assert.Equal(t, filepath.Join(dir, "id_rsa"), findPrivateKeyFor(dir, "ssh-rsa AAAArsa other-comment"))
+	assert.Empty(t, findPrivateKeyFor(dir, "ssh-ed25519 AAAAorphan"), "public key without a private key")
+	assert.Empty(t, findPrivateKeyFor(dir, "ssh-ed25519 AAAAunknown"))
+	assert.Empty(t, findPrivateKeyFor(dir, ""))
+}
+
+func TestSSHConfigIncluded(t *testing.T) {
+	sshDir := filepath.Join(t.TempDir(), ".ssh")
+	managed := filepath.Join(sshDir, "config.d", "ocloud")
+	require.NoError(t, os.MkdirAll(filepath.Dir(managed), 0o700))
+
+	assert.False(t, SSHConfigIncluded(managed), "no ~/.ssh/config")
+
+	tests := []struct {
+		config   string
+		included bool
+	}{
+		{config: "Include config.d/ocloud\n", included: true},
+		{config: "include config.d/*\nHost *\n", included: true},
+		{config: "Include ~/.ssh/config.d/ocloud\n", included: true},
+		{config: "Host *\n  User opc\n", included: false},
+	}
+	for _, tt := range tests {
+		require.NoError(t, os.WriteFile(filepath.Join(sshDir, "config"), []byte(tt.config), 0o600))
+		assert.Equal(t, tt.included, SSHConfigIncluded(managed), tt.config)
+	}
+}
+
+func TestSSHHostAlias(t *testing.T) {
+	assert.Equal(t, "ocloud-web-1", sshHostAlias("Web 1"))
+	assert.Equal(t, "ocloud-10.0.0.5", sshHostAlias("10.0.0.5"))
+	assert.Equal(t, "ocloud-app_server-prod", sshHostAlias("app_server (prod)"))
+}
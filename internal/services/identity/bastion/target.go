@@ -0,0 +1,339 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	ociInst "github.com/rozdolsky33/ocloud/internal/oci/compute/instance"
+	ociOke "github.com/rozdolsky33/ocloud/internal/oci/compute/oke"
+	ociadb "github.com/rozdolsky33/ocloud/internal/oci/database/autonomousdb"
+	ocicache "github.com/rozdolsky33/ocloud/internal/oci/database/cacheclusterdb"
+	ocihwdb "github.com/rozdolsky33/ocloud/internal/oci/database/heatwavedb"
+	ocilb "github.com/rozdolsky33/ocloud/internal/oci/network/loadbalancer"
+	instSvc "github.com/rozdolsky33/ocloud/internal/services/compute/instance"
+	okeSvc "github.com/rozdolsky33/ocloud/internal/services/compute/oke"
+	adbSvc "github.com/rozdolsky33/ocloud/internal/services/database/autonomousdb"
+	cacheSvc "github.com/rozdolsky33/ocloud/internal/services/database/cacheclusterdb"
+	hwdbSvc "github.com/rozdolsky33/ocloud/internal/services/database/heatwavedb"
+	lbSvc "github.com/rozdolsky33/ocloud/internal/services/network/loadbalancer"
+	"github.com/rozdolsky33/ocloud/internal/services/util"
+)
+
+// TargetKind identifies the type of resource a non-interactive session connects to.
+type TargetKind string
+
+const (
+	KindInstance TargetKind = "instance"
+	KindADB      TargetKind = "adb"
+	KindHeatWave TargetKind = "heatwave"
+	KindCache    TargetKind = "cache"
+	KindOKE      TargetKind = "oke"
+	KindLB       TargetKind = "lb"
+)
+
+// Standard target ports, matching the defaults offered by the interactive flows.
+const (
+	defaultInstancePort = 22
+	defaultADBPort      = 1521
+	defaultHeatWavePort = 3306
+	defaultCachePort    = 6379
+	defaultOKEPort      = 6443
+	defaultLBPort       = 443
+)
+
+// targetKinds lists the supported kinds in the order they are shown in help and errors.
+var targetKinds = []TargetKind{KindInstance, KindADB, KindHeatWave, KindCache, KindOKE, KindLB}
+
+// ResolvedTarget is a session target resolved to a network address.
+type ResolvedTarget struct {
+	Kind     TargetKind `json:"kind"`
+	ID       string     `json:"id"`
+	Name     string     `json:"name"`
+	IP       string     `json:"ip"`
+	Port     int        `json:"port"`
+	VcnID    string     `json:"vcn_id,omitempty"`
+	SubnetID string     `json:"subnet_id,omitempty"`
+	NsgIDs   []string   `json:"nsg_ids,omitempty"`
+}
+
+// ParseTargetSpec splits a "<kind>:<name|ocid>" target specification.
+func ParseTargetSpec(spec string) (TargetKind, string, error) {
+	kindStr, name, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("%w: %q must be in the form <kind>:<name|ocid>", ErrInvalidTarget, spec)
+	}
+	kind := TargetKind(strings.ToLower(strings.TrimSpace(kindStr)))
+	for _, k := range targetKinds {
+		if k == kind {
+			return kind, name, nil
+		}
+	}
+	return "", "", fmt.Errorf("%w: unknown kind %q (supported: %s)", ErrInvalidTarget, kindStr, joinKinds())
+}
+
+// joinKinds renders the supported target kinds for error messages.
+func joinKinds() string {
+	parts := make([]string, len(targetKinds))
+	for i, k := range targetKinds {
+		parts[i] = string(k)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// matchTarget returns the single item whose OCID or display name equals name.
+func matchTarget[T any](kind TargetKind, name string, items []T, id, displayName func(T) string) (T, error) {
+	var zero T
+	var matches []T
+	for _, it := range items {
+		if id(it) == name || displayName(it) == name {
+			matches = append(matches, it)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return zero, &ResolveError{Kind: kind, Name: name, Err: ErrTargetNotFound, Reason: "no resource with that name or OCID in the compartment"}
+	case 1:
+		return matches[0], nil
+	default:
+		return zero, &ResolveError{Kind: kind, Name: name, Err: ErrAmbiguousTarget, Reason: fmt.Sprintf("%d resources share this name; use the OCID instead", len(matches))}
+	}
+}
+
+// ResolveTarget looks up a target of the given kind by name or OCID in the current compartment
+// and returns the address a bastion session should forward to.
+func ResolveTarget(ctx context.Context, appCtx *app.ApplicationContext, kind TargetKind, name string) (*ResolvedTarget, error) {
+	switch kind {
+	case KindInstance:
+		return resolveInstance(ctx, appCtx, name)
+	case KindADB:
+		return resolveAutonomousDatabase(ctx, appCtx, name)
+	case KindHeatWave:
+		return resolveHeatWaveDatabase(ctx, appCtx, name)
+	case KindCache:
+		return resolveCacheCluster(ctx, appCtx, name)
+	case KindOKE:
+		return resolveOKECluster(ctx, appCtx, name)
+	case KindLB:
+		return resolveLoadBalancer(ctx, appCtx, name)
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q (supported: %s)", ErrInvalidTarget, kind, joinKinds())
+	}
+}
+
+func resolveInstance(ctx context.Context, appCtx *app.ApplicationContext, name string) (*ResolvedTarget, error) {
+	computeClient, err := oci.NewComputeClient(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating compute client: %w", err)
+	}
+	networkClient, err := oci.NewNetworkClient(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating network client: %w", err)
+	}
+	service := instSvc.NewService(ociInst.NewAdapter(computeClient, networkClient), appCtx.Logger, appCtx.CompartmentID)
+	instances, _, _, err := service.FetchPaginatedInstances(ctx, 1000, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list instances: %w", err)
+	}
+
+	inst, err := matchTarget(KindInstance, name, instances,
+		func(i instSvc.Instance) string { return i.OCID },
+		func(i instSvc.Instance) string { return i.DisplayName })
+	if err != nil {
+		return nil, err
+	}
+	if inst.PrimaryIP == "" {
+		return nil, &ResolveError{Kind: KindInstance, Name: name, Err: ErrTargetUnreachable, Reason: "instance has no primary private IP"}
+	}
+	return &ResolvedTarget{Kind: KindInstance, ID: inst.OCID, Name: inst.DisplayName, IP: inst.PrimaryIP,
+		Port: defaultInstancePort, VcnID: inst.VcnID, SubnetID: inst.SubnetID, NsgIDs: inst.NsgIDs}, nil
+}
+
+func resolveAutonomousDatabase(ctx context.Context, appCtx *app.ApplicationContext, name string) (*ResolvedTarget, error) {
+	adapter, err := ociadb.NewAdapter(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database adapter: %w", err)
+	}
+	dbs, _, _, err := adbSvc.NewService(adapter, appCtx).FetchPaginatedAutonomousDb(ctx, 1000, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list Autonomous Databases: %w", err)
+	}
+
+	db, err := matchTarget(KindADB, name, dbs,
+		func(d adbSvc.AutonomousDatabase) string { return d.ID },
+		func(d adbSvc.AutonomousDatabase) string { return d.Name })
+	if err != nil {
+		return nil, err
+	}
+	if db.PrivateEndpointIp == "" {
+		return nil, &ResolveError{Kind: KindADB, Name: name, Err: ErrTargetUnreachable, Reason: "database has no private endpoint IP"}
+	}
+	return &ResolvedTarget{Kind: KindADB, ID: db.ID, Name: db.Name, IP: db.PrivateEndpointIp,
+		Port: defaultADBPort, VcnID: db.VcnID, SubnetID: db.SubnetId, NsgIDs: db.NsgIds}, nil
+}
+
+func resolveHeatWaveDatabase(ctx context.Context, appCtx *app.ApplicationContext, name string) (*ResolvedTarget, error) {
+	adapter, err := ocihwdb.NewAdapter(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HeatWave database adapter: %w", err)
+	}
+	dbs, _, _, err := hwdbSvc.NewService(adapter, appCtx).FetchPaginatedHeatWaveDb(ctx, 1000, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list HeatWave databases: %w", err)
+	}
+
+	db, err := matchTarget(KindHeatWave, name, dbs,
+		func(d hwdbSvc.HeatWaveDatabase) string { return d.ID },
+		func(d hwdbSvc.HeatWaveDatabase) string { return d.DisplayName })
+	if err != nil {
+		return nil, err
+	}
+	if db.IpAddress == "" {
+		return nil, &ResolveError{Kind: KindHeatWave, Name: name, Err: ErrTargetUnreachable, Reason: "database has no IP address"}
+	}
+	port := defaultHeatWavePort
+	if db.Port != nil {
+		port = *db.Port
+	}
+	return &ResolvedTarget{Kind: KindHeatWave, ID: db.ID, Name: db.DisplayName, IP: db.IpAddress,
+		Port: port, VcnID: db.VcnID, SubnetID: db.SubnetId, NsgIDs: db.NsgIds}, nil
+}
+
+func resolveCacheCluster(ctx context.Context, appCtx *app.ApplicationContext, name string) (*ResolvedTarget, error) {
+	adapter, err := ocicache.NewAdapter(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cache cluster adapter: %w", err)
+	}
+	clusters, _, _, err := cacheSvc.NewService(adapter, appCtx).FetchPaginatedCacheClusters(ctx, 1000, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list cache clusters: %w", err)
+	}
+
+	cluster, err := matchTarget(KindCache, name, clusters,
+		func(c cacheSvc.CacheCluster) string { return c.ID },
+		func(c cacheSvc.CacheCluster) string { return c.DisplayName })
+	if err != nil {
+		return nil, err
+	}
+	if cluster.PrimaryEndpointIpAddress == "" {
+		return nil, &ResolveError{Kind: KindCache, Name: name, Err: ErrTargetUnreachable, Reason: "cache cluster has no primary endpoint IP"}
+	}
+	return &ResolvedTarget{Kind: KindCache, ID: cluster.ID, Name: cluster.DisplayName, IP: cluster.PrimaryEndpointIpAddress,
+		Port: defaultCachePort, VcnID: cluster.VcnID, SubnetID: cluster.SubnetId, NsgIDs: cluster.NsgIds}, nil
+}
+
+func resolveOKECluster(ctx context.Context, appCtx *app.ApplicationContext, name string) (*ResolvedTarget, error) {
+	containerEngineClient, err := oci.NewContainerEngineClient(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating container engine client: %w", err)
+	}
+	service := okeSvc.NewService(ociOke.NewAdapter(containerEngineClient), appCtx.Logger, appCtx.CompartmentID)
+	clusters, _, _, err := service.FetchPaginatedClusters(ctx, 1000, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list OKE clusters: %w", err)
+	}
+
+	cluster, err := matchTarget(KindOKE, name, clusters,
+		func(c okeSvc.Cluster) string { return c.OCID },
+		func(c okeSvc.Cluster) string { return c.DisplayName })
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer the private endpoint; the public endpoint hostname is only a fallback.
+	var targetIP string
+	var lastErr error
+	for _, endpoint := range []string{cluster.PrivateEndpoint, cluster.PublicEndpoint} {
+		host := util.ExtractHostname(endpoint)
+		if host == "" {
+			continue
+		}
+		ip, err := util.ResolveHostToIP(ctx, host)
+		if err == nil {
+			targetIP = ip
+			break
+		}
+		lastErr = err
+	}
+	if targetIP == "" {
+		reason := "cluster has no API endpoint"
+		if lastErr != nil {
+			reason = fmt.Sprintf("resolve API endpoint: %v", lastErr)
+		}
+		return nil, &ResolveError{Kind: KindOKE, Name: name, Err: ErrTargetUnreachable, Reason: reason}
+	}
+	return &ResolvedTarget{Kind: KindOKE, ID: cluster.OCID, Name: cluster.DisplayName, IP: targetIP,
+		Port: defaultOKEPort, VcnID: cluster.VcnOCID}, nil
+}
+
+func resolveLoadBalancer(ctx context.Context, appCtx *app.ApplicationContext, name string) (*ResolvedTarget, error) {
+	lbClient, err := oci.NewLoadBalancerClient(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating load balancer client: %w", err)
+	}
+	nwClient, err := oci.NewNetworkClient(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating network client: %w", err)
+	}
+	certsClient, err := oci.NewCertificatesManagementClient(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificates management client: %w", err)
+	}
+	lbs, err := lbSvc.NewService(ocilb.NewAdapter(lbClient, nwClient, certsClient), appCtx).ListLoadBalancers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list load balancers: %w", err)
+	}
+
+	lb, err := matchTarget(KindLB, name, lbs,
+		func(l lbSvc.LoadBalancer) string { return l.OCID },
+		func(l lbSvc.LoadBalancer) string { return l.Name })
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(lb.Type) != "private" {
+		return nil, &ResolveError{Kind: KindLB, Name: name, Err: ErrTargetUnreachable, Reason: "only private load balancers can be reached through a bastion"}
+	}
+	if len(lb.IPAddresses) == 0 {
+		return nil, &ResolveError{Kind: KindLB, Name: name, Err: ErrTargetUnreachable, Reason: "load balancer has no IP addresses"}
+	}
+	var subnetID string
+	if len(lb.Subnets) > 0 {
+		subnetID = lb.Subnets[0]
+	}
+	// NSGs are resolved to display names when possible; only the entries still holding an OCID can be evaluated.
+	var nsgIDs []string
+	for _, nsg := range lb.NSGs {
+		if strings.HasPrefix(nsg, "ocid1.networksecuritygroup.") {
+			nsgIDs = append(nsgIDs, nsg)
+		}
+	}
+	return &ResolvedTarget{Kind: KindLB, ID: lb.OCID, Name: lb.Name, IP: ExtractIPAddress(lb.IPAddresses[0]),
+		Port: defaultLBPort, VcnID: lb.VcnID, SubnetID: subnetID, NsgIDs: nsgIDs}, nil
+}
+
+// ReachabilityTarget describes the target for a reachability analysis; port 0 means the target's standard port.
+func (t ResolvedTarget) ReachabilityTarget(port int, clientIP string) ReachabilityTarget {
+	if port == 0 {
+		port = t.Port
+	}
+	return ReachabilityTarget{
+		IP:       t.IP,
+		Port:     port,
+		VcnID:    t.VcnID,
+		SubnetID: t.SubnetID,
+		NsgIDs:   t.NsgIDs,
+		ClientIP: clientIP,
+	}
+}
+
+// ExtractIPAddress extracts just the IP address from a string that may contain
+// a suffix like "(private)" or "(public)" added by the mapping layer.
+func ExtractIPAddress(ipWithSuffix string) string {
+	if idx := strings.Index(ipWithSuffix, " "); idx > 0 {
+		return ipWithSuffix[:idx]
+	}
+	return ipWithSuffix
+}
@@ -0,0 +1,76 @@
+package bastion
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTargetSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       string
+		expectKind TargetKind
+		expectName string
+		expectErr  bool
+	}{
+		{name: "instance by name", spec: "instance:web-1", expectKind: KindInstance, expectName: "web-1"},
+		{name: "kind is case insensitive", spec: "ADB:salesdb", expectKind: KindADB, expectName: "salesdb"},
+		{name: "ocid keeps colons", spec: "lb:ocid1.loadbalancer.oc1:phx:abc", expectKind: KindLB, expectName: "ocid1.loadbalancer.oc1:phx:abc"},
+		{name: "surrounding spaces", spec: " oke : prod ", expectKind: KindOKE, expectName: "prod"},
+		{name: "missing separator", spec: "web-1", expectErr: true},
+		{name: "missing name", spec: "cache:", expectErr: true},
+		{name: "unknown kind", spec: "vm:web-1", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, name, err := ParseTargetSpec(tt.spec)
+			if tt.expectErr {
+				assert.True(t, errors.Is(err, ErrInvalidTarget))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectKind, kind)
+			assert.Equal(t, tt.expectName, name)
+		})
+	}
+}
+
+func TestMatchTarget(t *testing.T) {
+	type res struct{ id, name string }
+	items := []res{{"ocid1.a", "alpha"}, {"ocid1.b", "beta"}, {"ocid1.c", "beta"}}
+	id := func(r res) string { return r.id }
+	name := func(r res) string { return r.name }
+
+	got, err := matchTarget(KindInstance, "alpha", items, id, name)
+	require.NoError(t, err)
+	assert.Equal(t, "ocid1.a", got.id)
+
+	got, err = matchTarget(KindInstance, "ocid1.c", items, id, name)
+	require.NoError(t, err)
+	assert.Equal(t, "beta", got.name)
+
+	_, err = matchTarget(KindInstance, "beta", items, id, name)
+	assert.True(t, errors.Is(err, ErrAmbiguousTarget))
+
+	_, err = matchTarget(KindInstance, "gamma", items, id, name)
+	assert.True(t, errors.Is(err, ErrTargetNotFound))
+	var re *ResolveError
+	require.True(t, errors.As(err, &re))
+	assert.Equal(t, "gamma", re.Name)
+}
+
+func TestResolvedTarget_ReachabilityTarget(t *testing.T) {
+	target := ResolvedTarget{Kind: KindADB, IP: "10.0.1.5", Port: 1521, VcnID: "vcn", SubnetID: "subnet", NsgIDs: []string{"nsg"}}
+
+	rt := target.ReachabilityTarget(0, "")
+	assert.Equal(t, 1521, rt.Port)
+	assert.Equal(t, []string{"nsg"}, rt.NsgIDs)
+
+	rt = target.ReachabilityTarget(5432, "203.0.113.10")
+	assert.Equal(t, 5432, rt.Port)
+	assert.Equal(t, "203.0.113.10", rt.ClientIP)
+}
@@ -0,0 +1,293 @@
+package bastion
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/bastion"
+	"github.com/rozdolsky33/ocloud/internal/config/flags"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+)
+
+// ErrTunnelNotFound is returned when no tracked tunnel listens on the requested local port.
+var ErrTunnelNotFound = errors.New("tunnel not found")
+
+// logFollowInterval is how often FollowLog checks the log file for new content.
+var logFollowInterval = 500 * time.Millisecond
+
+// PruneResult reports what PruneTunnels removed (or would remove in dry-run mode).
+type PruneResult struct {
+	StaleStates []int    `json:"stale_states"`
+	Logs        []string `json:"logs"`
+}
+
+// getLogsDir returns the directory where ssh tunnel log files are written.
+func getLogsDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	profile := os.Getenv(flags.EnvKeyProfile)
+	return filepath.Join(homeDir, flags.OCIConfigDirName, flags.OCISessionsDirName, profile, "logs"), nil
+}
+
+// loadTunnelStates reads every tunnel state file without checking whether the process is still running.
+func loadTunnelStates() ([]TunnelInfo, error) {
+	tunnelsDir, err := getTunnelsDir()
+	if err != nil {
+		return nil, fmt.Errorf("get tunnels dir: %w", err)
+	}
+	entries, err := os.ReadDir(tunnelsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read tunnels dir: %w", err)
+	}
+
+	var tunnels []TunnelInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(tunnelsDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var tunnel TunnelInfo
+		if err := json.Unmarshal(data, &tunnel); err != nil {
+			continue
+		}
+		tunnels = append(tunnels, tunnel)
+	}
+	return tunnels, nil
+}
+
+// ListTunnels returns the active tunnels sorted by local port.
+func ListTunnels() ([]TunnelInfo, error) {
+	tunnels, err := GetActiveTunnels()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tunnels, func(i, j int) bool { return tunnels[i].LocalPort < tunnels[j].LocalPort })
+	return tunnels, nil
+}
+
+// FindTunnel returns the active tunnel listening on localPort.
+func FindTunnel(localPort int) (*TunnelInfo, error) {
+	tunnels, err := GetActiveTunnels()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tunnels {
+		if t.LocalPort == localPort {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w on local port %d", ErrTunnelNotFound, localPort)
+}
+
+// StopTunnel terminates the tunnel's forwarder process and removes its state file.
+// A process that has already exited is not treated as an error, and a PID that now belongs to another
+// process is left alone: only the stale state is removed.
+func StopTunnel(tunnel TunnelInfo) error {
+	if isForwarderProcess(tunnel, time.Now()) {
+		if err := syscall.Kill(tunnel.PID, syscall.SIGTERM); err != nil && !errors.Is(err, syscall.ESRCH) {
+			return fmt.Errorf("stop tunnel on port %d (pid %d): %w", tunnel.LocalPort, tunnel.PID, err)
+		}
+	}
+	if err := RemoveTunnelState(tunnel.LocalPort); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove tunnel state: %w", err)
+	}
+	return nil
+}
+
+// isForwarderProcess reports whether the tunnel's PID still runs its ocloud forwarder. Where the command
+// line is readable it must be the ForwarderCommand; otherwise the forwarder's stats must be fresh, or the
+// tunnel must be younger than the first stats write.
+func isForwarderProcess(tunnel TunnelInfo, now time.Time) bool {
+	if !isProcessRunning(tunnel.PID) {
+		return false
+	}
+	if cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", tunnel.PID)); err == nil {
+		return slices.Contains(strings.Split(string(cmdline), "\x00"), ForwarderCommand)
+	}
+	stale := 3 * forwarderStatsInterval
+	if stats, err := ReadTunnelStats(tunnel.LocalPort); err == nil && now.Sub(stats.UpdatedAt) < stale {
+		return true
+	}
+	return now.Sub(tunnel.StartedAt) < stale
+}
+
+// TunnelLogFile returns the log file for the tunnel on localPort.
+// It prefers the path recorded in the state file and falls back to the newest log for that port.
+func TunnelLogFile(localPort int) (string, error) {
+	if states, err := loadTunnelStates(); err == nil {
+		for _, t := range states {
+			if t.LocalPort == localPort && t.LogFile != "" {
+				return t.LogFile, nil
+			}
+		}
+	}
+
+	logDir, err := getLogsDir()
+	if err != nil {
+		return "", fmt.Errorf("get logs dir: %w", err)
+	}
+	matches, err := filepath.Glob(filepath.Join(logDir, fmt.Sprintf("ssh-tunnel-%d-*.log", localPort)))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no log file found for tunnel on local port %d", localPort)
+	}
+	// File names embed a sortable timestamp, so the last match is the newest.
+	sort.Strings(matches)
+	return matches[len(matches)-1], nil
+}
+
+// TailLog writes the last n lines of the file at path to w. If n <= 0 the whole file is written.
+func TailLog(w io.Writer, path string, n int) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if n > 0 && len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read log file: %w", err)
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FollowLog streams content appended to the file at path to w until the context is cancelled.
+func FollowLog(ctx context.Context, w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("seek log file: %w", err)
+	}
+	for {
+		if _, err := io.Copy(w, f); err != nil {
+			return fmt.Errorf("follow log file: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logFollowInterval):
+		}
+	}
+}
+
+// PruneTunnels removes state files whose forwarder process is gone and log files older than maxLogAge.
+// Logs that belong to a running tunnel are always kept. With dryRun set nothing is deleted.
+func PruneTunnels(maxLogAge time.Duration, dryRun bool) (PruneResult, error) {
+	var result PruneResult
+
+	states, err := loadTunnelStates()
+	if err != nil {
+		return result, err
+	}
+	activeLogs := make(map[string]bool)
+	for _, t := range states {
+		if isProcessRunning(t.PID) {
+			activeLogs[t.LogFile] = true
+			continue
+		}
+		result.StaleStates = append(result.StaleStates, t.LocalPort)
+		if !dryRun {
+			if err := RemoveTunnelState(t.LocalPort); err != nil && !os.IsNotExist(err) {
+				return result, fmt.Errorf("remove tunnel state: %w", err)
+			}
+		}
+	}
+	sort.Ints(result.StaleStates)
+
+	logDir, err := getLogsDir()
+	if err != nil {
+		return result, fmt.Errorf("get logs dir: %w", err)
+	}
+	entries, err := os.ReadDir(logDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+		return result, fmt.Errorf("read logs dir: %w", err)
+	}
+	cutoff := time.Now().Add(-maxLogAge)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".log") {
+			continue
+		}
+		path := filepath.Join(logDir, entry.Name())
+		info, err := entry.Info()
+		if err != nil || activeLogs[path] || info.ModTime().After(cutoff) {
+			continue
+		}
+		result.Logs = append(result.Logs, path)
+		if !dryRun {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return result, fmt.Errorf("remove log file: %w", err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// SessionTTL returns the TTL of a bastion session and the time at which it expires,
+// computed from the session's creation time.
+func (s *Service) SessionTTL(ctx context.Context, sessionID string) (int, time.Time, error) {
+	resp, err := s.bastionClient.GetSession(ctx, bastion.GetSessionRequest{SessionId: &sessionID})
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("getting bastion session: %w", err)
+	}
+	if resp.TimeCreated == nil || resp.SessionTtlInSeconds == nil {
+		return 0, time.Time{}, fmt.Errorf("session %s does not report a TTL", sessionID)
+	}
+	ttl := *resp.SessionTtlInSeconds
+	return ttl, resp.TimeCreated.Add(time.Duration(ttl) * time.Second), nil
+}
+
+// RecordTunnel saves the tunnel state, filling in the session TTL and expiry from OCI when they are not set.
+// A failed TTL lookup is logged and the state is saved without it.
+func (s *Service) RecordTunnel(ctx context.Context, tunnel TunnelInfo) error {
+	if tunnel.SessionID != "" && tunnel.ExpiresAt.IsZero() {
+		ttl, expiresAt, err := s.SessionTTL(ctx, tunnel.SessionID)
+		if err != nil {
+			logger.LogWithLevel(s.logger, logger.Debug, "could not determine session expiry", "session_id", tunnel.SessionID, "error", err)
+		} else {
+			tunnel.SessionTTL = ttl
+			tunnel.ExpiresAt = expiresAt
+		}
+	}
+	return SaveTunnelState(tunnel)
+}
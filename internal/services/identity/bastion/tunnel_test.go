@@ -0,0 +1,192 @@
+package bastion
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deadPID is a PID that is assumed not to belong to a running process.
+const deadPID = 4194303
+
+// setupTunnelHome points the tunnel state and log directories at a temporary home.
+func setupTunnelHome(t *testing.T) (tunnelsDir, logsDir string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("OCI_CLI_PROFILE", "TEST")
+
+	var err error
+	tunnelsDir, err = getTunnelsDir()
+	require.NoError(t, err)
+	logsDir, err = getLogsDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(logsDir, 0o755))
+	return tunnelsDir, logsDir
+}
+
+func TestLoadTunnelStates(t *testing.T) {
+	tunnelsDir, _ := setupTunnelHome(t)
+
+	states, err := loadTunnelStates()
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	require.NoError(t, SaveTunnelState(TunnelInfo{PID: deadPID, LocalPort: 15432, TargetIP: "10.0.0.5", SessionID: "ocid1.bastionsession.oc1..a"}))
+	require.NoError(t, os.WriteFile(filepath.Join(tunnelsDir, "tunnel-1.json"), []byte("{broken"), 0o644))
+
+	states, err = loadTunnelStates()
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, 15432, states[0].LocalPort)
+	assert.Equal(t, "ocid1.bastionsession.oc1..a", states[0].SessionID)
+}
+
+func TestStopTunnel_DeadProcess(t *testing.T) {
+	tunnelsDir, _ := setupTunnelHome(t)
+	require.NoError(t, SaveTunnelState(TunnelInfo{PID: deadPID, LocalPort: 15432}))
+
+	require.NoError(t, StopTunnel(TunnelInfo{PID: deadPID, LocalPort: 15432}))
+	_, err := os.Stat(filepath.Join(tunnelsDir, "tunnel-15432.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Stopping again is a no-op
+	assert.NoError(t, StopTunnel(TunnelInfo{PID: deadPID, LocalPort: 15432}))
+}
+
+func TestStopTunnel_ReusedPID(t *testing.T) {
+	tunnelsDir, _ := setupTunnelHome(t)
+	// The PID now belongs to this test process, which has not written forwarder stats for the tunnel.
+	tunnel := TunnelInfo{PID: os.Getpid(), LocalPort: 15432, StartedAt: time.Now().Add(-time.Hour)}
+	require.NoError(t, SaveTunnelState(tunnel))
+
+	require.NoError(t, StopTunnel(tunnel))
+	_, err := os.Stat(filepath.Join(tunnelsDir, "tunnel-15432.json"))
+	assert.True(t, os.IsNotExist(err), "the stale state is removed")
+}
+
+func TestStopTunnel_Forwarder(t *testing.T) {
+	setupTunnelHome(t)
+	proc := exec.Command("sh", "-c", "trap 'exit 0' TERM; while :; do sleep 0.1; done", ForwarderCommand)
+	require.NoError(t, proc.Start())
+	exited := make(chan error, 1)
+	go func() { exited <- proc.Wait() }()
+	t.Cleanup(func() { _ = proc.Process.Kill() })
+
+	require.NoError(t, StopTunnel(TunnelInfo{PID: proc.Process.Pid, LocalPort: 15433, StartedAt: time.Now()}))
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("forwarder was not terminated")
+	}
+}
+
+func TestPruneTunnels(t *testing.T) {
+	tunnelsDir, logsDir := setupTunnelHome(t)
+
+	activeLog := filepath.Join(logsDir, "ssh-tunnel-2000-20240101-000000.log")
+	oldLog := filepath.Join(logsDir, "ssh-tunnel-1000-20240101-000000.log")
+	newLog := filepath.Join(logsDir, "ssh-tunnel-1000-20990101-000000.log")
+	for _, p := range []string{activeLog, oldLog, newLog} {
+		require.NoError(t, os.WriteFile(p, []byte("log\n"), 0o644))
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(oldLog, old, old))
+	require.NoError(t, os.Chtimes(activeLog, old, old))
+
+	require.NoError(t, SaveTunnelState(TunnelInfo{PID: deadPID, LocalPort: 1000, LogFile: oldLog}))
+	require.NoError(t, SaveTunnelState(TunnelInfo{PID: os.Getpid(), LocalPort: 2000, LogFile: activeLog}))
+
+	t.Run("dry run keeps files", func(t *testing.T) {
+		result, err := PruneTunnels(24*time.Hour, true)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1000}, result.StaleStates)
+		assert.Equal(t, []string{oldLog}, result.Logs)
+		assert.FileExists(t, oldLog)
+		assert.FileExists(t, filepath.Join(tunnelsDir, "tunnel-1000.json"))
+	})
+
+	t.Run("removes stale state and old logs", func(t *testing.T) {
+		result, err := PruneTunnels(24*time.Hour, false)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1000}, result.StaleStates)
+		assert.NoFileExists(t, oldLog)
+		assert.NoFileExists(t, filepath.Join(tunnelsDir, "tunnel-1000.json"))
+		assert.FileExists(t, newLog)
+		assert.FileExists(t, activeLog)
+		assert.FileExists(t, filepath.Join(tunnelsDir, "tunnel-2000.json"))
+	})
+}
+
+func TestTunnelLogFile(t *testing.T) {
+	_, logsDir := setupTunnelHome(t)
+
+	_, err := TunnelLogFile(3306)
+	assert.Error(t, err)
+
+	older := filepath.Join(logsDir, "ssh-tunnel-3306-20240101-000000.log")
+	newer := filepath.Join(logsDir, "ssh-tunnel-3306-20240102-000000.log")
+	require.NoError(t, os.WriteFile(older, nil, 0o644))
+	require.NoError(t, os.WriteFile(newer, nil, 0o644))
+
+	got, err := TunnelLogFile(3306)
+	require.NoError(t, err)
+	assert.Equal(t, newer, got)
+
+	require.NoError(t, SaveTunnelState(TunnelInfo{PID: deadPID, LocalPort: 3306, LogFile: older}))
+	got, err = TunnelLogFile(3306)
+	require.NoError(t, err)
+	assert.Equal(t, older, got)
+}
+
+func TestTailLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tunnel.log")
+	require.NoError(t, os.WriteFile(path, []byte("one\ntwo\nthree\nfour\n"), 0o644))
+
+	var buf bytes.Buffer
+	require.NoError(t, TailLog(&buf, path, 2))
+	assert.Equal(t, "three\nfour\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, TailLog(&buf, path, 0))
+	assert.Equal(t, "one\ntwo\nthree\nfour\n", buf.String())
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "45s", FormatDuration(45*time.Second))
+	assert.Equal(t, "12m05s", FormatDuration(12*time.Minute+5*time.Second))
+	assert.Equal(t, "2h15m", FormatDuration(2*time.Hour+15*time.Minute))
+	assert.Equal(t, "expired", FormatDuration(-time.Second))
+}
+
+func TestTunnelInfoTarget(t *testing.T) {
+	assert.Equal(t, "10.0.0.5", TunnelInfo{TargetIP: "10.0.0.5"}.Target())
+	assert.Equal(t, "10.0.0.5:5432", TunnelInfo{TargetIP: "10.0.0.5", RemotePort: 5432}.Target())
+	assert.Equal(t, "adb prod-adb:1521", TunnelInfo{TargetIP: "10.0.0.5", TargetName: "prod-adb", TargetKind: "adb", RemotePort: 1521}.Target())
+}
+
+func TestSaveTunnelState_RoundTripsMetadata(t *testing.T) {
+	setupTunnelHome(t)
+	expires := time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)
+	in := TunnelInfo{
+		PID: deadPID, LocalPort: 1521, TargetIP: "10.0.0.5",
+		SessionID: "ocid1.bastionsession.oc1..s", BastionID: "ocid1.bastion.oc1..b",
+		TargetID: "ocid1.autonomousdatabase.oc1..d", TargetName: "prod-adb", TargetKind: "adb",
+		RemotePort: 1521, SessionTTL: 10800, ExpiresAt: expires,
+	}
+	require.NoError(t, SaveTunnelState(in))
+
+	states, err := loadTunnelStates()
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, in.TargetName, states[0].TargetName)
+	assert.Equal(t, in.BastionID, states[0].BastionID)
+	assert.Equal(t, in.SessionTTL, states[0].SessionTTL)
+	assert.True(t, expires.Equal(states[0].ExpiresAt))
+}
@@ -28,3 +28,6 @@ type Config struct {
 	SshPrivateKeyFile string `yaml:"ssh_private_key_file"`
 	SessionTimeout    *int   `yaml:"session_timeout"`
 }
+
+// CreateBastionRequest is an alias to the domain.CreateBastionRequest type.
+type CreateBastionRequest = domain.CreateBastionRequest
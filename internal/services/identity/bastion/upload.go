@@ -0,0 +1,448 @@
+package bastion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// partialSuffix is appended to the remote path while a file is being uploaded. The partial file is
+// renamed once its sha256 matches, so an interrupted upload never leaves a truncated file in place
+// and the next attempt resumes from the partial file's size.
+const partialSuffix = ".ocloud-part"
+
+// Upload retry policy and progress throttling.
+var (
+	uploadRetryAttempts    = 3
+	uploadRetryBackoff     = 2 * time.Second
+	uploadProgressInterval = 100 * time.Millisecond
+)
+
+// errChecksumMismatch is returned when the uploaded file's sha256 differs from the local file.
+var errChecksumMismatch = errors.New("sha256 mismatch after upload")
+
+// TransferTarget describes an instance reached through a managed SSH session.
+type TransferTarget struct {
+	PrivateKeyPath string
+	SessionID      string
+	Region         string
+	TargetIP       string
+	TargetUser     string
+	TargetPort     int
+	// BastionAddr overrides the bastion host:port derived from the session and region.
+	BastionAddr string
+}
+
+// bastionAddr returns the SSH address of the bastion host.
+func (t TransferTarget) bastionAddr() string {
+	if t.BastionAddr != "" {
+		return t.BastionAddr
+	}
+	return net.JoinHostPort(bastionHost(t.SessionID, t.Region), "22")
+}
+
+// targetAddr returns the SSH address of the target instance.
+func (t TransferTarget) targetAddr() string {
+	port := t.TargetPort
+	if port == 0 {
+		port = 22
+	}
+	return net.JoinHostPort(t.TargetIP, strconv.Itoa(port))
+}
+
+// UploadFile is a local file and the remote path it is uploaded to.
+type UploadFile struct {
+	LocalPath  string
+	RemotePath string
+	Size       int64
+	Mode       fs.FileMode
+}
+
+// UploadProgress reports the progress of an upload aggregated across all files.
+type UploadProgress struct {
+	FileIndex  int
+	FileCount  int
+	File       string
+	BytesDone  int64
+	BytesTotal int64
+	Status     string
+}
+
+// UploadResult is the outcome of uploading one file.
+type UploadResult struct {
+	LocalPath  string `json:"local_path"`
+	RemotePath string `json:"remote_path"`
+	Size       int64  `json:"size"`
+	SHA256     string `json:"sha256"`
+	// ResumedAt is the offset a previous partial upload was resumed from.
+	ResumedAt int64 `json:"resumed_at,omitempty"`
+	// Skipped is set when the remote file already existed with the same sha256.
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+// PlanUpload expands local files and directories into the files to upload.
+// A single file is uploaded to remote itself unless remote ends with "/"; otherwise remote is a
+// directory and each source keeps its base name, with directories copied recursively like scp -r.
+func PlanUpload(sources []string, remote string) ([]UploadFile, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no files selected")
+	}
+	if remote == "" {
+		return nil, fmt.Errorf("remote path is required")
+	}
+
+	var files []UploadFile
+	for _, src := range sources {
+		info, err := os.Stat(src)
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", src, err)
+		}
+		if !info.IsDir() {
+			dest := path.Join(remote, filepath.Base(src))
+			if len(sources) == 1 && !strings.HasSuffix(remote, "/") {
+				dest = remote
+			}
+			files = append(files, UploadFile{LocalPath: src, RemotePath: dest, Size: info.Size(), Mode: info.Mode().Perm()})
+			continue
+		}
+
+		base := path.Join(remote, filepath.Base(filepath.Clean(src)))
+		err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			fi, err := d.Info()
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(src, p)
+			if err != nil {
+				return err
+			}
+			files = append(files, UploadFile{LocalPath: p, RemotePath: path.Join(base, filepath.ToSlash(rel)), Size: fi.Size(), Mode: fi.Mode().Perm()})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk %s: %w", src, err)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no regular files found in the selection")
+	}
+	return files, nil
+}
+
+// Uploader copies files to an instance over SSH through a managed SSH session.
+// Each file is written to a partial file, resumed from its size after a dropped connection,
+// verified with sha256sum on the target and then renamed into place.
+type Uploader struct {
+	target TransferTarget
+	signer ssh.Signer
+
+	bastion *ssh.Client
+	client  *ssh.Client
+}
+
+// NewUploader loads the private key for target.
+func NewUploader(target TransferTarget) (*Uploader, error) {
+	key, err := ExpandTilde(target.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("expand key path: %w", err)
+	}
+	signer, err := loadSigner(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Uploader{target: target, signer: signer}, nil
+}
+
+// connect opens the SSH connection to the target through the bastion.
+func (u *Uploader) connect() error {
+	u.Close()
+	bastionClient, err := ssh.Dial("tcp", u.target.bastionAddr(), &ssh.ClientConfig{
+		User: u.target.SessionID,
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(u.signer)},
+		// OCI Bastion presents a different host key for every session, so there is nothing stable to pin.
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         forwarderDialTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("connect to bastion %s: %w", u.target.bastionAddr(), err)
+	}
+	conn, err := bastionClient.Dial("tcp", u.target.targetAddr())
+	if err != nil {
+		_ = bastionClient.Close()
+		return fmt.Errorf("open channel to %s: %w", u.target.targetAddr(), err)
+	}
+	// Matches the ssh/scp commands built for managed sessions, which disable host key checking.
+	c, chans, reqs, err := ssh.NewClientConn(conn, u.target.targetAddr(), &ssh.ClientConfig{
+		User:            u.target.TargetUser,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(u.signer)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         forwarderDialTimeout,
+	})
+	if err != nil {
+		_ = conn.Close()
+		_ = bastionClient.Close()
+		return fmt.Errorf("connect to %s@%s: %w", u.target.TargetUser, u.target.targetAddr(), err)
+	}
+	u.bastion = bastionClient
+	u.client = ssh.NewClient(c, chans, reqs)
+	return nil
+}
+
+// Close closes the connections to the target and the bastion.
+func (u *Uploader) Close() {
+	if u.client != nil {
+		_ = u.client.Close()
+		u.client = nil
+	}
+	if u.bastion != nil {
+		_ = u.bastion.Close()
+		u.bastion = nil
+	}
+}
+
+// Upload uploads files in order, reporting aggregate progress. Failed files are retried after
+// reconnecting, resuming from the partial upload. It returns the results of the files uploaded so far.
+func (u *Uploader) Upload(ctx context.Context, files []UploadFile, progress func(UploadProgress)) ([]UploadResult, error) {
+	if progress == nil {
+		progress = func(UploadProgress) {}
+	}
+	var total, done int64
+	for _, f := range files {
+		total += f.Size
+	}
+
+	results := make([]UploadResult, 0, len(files))
+	for i, f := range files {
+		var lastReport time.Time
+		var lastStatus string
+		report := func(fileBytes int64, status string) {
+			now := time.Now()
+			if status == lastStatus && now.Sub(lastReport) < uploadProgressInterval {
+				return
+			}
+			lastReport, lastStatus = now, status
+			progress(UploadProgress{FileIndex: i + 1, FileCount: len(files), File: filepath.Base(f.LocalPath),
+				BytesDone: done + fileBytes, BytesTotal: total, Status: status})
+		}
+
+		var res UploadResult
+		var err error
+		for attempt := 1; attempt <= uploadRetryAttempts; attempt++ {
+			err = nil
+			if u.client == nil {
+				report(0, "Connecting via bastion...")
+				err = u.connect()
+			}
+			if err == nil {
+				res, err = u.uploadFile(ctx, f, report)
+			}
+			if err == nil || ctx.Err() != nil {
+				break
+			}
+			u.Close()
+			if attempt < uploadRetryAttempts {
+				report(0, fmt.Sprintf("Retrying %s after error: %v", filepath.Base(f.LocalPath), err))
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Duration(attempt) * uploadRetryBackoff):
+				}
+			}
+		}
+		if err != nil {
+			return results, fmt.Errorf("upload %s: %w", f.LocalPath, err)
+		}
+		done += f.Size
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// uploadFile uploads one file, resuming a partial upload and verifying the sha256 on the target.
+func (u *Uploader) uploadFile(ctx context.Context, f UploadFile, report func(int64, string)) (UploadResult, error) {
+	res := UploadResult{LocalPath: f.LocalPath, RemotePath: f.RemotePath, Size: f.Size}
+	partial := f.RemotePath + partialSuffix
+
+	if _, err := u.run(ctx, "mkdir -p -- "+quoteRemotePath(path.Dir(f.RemotePath)), nil); err != nil {
+		return res, err
+	}
+
+	// A complete file with the same content is not uploaded again.
+	if size, err := u.remoteSize(ctx, f.RemotePath); err == nil && size == f.Size {
+		report(0, "Comparing sha256 of existing remote file...")
+		local, err := fileSHA256(f.LocalPath)
+		if err != nil {
+			return res, err
+		}
+		if remote, err := u.remoteSHA256(ctx, f.RemotePath); err == nil && remote == local {
+			res.SHA256, res.Skipped = local, true
+			report(f.Size, "Already uploaded, skipped")
+			return res, nil
+		}
+	}
+
+	offset, err := u.remoteSize(ctx, partial)
+	if err != nil || offset > f.Size {
+		offset = 0
+	}
+
+	file, err := os.Open(f.LocalPath)
+	if err != nil {
+		return res, fmt.Errorf("open %s: %w", f.LocalPath, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if offset > 0 {
+		if _, err := io.CopyN(hash, file, offset); err != nil {
+			return res, fmt.Errorf("hash %s: %w", f.LocalPath, err)
+		}
+		res.ResumedAt = offset
+	}
+
+	status := "Uploading..."
+	if offset > 0 {
+		status = "Resuming at " + strconv.FormatInt(offset, 10) + " bytes..."
+	}
+	report(offset, status)
+	redirect := ">"
+	if offset > 0 {
+		redirect = ">>"
+	}
+	src := &countingReader{r: io.TeeReader(file, hash), n: offset, onRead: func(n int64) { report(n, status) }}
+	if _, err := u.run(ctx, "cat "+redirect+" "+quoteRemotePath(partial), src); err != nil {
+		return res, err
+	}
+
+	report(f.Size, "Verifying sha256...")
+	res.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	remote, err := u.remoteSHA256(ctx, partial)
+	if err != nil {
+		return res, err
+	}
+	if remote != res.SHA256 {
+		// Start over on the next attempt.
+		_, _ = u.run(ctx, "rm -f -- "+quoteRemotePath(partial), nil)
+		return res, fmt.Errorf("%w: local %s, remote %s", errChecksumMismatch, res.SHA256, remote)
+	}
+
+	finalize := fmt.Sprintf("mv -f -- %s %s && chmod %o %s", quoteRemotePath(partial), quoteRemotePath(f.RemotePath), f.Mode.Perm(), quoteRemotePath(f.RemotePath))
+	if _, err := u.run(ctx, finalize, nil); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// run executes cmd on the target with stdin and returns its standard output.
+// The session is closed when ctx is cancelled.
+func (u *Uploader) run(ctx context.Context, cmd string, stdin io.Reader) (string, error) {
+	sess, err := u.client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("open ssh session: %w", err)
+	}
+	defer sess.Close()
+	sess.Stdin = stdin
+	var stderr strings.Builder
+	sess.Stderr = &stderr
+
+	stop := context.AfterFunc(ctx, func() { _ = sess.Close() })
+	defer stop()
+	out, err := sess.Output(cmd)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("remote command failed: %s: %w", msg, err)
+		}
+		return "", fmt.Errorf("remote command failed: %w", err)
+	}
+	return string(out), nil
+}
+
+// remoteSize returns the size of a remote file, or an error if it does not exist.
+func (u *Uploader) remoteSize(ctx context.Context, p string) (int64, error) {
+	out, err := u.run(ctx, "stat -c %s -- "+quoteRemotePath(p), nil)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+}
+
+// remoteSHA256 returns the hex sha256 of a remote file using sha256sum.
+func (u *Uploader) remoteSHA256(ctx context.Context, p string) (string, error) {
+	out, err := u.run(ctx, "sha256sum -- "+quoteRemotePath(p), nil)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("unexpected sha256sum output %q", out)
+	}
+	return fields[0], nil
+}
+
+// fileSHA256 returns the hex sha256 of a local file.
+func fileSHA256(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", p, err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hash %s: %w", p, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteRemotePath quotes a remote path for a POSIX shell. A leading "~" would not be expanded inside
+// quotes, so it is replaced with "$HOME".
+func quoteRemotePath(p string) string {
+	switch {
+	case p == "~":
+		return `"$HOME"`
+	case strings.HasPrefix(p, "~/"):
+		return `"$HOME"/` + shellQuote(p[2:])
+	}
+	return shellQuote(p)
+}
+
+// countingReader reports the running byte count after every read.
+type countingReader struct {
+	r      io.Reader
+	n      int64
+	onRead func(int64)
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if n > 0 {
+		c.onRead(c.n)
+	}
+	return n, err
+}
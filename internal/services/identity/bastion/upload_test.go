@@ -0,0 +1,250 @@
+package bastion
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// startExecServer starts an SSH server that runs exec requests with sh, standing in for a target instance.
+func startExecServer(t *testing.T, authorized ssh.PublicKey) *net.TCPAddr {
+	t.Helper()
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if meta.User() == "opc" && bytes.Equal(key.Marshal(), authorized.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unauthorized")
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveExecConn(c, config)
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr)
+}
+
+func serveExecConn(c net.Conn, config *ssh.ServerConfig) {
+	sconn, chans, reqs, err := ssh.NewServerConn(c, config)
+	if err != nil {
+		return
+	}
+	defer sconn.Close()
+	go ssh.DiscardRequests(reqs)
+	for newCh := range chans {
+		if newCh.ChannelType() != "session" {
+			_ = newCh.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		ch, chReqs, err := newCh.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer ch.Close()
+			for req := range chReqs {
+				if req.Type != "exec" {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				var payload struct{ Command string }
+				_ = ssh.Unmarshal(req.Payload, &payload)
+				_ = req.Reply(true, nil)
+
+				cmd := exec.Command("sh", "-c", payload.Command)
+				cmd.Stdin, cmd.Stdout, cmd.Stderr = ch, ch, ch.Stderr()
+				status := 0
+				if err := cmd.Run(); err != nil {
+					status = 1
+					var exitErr *exec.ExitError
+					if errors.As(err, &exitErr) {
+						status = exitErr.ExitCode()
+					}
+				}
+				exit := make([]byte, 4)
+				binary.BigEndian.PutUint32(exit, uint32(status))
+				_, _ = ch.SendRequest("exit-status", false, exit)
+				return
+			}
+		}()
+	}
+}
+
+// newTestUploader returns an uploader connected through a bastion stand-in to a local exec server.
+func newTestUploader(t *testing.T) *Uploader {
+	t.Helper()
+	keyPath, pub := writeTestKey(t)
+	target := startExecServer(t, pub)
+	u, err := NewUploader(TransferTarget{
+		PrivateKeyPath: keyPath,
+		SessionID:      testSessionID,
+		TargetIP:       target.IP.String(),
+		TargetPort:     target.Port,
+		TargetUser:     "opc",
+		BastionAddr:    startBastionStandIn(t, pub),
+	})
+	require.NoError(t, err)
+	t.Cleanup(u.Close)
+	return u
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPlanUpload(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.jar"), []byte("jar"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "conf", "env"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "conf", "app.yaml"), []byte("a: 1"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "conf", "env", "prod.env"), []byte("X=1"), 0o644))
+
+	files, err := PlanUpload([]string{filepath.Join(dir, "app.jar")}, "/opt/app/app-1.2.jar")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "/opt/app/app-1.2.jar", files[0].RemotePath)
+	assert.Equal(t, int64(3), files[0].Size)
+
+	files, err = PlanUpload([]string{filepath.Join(dir, "app.jar")}, "/opt/app/")
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/app/app.jar", files[0].RemotePath)
+
+	files, err = PlanUpload([]string{filepath.Join(dir, "app.jar"), filepath.Join(dir, "conf")}, "/opt/app/")
+	require.NoError(t, err)
+	var remotes []string
+	for _, f := range files {
+		remotes = append(remotes, f.RemotePath)
+	}
+	assert.Equal(t, []string{"/opt/app/app.jar", "/opt/app/conf/app.yaml", "/opt/app/conf/env/prod.env"}, remotes)
+	assert.Equal(t, os.FileMode(0o600), files[1].Mode)
+
+	_, err = PlanUpload(nil, "/tmp/")
+	assert.Error(t, err)
+	_, err = PlanUpload([]string{filepath.Join(dir, "missing")}, "/tmp/")
+	assert.Error(t, err)
+}
+
+func TestUploader_UploadResumeAndSkip(t *testing.T) {
+	u := newTestUploader(t)
+	local, remote := t.TempDir(), t.TempDir()
+
+	big := bytes.Repeat([]byte("0123456789abcdef"), 64*1024)
+	require.NoError(t, os.WriteFile(filepath.Join(local, "artifact.bin"), big, 0o640))
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "conf"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "conf", "app.yaml"), []byte("port: 8080\n"), 0o644))
+
+	files, err := PlanUpload([]string{filepath.Join(local, "artifact.bin"), filepath.Join(local, "conf")}, remote+"/")
+	require.NoError(t, err)
+
+	var last UploadProgress
+	results, err := u.Upload(context.Background(), files, func(p UploadProgress) { last = p })
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	got, err := os.ReadFile(filepath.Join(remote, "artifact.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, big, got)
+	assert.Equal(t, sha256Hex(big), results[0].SHA256)
+	info, err := os.Stat(filepath.Join(remote, "artifact.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+	assert.FileExists(t, filepath.Join(remote, "conf", "app.yaml"))
+	assert.NoFileExists(t, filepath.Join(remote, "artifact.bin"+partialSuffix))
+	assert.Equal(t, 2, last.FileCount)
+	assert.Equal(t, int64(len(big)+len("port: 8080\n")), last.BytesTotal)
+
+	// A dropped transfer leaves a partial file; the next upload continues from its end.
+	require.NoError(t, os.Remove(filepath.Join(remote, "artifact.bin")))
+	half := len(big) / 2
+	require.NoError(t, os.WriteFile(filepath.Join(remote, "artifact.bin"+partialSuffix), big[:half], 0o644))
+	results, err = u.Upload(context.Background(), files[:1], nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(half), results[0].ResumedAt)
+	got, err = os.ReadFile(filepath.Join(remote, "artifact.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, big, got)
+
+	// Identical remote files are skipped.
+	results, err = u.Upload(context.Background(), files, nil)
+	require.NoError(t, err)
+	assert.True(t, results[0].Skipped)
+	assert.True(t, results[1].Skipped)
+}
+
+func TestUploader_HomeRelativeDestination(t *testing.T) {
+	u := newTestUploader(t)
+	home, local := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.WriteFile(filepath.Join(local, "app.yaml"), []byte("port: 8080\n"), 0o644))
+
+	files, err := PlanUpload([]string{filepath.Join(local, "app.yaml")}, "~/conf/")
+	require.NoError(t, err)
+	_, err = u.Upload(context.Background(), files, nil)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(home, "conf", "app.yaml"))
+	assert.NoDirExists(t, filepath.Join(home, "~"), "the remote shell expands ~ to the home directory")
+}
+
+func TestUploader_CorruptPartialIsReuploaded(t *testing.T) {
+	backoff := uploadRetryBackoff
+	uploadRetryBackoff = time.Millisecond
+	t.Cleanup(func() { uploadRetryBackoff = backoff })
+
+	u := newTestUploader(t)
+	local, remote := t.TempDir(), t.TempDir()
+	content := []byte("the quick brown fox jumps over the lazy dog")
+	require.NoError(t, os.WriteFile(filepath.Join(local, "notes.txt"), content, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(remote, "notes.txt"+partialSuffix), []byte("THE QUICK"), 0o644))
+
+	files, err := PlanUpload([]string{filepath.Join(local, "notes.txt")}, filepath.Join(remote, "notes.txt"))
+	require.NoError(t, err)
+	results, err := u.Upload(context.Background(), files, nil)
+	require.NoError(t, err)
+	assert.Zero(t, results[0].ResumedAt, "the retry after the checksum mismatch starts from scratch")
+	got, err := os.ReadFile(filepath.Join(remote, "notes.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'/tmp/a b'`, shellQuote("/tmp/a b"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
+
+func TestQuoteRemotePath(t *testing.T) {
+	assert.Equal(t, `'/tmp/a b'`, quoteRemotePath("/tmp/a b"))
+	assert.Equal(t, `"$HOME"/'dir/a b'`, quoteRemotePath("~/dir/a b"))
+	assert.Equal(t, `"$HOME"`, quoteRemotePath("~"))
+	assert.Equal(t, `'~user/x'`, quoteRemotePath("~user/x"), "only the caller's home is expanded")
+}
@@ -0,0 +1,69 @@
+package compartment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/domain/identity"
+)
+
+// Ref is a compartment a report covers, by OCID and display name.
+type Ref struct {
+	ID   string
+	Name string
+}
+
+// Tenancy returns the root compartment of a tenancy followed by every compartment in it.
+func Tenancy(ctx context.Context, repo identity.CompartmentRepository, tenancyID, name string) ([]Ref, error) {
+	// Listing the tenancy returns its whole subtree, so there is nothing left to walk.
+	children, err := repo.ListCompartments(ctx, tenancyID)
+	if err != nil {
+		return nil, fmt.Errorf("listing compartments of the tenancy: %w", err)
+	}
+	return appendUnique([]Ref{{ID: tenancyID, Name: name}}, map[string]bool{tenancyID: true}, children), nil
+}
+
+// Walk returns the compartments a report covers and a description of the choice: the root compartment and
+// every compartment of the tenancy with tenancyScope or when no compartment is configured, the configured
+// compartment and its descendants with recursive, otherwise only the configured compartment. A recursive
+// walk of the tenancy itself is a tenancy-wide one. Every compartment is returned once.
+func Walk(ctx context.Context, repo identity.CompartmentRepository, appCtx *app.ApplicationContext, tenancyScope, recursive bool) ([]Ref, string, error) {
+	if tenancyScope || appCtx.CompartmentID == "" || (recursive && appCtx.CompartmentID == appCtx.TenancyID) {
+		name := appCtx.TenancyName
+		if name == "" {
+			name = "root"
+		}
+		compartments, err := Tenancy(ctx, repo, appCtx.TenancyID, name)
+		if err != nil {
+			return nil, "", err
+		}
+		return compartments, "tenancy " + name, nil
+	}
+
+	compartments := []Ref{{ID: appCtx.CompartmentID, Name: appCtx.CompartmentName}}
+	if !recursive {
+		return compartments, "compartment " + appCtx.CompartmentName, nil
+	}
+	seen := map[string]bool{appCtx.CompartmentID: true}
+	for i := 0; i < len(compartments); i++ {
+		children, err := repo.ListCompartments(ctx, compartments[i].ID)
+		if err != nil {
+			return nil, "", fmt.Errorf("listing compartments of %s: %w", compartments[i].Name, err)
+		}
+		compartments = appendUnique(compartments, seen, children)
+	}
+	return compartments, "compartment " + appCtx.CompartmentName + " and its descendants", nil
+}
+
+// appendUnique appends the compartments not seen yet and marks them as seen.
+func appendUnique(refs []Ref, seen map[string]bool, compartments []identity.Compartment) []Ref {
+	for _, c := range compartments {
+		if seen[c.OCID] {
+			continue
+		}
+		seen[c.OCID] = true
+		refs = append(refs, Ref{ID: c.OCID, Name: c.DisplayName})
+	}
+	return refs
+}
@@ -0,0 +1,70 @@
+package compartment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/domain/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// treeRepository implements identity.CompartmentRepository with children by parent OCID and counts the
+// listings.
+type treeRepository struct {
+	children map[string][]identity.Compartment
+	listed   map[string]int
+}
+
+func (r *treeRepository) GetCompartment(ctx context.Context, ocid string) (*identity.Compartment, error) {
+	return nil, assert.AnError
+}
+
+func (r *treeRepository) ListCompartments(ctx context.Context, ocid string) ([]identity.Compartment, error) {
+	r.listed[ocid]++
+	return r.children[ocid], nil
+}
+
+func TestWalk(t *testing.T) {
+	newRepo := func() *treeRepository {
+		return &treeRepository{listed: map[string]int{}, children: map[string][]identity.Compartment{
+			// Listing the tenancy returns its whole subtree.
+			"tenancy": {{OCID: "c-prod", DisplayName: "prod"}, {OCID: "c-team", DisplayName: "team"}, {OCID: "c-dev", DisplayName: "dev"}},
+			"c-prod":  {{OCID: "c-team", DisplayName: "team"}},
+			// A child listed twice is returned once.
+			"c-team": {{OCID: "c-nested", DisplayName: "nested"}, {OCID: "c-nested", DisplayName: "nested"}},
+		}}
+	}
+	appCtx := &app.ApplicationContext{TenancyID: "tenancy", TenancyName: "acme", CompartmentID: "c-prod", CompartmentName: "prod"}
+
+	repo := newRepo()
+	refs, scope, err := Walk(context.Background(), repo, appCtx, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "compartment prod", scope)
+	assert.Equal(t, []Ref{{ID: "c-prod", Name: "prod"}}, refs)
+	assert.Empty(t, repo.listed)
+
+	refs, scope, err = Walk(context.Background(), newRepo(), appCtx, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, "compartment prod and its descendants", scope)
+	assert.Equal(t, []Ref{{ID: "c-prod", Name: "prod"}, {ID: "c-team", Name: "team"}, {ID: "c-nested", Name: "nested"}}, refs)
+
+	refs, scope, err = Walk(context.Background(), newRepo(), appCtx, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, "tenancy acme", scope)
+	assert.Len(t, refs, 4)
+
+	repo = newRepo()
+	tenancyCtx := &app.ApplicationContext{TenancyID: "tenancy", TenancyName: "acme", CompartmentID: "tenancy", CompartmentName: "acme"}
+	refs, scope, err = Walk(context.Background(), repo, tenancyCtx, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, "tenancy acme", scope)
+	assert.Equal(t, []Ref{{ID: "tenancy", Name: "acme"}, {ID: "c-prod", Name: "prod"}, {ID: "c-team", Name: "team"}, {ID: "c-dev", Name: "dev"}}, refs)
+	assert.Equal(t, map[string]int{"tenancy": 1}, repo.listed, "the tenancy's subtree is not walked again")
+}
+
+func TestTenancy_Error(t *testing.T) {
+	_, err := Tenancy(context.Background(), &mockCompartmentRepository{err: assert.AnError}, "tenancy", "acme")
+	require.ErrorIs(t, err, assert.AnError)
+}
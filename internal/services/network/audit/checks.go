@@ -0,0 +1,207 @@
+package audit
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+)
+
+// adminPorts and databasePorts are the TCP ports that must not be open to the internet.
+var (
+	adminPorts    = []int{22, 3389}
+	databasePorts = []int{1433, 1521, 1522, 3306, 5432, 6379, 9200, 27017, 33060}
+)
+
+// ephemeralPorts is the destination port range of return traffic to a client.
+var ephemeralPorts = domain.PortRange{Min: 1024, Max: 65535}
+
+// ruleSet is the rules of one security list or NSG, as a finding points at them.
+type ruleSet struct {
+	resourceType string
+	name         string
+	id           string
+	ingress      []domain.SecurityRule
+	egress       []domain.SecurityRule
+}
+
+// AuditVCN checks the security lists and NSGs of v. nsgInUse tells which NSGs have VNICs attached;
+// NSGs missing from it are not checked for use.
+func AuditVCN(v domain.VCN, compartment string, nsgInUse map[string]bool) []Finding {
+	var findings []Finding
+	add := func(check Check, severity Severity, set ruleSet, rule, format string, args ...any) {
+		findings = append(findings, Finding{
+			Severity:     severity,
+			CheckID:      check.ID,
+			Check:        check.Name,
+			Compartment:  compartment,
+			VCN:          v.DisplayName,
+			ResourceType: set.resourceType,
+			ResourceName: set.name,
+			ResourceID:   set.id,
+			Rule:         rule,
+			Detail:       fmt.Sprintf(format, args...),
+		})
+	}
+
+	attached := map[string]bool{}
+	for _, s := range v.Subnets {
+		for _, id := range s.SecurityListIDs {
+			attached[id] = true
+		}
+	}
+
+	var sets []ruleSet
+	for _, sl := range v.SecurityLists {
+		set := ruleSet{resourceType: ResourceSecurityList, name: sl.DisplayName, id: sl.OCID, ingress: sl.IngressRules, egress: sl.EgressRules}
+		sets = append(sets, set)
+		if !attached[sl.OCID] {
+			add(CheckUnusedSecurityList, CheckUnusedSecurityList.Severity, set, "", "security list %s is not attached to any subnet", sl.DisplayName)
+		}
+	}
+	for _, nsg := range v.NSGs {
+		set := ruleSet{resourceType: ResourceNSG, name: nsg.DisplayName, id: nsg.OCID}
+		for _, r := range nsg.Rules {
+			if r.Direction == domain.DirectionEgress {
+				set.egress = append(set.egress, r)
+			} else {
+				set.ingress = append(set.ingress, r)
+			}
+		}
+		sets = append(sets, set)
+		if inUse, ok := nsgInUse[nsg.OCID]; ok && !inUse {
+			add(CheckUnusedNSG, CheckUnusedNSG.Severity, set, "", "NSG %s has no VNICs attached", nsg.DisplayName)
+		}
+	}
+
+	for _, set := range sets {
+		for i, r := range set.ingress {
+			rule := fmt.Sprintf("ingress rule #%d", i+1)
+			world := isWorld(r)
+			if r.Protocol == domain.ProtocolAll {
+				if world {
+					add(CheckAllProtocols, SeverityCritical, set, rule, "all protocols and ports are open to %s", r.Peer)
+				} else {
+					add(CheckAllProtocols, CheckAllProtocols.Severity, set, rule, "all protocols and ports are open to %s", peerLabel(r))
+				}
+			} else if world && r.Protocol == domain.ProtocolTCP {
+				if open := openPorts(r, adminPorts); len(open) > 0 {
+					add(CheckWorldAdminPort, CheckWorldAdminPort.Severity, set, rule, "TCP %s open to %s", joinPorts(open), r.Peer)
+				}
+				if open := openPorts(r, databasePorts); len(open) > 0 {
+					add(CheckWorldDBPort, CheckWorldDBPort.Severity, set, rule, "TCP %s open to %s", joinPorts(open), r.Peer)
+				}
+			}
+			if r.Stateless && !hasReturnRule(r, set.egress) {
+				add(CheckStatelessReturn, CheckStatelessReturn.Severity, set, rule,
+					"stateless %s %s from %s has no egress rule for the replies", r.ProtocolName(), r.PortSummary(), peerLabel(r))
+			}
+		}
+		for i, r := range set.egress {
+			if r.Stateless && !hasReturnRule(r, set.ingress) {
+				add(CheckStatelessReturn, CheckStatelessReturn.Severity, set, fmt.Sprintf("egress rule #%d", i+1),
+					"stateless %s %s to %s has no ingress rule for the replies", r.ProtocolName(), r.PortSummary(), peerLabel(r))
+			}
+		}
+	}
+	return findings
+}
+
+// SortFindings orders findings from most to least severe, then by location.
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity.Rank() != b.Severity.Rank() {
+			return a.Severity.Rank() < b.Severity.Rank()
+		}
+		for _, pair := range [][2]string{{a.Compartment, b.Compartment}, {a.VCN, b.VCN}, {a.ResourceName, b.ResourceName}, {a.Rule, b.Rule}} {
+			if pair[0] != pair[1] {
+				return pair[0] < pair[1]
+			}
+		}
+		return false
+	})
+}
+
+// hasReturnRule reports whether any rule in the opposite direction lets the replies to r through:
+// the same protocol and peer, with the ports swapped.
+func hasReturnRule(r domain.SecurityRule, opposite []domain.SecurityRule) bool {
+	for _, o := range opposite {
+		if o.Protocol != domain.ProtocolAll && o.Protocol != r.Protocol {
+			continue
+		}
+		if !peerCovers(o, r) {
+			continue
+		}
+		if o.Protocol == domain.ProtocolAll || (r.Protocol != domain.ProtocolTCP && r.Protocol != domain.ProtocolUDP) {
+			return true
+		}
+		clientPorts := r.SourcePorts
+		if clientPorts == nil {
+			clientPorts = &ephemeralPorts
+		}
+		if portsCover(o.SourcePorts, r.DestinationPorts) && portsCover(o.DestinationPorts, clientPorts) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerCovers reports whether the peer of o includes the peer of r.
+func peerCovers(o, r domain.SecurityRule) bool {
+	if o.PeerType != domain.AddressTypeCIDR || r.PeerType != domain.AddressTypeCIDR {
+		return o.PeerType == r.PeerType && o.Peer == r.Peer
+	}
+	_, outer, err := net.ParseCIDR(o.Peer)
+	if err != nil {
+		return false
+	}
+	_, inner, err := net.ParseCIDR(r.Peer)
+	if err != nil {
+		return false
+	}
+	outerOnes, _ := outer.Mask.Size()
+	innerOnes, _ := inner.Mask.Size()
+	return outer.Contains(inner.IP) && outerOnes <= innerOnes
+}
+
+// portsCover reports whether outer includes inner; nil means every port.
+func portsCover(outer, inner *domain.PortRange) bool {
+	if outer == nil {
+		return true
+	}
+	return inner != nil && outer.Min <= inner.Min && inner.Max <= outer.Max
+}
+
+// openPorts returns the ports the rule's destination range opens.
+func openPorts(r domain.SecurityRule, ports []int) []int {
+	var open []int
+	for _, p := range ports {
+		if r.DestinationPorts == nil || r.DestinationPorts.Contains(p) {
+			open = append(open, p)
+		}
+	}
+	return open
+}
+
+func isWorld(r domain.SecurityRule) bool {
+	return r.PeerType == domain.AddressTypeCIDR && (r.Peer == "0.0.0.0/0" || r.Peer == "::/0")
+}
+
+func peerLabel(r domain.SecurityRule) string {
+	if r.PeerType == domain.AddressTypeNSG && r.PeerName != "" {
+		return r.PeerName + " (NSG)"
+	}
+	return r.Peer
+}
+
+func joinPorts(ports []int) string {
+	parts := make([]string, len(ports))
+	for i, p := range ports {
+		parts[i] = strconv.Itoa(p)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"testing"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ports(min, max int) *domain.PortRange { return &domain.PortRange{Min: min, Max: max} }
+
+func ingress(protocol, peer string, dst *domain.PortRange) domain.SecurityRule {
+	return domain.SecurityRule{Direction: domain.DirectionIngress, Protocol: protocol, Peer: peer, PeerType: domain.AddressTypeCIDR, DestinationPorts: dst}
+}
+
+// auditFixture has one risky, one clean and one unused security list, and a used and an unused NSG.
+func auditFixture() domain.VCN {
+	statelessDNS := ingress(domain.ProtocolUDP, "10.0.0.0/16", ports(53, 53))
+	statelessDNS.Stateless = true
+	statelessNTP := ingress(domain.ProtocolUDP, "10.0.0.0/16", ports(123, 123))
+	statelessNTP.Stateless = true
+	return domain.VCN{
+		OCID: "vcn-1", DisplayName: "prod",
+		Subnets: []domain.Subnet{
+			{OCID: "sn-1", SecurityListIDs: []string{"sl-public", "sl-clean"}},
+		},
+		SecurityLists: []domain.SecurityList{
+			{OCID: "sl-public", DisplayName: "public", IngressRules: []domain.SecurityRule{
+				ingress(domain.ProtocolTCP, "0.0.0.0/0", ports(22, 22)),
+				ingress(domain.ProtocolTCP, "0.0.0.0/0", nil),
+				ingress(domain.ProtocolTCP, "0.0.0.0/0", ports(443, 443)),
+				statelessDNS,
+				statelessNTP,
+			}, EgressRules: []domain.SecurityRule{
+				{Direction: domain.DirectionEgress, Protocol: domain.ProtocolUDP, Peer: "10.0.0.0/8", PeerType: domain.AddressTypeCIDR,
+					SourcePorts: ports(53, 53), Stateless: true},
+			}},
+			{OCID: "sl-clean", DisplayName: "clean", IngressRules: []domain.SecurityRule{
+				ingress(domain.ProtocolTCP, "10.0.0.0/16", ports(1521, 1521)),
+			}},
+			{OCID: "sl-old", DisplayName: "old"},
+		},
+		NSGs: []domain.NSG{
+			{OCID: "nsg-used", DisplayName: "used", Rules: []domain.SecurityRule{ingress(domain.ProtocolAll, "0.0.0.0/0", nil)}},
+			{OCID: "nsg-idle", DisplayName: "idle", Rules: []domain.SecurityRule{ingress(domain.ProtocolAll, "10.1.0.0/16", nil)}},
+			{OCID: "nsg-unknown", DisplayName: "unknown"},
+		},
+	}
+}
+
+func TestAuditVCN(t *testing.T) {
+	findings := AuditVCN(auditFixture(), "prod-comp", map[string]bool{"nsg-used": true, "nsg-idle": false})
+	SortFindings(findings)
+
+	type key struct {
+		severity              Severity
+		check, resource, rule string
+	}
+	var got []key
+	for _, f := range findings {
+		got = append(got, key{f.Severity, f.Check, f.ResourceName, f.Rule})
+	}
+	assert.Equal(t, []key{
+		{SeverityCritical, CheckWorldAdminPort.Name, "public", "ingress rule #1"},
+		{SeverityCritical, CheckWorldAdminPort.Name, "public", "ingress rule #2"},
+		{SeverityCritical, CheckAllProtocols.Name, "used", "ingress rule #1"},
+		{SeverityHigh, CheckWorldDBPort.Name, "public", "ingress rule #2"},
+		{SeverityMedium, CheckAllProtocols.Name, "idle", "ingress rule #1"},
+		{SeverityMedium, CheckStatelessReturn.Name, "public", "egress rule #1"},
+		{SeverityMedium, CheckStatelessReturn.Name, "public", "ingress rule #5"},
+		{SeverityLow, CheckUnusedNSG.Name, "idle", ""},
+		{SeverityLow, CheckUnusedSecurityList.Name, "old", ""},
+	}, got)
+
+	require.NotEmpty(t, findings)
+	assert.Equal(t, "prod-comp", findings[0].Compartment)
+	assert.Equal(t, "prod", findings[0].VCN)
+	assert.Equal(t, "sl-public", findings[0].ResourceID)
+	assert.Equal(t, "nsg-used", findings[2].ResourceID)
+	for _, f := range findings {
+		if f.Check == CheckWorldAdminPort.Name && f.Rule == "ingress rule #2" {
+			assert.Equal(t, "TCP 22, 3389 open to 0.0.0.0/0", f.Detail)
+		}
+	}
+}
+
+func TestHasReturnRule(t *testing.T) {
+	request := ingress(domain.ProtocolTCP, "10.0.1.0/24", ports(8080, 8080))
+	reply := domain.SecurityRule{Protocol: domain.ProtocolTCP, Peer: "10.0.0.0/16", PeerType: domain.AddressTypeCIDR, SourcePorts: ports(8080, 8080)}
+	assert.True(t, hasReturnRule(request, []domain.SecurityRule{reply}))
+
+	narrow := reply
+	narrow.Peer = "10.0.1.128/25"
+	assert.False(t, hasReturnRule(request, []domain.SecurityRule{narrow}), "the reply rule must cover the whole peer CIDR")
+
+	toFixedPort := reply
+	toFixedPort.DestinationPorts = ports(8080, 8080)
+	assert.False(t, hasReturnRule(request, []domain.SecurityRule{toFixedPort}), "replies go to the client's ephemeral port")
+
+	assert.True(t, hasReturnRule(request, []domain.SecurityRule{{Protocol: domain.ProtocolAll, Peer: "0.0.0.0/0", PeerType: domain.AddressTypeCIDR}}))
+}
@@ -0,0 +1,153 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/rozdolsky33/ocloud/buildinfo"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/printer"
+)
+
+// PrintReport prints the audit summary and findings as tables, or the report as JSON or SARIF.
+func PrintReport(report *Report, appCtx *app.ApplicationContext, useJSON, useSARIF bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useSARIF {
+		return p.MarshalToJSON(toSARIF(report))
+	}
+	if useJSON {
+		return p.MarshalToJSON(report)
+	}
+
+	counts := map[Severity]int{}
+	for _, f := range report.Findings {
+		counts[f.Severity]++
+	}
+	summary := map[string]string{
+		"Scope":          report.Scope,
+		"Compartments":   fmt.Sprintf("%d", report.Compartments),
+		"VCNs":           fmt.Sprintf("%d", report.VCNs),
+		"Security Lists": fmt.Sprintf("%d", report.SecurityLists),
+		"NSGs":           fmt.Sprintf("%d", report.NSGs),
+		"Findings": fmt.Sprintf("%d critical, %d high, %d medium, %d low",
+			counts[SeverityCritical], counts[SeverityHigh], counts[SeverityMedium], counts[SeverityLow]),
+	}
+	keys := []string{"Scope", "Compartments", "VCNs", "Security Lists", "NSGs", "Findings"}
+	p.PrintKeyValuesNoTruncate("Network security audit", summary, keys)
+
+	if len(report.Findings) > 0 {
+		headers := []string{"Severity", "Check", "Compartment", "VCN", "Resource", "Rule", "Detail"}
+		rows := make([][]string, 0, len(report.Findings))
+		for _, f := range report.Findings {
+			rule := f.Rule
+			if rule == "" {
+				rule = "-"
+			}
+			rows = append(rows, []string{string(f.Severity), f.Check, f.Compartment, f.VCN, f.ResourceType + " " + f.ResourceName, rule, f.Detail})
+		}
+		p.PrintTableNoTruncate("Findings", headers, rows)
+	}
+	for _, e := range report.Errors {
+		fmt.Fprintf(appCtx.Stdout, "Skipped %s\n", e)
+	}
+	return nil
+}
+
+// SARIF 2.1.0 log, limited to the properties the audit fills in.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID                   string             `json:"id"`
+		Name                 string             `json:"name"`
+		ShortDescription     sarifMessage       `json:"shortDescription"`
+		DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	}
+	sarifConfiguration struct {
+		Level string `json:"level"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID     string          `json:"ruleId"`
+		Level      string          `json:"level"`
+		Message    sarifMessage    `json:"message"`
+		Locations  []sarifLocation `json:"locations"`
+		Properties sarifProperties `json:"properties"`
+	}
+	sarifLocation struct {
+		LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+	}
+	sarifLogicalLocation struct {
+		Name               string `json:"name"`
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+		Kind               string `json:"kind"`
+	}
+	sarifProperties struct {
+		Severity    Severity `json:"severity"`
+		Compartment string   `json:"compartment"`
+		VCN         string   `json:"vcn"`
+	}
+)
+
+// toSARIF converts the report to a SARIF log with one rule per check. Findings point at their security
+// list or NSG as a logical location, since they have no source file.
+func toSARIF(report *Report) sarifLog {
+	driver := sarifDriver{Name: "ocloud", Version: buildinfo.Version, InformationURI: "https://github.com/rozdolsky33/ocloud"}
+	for _, c := range AllChecks {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   c.ID,
+			Name:                 c.Name,
+			ShortDescription:     sarifMessage{Text: c.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(c.Severity)},
+		})
+	}
+	results := make([]sarifResult, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		name := f.ResourceType + " " + f.ResourceName
+		if f.Rule != "" {
+			name += " " + f.Rule
+		}
+		results = append(results, sarifResult{
+			RuleID:  f.CheckID,
+			Level:   sarifLevel(f.Severity),
+			Message: sarifMessage{Text: f.Detail},
+			Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+				Name: name, FullyQualifiedName: f.ResourceID, Kind: "resource",
+			}}}},
+			Properties: sarifProperties{Severity: f.Severity, Compartment: f.Compartment, VCN: f.VCN},
+		})
+	}
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityCritical, SeverityHigh:
+		return "error"
+	case SeverityMedium:
+		return "warning"
+	}
+	return "note"
+}
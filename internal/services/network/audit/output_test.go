@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReport() *Report {
+	findings := AuditVCN(auditFixture(), "prod", map[string]bool{"nsg-used": true, "nsg-idle": false, "nsg-unknown": true})
+	SortFindings(findings)
+	return &Report{Scope: "compartment prod", Compartments: 1, VCNs: 1, SecurityLists: 3, NSGs: 3, Findings: findings,
+		Errors: []string{"compartment secret: denied"}}
+}
+
+func TestPrintReport_Table(t *testing.T) {
+	buf := &bytes.Buffer{}
+	appCtx := &app.ApplicationContext{Logger: logger.NewTestLogger(), Stdout: buf}
+
+	require.NoError(t, PrintReport(testReport(), appCtx, false, false))
+	out := buf.String()
+	assert.Contains(t, out, "Network security audit")
+	assert.Contains(t, out, "3 critical, 1 high, 3 medium, 2 low")
+	assert.Contains(t, out, "world-open-admin-port")
+	assert.Contains(t, out, "Skipped compartment secret: denied")
+
+	buf.Reset()
+	require.NoError(t, PrintReport(testReport(), appCtx, true, false))
+	assert.Contains(t, buf.String(), `"check_id": "OCLOUD-NET-001"`)
+}
+
+func TestPrintReport_SARIF(t *testing.T) {
+	buf := &bytes.Buffer{}
+	appCtx := &app.ApplicationContext{Logger: logger.NewTestLogger(), Stdout: buf}
+
+	require.NoError(t, PrintReport(testReport(), appCtx, true, true))
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log), "SARIF takes precedence over JSON")
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(AllChecks))
+	require.Len(t, log.Runs[0].Results, 9)
+	first := log.Runs[0].Results[0]
+	assert.Equal(t, "OCLOUD-NET-001", first.RuleID)
+	assert.Equal(t, "error", first.Level)
+	assert.Equal(t, "sl-public", first.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "note", log.Runs[0].Results[8].Level)
+}
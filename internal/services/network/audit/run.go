@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	"github.com/rozdolsky33/ocloud/internal/oci/identity/compartment"
+	ocivcn "github.com/rozdolsky33/ocloud/internal/oci/network/vcn"
+)
+
+// RunAudit audits the security lists and NSGs of the selected compartments and prints the findings
+// as a table, JSON or SARIF.
+func RunAudit(appCtx *app.ApplicationContext, opts Options, useJSON, useSARIF bool) error {
+	ctx := context.Background()
+	compartmentRepo := compartment.NewCompartmentAdapter(appCtx.IdentityClient, appCtx.TenancyID)
+	compartments, scope, err := Compartments(ctx, compartmentRepo, appCtx, opts)
+	if err != nil {
+		return err
+	}
+
+	networkClient, err := oci.NewNetworkClient(appCtx.Provider)
+	if err != nil {
+		return fmt.Errorf("creating network client: %w", err)
+	}
+	service := NewService(ocivcn.NewAdapterWithRules(networkClient), appCtx.Logger)
+	report := service.Audit(ctx, compartments)
+	report.Scope = scope
+
+	return PrintReport(report, appCtx, useJSON, useSARIF)
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/domain/identity"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/services/identity/compartment"
+)
+
+// Repository is what the audit reads: enriched VCNs and whether their NSGs are attached.
+type Repository interface {
+	domain.VCNRepository
+	domain.NSGUsageRepository
+}
+
+// Service is the application-layer service for security rule audits.
+type Service struct {
+	repo   Repository
+	logger logr.Logger
+}
+
+// NewService initializes a new Service instance.
+func NewService(repo Repository, logger logr.Logger) *Service {
+	return &Service{repo: repo, logger: logger}
+}
+
+// Compartments returns the compartments an audit covers: the root compartment and every compartment of the
+// tenancy with TenancyScope, the configured compartment and its descendants with Recursive, otherwise only
+// the configured compartment. The scope describes the choice for the report.
+func Compartments(ctx context.Context, repo identity.CompartmentRepository, appCtx *app.ApplicationContext, opts Options) ([]Compartment, string, error) {
+	return compartment.Walk(ctx, repo, appCtx, opts.TenancyScope, opts.Recursive)
+}
+
+// Audit checks every VCN of the compartments and returns the findings ranked by severity.
+func (s *Service) Audit(ctx context.Context, compartments []Compartment) *Report {
+	report := &Report{Compartments: len(compartments)}
+	for _, c := range compartments {
+		s.logger.V(logger.Debug).Info("auditing compartment", "compartment", c.Name)
+		vcns, err := s.repo.ListEnrichedVcns(ctx, c.ID)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("compartment %s: %v", c.Name, err))
+			continue
+		}
+		for _, v := range vcns {
+			report.VCNs++
+			report.SecurityLists += len(v.SecurityLists)
+			report.NSGs += len(v.NSGs)
+			inUse := make(map[string]bool, len(v.NSGs))
+			for _, nsg := range v.NSGs {
+				used, err := s.repo.NSGHasVnics(ctx, nsg.OCID)
+				if err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("NSG %s in VCN %s: %v", nsg.DisplayName, v.DisplayName, err))
+					continue
+				}
+				inUse[nsg.OCID] = used
+			}
+			report.Findings = append(report.Findings, AuditVCN(v, c.Name, inUse)...)
+		}
+	}
+	SortFindings(report.Findings)
+	return report
+}
@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/domain/identity"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepo implements Repository with VCNs by compartment and attached NSGs.
+type fakeRepo struct {
+	byCompartment map[string][]domain.VCN
+	attached      map[string]bool
+}
+
+func (f *fakeRepo) GetEnrichedVcn(ctx context.Context, ocid string) (domain.VCN, error) {
+	return domain.VCN{}, assert.AnError
+}
+
+func (f *fakeRepo) ListVcns(ctx context.Context, compartmentID string) ([]domain.VCN, error) {
+	return f.ListEnrichedVcns(ctx, compartmentID)
+}
+
+func (f *fakeRepo) ListEnrichedVcns(ctx context.Context, compartmentID string) ([]domain.VCN, error) {
+	vcns, ok := f.byCompartment[compartmentID]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return vcns, nil
+}
+
+func (f *fakeRepo) NSGHasVnics(ctx context.Context, nsgID string) (bool, error) {
+	attached, ok := f.attached[nsgID]
+	if !ok {
+		return false, assert.AnError
+	}
+	return attached, nil
+}
+
+// fakeCompartments implements identity.CompartmentRepository with children by parent OCID.
+type fakeCompartments map[string][]identity.Compartment
+
+func (f fakeCompartments) GetCompartment(ctx context.Context, ocid string) (*identity.Compartment, error) {
+	return nil, assert.AnError
+}
+
+func (f fakeCompartments) ListCompartments(ctx context.Context, ocid string) ([]identity.Compartment, error) {
+	return f[ocid], nil
+}
+
+func TestService_Audit(t *testing.T) {
+	repo := &fakeRepo{
+		byCompartment: map[string][]domain.VCN{"c-prod": {auditFixture()}, "c-dev": nil},
+		attached:      map[string]bool{"nsg-used": true, "nsg-idle": false},
+	}
+	svc := NewService(repo, logger.NewTestLogger())
+
+	report := svc.Audit(context.Background(), []Compartment{{ID: "c-prod", Name: "prod"}, {ID: "c-dev", Name: "dev"}, {ID: "c-denied", Name: "secret"}})
+
+	assert.Equal(t, 3, report.Compartments)
+	assert.Equal(t, 1, report.VCNs)
+	assert.Equal(t, 3, report.SecurityLists)
+	assert.Equal(t, 3, report.NSGs)
+	assert.Len(t, report.Findings, 9)
+	assert.Equal(t, SeverityCritical, report.Findings[0].Severity)
+	assert.Equal(t, SeverityLow, report.Findings[len(report.Findings)-1].Severity)
+	require.Len(t, report.Errors, 2, "the unreadable NSG and compartment are reported")
+	assert.Contains(t, report.Errors[0], "NSG unknown")
+	assert.Contains(t, report.Errors[1], "compartment secret")
+}
+
+func TestCompartments(t *testing.T) {
+	repo := fakeCompartments{
+		"tenancy": {{OCID: "c-prod", DisplayName: "prod"}, {OCID: "c-team", DisplayName: "team"}},
+		"c-prod":  {{OCID: "c-team", DisplayName: "team"}},
+	}
+	appCtx := &app.ApplicationContext{TenancyID: "tenancy", TenancyName: "acme", CompartmentID: "c-prod", CompartmentName: "prod"}
+
+	compartments, scope, err := Compartments(context.Background(), repo, appCtx, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "compartment prod", scope)
+	assert.Equal(t, []Compartment{{ID: "c-prod", Name: "prod"}}, compartments)
+
+	compartments, scope, err = Compartments(context.Background(), repo, appCtx, Options{Recursive: true})
+	require.NoError(t, err)
+	assert.Equal(t, "compartment prod and its descendants", scope)
+	assert.Equal(t, []Compartment{{ID: "c-prod", Name: "prod"}, {ID: "c-team", Name: "team"}}, compartments)
+
+	compartments, scope, err = Compartments(context.Background(), repo, appCtx, Options{TenancyScope: true})
+	require.NoError(t, err)
+	assert.Equal(t, "tenancy acme", scope)
+	assert.Len(t, compartments, 3)
+
+	appCtx.CompartmentID, appCtx.CompartmentName = "tenancy", "acme"
+	compartments, scope, err = Compartments(context.Background(), repo, appCtx, Options{Recursive: true})
+	require.NoError(t, err)
+	assert.Equal(t, "tenancy acme", scope)
+	assert.Equal(t, []Compartment{{ID: "tenancy", Name: "acme"}, {ID: "c-prod", Name: "prod"}, {ID: "c-team", Name: "team"}}, compartments,
+		"a recursive walk of the tenancy lists each compartment once")
+}
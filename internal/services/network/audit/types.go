@@ -0,0 +1,105 @@
+package audit
+
+import "github.com/rozdolsky33/ocloud/internal/services/identity/compartment"
+
+// Severity ranks a finding; Rank orders them from most to least severe.
+type Severity string
+
+const (
+	SeverityCritical Severity = "CRITICAL"
+	SeverityHigh     Severity = "HIGH"
+	SeverityMedium   Severity = "MEDIUM"
+	SeverityLow      Severity = "LOW"
+)
+
+// Rank returns 0 for the most severe findings and higher numbers for less severe ones.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 0
+	case SeverityHigh:
+		return 1
+	case SeverityMedium:
+		return 2
+	}
+	return 3
+}
+
+// Check describes one kind of risky pattern the audit looks for.
+type Check struct {
+	ID          string
+	Name        string
+	Description string
+	Severity    Severity
+}
+
+// Checks, in the order they are listed in the SARIF rules.
+var (
+	CheckWorldAdminPort = Check{
+		ID: "OCLOUD-NET-001", Name: "world-open-admin-port", Severity: SeverityCritical,
+		Description: "Ingress from 0.0.0.0/0 allows SSH or RDP",
+	}
+	CheckWorldDBPort = Check{
+		ID: "OCLOUD-NET-002", Name: "world-open-database-port", Severity: SeverityHigh,
+		Description: "Ingress from 0.0.0.0/0 allows a database port",
+	}
+	CheckAllProtocols = Check{
+		ID: "OCLOUD-NET-003", Name: "all-protocol-ingress", Severity: SeverityMedium,
+		Description: "Ingress allows every protocol and port",
+	}
+	CheckStatelessReturn = Check{
+		ID: "OCLOUD-NET-004", Name: "stateless-without-return", Severity: SeverityMedium,
+		Description: "Stateless rule has no rule in the opposite direction for its return traffic",
+	}
+	CheckUnusedNSG = Check{
+		ID: "OCLOUD-NET-005", Name: "unused-nsg", Severity: SeverityLow,
+		Description: "Network security group is not attached to any VNIC",
+	}
+	CheckUnusedSecurityList = Check{
+		ID: "OCLOUD-NET-006", Name: "unused-security-list", Severity: SeverityLow,
+		Description: "Security list is not attached to any subnet",
+	}
+
+	AllChecks = []Check{CheckWorldAdminPort, CheckWorldDBPort, CheckAllProtocols, CheckStatelessReturn, CheckUnusedNSG, CheckUnusedSecurityList}
+)
+
+// Resource types a finding can point at.
+const (
+	ResourceSecurityList = "Security list"
+	ResourceNSG          = "NSG"
+)
+
+// Finding is one risky rule or unused resource.
+type Finding struct {
+	Severity     Severity `json:"severity"`
+	CheckID      string   `json:"check_id"`
+	Check        string   `json:"check"`
+	Compartment  string   `json:"compartment"`
+	VCN          string   `json:"vcn"`
+	ResourceType string   `json:"resource_type"`
+	ResourceName string   `json:"resource_name"`
+	ResourceID   string   `json:"resource_id"`
+	Rule         string   `json:"rule,omitempty"`
+	Detail       string   `json:"detail"`
+}
+
+// Compartment is a compartment whose VCNs are audited.
+type Compartment = compartment.Ref
+
+// Report is the severity-ranked result of an audit. Compartments, VCNs and NSGs that could not be read
+// are listed in Errors rather than failing the audit.
+type Report struct {
+	Scope         string    `json:"scope"`
+	Compartments  int       `json:"compartments"`
+	VCNs          int       `json:"vcns"`
+	SecurityLists int       `json:"security_lists"`
+	NSGs          int       `json:"nsgs"`
+	Findings      []Finding `json:"findings"`
+	Errors        []string  `json:"errors,omitempty"`
+}
+
+// Options selects the compartments to audit.
+type Options struct {
+	TenancyScope bool
+	Recursive    bool
+}
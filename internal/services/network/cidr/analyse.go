@@ -0,0 +1,170 @@
+package cidr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// reservedPerSubnet is the number of addresses OCI reserves in every IPv4 subnet: the network address,
+// the default gateway and the broadcast address.
+const reservedPerSubnet = 3
+
+// FindOverlaps returns every pair of ranges of the same kind in different VCNs that share addresses,
+// VCN overlaps first. Ranges that are not valid CIDR blocks are ignored.
+func FindOverlaps(ranges []Range) []Overlap {
+	type parsed struct {
+		Range
+		net *net.IPNet
+	}
+	var blocks []parsed
+	for _, r := range ranges {
+		if _, n, err := net.ParseCIDR(r.CIDR); err == nil {
+			blocks = append(blocks, parsed{Range: r, net: n})
+		}
+	}
+
+	var overlaps []Overlap
+	for i := range blocks {
+		for j := i + 1; j < len(blocks); j++ {
+			a, b := blocks[i], blocks[j]
+			if a.Kind != b.Kind || a.VcnID == b.VcnID {
+				continue
+			}
+			if !a.net.Contains(b.net.IP) && !b.net.Contains(a.net.IP) {
+				continue
+			}
+			shared := a.net
+			if prefixLen(a.net) < prefixLen(b.net) {
+				shared = b.net
+			}
+			overlaps = append(overlaps, Overlap{Kind: a.Kind, A: a.Range, B: b.Range, Shared: shared.String()})
+		}
+	}
+	sort.SliceStable(overlaps, func(i, j int) bool {
+		if overlaps[i].Kind != overlaps[j].Kind {
+			return overlaps[i].Kind == KindVCN
+		}
+		if overlaps[i].A.Location() != overlaps[j].A.Location() {
+			return overlaps[i].A.Location() < overlaps[j].A.Location()
+		}
+		return overlaps[i].B.Location() < overlaps[j].B.Location()
+	})
+	return overlaps
+}
+
+// FreeBlocks returns up to limit IPv4 blocks with the given prefix length inside within, in address order,
+// that do not overlap any of the used CIDR blocks. Used blocks that are not IPv4 are ignored.
+func FreeBlocks(within string, size int, used []string, limit int) ([]string, error) {
+	outer, err := parseRange(within, size)
+	if err != nil {
+		return nil, err
+	}
+	outerOnes := prefixLen(outer)
+
+	start := uint64(ipToUint(outer.IP))
+	end := start + uint64(1)<<(32-outerOnes) - 1
+	step := uint64(1) << (32 - size)
+
+	free := []string{}
+	taken := mergeIntervals(used)
+	next := 0
+	for block := start; block+step-1 <= end && len(free) < limit; {
+		for next < len(taken) && taken[next].last < block {
+			next++
+		}
+		if next < len(taken) && taken[next].first <= block+step-1 {
+			// Skip to the first aligned block after the used range.
+			block = (taken[next].last/step + 1) * step
+			continue
+		}
+		free = append(free, fmt.Sprintf("%s/%d", uintToIP(uint32(block)), size))
+		block += step
+	}
+	return free, nil
+}
+
+// parseRange parses the IPv4 range to search and checks that blocks of the given size fit in it.
+func parseRange(within string, size int) (*net.IPNet, error) {
+	_, outer, err := net.ParseCIDR(within)
+	if err != nil || outer.IP.To4() == nil {
+		return nil, fmt.Errorf("invalid IPv4 range %q", within)
+	}
+	if ones := prefixLen(outer); size < ones || size > 30 {
+		return nil, fmt.Errorf("block size /%d must be between /%d and /30", size, ones)
+	}
+	return outer, nil
+}
+
+// ParseSize parses a prefix length given as "/24" or "24".
+func ParseSize(s string) (int, error) {
+	size, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(s), "/"))
+	if err != nil || size < 0 || size > 32 {
+		return 0, fmt.Errorf("invalid block size %q: use a prefix length such as /24", s)
+	}
+	return size, nil
+}
+
+// Capacity returns the number of private IPs that can be allocated in an IPv4 subnet, or 0 if the CIDR is
+// not a valid IPv4 block.
+func Capacity(cidr string) int {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil || n.IP.To4() == nil {
+		return 0
+	}
+	total := 1 << (32 - prefixLen(n))
+	if total <= reservedPerSubnet {
+		return 0
+	}
+	return total - reservedPerSubnet
+}
+
+// interval is an inclusive range of IPv4 addresses.
+type interval struct {
+	first, last uint64
+}
+
+// mergeIntervals converts the IPv4 CIDR blocks to address ranges, sorted and with overlapping or adjacent
+// ranges merged.
+func mergeIntervals(cidrs []string) []interval {
+	var ranges []interval
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil || n.IP.To4() == nil {
+			continue
+		}
+		first := uint64(ipToUint(n.IP))
+		ranges = append(ranges, interval{first: first, last: first + uint64(1)<<(32-prefixLen(n)) - 1})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].first < ranges[j].first })
+
+	var merged []interval
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.first <= merged[n-1].last+1 {
+			if r.last > merged[n-1].last {
+				merged[n-1].last = r.last
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+func prefixLen(n *net.IPNet) int {
+	ones, _ := n.Mask.Size()
+	return ones
+}
+
+func ipToUint(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func uintToIP(v uint32) net.IP {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, v)
+	return ip
+}
@@ -0,0 +1,68 @@
+package cidr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOverlaps(t *testing.T) {
+	ranges := []Range{
+		{Kind: KindVCN, Tenancy: "acme", Region: "r1", Compartment: "prod", VCN: "prod", VcnID: "v1", CIDR: "10.0.0.0/16"},
+		{Kind: KindSubnet, Tenancy: "acme", Region: "r1", Compartment: "prod", VCN: "prod", VcnID: "v1", Subnet: "app", CIDR: "10.0.1.0/24"},
+		{Kind: KindSubnet, Tenancy: "acme", Region: "r1", Compartment: "prod", VCN: "prod", VcnID: "v1", Subnet: "db", CIDR: "10.0.2.0/24"},
+		{Kind: KindVCN, Tenancy: "acme", Region: "r2", Compartment: "dr", VCN: "dr", VcnID: "v2", CIDR: "10.0.0.0/8"},
+		{Kind: KindSubnet, Tenancy: "acme", Region: "r2", Compartment: "dr", VCN: "dr", VcnID: "v2", Subnet: "all", CIDR: "10.0.0.0/20"},
+		{Kind: KindVCN, Tenancy: "other", Region: "r1", Compartment: "lab", VCN: "lab", VcnID: "v3", CIDR: "192.168.0.0/16"},
+		{Kind: KindVCN, Tenancy: "other", Region: "r1", Compartment: "lab", VCN: "broken", VcnID: "v4", CIDR: "not-a-cidr"},
+	}
+
+	overlaps := FindOverlaps(ranges)
+
+	require.Len(t, overlaps, 3)
+	assert.Equal(t, KindVCN, overlaps[0].Kind)
+	assert.Equal(t, "acme/r1/prod/prod", overlaps[0].A.Location())
+	assert.Equal(t, "acme/r2/dr/dr", overlaps[0].B.Location())
+	assert.Equal(t, "10.0.0.0/16", overlaps[0].Shared)
+	assert.Equal(t, "acme/r1/prod/prod/app", overlaps[1].A.Location())
+	assert.Equal(t, "10.0.1.0/24", overlaps[1].Shared)
+	assert.Equal(t, "acme/r1/prod/prod/db", overlaps[2].A.Location())
+	assert.Equal(t, "acme/r2/dr/dr/all", overlaps[2].B.Location())
+}
+
+func TestFreeBlocks(t *testing.T) {
+	free, err := FreeBlocks("10.0.0.0/22", 24, []string{"10.0.1.0/24", "10.0.0.128/25", "192.168.0.0/16", "fd00::/8"}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.2.0/24", "10.0.3.0/24"}, free)
+
+	free, err = FreeBlocks("10.0.0.0/8", 16, []string{"10.0.0.0/16", "10.1.0.0/16", "10.2.5.0/24"}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.3.0.0/16", "10.4.0.0/16"}, free, "a block partly used by a smaller range is skipped")
+
+	free, err = FreeBlocks("10.0.0.0/24", 24, []string{"10.0.0.0/8"}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, free)
+	assert.NotNil(t, free)
+
+	_, err = FreeBlocks("10.0.0.0/16", 8, nil, 10)
+	assert.ErrorContains(t, err, "must be between /16 and /30")
+	_, err = FreeBlocks("fd00::/8", 64, nil, 10)
+	assert.ErrorContains(t, err, "invalid IPv4 range")
+}
+
+func TestParseSizeAndCapacity(t *testing.T) {
+	size, err := ParseSize("/24")
+	require.NoError(t, err)
+	assert.Equal(t, 24, size)
+	size, err = ParseSize("16")
+	require.NoError(t, err)
+	assert.Equal(t, 16, size)
+	_, err = ParseSize("/33")
+	assert.Error(t, err)
+
+	assert.Equal(t, 253, Capacity("10.0.1.0/24"))
+	assert.Equal(t, 1, Capacity("10.0.1.0/30"))
+	assert.Equal(t, 0, Capacity("10.0.1.0/31"))
+	assert.Equal(t, 0, Capacity("garbage"))
+}
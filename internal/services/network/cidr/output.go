@@ -0,0 +1,113 @@
+package cidr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/printer"
+)
+
+// PrintOverlaps prints the overlapping ranges as a table, or the report as JSON.
+func PrintOverlaps(report *OverlapReport, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(report)
+	}
+
+	vcnOverlaps := 0
+	for _, o := range report.Overlaps {
+		if o.Kind == KindVCN {
+			vcnOverlaps++
+		}
+	}
+	summary := map[string]string{
+		"Scanned":      targetsLabel(report.Targets),
+		"Compartments": fmt.Sprintf("%d", report.Compartments),
+		"VCNs":         fmt.Sprintf("%d", report.VCNs),
+		"Overlaps":     fmt.Sprintf("%d VCN, %d subnet", vcnOverlaps, len(report.Overlaps)-vcnOverlaps),
+	}
+	p.PrintKeyValuesNoTruncate("CIDR overlaps", summary, []string{"Scanned", "Compartments", "VCNs", "Overlaps"})
+
+	if len(report.Overlaps) > 0 {
+		headers := []string{"Kind", "Range", "Location", "Overlaps", "Location", "Shared"}
+		rows := make([][]string, 0, len(report.Overlaps))
+		for _, o := range report.Overlaps {
+			rows = append(rows, []string{o.Kind, o.A.CIDR, o.A.Location(), o.B.CIDR, o.B.Location(), o.Shared})
+		}
+		p.PrintTableNoTruncate("Overlapping ranges", headers, rows)
+	}
+	printErrors(appCtx, report.Errors)
+	return nil
+}
+
+// PrintFree prints the free blocks and the VCN ranges they avoid, or the report as JSON.
+func PrintFree(report *FreeReport, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(report)
+	}
+
+	used := "-"
+	if len(report.Used) > 0 {
+		used = strings.Join(report.Used, ", ")
+	}
+	summary := map[string]string{
+		"Scanned":    targetsLabel(report.Targets),
+		"Within":     report.Within,
+		"Block Size": fmt.Sprintf("/%d", report.Size),
+		"In Use":     used,
+	}
+	p.PrintKeyValuesNoTruncate("Free CIDR blocks", summary, []string{"Scanned", "Within", "Block Size", "In Use"})
+
+	if len(report.Free) == 0 {
+		fmt.Fprintf(appCtx.Stdout, "No free /%d block in %s.\n", report.Size, report.Within)
+	} else {
+		rows := make([][]string, 0, len(report.Free))
+		for _, block := range report.Free {
+			rows = append(rows, []string{block, fmt.Sprintf("%d", Capacity(block))})
+		}
+		p.PrintTableNoTruncate("Suggested blocks", []string{"CIDR", "Usable IPs per Subnet"}, rows)
+	}
+	printErrors(appCtx, report.Errors)
+	return nil
+}
+
+// PrintUsage prints the private IP utilisation of each subnet as a table, or the report as JSON.
+func PrintUsage(report *UsageReport, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(report)
+	}
+
+	if len(report.Subnets) == 0 {
+		fmt.Fprintf(appCtx.Stdout, "No subnets found in %s.\n", report.Scope)
+	} else {
+		headers := []string{"Compartment", "VCN", "Subnet", "CIDR", "Used", "Available", "Capacity", "Utilisation"}
+		rows := make([][]string, 0, len(report.Subnets))
+		for _, u := range report.Subnets {
+			rows = append(rows, []string{
+				u.Compartment, u.VCN, u.Subnet, u.CIDR,
+				fmt.Sprintf("%d", u.Used), fmt.Sprintf("%d", u.Available), fmt.Sprintf("%d", u.Capacity),
+				fmt.Sprintf("%.1f%%", u.Utilisation),
+			})
+		}
+		p.PrintTableNoTruncate("Subnet utilisation in "+report.Scope, headers, rows)
+	}
+	printErrors(appCtx, report.Errors)
+	return nil
+}
+
+func targetsLabel(targets []Target) string {
+	parts := make([]string, len(targets))
+	for i, t := range targets {
+		parts[i] = t.Tenancy + "/" + t.Region
+	}
+	return strings.Join(parts, ", ")
+}
+
+func printErrors(appCtx *app.ApplicationContext, errs []string) {
+	for _, e := range errs {
+		fmt.Fprintf(appCtx.Stdout, "Skipped %s\n", e)
+	}
+}
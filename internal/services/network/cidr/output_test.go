@@ -0,0 +1,47 @@
+package cidr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintOutputs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	appCtx := &app.ApplicationContext{Logger: logger.NewTestLogger(), Stdout: buf}
+	targets := []Target{{Tenancy: "acme", Region: "us-ashburn-1"}}
+
+	overlaps := &OverlapReport{Targets: targets, Compartments: 2, VCNs: 2, Overlaps: FindOverlaps([]Range{
+		{Kind: KindVCN, Tenancy: "acme", Region: "us-ashburn-1", Compartment: "prod", VCN: "a", VcnID: "v1", CIDR: "10.0.0.0/16"},
+		{Kind: KindVCN, Tenancy: "acme", Region: "us-ashburn-1", Compartment: "prod", VCN: "b", VcnID: "v2", CIDR: "10.0.0.0/24"},
+	}), Errors: []string{"lab/us-ashburn-1: denied"}}
+	require.NoError(t, PrintOverlaps(overlaps, appCtx, false))
+	out := buf.String()
+	assert.Contains(t, out, "acme/us-ashburn-1")
+	assert.Contains(t, out, "1 VCN, 0 subnet")
+	assert.Contains(t, out, "acme/us-ashburn-1/prod/b")
+	assert.Contains(t, out, "Skipped lab/us-ashburn-1: denied")
+
+	buf.Reset()
+	require.NoError(t, PrintFree(&FreeReport{Targets: targets, Within: "10.0.0.0/16", Size: 24, Free: []string{"10.0.1.0/24"}}, appCtx, false))
+	assert.Contains(t, buf.String(), "10.0.1.0/24")
+	assert.Contains(t, buf.String(), "253")
+
+	buf.Reset()
+	require.NoError(t, PrintFree(&FreeReport{Targets: targets, Within: "10.0.0.0/24", Size: 24, Used: []string{"10.0.0.0/8"}}, appCtx, false))
+	assert.Contains(t, buf.String(), "No free /24 block in 10.0.0.0/24.")
+
+	buf.Reset()
+	require.NoError(t, PrintFree(&FreeReport{Targets: targets, Within: "10.0.0.0/24", Size: 24, Free: []string{}}, appCtx, true))
+	assert.Contains(t, buf.String(), `"free": []`)
+
+	buf.Reset()
+	usage := &UsageReport{Scope: "compartment prod", Subnets: []SubnetUsage{{Compartment: "prod", VCN: "a", Subnet: "app", CIDR: "10.0.1.0/24", Capacity: 253, Used: 23, Available: 230, Utilisation: 9.09}}}
+	require.NoError(t, PrintUsage(usage, appCtx, false))
+	assert.Contains(t, buf.String(), "Subnet utilisation in compartment prod")
+	assert.Contains(t, buf.String(), "9.1%")
+}
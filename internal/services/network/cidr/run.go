@@ -0,0 +1,87 @@
+package cidr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	"github.com/rozdolsky33/ocloud/internal/oci/identity/compartment"
+	ocivcn "github.com/rozdolsky33/ocloud/internal/oci/network/vcn"
+)
+
+// RunOverlaps reports overlapping VCN and subnet ranges across the compartments of the current tenancy,
+// or of every tenancy and region in the tenancy map with mapped.
+func RunOverlaps(appCtx *app.ApplicationContext, mapped, useJSON bool) error {
+	service, targets, err := newScan(appCtx, mapped)
+	if err != nil {
+		return err
+	}
+	return PrintOverlaps(service.Overlaps(context.Background(), targets), appCtx, useJSON)
+}
+
+// RunFree suggests blocks of the given size inside within that do not conflict with any VCN.
+func RunFree(appCtx *app.ApplicationContext, within, size string, limit int, mapped, useJSON bool) error {
+	prefix, err := ParseSize(size)
+	if err != nil {
+		return err
+	}
+	service, targets, err := newScan(appCtx, mapped)
+	if err != nil {
+		return err
+	}
+	report, err := service.Free(context.Background(), targets, within, prefix, limit)
+	if err != nil {
+		return err
+	}
+	return PrintFree(report, appCtx, useJSON)
+}
+
+// RunUsage prints the private IP utilisation of the subnets in the configured compartment, or in the whole
+// tenancy with tenancyScope.
+func RunUsage(appCtx *app.ApplicationContext, tenancyScope, useJSON bool) error {
+	ctx := context.Background()
+	compartmentRepo := compartment.NewCompartmentAdapter(appCtx.IdentityClient, appCtx.TenancyID)
+	compartments, scope, err := UsageCompartments(ctx, compartmentRepo, appCtx, tenancyScope)
+	if err != nil {
+		return err
+	}
+	region, err := appCtx.Provider.Region()
+	if err != nil {
+		return fmt.Errorf("getting region: %w", err)
+	}
+	service := NewService(compartmentRepo, regionalRepositories(appCtx), appCtx.Logger)
+	report := service.Usage(ctx, region, compartments)
+	report.Scope = scope
+	return PrintUsage(report, appCtx, useJSON)
+}
+
+// newScan creates the service and targets of a multi-tenancy scan.
+func newScan(appCtx *app.ApplicationContext, mapped bool) (*Service, []Target, error) {
+	region, err := appCtx.Provider.Region()
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting region: %w", err)
+	}
+	var mappings []config.MappingsFile
+	if mapped {
+		if mappings, err = config.LoadTenancyMap(); err != nil {
+			return nil, nil, fmt.Errorf("loading tenancy map: %w", err)
+		}
+	}
+	compartmentRepo := compartment.NewCompartmentAdapter(appCtx.IdentityClient, appCtx.TenancyID)
+	return NewService(compartmentRepo, regionalRepositories(appCtx), appCtx.Logger), Targets(appCtx, region, mapped, mappings), nil
+}
+
+// regionalRepositories returns a factory of VCN adapters whose clients point at the given region.
+func regionalRepositories(appCtx *app.ApplicationContext) RepositoryFactory {
+	return func(region string) (domain.AddressSpaceRepository, error) {
+		client, err := oci.NewNetworkClient(appCtx.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("creating network client: %w", err)
+		}
+		client.SetRegion(region)
+		return ocivcn.NewAdapter(client), nil
+	}
+}
@@ -0,0 +1,213 @@
+package cidr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config"
+	"github.com/rozdolsky33/ocloud/internal/domain/identity"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/services/identity/compartment"
+)
+
+// RepositoryFactory returns the address space repository of a region.
+type RepositoryFactory func(region string) (domain.AddressSpaceRepository, error)
+
+// Service is the application-layer service for CIDR planning.
+type Service struct {
+	compartments identity.CompartmentRepository
+	networks     RepositoryFactory
+	logger       logr.Logger
+}
+
+// NewService initializes a new Service instance.
+func NewService(compartments identity.CompartmentRepository, networks RepositoryFactory, logger logr.Logger) *Service {
+	return &Service{compartments: compartments, networks: networks, logger: logger}
+}
+
+// Targets returns the tenancies and regions to scan. Without mapped, that is the current tenancy in the
+// current region; with mapped, every tenancy of the tenancy map in each of its regions, or in the current
+// region if it lists none.
+func Targets(appCtx *app.ApplicationContext, region string, mapped bool, mappings []config.MappingsFile) []Target {
+	name := appCtx.TenancyName
+	if name == "" {
+		name = appCtx.TenancyID
+	}
+	if !mapped {
+		return []Target{{Tenancy: name, TenancyID: appCtx.TenancyID, Region: region}}
+	}
+
+	var targets []Target
+	seen := map[Target]bool{}
+	for _, m := range mappings {
+		regions := m.Regions
+		if len(regions) == 0 {
+			regions = []string{region}
+		}
+		for _, r := range regions {
+			t := Target{Tenancy: m.Tenancy, TenancyID: m.TenancyID, Region: r}
+			if !seen[t] {
+				seen[t] = true
+				targets = append(targets, t)
+			}
+		}
+	}
+	return targets
+}
+
+// UsageCompartments returns the compartments whose subnets the usage view covers: the configured compartment,
+// or the root compartment and every compartment of the tenancy with tenancyScope. The scope describes the
+// choice for the report.
+func UsageCompartments(ctx context.Context, repo identity.CompartmentRepository, appCtx *app.ApplicationContext, tenancyScope bool) ([]Compartment, string, error) {
+	return compartment.Walk(ctx, repo, appCtx, tenancyScope, false)
+}
+
+// Inventory collects the VCN and subnet ranges of every compartment of the targets.
+func (s *Service) Inventory(ctx context.Context, targets []Target) *Inventory {
+	inv := &Inventory{Targets: targets}
+	for _, t := range targets {
+		where := t.Tenancy + "/" + t.Region
+		repo, err := s.networks(t.Region)
+		if err != nil {
+			inv.Errors = append(inv.Errors, fmt.Sprintf("%s: %v", where, err))
+			continue
+		}
+		compartments, err := compartment.Tenancy(ctx, s.compartments, t.TenancyID, t.Tenancy)
+		if err != nil {
+			inv.Errors = append(inv.Errors, fmt.Sprintf("%s: %v", where, err))
+			continue
+		}
+		for _, c := range compartments {
+			s.logger.V(logger.Debug).Info("collecting CIDR blocks", "tenancy", t.Tenancy, "region", t.Region, "compartment", c.Name)
+			inv.Compartments++
+			vcns, err := repo.ListVcns(ctx, c.ID)
+			if err != nil {
+				inv.Errors = append(inv.Errors, fmt.Sprintf("%s compartment %s: %v", where, c.Name, err))
+				continue
+			}
+			for _, v := range vcns {
+				inv.VCNs++
+				base := Range{Tenancy: t.Tenancy, Region: t.Region, Compartment: c.Name, VCN: v.DisplayName, VcnID: v.OCID}
+				for _, block := range v.CidrBlocks {
+					r := base
+					r.Kind, r.CIDR = KindVCN, block
+					inv.Ranges = append(inv.Ranges, r)
+				}
+				subnets, err := repo.ListSubnets(ctx, c.ID, v.OCID)
+				if err != nil {
+					inv.Errors = append(inv.Errors, fmt.Sprintf("%s VCN %s: %v", where, v.DisplayName, err))
+					continue
+				}
+				for _, sn := range subnets {
+					r := base
+					r.Kind, r.Subnet, r.CIDR = KindSubnet, sn.DisplayName, sn.CidrBlock
+					inv.Ranges = append(inv.Ranges, r)
+				}
+			}
+		}
+	}
+	return inv
+}
+
+// Overlaps reports the overlapping VCN and subnet ranges of the targets.
+func (s *Service) Overlaps(ctx context.Context, targets []Target) *OverlapReport {
+	inv := s.Inventory(ctx, targets)
+	return &OverlapReport{
+		Targets:      inv.Targets,
+		Compartments: inv.Compartments,
+		VCNs:         inv.VCNs,
+		Overlaps:     FindOverlaps(inv.Ranges),
+		Errors:       inv.Errors,
+	}
+}
+
+// Free suggests up to limit blocks of the given size inside within that no VCN of the targets uses.
+func (s *Service) Free(ctx context.Context, targets []Target, within string, size, limit int) (*FreeReport, error) {
+	outer, err := parseRange(within, size)
+	if err != nil {
+		return nil, err
+	}
+	inv := s.Inventory(ctx, targets)
+
+	var blocks []*net.IPNet
+	seen := map[string]bool{}
+	for _, r := range inv.Ranges {
+		_, n, err := net.ParseCIDR(r.CIDR)
+		if r.Kind != KindVCN || err != nil || n.IP.To4() == nil || seen[n.String()] {
+			continue
+		}
+		if outer.Contains(n.IP) || n.Contains(outer.IP) {
+			seen[n.String()] = true
+			blocks = append(blocks, n)
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return ipToUint(blocks[i].IP) < ipToUint(blocks[j].IP) })
+	used := make([]string, len(blocks))
+	for i, n := range blocks {
+		used[i] = n.String()
+	}
+
+	free, err := FreeBlocks(outer.String(), size, used, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &FreeReport{Targets: inv.Targets, Within: outer.String(), Size: size, Used: used, Free: free, Errors: inv.Errors}, nil
+}
+
+// Usage counts the private IPs allocated in every subnet of the compartments.
+func (s *Service) Usage(ctx context.Context, region string, compartments []Compartment) *UsageReport {
+	report := &UsageReport{}
+	repo, err := s.networks(region)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return report
+	}
+	for _, c := range compartments {
+		vcns, err := repo.ListVcns(ctx, c.ID)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("compartment %s: %v", c.Name, err))
+			continue
+		}
+		for _, v := range vcns {
+			subnets, err := repo.ListSubnets(ctx, c.ID, v.OCID)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("VCN %s: %v", v.DisplayName, err))
+				continue
+			}
+			for _, sn := range subnets {
+				s.logger.V(logger.Debug).Info("counting private IPs", "subnet", sn.DisplayName)
+				used, err := repo.CountPrivateIPs(ctx, sn.OCID)
+				if err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("subnet %s: %v", sn.DisplayName, err))
+					continue
+				}
+				report.Subnets = append(report.Subnets, newSubnetUsage(c.Name, v.DisplayName, sn, used))
+			}
+		}
+	}
+	return report
+}
+
+func newSubnetUsage(compartment, vcn string, sn domain.Subnet, used int) SubnetUsage {
+	u := SubnetUsage{
+		Compartment: compartment,
+		VCN:         vcn,
+		Subnet:      sn.DisplayName,
+		SubnetID:    sn.OCID,
+		CIDR:        sn.CidrBlock,
+		Capacity:    Capacity(sn.CidrBlock),
+		Used:        used,
+	}
+	if u.Capacity > u.Used {
+		u.Available = u.Capacity - u.Used
+	}
+	if u.Capacity > 0 {
+		u.Utilisation = float64(u.Used) * 100 / float64(u.Capacity)
+	}
+	return u
+}
@@ -0,0 +1,168 @@
+package cidr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/config"
+	"github.com/rozdolsky33/ocloud/internal/domain/identity"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegion implements domain.AddressSpaceRepository for one region.
+type fakeRegion struct {
+	vcns     map[string][]domain.VCN
+	subnets  map[string][]domain.Subnet
+	usedIPs  map[string]int
+	badVcnID string
+}
+
+func (f *fakeRegion) ListVcns(ctx context.Context, compartmentID string) ([]domain.VCN, error) {
+	return f.vcns[compartmentID], nil
+}
+
+func (f *fakeRegion) ListSubnets(ctx context.Context, compartmentID, vcnID string) ([]domain.Subnet, error) {
+	if vcnID == f.badVcnID {
+		return nil, assert.AnError
+	}
+	return f.subnets[vcnID], nil
+}
+
+func (f *fakeRegion) CountPrivateIPs(ctx context.Context, subnetID string) (int, error) {
+	used, ok := f.usedIPs[subnetID]
+	if !ok {
+		return 0, assert.AnError
+	}
+	return used, nil
+}
+
+// fakeCompartments implements identity.CompartmentRepository with children by parent OCID.
+type fakeCompartments map[string][]identity.Compartment
+
+func (f fakeCompartments) GetCompartment(ctx context.Context, ocid string) (*identity.Compartment, error) {
+	return nil, assert.AnError
+}
+
+func (f fakeCompartments) ListCompartments(ctx context.Context, ocid string) ([]identity.Compartment, error) {
+	children, ok := f[ocid]
+	if !ok {
+		return nil, fmt.Errorf("tenancy %s is not authorized", ocid)
+	}
+	return children, nil
+}
+
+func testService() *Service {
+	compartments := fakeCompartments{
+		"t-acme": {{OCID: "c-prod", DisplayName: "prod"}},
+	}
+	regions := map[string]*fakeRegion{
+		"us-ashburn-1": {
+			vcns: map[string][]domain.VCN{
+				"t-acme": {{OCID: "v-hub", DisplayName: "hub", CidrBlocks: []string{"10.0.0.0/16"}}},
+				"c-prod": {{OCID: "v-prod", DisplayName: "prod", CidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/24"}}},
+			},
+			subnets: map[string][]domain.Subnet{
+				"v-hub":  {{OCID: "s-hub", DisplayName: "hub-a", CidrBlock: "10.0.0.0/24"}},
+				"v-prod": {{OCID: "s-app", DisplayName: "app", CidrBlock: "10.1.0.0/24"}, {OCID: "s-db", DisplayName: "db", CidrBlock: "10.0.0.0/28"}},
+			},
+			usedIPs: map[string]int{"s-hub": 23, "s-app": 253},
+		},
+		"us-phoenix-1": {
+			vcns:     map[string][]domain.VCN{"t-acme": {{OCID: "v-phx", DisplayName: "phx", CidrBlocks: []string{"10.2.0.0/16"}}}},
+			badVcnID: "v-phx",
+		},
+	}
+	networks := func(region string) (domain.AddressSpaceRepository, error) {
+		r, ok := regions[region]
+		if !ok {
+			return nil, fmt.Errorf("region %s is not subscribed", region)
+		}
+		return r, nil
+	}
+	return NewService(compartments, networks, logger.NewTestLogger())
+}
+
+func TestTargets(t *testing.T) {
+	appCtx := &app.ApplicationContext{TenancyID: "t-acme", TenancyName: "acme"}
+	assert.Equal(t, []Target{{Tenancy: "acme", TenancyID: "t-acme", Region: "us-ashburn-1"}}, Targets(appCtx, "us-ashburn-1", false, nil))
+
+	mappings := []config.MappingsFile{
+		{Tenancy: "acme", TenancyID: "t-acme", Regions: []string{"us-ashburn-1", "us-phoenix-1", "us-ashburn-1"}},
+		{Tenancy: "lab", TenancyID: "t-lab"},
+	}
+	assert.Equal(t, []Target{
+		{Tenancy: "acme", TenancyID: "t-acme", Region: "us-ashburn-1"},
+		{Tenancy: "acme", TenancyID: "t-acme", Region: "us-phoenix-1"},
+		{Tenancy: "lab", TenancyID: "t-lab", Region: "us-ashburn-1"},
+	}, Targets(appCtx, "us-ashburn-1", true, mappings))
+}
+
+func TestService_Overlaps(t *testing.T) {
+	targets := []Target{
+		{Tenancy: "acme", TenancyID: "t-acme", Region: "us-ashburn-1"},
+		{Tenancy: "acme", TenancyID: "t-acme", Region: "us-phoenix-1"},
+		{Tenancy: "lab", TenancyID: "t-lab", Region: "us-ashburn-1"},
+		{Tenancy: "acme", TenancyID: "t-acme", Region: "eu-frankfurt-1"},
+	}
+
+	report := testService().Overlaps(context.Background(), targets)
+
+	assert.Equal(t, 4, report.Compartments)
+	assert.Equal(t, 3, report.VCNs)
+	require.Len(t, report.Overlaps, 2)
+	assert.Equal(t, KindVCN, report.Overlaps[0].Kind)
+	assert.Equal(t, "10.0.0.0/24", report.Overlaps[0].Shared)
+	assert.Equal(t, KindSubnet, report.Overlaps[1].Kind)
+	assert.Equal(t, "acme/us-ashburn-1/acme/hub/hub-a", report.Overlaps[1].A.Location())
+	assert.Equal(t, "acme/us-ashburn-1/prod/prod/db", report.Overlaps[1].B.Location())
+	require.Len(t, report.Errors, 3)
+	assert.Contains(t, report.Errors[0], "acme/us-phoenix-1 VCN phx")
+	assert.Contains(t, report.Errors[1], "lab/us-ashburn-1: listing compartments of the tenancy")
+	assert.Contains(t, report.Errors[2], "eu-frankfurt-1 is not subscribed")
+}
+
+func TestService_Free(t *testing.T) {
+	targets := []Target{{Tenancy: "acme", TenancyID: "t-acme", Region: "us-ashburn-1"}, {Tenancy: "acme", TenancyID: "t-acme", Region: "us-phoenix-1"}}
+
+	report, err := testService().Free(context.Background(), targets, "10.0.0.0/8", 16, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8", report.Within)
+	assert.Equal(t, []string{"10.0.0.0/16", "10.0.0.0/24", "10.1.0.0/16", "10.2.0.0/16"}, report.Used)
+	assert.Equal(t, []string{"10.3.0.0/16", "10.4.0.0/16"}, report.Free)
+
+	_, err = testService().Free(context.Background(), targets, "10.0.0.0/8", 4, 2)
+	assert.ErrorContains(t, err, "must be between /8 and /30")
+}
+
+func TestService_Usage(t *testing.T) {
+	report := testService().Usage(context.Background(), "us-ashburn-1", []Compartment{{ID: "t-acme", Name: "acme"}, {ID: "c-prod", Name: "prod"}})
+
+	require.Len(t, report.Subnets, 2)
+	hub := report.Subnets[0]
+	assert.Equal(t, SubnetUsage{Compartment: "acme", VCN: "hub", Subnet: "hub-a", SubnetID: "s-hub", CIDR: "10.0.0.0/24",
+		Capacity: 253, Used: 23, Available: 230, Utilisation: float64(23) * 100 / 253}, hub)
+	assert.Equal(t, 100.0, report.Subnets[1].Utilisation)
+	assert.Equal(t, 0, report.Subnets[1].Available)
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "subnet db")
+}
+
+func TestUsageCompartments(t *testing.T) {
+	repo := fakeCompartments{"t-acme": {{OCID: "c-prod", DisplayName: "prod"}}}
+	appCtx := &app.ApplicationContext{TenancyID: "t-acme", TenancyName: "acme", CompartmentID: "c-prod", CompartmentName: "prod"}
+
+	compartments, scope, err := UsageCompartments(context.Background(), repo, appCtx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "compartment prod", scope)
+	assert.Equal(t, []Compartment{{ID: "c-prod", Name: "prod"}}, compartments)
+
+	compartments, scope, err = UsageCompartments(context.Background(), repo, appCtx, true)
+	require.NoError(t, err)
+	assert.Equal(t, "tenancy acme", scope)
+	assert.Equal(t, []Compartment{{ID: "t-acme", Name: "acme"}, {ID: "c-prod", Name: "prod"}}, compartments)
+}
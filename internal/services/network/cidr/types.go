@@ -0,0 +1,102 @@
+package cidr
+
+import (
+	"strings"
+
+	"github.com/rozdolsky33/ocloud/internal/services/identity/compartment"
+)
+
+// Target is a tenancy and region whose VCNs are scanned.
+type Target struct {
+	Tenancy   string `json:"tenancy"`
+	TenancyID string `json:"tenancy_id"`
+	Region    string `json:"region"`
+}
+
+// Range kinds.
+const (
+	KindVCN    = "VCN"
+	KindSubnet = "Subnet"
+)
+
+// Range is an allocated address block: one CIDR of a VCN, or the CIDR of a subnet.
+type Range struct {
+	Kind        string `json:"kind"`
+	Tenancy     string `json:"tenancy"`
+	Region      string `json:"region"`
+	Compartment string `json:"compartment"`
+	VCN         string `json:"vcn"`
+	VcnID       string `json:"vcn_id"`
+	Subnet      string `json:"subnet,omitempty"`
+	CIDR        string `json:"cidr"`
+}
+
+// Location names the range as tenancy/region/compartment/vcn, followed by the subnet for subnet ranges.
+func (r Range) Location() string {
+	parts := []string{r.Tenancy, r.Region, r.Compartment, r.VCN}
+	if r.Subnet != "" {
+		parts = append(parts, r.Subnet)
+	}
+	return strings.Join(parts, "/")
+}
+
+// Overlap is a pair of ranges of the same kind in different VCNs that share addresses. Since CIDR blocks
+// either nest or are disjoint, Shared is the smaller of the two.
+type Overlap struct {
+	Kind   string `json:"kind"`
+	A      Range  `json:"a"`
+	B      Range  `json:"b"`
+	Shared string `json:"shared"`
+}
+
+// Inventory is the address space found in the scanned targets. Targets and compartments that could not
+// be read are listed in Errors rather than failing the scan.
+type Inventory struct {
+	Targets      []Target `json:"targets"`
+	Compartments int      `json:"compartments"`
+	VCNs         int      `json:"vcns"`
+	Ranges       []Range  `json:"ranges"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// OverlapReport lists the overlapping ranges of an inventory.
+type OverlapReport struct {
+	Targets      []Target  `json:"targets"`
+	Compartments int       `json:"compartments"`
+	VCNs         int       `json:"vcns"`
+	Overlaps     []Overlap `json:"overlaps"`
+	Errors       []string  `json:"errors,omitempty"`
+}
+
+// FreeReport lists the blocks of the requested size inside Within that no VCN uses.
+type FreeReport struct {
+	Targets []Target `json:"targets"`
+	Within  string   `json:"within"`
+	Size    int      `json:"size"`
+	Used    []string `json:"used"`
+	Free    []string `json:"free"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// SubnetUsage is the private IP utilisation of a subnet. Capacity excludes the addresses OCI reserves.
+type SubnetUsage struct {
+	Compartment string  `json:"compartment"`
+	VCN         string  `json:"vcn"`
+	Subnet      string  `json:"subnet"`
+	SubnetID    string  `json:"subnet_id"`
+	CIDR        string  `json:"cidr"`
+	Capacity    int     `json:"capacity"`
+	Used        int     `json:"used"`
+	Available   int     `json:"available"`
+	Utilisation float64 `json:"utilisation_percent"`
+}
+
+// UsageReport lists the utilisation of every subnet in the scanned compartments.
+type UsageReport struct {
+	Scope   string        `json:"scope"`
+	Subnets []SubnetUsage `json:"subnets"`
+	Errors  []string      `json:"errors,omitempty"`
+}
+
+// Compartment is a compartment whose VCNs are scanned.
+type Compartment = compartment.Ref
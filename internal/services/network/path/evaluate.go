@@ -0,0 +1,410 @@
+package path
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+)
+
+// icmpEchoRequest is the ICMP type evaluated for ICMP queries.
+const icmpEchoRequest = 8
+
+// location is where an endpoint sits in the topology.
+type location struct {
+	vcn    *domain.VCN
+	subnet *domain.Subnet
+}
+
+// Evaluate traces q through topo: egress at the source, routing in both directions and ingress at the
+// destination. It only reads topo, so it can be run against fixtures. Endpoints outside the topology are
+// treated as external addresses and the checks that need their configuration are skipped.
+func Evaluate(topo *Topology, q Query) *Report {
+	r := &Report{
+		Source:      q.Source,
+		Destination: q.Destination,
+		Protocol:    domain.ProtocolName(q.Protocol),
+		Port:        q.Port,
+	}
+	if q.Protocol == domain.ProtocolICMP {
+		r.Port = 0
+	}
+
+	src := topo.locate(r, CheckSource, q.Source)
+	dst := topo.locate(r, CheckDestination, q.Destination)
+
+	topo.checkRules(r, CheckSourceEgress, q, src, q.Source, q.Destination, true)
+
+	if src != nil && dst != nil && src.vcn.OCID == dst.vcn.OCID {
+		if src.subnet.OCID == dst.subnet.OCID {
+			r.add(CheckRouting, StatusPass, "", "source and destination are in subnet %s", src.subnet.DisplayName)
+		} else {
+			r.add(CheckRouting, StatusPass, "", "source and destination are in VCN %s; traffic uses the local route", src.vcn.DisplayName)
+		}
+	} else {
+		topo.checkRoute(r, CheckRouting, src, dst, q.Source, q.Destination.IP, true)
+		topo.checkRoute(r, CheckReturnRoute, dst, src, q.Destination, q.Source.IP, false)
+	}
+
+	topo.checkRules(r, CheckDestinationIngress, q, dst, q.Destination, q.Source, false)
+
+	r.Verdict = VerdictAllowed
+	for _, s := range r.Steps {
+		switch s.Status {
+		case StatusFail:
+			r.Verdict, r.Reason = VerdictBlocked, s.Check+": "+s.Detail
+			return r
+		case StatusSkip:
+			r.Verdict = VerdictInconclusive
+		}
+	}
+	return r
+}
+
+func (r *Report) add(check string, status Status, rule, format string, args ...any) {
+	r.Steps = append(r.Steps, Step{Check: check, Status: status, Detail: fmt.Sprintf(format, args...), Rule: rule})
+}
+
+// locate finds the subnet of e, by OCID when it is known and otherwise by the subnet CIDR that holds its IP.
+func (t *Topology) locate(r *Report, check string, e Endpoint) *location {
+	for i := range t.VCNs {
+		v := &t.VCNs[i]
+		for j := range v.Subnets {
+			s := &v.Subnets[j]
+			if (e.SubnetID != "" && s.OCID == e.SubnetID) || (e.SubnetID == "" && CIDRContains(s.CidrBlock, e.IP)) {
+				r.add(check, StatusPass, "", "%s is in subnet %s (%s, %s) of VCN %s",
+					e.IP, s.DisplayName, s.CidrBlock, publicity(s.Public), v.DisplayName)
+				return &location{vcn: v, subnet: s}
+			}
+		}
+	}
+	switch {
+	case e.SubnetID != "":
+		r.add(check, StatusWarn, "", "subnet %s of %s was not found in the fetched VCNs", e.SubnetID, e.Name)
+	case e.Kind != KindIP:
+		r.add(check, StatusWarn, "", "%s (%s) is not in a subnet of the fetched VCNs", e.Name, e.IP)
+	case isPrivate(e.IP):
+		r.add(check, StatusPass, "", "%s is a private address outside the fetched VCNs (on-premises or a peered VCN)", e.IP)
+	default:
+		r.add(check, StatusPass, "", "%s is a public address", e.IP)
+	}
+	return nil
+}
+
+// checkRoute evaluates the route table of the from subnet for traffic to ip. The leg is outbound when the
+// from side initiates the connection; only outbound legs may use a NAT gateway. The routing of external
+// addresses is out of scope and not reported.
+func (t *Topology) checkRoute(r *Report, check string, from, to *location, self Endpoint, ip string, outbound bool) {
+	if from == nil {
+		if self.Kind != KindIP {
+			r.add(check, StatusSkip, "", "%s is not in the fetched VCNs; its routing is not evaluated", self.Name)
+		}
+		return
+	}
+	table := findRouteTable(from.vcn, from.subnet.RouteTableID)
+	if table == nil {
+		r.add(check, StatusWarn, "", "route table %s of subnet %s was not found", from.subnet.RouteTableID, from.subnet.DisplayName)
+		return
+	}
+	rule, ok := RouteFor(table.Rules, ip)
+	if !ok {
+		r.add(check, StatusFail, "", "route table %s of subnet %s has no rule for %s", table.DisplayName, from.subnet.DisplayName, ip)
+		return
+	}
+	name := fmt.Sprintf("route table %s rule %s -> %s %s", table.DisplayName, rule.Destination, rule.TargetType, targetName(rule))
+	private := to != nil || isPrivate(ip)
+	switch rule.TargetType {
+	case domain.TargetTypeLocalPeering, domain.TargetTypeDRG:
+		if to != nil {
+			r.add(check, StatusPass, name, "routed to VCN %s by %s", to.vcn.DisplayName, name)
+		} else {
+			r.add(check, StatusPass, name, "routed by %s; the network beyond it is not evaluated", name)
+		}
+	case domain.TargetTypeInternet:
+		switch {
+		case private:
+			r.add(check, StatusFail, name, "%s sends private address %s to an internet gateway", name, ip)
+		case !from.subnet.Public:
+			r.add(check, StatusFail, name, "%s uses an internet gateway from private subnet %s", name, from.subnet.DisplayName)
+		default:
+			r.add(check, StatusPass, name, "routed to the internet by %s", name)
+		}
+	case domain.TargetTypeNAT:
+		switch {
+		case private:
+			r.add(check, StatusFail, name, "%s sends private address %s to a NAT gateway", name, ip)
+		case !outbound:
+			r.add(check, StatusFail, name, "%s uses a NAT gateway, which does not accept connections from %s", name, ip)
+		default:
+			r.add(check, StatusPass, name, "routed to the internet by %s", name)
+		}
+	case domain.TargetTypeService:
+		if private {
+			r.add(check, StatusFail, name, "%s sends private address %s to a service gateway", name, ip)
+		} else {
+			r.add(check, StatusWarn, name, "routed by %s; a service gateway only reaches the Oracle Services Network", name)
+		}
+	default:
+		r.add(check, StatusWarn, name, "routed by %s; the path beyond that hop is not evaluated", name)
+	}
+}
+
+// NamedRule is a security rule together with a readable reference to it, e.g. "NSG app ingress rule #2".
+type NamedRule struct {
+	Name string
+	Rule domain.SecurityRule
+}
+
+// MatchRule returns the rule that allows protocol to port with peer as the remote end, preferring stateful
+// rules, and the first rule that covers the peer but not the traffic. Both are nil when nothing applies.
+// This is the security rule evaluator shared by "network path" and "bastion diagnose".
+func MatchRule(rules []NamedRule, peer Endpoint, protocol string, port int) (allowed, closest *NamedRule) {
+	var stateless *NamedRule
+	for i := range rules {
+		nr := &rules[i]
+		if !peerMatches(nr.Rule, peer) {
+			continue
+		}
+		if !trafficMatches(nr.Rule, protocol, port) {
+			if closest == nil {
+				closest = nr
+			}
+			continue
+		}
+		if !nr.Rule.Stateless {
+			return nr, closest
+		}
+		if stateless == nil {
+			stateless = nr
+		}
+	}
+	return stateless, closest
+}
+
+// checkRules evaluates the egress rules at self (egress) or its ingress rules for traffic with peer.
+// The rules are the union of the subnet's security lists and the endpoint's NSGs, as in OCI.
+func (t *Topology) checkRules(r *Report, check string, q Query, loc *location, self, peer Endpoint, egress bool) {
+	if loc == nil && len(self.NsgIDs) == 0 {
+		if self.Kind != KindIP {
+			r.add(check, StatusSkip, "", "%s is not in the fetched VCNs; its security rules are not evaluated", self.Name)
+		}
+		return
+	}
+	rules, warnings := t.rulesFor(loc, self.NsgIDs, egress)
+	for _, w := range warnings {
+		r.add(check, StatusWarn, "", "%s", w)
+	}
+
+	allowed, closest := MatchRule(rules, peer, q.Protocol, q.Port)
+	switch {
+	case allowed != nil && allowed.Rule.Stateless:
+		r.add(check, StatusWarn, allowed.Name, "allowed by stateless %s (%s); return traffic needs a matching rule in the opposite direction",
+			allowed.Name, ruleSummary(allowed.Rule))
+		return
+	case allowed != nil:
+		r.add(check, StatusPass, allowed.Name, "allowed by %s (%s)", allowed.Name, ruleSummary(allowed.Rule))
+		return
+	}
+
+	direction, preposition := "ingress", "from"
+	if egress {
+		direction, preposition = "egress", "to"
+	}
+	detail := fmt.Sprintf("no %s rule allows %s %s %s", direction, trafficSummary(q), preposition, peer.IP)
+	if closest != nil {
+		detail += fmt.Sprintf("; closest rule is %s (%s)", closest.Name, ruleSummary(closest.Rule))
+	}
+	switch {
+	case len(warnings) > 0:
+		r.add(check, StatusWarn, "", "%s among the rules that could be read", detail)
+	case closest != nil:
+		r.add(check, StatusFail, closest.Name, "%s", detail)
+	default:
+		r.add(check, StatusFail, "", "%s", detail)
+	}
+}
+
+// rulesFor collects the egress or ingress rules of the subnet's security lists and of the NSGs.
+// Lists and NSGs that are not in the topology are returned as warnings.
+func (t *Topology) rulesFor(loc *location, nsgIDs []string, egress bool) ([]NamedRule, []string) {
+	direction := "ingress"
+	if egress {
+		direction = "egress"
+	}
+	var rules []NamedRule
+	var warnings []string
+	if loc != nil {
+		for _, id := range loc.subnet.SecurityListIDs {
+			sl := findSecurityList(loc.vcn, id)
+			if sl == nil {
+				warnings = append(warnings, fmt.Sprintf("security list %s was not found", id))
+				continue
+			}
+			list := sl.IngressRules
+			if egress {
+				list = sl.EgressRules
+			}
+			for i, rule := range list {
+				rules = append(rules, NamedRule{Name: fmt.Sprintf("security list %s %s rule #%d", sl.DisplayName, direction, i+1), Rule: rule})
+			}
+		}
+	}
+	for _, id := range nsgIDs {
+		nsg := t.findNSG(id)
+		if nsg == nil {
+			warnings = append(warnings, fmt.Sprintf("NSG %s was not found", id))
+			continue
+		}
+		index := 0
+		for _, rule := range nsg.Rules {
+			if (rule.Direction == domain.DirectionEgress) != egress {
+				continue
+			}
+			index++
+			rules = append(rules, NamedRule{Name: fmt.Sprintf("NSG %s %s rule #%d", nsg.DisplayName, direction, index), Rule: rule})
+		}
+	}
+	return rules, warnings
+}
+
+// peerMatches reports whether the rule's source or destination covers the peer endpoint.
+// Service CIDR labels cannot be resolved offline and never match.
+func peerMatches(rule domain.SecurityRule, peer Endpoint) bool {
+	switch rule.PeerType {
+	case domain.AddressTypeNSG:
+		for _, id := range peer.NsgIDs {
+			if id == rule.Peer {
+				return true
+			}
+		}
+		return false
+	case domain.AddressTypeService:
+		return false
+	}
+	return CIDRContains(rule.Peer, peer.IP)
+}
+
+// trafficMatches reports whether the rule allows protocol to the destination port from any ephemeral
+// source port, or an ICMP echo request.
+func trafficMatches(rule domain.SecurityRule, protocol string, port int) bool {
+	if rule.Protocol == domain.ProtocolAll {
+		return true
+	}
+	if rule.Protocol != protocol {
+		return false
+	}
+	switch protocol {
+	case domain.ProtocolTCP, domain.ProtocolUDP:
+		if rule.DestinationPorts != nil && !rule.DestinationPorts.Contains(port) {
+			return false
+		}
+		src := rule.SourcePorts
+		return src == nil || (src.Contains(ephemeralPorts.Min) && src.Contains(ephemeralPorts.Max))
+	case domain.ProtocolICMP:
+		return rule.IcmpType == nil || *rule.IcmpType == icmpEchoRequest
+	}
+	return true
+}
+
+// ephemeralPorts is the source port range a client may pick.
+var ephemeralPorts = domain.PortRange{Min: 1024, Max: 65535}
+
+// RouteFor returns the most specific CIDR route rule whose destination contains ip.
+func RouteFor(rules []domain.RouteRule, ip string) (domain.RouteRule, bool) {
+	var best domain.RouteRule
+	bestLen := -1
+	for _, rule := range rules {
+		if rule.DestinationType != domain.AddressTypeCIDR || !CIDRContains(rule.Destination, ip) {
+			continue
+		}
+		_, network, _ := net.ParseCIDR(rule.Destination)
+		if ones, _ := network.Mask.Size(); ones > bestLen {
+			best, bestLen = rule, ones
+		}
+	}
+	return best, bestLen >= 0
+}
+
+func findRouteTable(v *domain.VCN, id string) *domain.RouteTable {
+	for i := range v.RouteTables {
+		if v.RouteTables[i].OCID == id {
+			return &v.RouteTables[i]
+		}
+	}
+	return nil
+}
+
+func findSecurityList(v *domain.VCN, id string) *domain.SecurityList {
+	for i := range v.SecurityLists {
+		if v.SecurityLists[i].OCID == id {
+			return &v.SecurityLists[i]
+		}
+	}
+	return nil
+}
+
+func (t *Topology) findNSG(id string) *domain.NSG {
+	for i := range t.VCNs {
+		for j := range t.VCNs[i].NSGs {
+			if t.VCNs[i].NSGs[j].OCID == id {
+				return &t.VCNs[i].NSGs[j]
+			}
+		}
+	}
+	return nil
+}
+
+// ruleSummary describes a rule as e.g. "TCP 1521 from 10.0.0.0/16" or "all to app-nsg (NSG)".
+func ruleSummary(rule domain.SecurityRule) string {
+	peer := rule.Peer
+	if rule.PeerType == domain.AddressTypeNSG && rule.PeerName != "" {
+		peer = rule.PeerName + " (NSG)"
+	}
+	preposition := "from"
+	if rule.Direction == domain.DirectionEgress {
+		preposition = "to"
+	}
+	if rule.Protocol == domain.ProtocolAll {
+		return fmt.Sprintf("all %s %s", preposition, peer)
+	}
+	return fmt.Sprintf("%s %s %s %s", rule.ProtocolName(), rule.PortSummary(), preposition, peer)
+}
+
+// trafficSummary describes the queried traffic as e.g. "TCP 1521" or "ICMP echo".
+func trafficSummary(q Query) string {
+	if q.Protocol == domain.ProtocolICMP {
+		return "ICMP echo"
+	}
+	return fmt.Sprintf("%s %d", domain.ProtocolName(q.Protocol), q.Port)
+}
+
+func targetName(rule domain.RouteRule) string {
+	if rule.TargetName != "" {
+		return rule.TargetName
+	}
+	return rule.TargetID
+}
+
+func publicity(public bool) string {
+	if public {
+		return "public"
+	}
+	return "private"
+}
+
+// CIDRContains reports whether ip lies inside cidr. Invalid values never match.
+func CIDRContains(cidr, ip string) bool {
+	_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return false
+	}
+	addr := net.ParseIP(strings.TrimSpace(ip))
+	return addr != nil && network.Contains(addr)
+}
+
+// isPrivate reports whether ip is an RFC 1918 (or IPv6 unique local) address.
+func isPrivate(ip string) bool {
+	addr := net.ParseIP(strings.TrimSpace(ip))
+	return addr != nil && addr.IsPrivate()
+}
@@ -0,0 +1,241 @@
+package path
+
+import (
+	"testing"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	nsgApp = "ocid1.networksecuritygroup.oc1..app"
+	nsgDB  = "ocid1.networksecuritygroup.oc1..db"
+)
+
+func port(n int) *domain.PortRange { return &domain.PortRange{Min: n, Max: n} }
+
+// fixture is a prod VCN with an app and a db subnet, peered over an LPG with a shared VCN.
+func fixture() *Topology {
+	prod := domain.VCN{
+		OCID: "vcn-prod", DisplayName: "prod",
+		Subnets: []domain.Subnet{
+			{OCID: "sn-app", DisplayName: "app", CidrBlock: "10.0.1.0/24", RouteTableID: "rt-app", SecurityListIDs: []string{"sl-app"}},
+			{OCID: "sn-db", DisplayName: "db", CidrBlock: "10.0.2.0/24", RouteTableID: "rt-db", SecurityListIDs: []string{"sl-db"}},
+			{OCID: "sn-web", DisplayName: "web", CidrBlock: "10.0.0.0/24", Public: true, RouteTableID: "rt-web", SecurityListIDs: []string{"sl-app"}},
+		},
+		RouteTables: []domain.RouteTable{
+			{OCID: "rt-app", DisplayName: "private", Rules: []domain.RouteRule{
+				{Destination: "0.0.0.0/0", DestinationType: domain.AddressTypeCIDR, TargetType: domain.TargetTypeNAT, TargetName: "nat"},
+				{Destination: "10.1.0.0/16", DestinationType: domain.AddressTypeCIDR, TargetType: domain.TargetTypeLocalPeering, TargetName: "to-shared"},
+			}},
+			{OCID: "rt-db", DisplayName: "db", Rules: []domain.RouteRule{
+				{Destination: "0.0.0.0/0", DestinationType: domain.AddressTypeCIDR, TargetType: domain.TargetTypeNAT, TargetName: "nat"},
+			}},
+			{OCID: "rt-web", DisplayName: "public", Rules: []domain.RouteRule{
+				{Destination: "0.0.0.0/0", DestinationType: domain.AddressTypeCIDR, TargetType: domain.TargetTypeInternet, TargetName: "igw"},
+			}},
+		},
+		SecurityLists: []domain.SecurityList{
+			{OCID: "sl-app", DisplayName: "app", EgressRules: []domain.SecurityRule{
+				{Direction: domain.DirectionEgress, Protocol: domain.ProtocolAll, Peer: "0.0.0.0/0", PeerType: domain.AddressTypeCIDR},
+			}, IngressRules: []domain.SecurityRule{
+				{Direction: domain.DirectionIngress, Protocol: domain.ProtocolTCP, Peer: "0.0.0.0/0", PeerType: domain.AddressTypeCIDR, DestinationPorts: port(443)},
+			}},
+			{OCID: "sl-db", DisplayName: "db", IngressRules: []domain.SecurityRule{
+				{Direction: domain.DirectionIngress, Protocol: domain.ProtocolTCP, Peer: "10.0.0.0/16", PeerType: domain.AddressTypeCIDR, DestinationPorts: port(22)},
+			}},
+		},
+		NSGs: []domain.NSG{
+			{OCID: nsgApp, DisplayName: "app"},
+			{OCID: nsgDB, DisplayName: "db", Rules: []domain.SecurityRule{
+				{Direction: domain.DirectionIngress, Protocol: domain.ProtocolTCP, Peer: nsgApp, PeerType: domain.AddressTypeNSG, PeerName: "app",
+					DestinationPorts: port(1521)},
+				{Direction: domain.DirectionIngress, Protocol: domain.ProtocolUDP, Peer: "10.1.0.0/16", PeerType: domain.AddressTypeCIDR, Stateless: true},
+			}},
+		},
+	}
+	shared := domain.VCN{
+		OCID: "vcn-shared", DisplayName: "shared",
+		Subnets: []domain.Subnet{
+			{OCID: "sn-tools", DisplayName: "tools", CidrBlock: "10.1.0.0/24", RouteTableID: "rt-tools", SecurityListIDs: []string{"sl-tools"}},
+		},
+		RouteTables: []domain.RouteTable{{OCID: "rt-tools", DisplayName: "tools"}},
+		SecurityLists: []domain.SecurityList{{OCID: "sl-tools", DisplayName: "tools", EgressRules: []domain.SecurityRule{
+			{Direction: domain.DirectionEgress, Protocol: domain.ProtocolAll, Peer: "0.0.0.0/0", PeerType: domain.AddressTypeCIDR},
+		}}},
+	}
+	return &Topology{VCNs: []domain.VCN{prod, shared}}
+}
+
+var (
+	appServer = Endpoint{Kind: "instance", Name: "app-1", IP: "10.0.1.5", VcnID: "vcn-prod", SubnetID: "sn-app", NsgIDs: []string{nsgApp}}
+	database  = Endpoint{Kind: "adb", Name: "salesdb", IP: "10.0.2.9", VcnID: "vcn-prod", SubnetID: "sn-db", NsgIDs: []string{nsgDB}}
+	tools     = Endpoint{Kind: KindIP, Name: "10.1.0.7", IP: "10.1.0.7"}
+)
+
+func step(t *testing.T, r *Report, check string) Step {
+	t.Helper()
+	for _, s := range r.Steps {
+		if s.Check == check {
+			return s
+		}
+	}
+	require.Failf(t, "missing step", "no %q step in %+v", check, r.Steps)
+	return Step{}
+}
+
+func TestEvaluate_AllowedByNSGReference(t *testing.T) {
+	r := Evaluate(fixture(), Query{Source: appServer, Destination: database, Protocol: domain.ProtocolTCP, Port: 1521})
+
+	assert.Equal(t, VerdictAllowed, r.Verdict)
+	assert.Equal(t, "TCP", r.Protocol)
+	assert.Contains(t, step(t, r, CheckRouting).Detail, "local route")
+	assert.Equal(t, "security list app egress rule #1", step(t, r, CheckSourceEgress).Rule)
+	ingress := step(t, r, CheckDestinationIngress)
+	assert.Equal(t, StatusPass, ingress.Status)
+	assert.Equal(t, "NSG db ingress rule #1", ingress.Rule)
+	assert.Contains(t, ingress.Detail, "TCP 1521 from app (NSG)")
+}
+
+func TestEvaluate_BlockedNamesClosestRule(t *testing.T) {
+	r := Evaluate(fixture(), Query{Source: appServer, Destination: database, Protocol: domain.ProtocolTCP, Port: 5432})
+
+	assert.Equal(t, VerdictBlocked, r.Verdict)
+	ingress := step(t, r, CheckDestinationIngress)
+	assert.Equal(t, StatusFail, ingress.Status)
+	assert.Equal(t, "security list db ingress rule #1", ingress.Rule)
+	assert.Contains(t, r.Reason, "no ingress rule allows TCP 5432 from 10.0.1.5; closest rule is security list db ingress rule #1 (TCP 22 from 10.0.0.0/16)")
+}
+
+func TestEvaluate_OutboundThroughNAT(t *testing.T) {
+	internet := Endpoint{Kind: KindIP, Name: "8.8.8.8", IP: "8.8.8.8"}
+	r := Evaluate(fixture(), Query{Source: appServer, Destination: internet, Protocol: domain.ProtocolUDP, Port: 53})
+
+	assert.Equal(t, VerdictAllowed, r.Verdict)
+	assert.Equal(t, "route table private rule 0.0.0.0/0 -> NAT nat", step(t, r, CheckRouting).Rule)
+	assert.Len(t, r.Steps, 4, "the routing and rules of external addresses are not reported")
+}
+
+func TestEvaluate_InboundThroughNATIsBlocked(t *testing.T) {
+	client := Endpoint{Kind: KindIP, Name: "203.0.113.7", IP: "203.0.113.7"}
+	r := Evaluate(fixture(), Query{Source: client, Destination: Endpoint{Kind: KindIP, Name: "10.0.1.5", IP: "10.0.1.5"},
+		Protocol: domain.ProtocolTCP, Port: 443})
+
+	assert.Equal(t, VerdictBlocked, r.Verdict)
+	back := step(t, r, CheckReturnRoute)
+	assert.Equal(t, StatusFail, back.Status)
+	assert.Contains(t, back.Detail, "does not accept connections from 203.0.113.7")
+	assert.Equal(t, StatusPass, step(t, r, CheckDestinationIngress).Status, "located by IP, the subnet's security lists apply")
+}
+
+func TestEvaluate_InboundThroughInternetGateway(t *testing.T) {
+	client := Endpoint{Kind: KindIP, Name: "203.0.113.7", IP: "203.0.113.7"}
+	r := Evaluate(fixture(), Query{Source: client, Destination: Endpoint{Kind: KindIP, Name: "10.0.0.4", IP: "10.0.0.4"},
+		Protocol: domain.ProtocolTCP, Port: 443})
+
+	assert.Equal(t, VerdictAllowed, r.Verdict)
+	assert.Equal(t, "route table public rule 0.0.0.0/0 -> Internet igw", step(t, r, CheckReturnRoute).Rule)
+}
+
+func TestEvaluate_PeeredVCNWithoutReturnRoute(t *testing.T) {
+	r := Evaluate(fixture(), Query{Source: appServer, Destination: tools, Protocol: domain.ProtocolTCP, Port: 22})
+
+	assert.Equal(t, VerdictBlocked, r.Verdict)
+	assert.Equal(t, StatusPass, step(t, r, CheckRouting).Status)
+	assert.Contains(t, step(t, r, CheckRouting).Detail, "routed to VCN shared")
+	assert.Equal(t, "Return route: route table tools of subnet tools has no rule for 10.0.1.5", r.Reason)
+}
+
+func TestEvaluate_StatelessRuleWarns(t *testing.T) {
+	r := Evaluate(fixture(), Query{Source: tools, Destination: database, Protocol: domain.ProtocolUDP, Port: 161})
+
+	ingress := step(t, r, CheckDestinationIngress)
+	assert.Equal(t, StatusWarn, ingress.Status)
+	assert.Equal(t, "NSG db ingress rule #2", ingress.Rule)
+	assert.Contains(t, ingress.Detail, "return traffic")
+}
+
+func TestEvaluate_UnknownSourceSubnet(t *testing.T) {
+	lost := Endpoint{Kind: "instance", Name: "lost", IP: "192.168.9.9", SubnetID: "sn-gone"}
+	r := Evaluate(fixture(), Query{Source: lost, Destination: database, Protocol: domain.ProtocolICMP})
+
+	assert.Equal(t, StatusWarn, step(t, r, CheckSource).Status)
+	assert.Equal(t, StatusSkip, step(t, r, CheckSourceEgress).Status)
+	assert.Equal(t, 0, r.Port)
+	assert.Equal(t, VerdictBlocked, r.Verdict, "the db subnet does not route back to 192.168.9.9")
+}
+
+func TestTrafficMatches(t *testing.T) {
+	tcp22 := domain.SecurityRule{Protocol: domain.ProtocolTCP, DestinationPorts: port(22)}
+	assert.True(t, trafficMatches(tcp22, domain.ProtocolTCP, 22))
+	assert.False(t, trafficMatches(tcp22, domain.ProtocolTCP, 23))
+	assert.False(t, trafficMatches(tcp22, domain.ProtocolUDP, 22))
+
+	fixedSource := domain.SecurityRule{Protocol: domain.ProtocolTCP, SourcePorts: port(1521)}
+	assert.False(t, trafficMatches(fixedSource, domain.ProtocolTCP, 1521), "clients use ephemeral source ports")
+
+	unreachable := 3
+	assert.False(t, trafficMatches(domain.SecurityRule{Protocol: domain.ProtocolICMP, IcmpType: &unreachable}, domain.ProtocolICMP, 0))
+	assert.True(t, trafficMatches(domain.SecurityRule{Protocol: domain.ProtocolAll}, domain.ProtocolICMP, 0))
+}
+
+func TestMatchRule(t *testing.T) {
+	cidr := func(peer string) domain.SecurityRule {
+		return domain.SecurityRule{Protocol: domain.ProtocolTCP, Peer: peer, PeerType: domain.AddressTypeCIDR}
+	}
+	udp := cidr("0.0.0.0/0")
+	udp.Protocol = domain.ProtocolUDP
+	stateless := cidr("10.0.0.0/16")
+	stateless.Protocol, stateless.Stateless = domain.ProtocolAll, true
+	ssh := cidr("10.0.0.0/24")
+	ssh.DestinationPorts = port(22)
+	rules := []NamedRule{
+		{Name: "udp", Rule: udp},
+		{Name: "other subnet", Rule: cidr("10.1.0.0/16")},
+		{Name: "service", Rule: domain.SecurityRule{Protocol: domain.ProtocolAll, Peer: "all-iad-services-in-oracle-services-network", PeerType: domain.AddressTypeService}},
+		{Name: "stateless", Rule: stateless},
+		{Name: "ssh", Rule: ssh},
+	}
+	peer := Endpoint{IP: "10.0.0.7"}
+
+	allowed, _ := MatchRule(rules, peer, domain.ProtocolTCP, 22)
+	require.NotNil(t, allowed)
+	assert.Equal(t, "ssh", allowed.Name, "stateful rules are preferred")
+
+	allowed, _ = MatchRule(rules, peer, domain.ProtocolTCP, 1521)
+	require.NotNil(t, allowed)
+	assert.Equal(t, "stateless", allowed.Name)
+
+	allowed, closest := MatchRule([]NamedRule{rules[0], rules[4]}, peer, domain.ProtocolTCP, 1521)
+	assert.Nil(t, allowed)
+	require.NotNil(t, closest)
+	assert.Equal(t, "udp", closest.Name, "the first rule that covers the peer")
+
+	allowed, closest = MatchRule(rules[1:], Endpoint{IP: "172.16.0.1"}, domain.ProtocolTCP, 22)
+	assert.Nil(t, allowed)
+	assert.Nil(t, closest)
+}
+
+func TestRouteFor(t *testing.T) {
+	rules := []domain.RouteRule{
+		{Destination: "0.0.0.0/0", DestinationType: domain.AddressTypeCIDR, TargetID: "natgw"},
+		{Destination: "10.1.0.0/16", DestinationType: domain.AddressTypeCIDR, TargetID: "lpg"},
+		{Destination: "all-iad-services-in-oracle-services-network", DestinationType: domain.AddressTypeService, TargetID: "sgw"},
+	}
+
+	rule, ok := RouteFor(rules, "10.1.4.4")
+	require.True(t, ok)
+	assert.Equal(t, "lpg", rule.TargetID, "most specific route wins")
+
+	_, ok = RouteFor(rules[1:3], "192.168.0.1")
+	assert.False(t, ok)
+}
+
+func TestCIDRContains(t *testing.T) {
+	assert.True(t, CIDRContains("10.0.0.0/16", "10.0.5.4"))
+	assert.True(t, CIDRContains("0.0.0.0/0", "192.168.1.1"))
+	assert.False(t, CIDRContains("10.0.0.0/24", "10.0.1.4"))
+	assert.False(t, CIDRContains("not-a-cidr", "10.0.0.1"))
+	assert.False(t, CIDRContains("10.0.0.0/16", ""))
+}
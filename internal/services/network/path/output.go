@@ -0,0 +1,44 @@
+package path
+
+import (
+	"fmt"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/printer"
+)
+
+// PrintReport prints the steps of a path evaluation and its verdict, or the report as JSON.
+func PrintReport(report *Report, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(report)
+	}
+
+	traffic := report.Protocol
+	if report.Port > 0 {
+		traffic = fmt.Sprintf("%s/%d", report.Protocol, report.Port)
+	}
+	title := fmt.Sprintf("Path: %s -> %s (%s)", endpointLabel(report.Source), endpointLabel(report.Destination), traffic)
+	rows := make([][]string, 0, len(report.Steps))
+	for _, s := range report.Steps {
+		rows = append(rows, []string{s.Check, string(s.Status), s.Detail})
+	}
+	p.PrintTableNoTruncate(title, []string{"Check", "Status", "Detail"}, rows)
+
+	data := map[string]string{"Verdict": string(report.Verdict)}
+	order := []string{"Verdict"}
+	if report.Reason != "" {
+		data["Blocked by"] = report.Reason
+		order = append(order, "Blocked by")
+	}
+	p.PrintKeyValuesNoTruncate("Verdict", data, order)
+	return nil
+}
+
+// endpointLabel renders an endpoint as "web-1 (10.0.1.5)", or just the address for raw IPs.
+func endpointLabel(e Endpoint) string {
+	if e.Kind == KindIP || e.Name == "" {
+		return e.IP
+	}
+	return fmt.Sprintf("%s (%s)", e.Name, e.IP)
+}
@@ -0,0 +1,29 @@
+package path
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintReport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	appCtx := &app.ApplicationContext{Logger: logger.NewTestLogger(), Stdout: buf}
+	report := Evaluate(fixture(), Query{Source: appServer, Destination: database, Protocol: domain.ProtocolTCP, Port: 5432})
+
+	require.NoError(t, PrintReport(report, appCtx, false))
+	out := buf.String()
+	assert.Contains(t, out, "Path: app-1 (10.0.1.5) -> salesdb (10.0.2.9) (TCP/5432)")
+	assert.Contains(t, out, "BLOCKED")
+	assert.Contains(t, out, "closest rule is security list db ingress rule #1")
+
+	buf.Reset()
+	require.NoError(t, PrintReport(report, appCtx, true))
+	assert.Contains(t, buf.String(), `"verdict": "BLOCKED"`)
+	assert.Contains(t, buf.String(), `"rule": "security list db ingress rule #1"`)
+}
@@ -0,0 +1,66 @@
+package path
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+)
+
+// Service is the application-layer service for path evaluation.
+type Service struct {
+	vcnRepo       domain.VCNRepository
+	logger        logr.Logger
+	compartmentID string
+}
+
+// NewService initializes a new Service instance.
+func NewService(repo domain.VCNRepository, logger logr.Logger, compartmentID string) *Service {
+	return &Service{
+		vcnRepo:       repo,
+		logger:        logger,
+		compartmentID: compartmentID,
+	}
+}
+
+// LoadTopology fetches the enriched VCNs of the compartment, plus the VCN of any endpoint that lives in
+// another compartment.
+func (s *Service) LoadTopology(ctx context.Context, endpoints ...Endpoint) (*Topology, error) {
+	s.logger.V(logger.Debug).Info("loading network topology", "compartmentID", s.compartmentID)
+	vcns, err := s.vcnRepo.ListEnrichedVcns(ctx, s.compartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("listing vcns from repository: %w", err)
+	}
+	topo := &Topology{VCNs: vcns}
+	for _, e := range endpoints {
+		if e.VcnID == "" || topo.hasVCN(e.VcnID) {
+			continue
+		}
+		v, err := s.vcnRepo.GetEnrichedVcn(ctx, e.VcnID)
+		if err != nil {
+			return nil, fmt.Errorf("getting vcn %s of %s: %w", e.VcnID, e.Name, err)
+		}
+		topo.VCNs = append(topo.VCNs, v)
+	}
+	return topo, nil
+}
+
+// Trace loads the topology around the query's endpoints and evaluates the query against it.
+func (s *Service) Trace(ctx context.Context, q Query) (*Report, error) {
+	topo, err := s.LoadTopology(ctx, q.Source, q.Destination)
+	if err != nil {
+		return nil, err
+	}
+	return Evaluate(topo, q), nil
+}
+
+func (t *Topology) hasVCN(id string) bool {
+	for _, v := range t.VCNs {
+		if v.OCID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package path
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVCNRepo implements domain.VCNRepository with the compartment's VCNs and VCNs fetched by OCID.
+type fakeVCNRepo struct {
+	vcns  []domain.VCN
+	byID  map[string]domain.VCN
+	calls []string
+}
+
+func (f *fakeVCNRepo) GetEnrichedVcn(ctx context.Context, ocid string) (domain.VCN, error) {
+	f.calls = append(f.calls, ocid)
+	if v, ok := f.byID[ocid]; ok {
+		return v, nil
+	}
+	return domain.VCN{}, assert.AnError
+}
+
+func (f *fakeVCNRepo) ListVcns(ctx context.Context, compartmentID string) ([]domain.VCN, error) {
+	return f.vcns, nil
+}
+
+func (f *fakeVCNRepo) ListEnrichedVcns(ctx context.Context, compartmentID string) ([]domain.VCN, error) {
+	return f.vcns, nil
+}
+
+func TestService_Trace_FetchesOtherCompartmentVCN(t *testing.T) {
+	topo := fixture()
+	repo := &fakeVCNRepo{vcns: topo.VCNs[:1], byID: map[string]domain.VCN{"vcn-shared": topo.VCNs[1]}}
+	svc := NewService(repo, logger.NewTestLogger(), "c-prod")
+	remote := Endpoint{Kind: "instance", Name: "tools-1", IP: "10.1.0.7", VcnID: "vcn-shared", SubnetID: "sn-tools"}
+
+	report, err := svc.Trace(context.Background(), Query{Source: appServer, Destination: remote, Protocol: domain.ProtocolTCP, Port: 22})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vcn-shared"}, repo.calls, "only the VCN missing from the compartment is fetched")
+	assert.Contains(t, step(t, report, CheckDestination).Detail, "VCN shared")
+
+	repo.byID = nil
+	_, err = svc.Trace(context.Background(), Query{Source: appServer, Destination: remote, Protocol: domain.ProtocolTCP, Port: 22})
+	assert.ErrorContains(t, err, "getting vcn vcn-shared of tools-1")
+}
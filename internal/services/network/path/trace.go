@@ -0,0 +1,32 @@
+package path
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	ocivcn "github.com/rozdolsky33/ocloud/internal/oci/network/vcn"
+)
+
+// TracePath evaluates whether the query's source can reach its destination and prints the report.
+// It returns ErrBlocked when a step of the path fails.
+func TracePath(ctx context.Context, appCtx *app.ApplicationContext, q Query, useJSON bool) error {
+	networkClient, err := oci.NewNetworkClient(appCtx.Provider)
+	if err != nil {
+		return fmt.Errorf("creating network client: %w", err)
+	}
+	service := NewService(ocivcn.NewAdapterWithRules(networkClient), appCtx.Logger, appCtx.CompartmentID)
+
+	report, err := service.Trace(ctx, q)
+	if err != nil {
+		return fmt.Errorf("tracing path: %w", err)
+	}
+	if err := PrintReport(report, appCtx, useJSON); err != nil {
+		return err
+	}
+	if report.Verdict == VerdictBlocked {
+		return fmt.Errorf("%w: %s", ErrBlocked, report.Reason)
+	}
+	return nil
+}
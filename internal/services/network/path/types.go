@@ -0,0 +1,90 @@
+package path
+
+import (
+	"errors"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+)
+
+// ErrBlocked is returned when the evaluated path does not allow the traffic.
+var ErrBlocked = errors.New("path is blocked")
+
+// KindIP is the endpoint kind of a raw IP address.
+const KindIP = "ip"
+
+// Endpoint is one end of a path: a resource resolved to its address, subnet and NSGs, or a raw IP.
+type Endpoint struct {
+	Kind     string   `json:"kind"`
+	ID       string   `json:"id,omitempty"`
+	Name     string   `json:"name"`
+	IP       string   `json:"ip"`
+	VcnID    string   `json:"vcn_id,omitempty"`
+	SubnetID string   `json:"subnet_id,omitempty"`
+	NsgIDs   []string `json:"nsg_ids,omitempty"`
+}
+
+// Query is the traffic to evaluate. Protocol is an IANA number (domain.ProtocolTCP, ProtocolUDP or ProtocolICMP);
+// Port is the destination port and is ignored for ICMP, which is evaluated as an echo request.
+type Query struct {
+	Source      Endpoint
+	Destination Endpoint
+	Protocol    string
+	Port        int
+}
+
+// Topology is the network configuration a path is evaluated against: enriched VCNs with their subnets,
+// route tables, security lists, NSGs and gateways.
+type Topology struct {
+	VCNs []domain.VCN
+}
+
+// Status is the outcome of a single step of the evaluation.
+type Status string
+
+const (
+	StatusPass Status = "PASS"
+	StatusFail Status = "FAIL"
+	StatusWarn Status = "WARN"
+	StatusSkip Status = "SKIP"
+)
+
+// Verdict is the overall outcome of the evaluation.
+type Verdict string
+
+const (
+	// VerdictAllowed means every step passed, possibly with warnings.
+	VerdictAllowed Verdict = "ALLOWED"
+	// VerdictBlocked means at least one step failed.
+	VerdictBlocked Verdict = "BLOCKED"
+	// VerdictInconclusive means nothing failed but a step could not be evaluated.
+	VerdictInconclusive Verdict = "INCONCLUSIVE"
+)
+
+// Step names, in the order they are reported.
+const (
+	CheckSource             = "Source"
+	CheckDestination        = "Destination"
+	CheckSourceEgress       = "Source egress"
+	CheckRouting            = "Routing"
+	CheckReturnRoute        = "Return route"
+	CheckDestinationIngress = "Destination ingress"
+)
+
+// Step is the result of one check along the path. Rule names the rule that decided the step, if any.
+type Step struct {
+	Check  string `json:"check"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+	Rule   string `json:"rule,omitempty"`
+}
+
+// Report is the explained verdict for a query. Reason is the detail of the first failed step.
+type Report struct {
+	Source      Endpoint `json:"source"`
+	Destination Endpoint `json:"destination"`
+	Protocol    string   `json:"protocol"`
+	Port        int      `json:"port,omitempty"`
+	Verdict     Verdict  `json:"verdict"`
+	Reason      string   `json:"reason,omitempty"`
+	Steps       []Step   `json:"steps"`
+}
@@ -0,0 +1,121 @@
+package vcn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	domainlb "github.com/rozdolsky33/ocloud/internal/domain/network/loadbalancer"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	ociinstance "github.com/rozdolsky33/ocloud/internal/oci/compute/instance"
+	ociadb "github.com/rozdolsky33/ocloud/internal/oci/database/autonomousdb"
+	ociheatwave "github.com/rozdolsky33/ocloud/internal/oci/database/heatwavedb"
+	ocigateway "github.com/rozdolsky33/ocloud/internal/oci/network/gateway"
+	ocilb "github.com/rozdolsky33/ocloud/internal/oci/network/loadbalancer"
+	ocivcn "github.com/rozdolsky33/ocloud/internal/oci/network/vcn"
+)
+
+// DiagramVCN draws the VCN given by name or OCID as a DOT, Mermaid or SVG-ready DOT diagram. With resources,
+// the instances, load balancers and databases of the compartment are placed in their subnets.
+func DiagramVCN(appCtx *app.ApplicationContext, ref, format string, resources bool) error {
+	ctx := context.Background()
+	networkClient, err := oci.NewNetworkClient(appCtx.Provider)
+	if err != nil {
+		return fmt.Errorf("creating network client: %w", err)
+	}
+
+	service := NewService(ocivcn.NewAdapterWithRules(networkClient), appCtx.Logger, appCtx.CompartmentID)
+	v, err := service.FindVCN(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	var notes []string
+	var summary *domain.Gateways
+	if s, err := ocigateway.NewAdapter(networkClient).GatewaysSummary(ctx, v.CompartmentID, v.OCID); err != nil {
+		notes = append(notes, fmt.Sprintf("gateway summary unavailable: %v", err))
+	} else {
+		summary = &s
+	}
+
+	var placed []DiagramResource
+	if resources {
+		var resourceNotes []string
+		placed, resourceNotes = listDiagramResources(ctx, appCtx)
+		notes = append(notes, resourceNotes...)
+	}
+
+	d := BuildDiagram(v, summary, placed)
+	d.Notes = append(notes, d.Notes...)
+	return RenderDiagram(appCtx.Stdout, d, format)
+}
+
+// listDiagramResources lists the instances, load balancers, autonomous and HeatWave databases of the
+// compartment. A kind that cannot be listed is left out and explained in a note.
+func listDiagramResources(ctx context.Context, appCtx *app.ApplicationContext) ([]DiagramResource, []string) {
+	var resources []DiagramResource
+	var notes []string
+	skip := func(kind string, err error) {
+		notes = append(notes, fmt.Sprintf("%s not shown: %v", kind, err))
+	}
+
+	if computeClient, err := oci.NewComputeClient(appCtx.Provider); err != nil {
+		skip("instances", err)
+	} else if networkClient, err := oci.NewNetworkClient(appCtx.Provider); err != nil {
+		skip("instances", err)
+	} else if instances, err := ociinstance.NewAdapter(computeClient, networkClient).ListEnrichedInstances(ctx, appCtx.CompartmentID); err != nil {
+		skip("instances", err)
+	} else {
+		for _, i := range instances {
+			resources = append(resources, DiagramResource{Kind: "Instance", Name: i.DisplayName, Subnet: i.SubnetID})
+		}
+	}
+
+	if lbs, err := listLoadBalancers(ctx, appCtx); err != nil {
+		skip("load balancers", err)
+	} else {
+		for _, lb := range lbs {
+			for _, subnet := range lb.Subnets {
+				resources = append(resources, DiagramResource{Kind: "Load Balancer", Name: lb.Name, Subnet: subnet})
+			}
+		}
+	}
+
+	if adapter, err := ociadb.NewAdapter(appCtx.Provider); err != nil {
+		skip("autonomous databases", err)
+	} else if dbs, err := adapter.ListAutonomousDatabases(ctx, appCtx.CompartmentID); err != nil {
+		skip("autonomous databases", err)
+	} else {
+		for _, db := range dbs {
+			resources = append(resources, DiagramResource{Kind: "Autonomous Database", Name: db.Name, Subnet: db.SubnetId})
+		}
+	}
+
+	if adapter, err := ociheatwave.NewAdapter(appCtx.Provider); err != nil {
+		skip("HeatWave databases", err)
+	} else if dbs, err := adapter.ListHeatWaveDatabases(ctx, appCtx.CompartmentID); err != nil {
+		skip("HeatWave databases", err)
+	} else {
+		for _, db := range dbs {
+			resources = append(resources, DiagramResource{Kind: "HeatWave", Name: db.DisplayName, Subnet: db.SubnetId})
+		}
+	}
+	return resources, notes
+}
+
+func listLoadBalancers(ctx context.Context, appCtx *app.ApplicationContext) ([]domainlb.LoadBalancer, error) {
+	lbClient, err := oci.NewLoadBalancerClient(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating load balancer client: %w", err)
+	}
+	nwClient, err := oci.NewNetworkClient(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating network client: %w", err)
+	}
+	certsClient, err := oci.NewCertificatesManagementClient(appCtx.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificates management client: %w", err)
+	}
+	return ocilb.NewAdapter(lbClient, nwClient, certsClient).ListLoadBalancers(ctx, appCtx.CompartmentID)
+}
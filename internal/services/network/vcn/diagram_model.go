@@ -0,0 +1,258 @@
+package vcn
+
+import (
+	"fmt"
+	"strings"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+)
+
+// Diagram formats.
+const (
+	DiagramFormatDOT      = "dot"
+	DiagramFormatMermaid  = "mermaid"
+	DiagramFormatSVGReady = "svg-ready"
+)
+
+// Diagram node kinds.
+const (
+	NodeVCN      = "vcn"
+	NodeSubnet   = "subnet"
+	NodeGateway  = "gateway"
+	NodeResource = "resource"
+	NodeExternal = "external"
+)
+
+// DiagramNode is a box in a VCN diagram. Type is the gateway or route target type for gateways and the
+// resource kind for resources; Detail is shown as a tooltip in styled output.
+type DiagramNode struct {
+	ID     string
+	Kind   string
+	Type   string
+	Label  string
+	Detail string
+}
+
+// DiagramSubnet is a subnet node and the resources placed in it.
+type DiagramSubnet struct {
+	DiagramNode
+	Resources []DiagramNode
+}
+
+// DiagramEdge connects two nodes. Route rules are solid; other links, such as peerings, are dashed.
+type DiagramEdge struct {
+	From   string
+	To     string
+	Label  string
+	Dashed bool
+}
+
+// Diagram is the topology of one VCN: subnets and gateways inside the VCN, the networks outside it that the
+// gateways lead to, and the route rules between them. Notes explain what could not be drawn.
+type Diagram struct {
+	VCN      DiagramNode
+	Subnets  []DiagramSubnet
+	Gateways []DiagramNode
+	External []DiagramNode
+	Edges    []DiagramEdge
+	Notes    []string
+}
+
+// DiagramResource is a workload to place in its subnet. Subnet is the subnet OCID, or "name (CIDR)" as
+// load balancers report their subnets.
+type DiagramResource struct {
+	Kind   string
+	Name   string
+	Subnet string
+}
+
+// BuildDiagram lays out the enriched VCN. The gateway summary, when given, names the DRG, the services of the
+// service gateway and the VCNs peered over local peering gateways. Resources outside the VCN are ignored.
+func BuildDiagram(v VCN, summary *domain.Gateways, resources []DiagramResource) *Diagram {
+	d := &Diagram{VCN: DiagramNode{ID: "vcn", Kind: NodeVCN, Label: v.DisplayName + "\n" + strings.Join(v.CidrBlocks, ", "), Detail: v.OCID}}
+
+	routeTables := map[string]domain.RouteTable{}
+	for _, rt := range v.RouteTables {
+		routeTables[rt.OCID] = rt
+	}
+
+	subnetIndex := map[string]int{}
+	for i, sn := range v.Subnets {
+		access := "private"
+		if sn.Public {
+			access = "public"
+		}
+		detail := sn.OCID
+		if rt, ok := routeTables[sn.RouteTableID]; ok {
+			detail += "\nroute table: " + rt.DisplayName
+		}
+		d.Subnets = append(d.Subnets, DiagramSubnet{DiagramNode: DiagramNode{
+			ID:     fmt.Sprintf("subnet_%d", i+1),
+			Kind:   NodeSubnet,
+			Label:  fmt.Sprintf("%s\n%s (%s)", sn.DisplayName, sn.CidrBlock, access),
+			Detail: detail,
+		}})
+		subnetIndex[sn.OCID] = i
+		subnetIndex[fmt.Sprintf("%s (%s)", sn.DisplayName, sn.CidrBlock)] = i
+	}
+	for _, r := range resources {
+		i, ok := subnetIndex[r.Subnet]
+		if !ok {
+			continue
+		}
+		d.Subnets[i].Resources = append(d.Subnets[i].Resources, DiagramNode{
+			ID:    fmt.Sprintf("res_%d", countResources(d)+1),
+			Kind:  NodeResource,
+			Type:  r.Kind,
+			Label: r.Name + "\n" + r.Kind,
+		})
+	}
+
+	gatewayIDs := map[string]string{}
+	drgID := ""
+	for _, g := range v.Gateways {
+		node := d.addGateway(g.Type, g.DisplayName, g.OCID)
+		gatewayIDs[g.OCID] = node.ID
+		if g.Type == domain.TargetTypeDRG && drgID == "" {
+			drgID = node.ID
+		}
+	}
+	if summary != nil {
+		d.applySummary(*summary)
+	}
+	d.addExternalLinks()
+
+	for i, sn := range v.Subnets {
+		rt, ok := routeTables[sn.RouteTableID]
+		if !ok {
+			d.Notes = append(d.Notes, fmt.Sprintf("route table of subnet %s was not found", sn.DisplayName))
+			continue
+		}
+		var targets []string
+		destinations := map[string][]string{}
+		for _, r := range rt.Rules {
+			to := gatewayIDs[r.TargetID]
+			if to == "" && r.TargetType == domain.TargetTypeDRG && drgID != "" {
+				// Route rules target the DRG, while the VCN lists its DRG attachment.
+				to = drgID
+			}
+			if to == "" {
+				name := r.TargetName
+				if name == "" {
+					name = r.TargetID
+				}
+				to = d.addGateway(r.TargetType, name, r.TargetID).ID
+				gatewayIDs[r.TargetID] = to
+			}
+			if _, seen := destinations[to]; !seen {
+				targets = append(targets, to)
+			}
+			destinations[to] = append(destinations[to], r.Destination)
+		}
+		for _, to := range targets {
+			d.Edges = append(d.Edges, DiagramEdge{From: d.Subnets[i].ID, To: to, Label: strings.Join(destinations[to], ", ")})
+		}
+	}
+	return d
+}
+
+// addGateway adds a gateway or other route target inside the VCN.
+func (d *Diagram) addGateway(gatewayType, name, ocid string) DiagramNode {
+	node := DiagramNode{
+		ID:     fmt.Sprintf("gw_%d", len(d.Gateways)+1),
+		Kind:   NodeGateway,
+		Type:   gatewayType,
+		Label:  gatewayAbbreviation(gatewayType) + "\n" + name,
+		Detail: ocid,
+	}
+	d.Gateways = append(d.Gateways, node)
+	return node
+}
+
+// addExternal adds a network outside the VCN, once per label.
+func (d *Diagram) addExternal(label string) string {
+	for _, e := range d.External {
+		if e.Label == label {
+			return e.ID
+		}
+	}
+	id := fmt.Sprintf("ext_%d", len(d.External)+1)
+	d.External = append(d.External, DiagramNode{ID: id, Kind: NodeExternal, Label: label})
+	return id
+}
+
+// applySummary relabels the DRG and service gateway with the names from the gateway summary and links local
+// peering gateways to the VCNs they peer with.
+func (d *Diagram) applySummary(summary domain.Gateways) {
+	relabel := func(gatewayType, value string) {
+		if value == "" || value == "—" {
+			return
+		}
+		for i, g := range d.Gateways {
+			if g.Type == gatewayType {
+				d.Gateways[i].Label = gatewayAbbreviation(gatewayType) + "\n" + value
+				return
+			}
+		}
+	}
+	relabel(domain.TargetTypeDRG, summary.Drg)
+	relabel(domain.TargetTypeService, summary.ServiceGateway)
+
+	for _, peer := range summary.LocalPeeringPeers {
+		lpg, vcn, ok := strings.Cut(peer, " → ")
+		if !ok {
+			continue
+		}
+		if vcn == "<peer>" {
+			vcn = "peer"
+		}
+		for _, g := range d.Gateways {
+			if g.Type == domain.TargetTypeLocalPeering && g.Label == gatewayAbbreviation(g.Type)+"\n"+lpg {
+				d.Edges = append(d.Edges, DiagramEdge{From: g.ID, To: d.addExternal("VCN " + vcn), Label: "peering", Dashed: true})
+				break
+			}
+		}
+	}
+}
+
+// addExternalLinks links internet and NAT gateways to the internet and service gateways to the Oracle
+// Services Network.
+func (d *Diagram) addExternalLinks() {
+	for _, g := range d.Gateways {
+		switch g.Type {
+		case domain.TargetTypeInternet, domain.TargetTypeNAT:
+			d.Edges = append(d.Edges, DiagramEdge{From: g.ID, To: d.addExternal("Internet"), Dashed: true})
+		case domain.TargetTypeService:
+			d.Edges = append(d.Edges, DiagramEdge{From: g.ID, To: d.addExternal("Oracle Services Network"), Dashed: true})
+		}
+	}
+}
+
+func countResources(d *Diagram) int {
+	n := 0
+	for _, s := range d.Subnets {
+		n += len(s.Resources)
+	}
+	return n
+}
+
+// gatewayAbbreviation returns the short name architects use for a gateway or route target type.
+func gatewayAbbreviation(gatewayType string) string {
+	switch gatewayType {
+	case domain.TargetTypeInternet:
+		return "IGW"
+	case domain.TargetTypeNAT:
+		return "NAT"
+	case domain.TargetTypeService:
+		return "SGW"
+	case domain.TargetTypeDRG:
+		return "DRG"
+	case domain.TargetTypeLocalPeering:
+		return "LPG"
+	case domain.TargetTypePrivateIP:
+		return "Private IP"
+	case "":
+		return "Target"
+	}
+	return gatewayType
+}
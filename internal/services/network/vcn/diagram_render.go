@@ -0,0 +1,183 @@
+package vcn
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+)
+
+// RenderDiagram writes the diagram in the given format: Graphviz DOT, Mermaid, or DOT with the colours,
+// fonts and tooltips needed for a readable SVG from "dot -Tsvg".
+func RenderDiagram(w io.Writer, d *Diagram, format string) error {
+	switch format {
+	case DiagramFormatDOT:
+		return renderDOT(w, d, false)
+	case DiagramFormatSVGReady:
+		return renderDOT(w, d, true)
+	case DiagramFormatMermaid:
+		return renderMermaid(w, d)
+	}
+	return fmt.Errorf("unknown diagram format %q: use %s, %s or %s", format, DiagramFormatDOT, DiagramFormatMermaid, DiagramFormatSVGReady)
+}
+
+// dotStyles are the node attributes of styled DOT output, by node kind and then gateway or resource type.
+var dotStyles = map[string]string{
+	NodeSubnet:                    `fillcolor="#dbeafe", color="#2563eb"`,
+	NodeResource:                  `fillcolor="#fef3c7", color="#d97706", shape=component`,
+	NodeExternal:                  `fillcolor="#f3f4f6", color="#6b7280", shape=ellipse`,
+	NodeGateway:                   `fillcolor="#e5e7eb", color="#374151", shape=hexagon`,
+	domain.TargetTypeInternet:     `fillcolor="#dcfce7", color="#16a34a", shape=hexagon`,
+	domain.TargetTypeNAT:          `fillcolor="#dcfce7", color="#15803d", shape=hexagon`,
+	domain.TargetTypeService:      `fillcolor="#fee2e2", color="#dc2626", shape=hexagon`,
+	domain.TargetTypeDRG:          `fillcolor="#ede9fe", color="#7c3aed", shape=hexagon`,
+	domain.TargetTypeLocalPeering: `fillcolor="#ede9fe", color="#6d28d9", shape=hexagon`,
+	domain.TargetTypePrivateIP:    `fillcolor="#e5e7eb", color="#374151", shape=diamond`,
+}
+
+func renderDOT(w io.Writer, d *Diagram, styled bool) error {
+	var b strings.Builder
+	for _, n := range d.Notes {
+		fmt.Fprintf(&b, "// %s\n", n)
+	}
+	name, _, _ := strings.Cut(d.VCN.Label, "\n")
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	if styled {
+		b.WriteString("  graph [fontname=\"Helvetica\", fontsize=12, nodesep=0.4, ranksep=1.0, bgcolor=\"white\"];\n")
+		b.WriteString("  node [style=\"rounded,filled\", fontname=\"Helvetica\", fontsize=11];\n")
+		b.WriteString("  edge [fontname=\"Helvetica\", fontsize=9, color=\"#4b5563\"];\n")
+	}
+
+	node := func(indent string, n DiagramNode) {
+		attrs := "label=" + dotQuote(n.Label)
+		if styled {
+			style := dotStyles[n.Type]
+			if n.Kind != NodeGateway || style == "" {
+				style = dotStyles[n.Kind]
+			}
+			if style != "" {
+				attrs += ", " + style
+			}
+			if n.Detail != "" {
+				attrs += ", tooltip=" + dotQuote(n.Detail)
+			}
+		}
+		fmt.Fprintf(&b, "%s%s [%s];\n", indent, n.ID, attrs)
+	}
+
+	b.WriteString("  subgraph cluster_vcn {\n")
+	fmt.Fprintf(&b, "    label=%s;\n", dotQuote(d.VCN.Label))
+	if styled {
+		fmt.Fprintf(&b, "    style=\"rounded,filled\"; fillcolor=\"#f8fafc\"; color=\"#94a3b8\"; tooltip=%s;\n", dotQuote(d.VCN.Detail))
+	}
+	for _, s := range d.Subnets {
+		if len(s.Resources) == 0 {
+			node("    ", s.DiagramNode)
+			continue
+		}
+		fmt.Fprintf(&b, "    subgraph cluster_%s {\n", s.ID)
+		b.WriteString("      label=\"\"; style=dashed;\n")
+		node("      ", s.DiagramNode)
+		for _, r := range s.Resources {
+			node("      ", r)
+		}
+		b.WriteString("    }\n")
+	}
+	for _, g := range d.Gateways {
+		node("    ", g)
+	}
+	b.WriteString("  }\n")
+
+	for _, e := range d.External {
+		node("  ", e)
+	}
+	for _, e := range d.Edges {
+		var attrs []string
+		if e.Label != "" {
+			attrs = append(attrs, "label="+dotQuote(e.Label))
+		}
+		if e.Dashed {
+			attrs = append(attrs, "style=dashed")
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, "  %s -> %s [%s];\n", e.From, e.To, strings.Join(attrs, ", "))
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s;\n", e.From, e.To)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func renderMermaid(w io.Writer, d *Diagram) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range d.Notes {
+		fmt.Fprintf(&b, "  %%%% %s\n", n)
+	}
+
+	node := func(indent string, n DiagramNode) {
+		open, closing := "[", "]"
+		switch n.Kind {
+		case NodeGateway:
+			open, closing = "{{", "}}"
+		case NodeResource:
+			open, closing = "([", "])"
+		case NodeExternal:
+			open, closing = "((", "))"
+		}
+		fmt.Fprintf(&b, "%s%s%s%s%s\n", indent, n.ID, open, mermaidQuote(n.Label), closing)
+	}
+
+	fmt.Fprintf(&b, "  subgraph %s[%s]\n", d.VCN.ID, mermaidQuote(d.VCN.Label))
+	for _, s := range d.Subnets {
+		if len(s.Resources) == 0 {
+			node("    ", s.DiagramNode)
+			continue
+		}
+		fmt.Fprintf(&b, "    subgraph %s_group[%s]\n", s.ID, mermaidQuote(" "))
+		node("      ", s.DiagramNode)
+		for _, r := range s.Resources {
+			node("      ", r)
+		}
+		b.WriteString("    end\n")
+	}
+	for _, g := range d.Gateways {
+		node("    ", g)
+	}
+	b.WriteString("  end\n")
+
+	for _, e := range d.External {
+		node("  ", e)
+	}
+	for _, e := range d.Edges {
+		arrow := "-->"
+		if e.Dashed {
+			arrow = "-.->"
+		}
+		if e.Label != "" {
+			fmt.Fprintf(&b, "  %s %s|%s| %s\n", e.From, arrow, mermaidQuote(e.Label), e.To)
+		} else {
+			fmt.Fprintf(&b, "  %s %s %s\n", e.From, arrow, e.To)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote returns s as a quoted DOT string with escaped quotes and line breaks.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+// mermaidQuote returns s as a quoted Mermaid label with escaped quotes and line breaks.
+func mermaidQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	return `"` + strings.ReplaceAll(s, "\n", "<br/>") + `"`
+}
@@ -0,0 +1,112 @@
+package vcn
+
+import (
+	"bytes"
+	"testing"
+
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diagramVCN() VCN {
+	return VCN{
+		OCID:        "ocid1.vcn.oc1..prod",
+		DisplayName: "prod",
+		CidrBlocks:  []string{"10.0.0.0/16"},
+		Gateways: []domain.Gateway{
+			{OCID: "ocid1.internetgateway.oc1..igw", DisplayName: "igw", Type: domain.TargetTypeInternet},
+			{OCID: "ocid1.natgateway.oc1..nat", DisplayName: "nat", Type: domain.TargetTypeNAT},
+			{OCID: "ocid1.drgattachment.oc1..att", DisplayName: "att", Type: domain.TargetTypeDRG},
+			{OCID: "ocid1.localpeeringgateway.oc1..lpg", DisplayName: "to-shared", Type: domain.TargetTypeLocalPeering},
+		},
+		Subnets: []Subnet{
+			{OCID: "ocid1.subnet.oc1..web", DisplayName: "web", CidrBlock: "10.0.0.0/24", Public: true, RouteTableID: "rt-public"},
+			{OCID: "ocid1.subnet.oc1..app", DisplayName: "app", CidrBlock: "10.0.1.0/24", RouteTableID: "rt-private"},
+			{OCID: "ocid1.subnet.oc1..orphan", DisplayName: "orphan", CidrBlock: "10.0.2.0/24", RouteTableID: "rt-missing"},
+		},
+		RouteTables: []domain.RouteTable{
+			{OCID: "rt-public", DisplayName: "public", Rules: []domain.RouteRule{
+				{Destination: "0.0.0.0/0", TargetID: "ocid1.internetgateway.oc1..igw", TargetType: domain.TargetTypeInternet},
+			}},
+			{OCID: "rt-private", DisplayName: "private", Rules: []domain.RouteRule{
+				{Destination: "0.0.0.0/0", TargetID: "ocid1.natgateway.oc1..nat", TargetType: domain.TargetTypeNAT},
+				{Destination: "172.16.0.0/12", TargetID: "ocid1.drg.oc1..drg", TargetType: domain.TargetTypeDRG},
+				{Destination: "192.168.0.0/16", TargetID: "ocid1.drg.oc1..drg", TargetType: domain.TargetTypeDRG},
+				{Destination: "10.9.0.0/16", TargetID: "ocid1.privateip.oc1..fw", TargetType: domain.TargetTypePrivateIP, TargetName: "firewall"},
+			}},
+		},
+	}
+}
+
+func TestBuildDiagram(t *testing.T) {
+	summary := &domain.Gateways{Drg: "core-drg (attached)", ServiceGateway: "—", LocalPeeringPeers: []string{"to-shared → shared-vcn"}}
+	resources := []DiagramResource{
+		{Kind: "Instance", Name: "app-1", Subnet: "ocid1.subnet.oc1..app"},
+		{Kind: "Load Balancer", Name: "public-lb", Subnet: "web (10.0.0.0/24)"},
+		{Kind: "Instance", Name: "elsewhere", Subnet: "ocid1.subnet.oc1..other"},
+	}
+
+	d := BuildDiagram(diagramVCN(), summary, resources)
+
+	assert.Equal(t, "prod\n10.0.0.0/16", d.VCN.Label)
+	require.Len(t, d.Subnets, 3)
+	assert.Equal(t, "web\n10.0.0.0/24 (public)", d.Subnets[0].Label)
+	assert.Equal(t, "ocid1.subnet.oc1..web\nroute table: public", d.Subnets[0].Detail)
+	require.Len(t, d.Subnets[0].Resources, 1)
+	assert.Equal(t, "public-lb\nLoad Balancer", d.Subnets[0].Resources[0].Label)
+	require.Len(t, d.Subnets[1].Resources, 1)
+	assert.Equal(t, "res_1", d.Subnets[1].Resources[0].ID)
+	assert.Equal(t, "res_2", d.Subnets[0].Resources[0].ID)
+
+	var labels []string
+	for _, g := range d.Gateways {
+		labels = append(labels, g.Label)
+	}
+	assert.Equal(t, []string{"IGW\nigw", "NAT\nnat", "DRG\ncore-drg (attached)", "LPG\nto-shared", "Private IP\nfirewall"}, labels)
+
+	var externals []string
+	for _, e := range d.External {
+		externals = append(externals, e.Label)
+	}
+	assert.Equal(t, []string{"VCN shared-vcn", "Internet"}, externals)
+
+	assert.Contains(t, d.Edges, DiagramEdge{From: "subnet_1", To: "gw_1", Label: "0.0.0.0/0"})
+	assert.Contains(t, d.Edges, DiagramEdge{From: "subnet_2", To: "gw_3", Label: "172.16.0.0/12, 192.168.0.0/16"}, "DRG routes go to the DRG attachment")
+	assert.Contains(t, d.Edges, DiagramEdge{From: "subnet_2", To: "gw_5", Label: "10.9.0.0/16"})
+	assert.Contains(t, d.Edges, DiagramEdge{From: "gw_4", To: "ext_1", Label: "peering", Dashed: true})
+	assert.Contains(t, d.Edges, DiagramEdge{From: "gw_2", To: "ext_2", Dashed: true})
+	assert.Equal(t, []string{"route table of subnet orphan was not found"}, d.Notes)
+}
+
+func TestRenderDiagram(t *testing.T) {
+	d := BuildDiagram(diagramVCN(), nil, []DiagramResource{{Kind: "Instance", Name: `app "1"`, Subnet: "ocid1.subnet.oc1..app"}})
+
+	var dot bytes.Buffer
+	require.NoError(t, RenderDiagram(&dot, d, DiagramFormatDOT))
+	out := dot.String()
+	assert.Contains(t, out, "// route table of subnet orphan was not found\ndigraph \"prod\" {")
+	assert.Contains(t, out, `subgraph cluster_vcn {`)
+	assert.Contains(t, out, `subgraph cluster_subnet_2 {`)
+	assert.Contains(t, out, `res_1 [label="app \"1\"\nInstance"];`)
+	assert.Contains(t, out, `subnet_1 -> gw_1 [label="0.0.0.0/0"];`)
+	assert.Contains(t, out, `gw_1 -> ext_1 [style=dashed];`)
+	assert.NotContains(t, out, "tooltip")
+
+	var svg bytes.Buffer
+	require.NoError(t, RenderDiagram(&svg, d, DiagramFormatSVGReady))
+	assert.Contains(t, svg.String(), `gw_1 [label="IGW\nigw", fillcolor="#dcfce7", color="#16a34a", shape=hexagon, tooltip="ocid1.internetgateway.oc1..igw"];`)
+	assert.Contains(t, svg.String(), `fontname="Helvetica"`)
+
+	var mermaid bytes.Buffer
+	require.NoError(t, RenderDiagram(&mermaid, d, DiagramFormatMermaid))
+	out = mermaid.String()
+	assert.Contains(t, out, "flowchart LR\n  %% route table of subnet orphan was not found\n")
+	assert.Contains(t, out, `subgraph vcn["prod<br/>10.0.0.0/16"]`)
+	assert.Contains(t, out, `res_1(["app #quot;1#quot;<br/>Instance"])`)
+	assert.Contains(t, out, `gw_1{{"IGW<br/>igw"}}`)
+	assert.Contains(t, out, `subnet_1 -->|"0.0.0.0/0"| gw_1`)
+	assert.Contains(t, out, `gw_1 -.-> ext_1`)
+
+	assert.ErrorContains(t, RenderDiagram(&mermaid, d, "png"), `unknown diagram format "png"`)
+}
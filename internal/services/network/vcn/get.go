@@ -18,7 +18,11 @@ func GetVCNs(appCtx *app.ApplicationContext, limit, page int, useJSON, gateways,
 		return fmt.Errorf("creating network client: %w", err)
 	}
 
+	// NSG rules and route target names cost extra API calls per VCN, so they are only fetched when shown.
 	adapter := ocivcn.NewAdapter(networkClient)
+	if nsgs || routes {
+		adapter = ocivcn.NewAdapterWithRules(networkClient)
+	}
 	service := NewService(adapter, appCtx.Logger, appCtx.CompartmentID)
 
 	vcns, totalCount, nextPageToken, err := service.FetchPaginatedVCNs(ctx, limit, page)
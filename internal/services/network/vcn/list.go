@@ -35,7 +35,12 @@ func ListVCNs(appCtx *app.ApplicationContext, useJSON, gateways, subnets, nsgs,
 		return fmt.Errorf("listing vcn: %w", err)
 	}
 
-	vcn, err := service.vcnRepo.GetEnrichedVcn(ctx, id)
+	// Only the chosen VCN is fetched with NSG rules and route target names, and only when they are shown.
+	repo := service.vcnRepo
+	if nsgs || routes {
+		repo = ocivcn.NewAdapterWithRules(networkClient)
+	}
+	vcn, err := repo.GetEnrichedVcn(ctx, id)
 	if err != nil {
 		return fmt.Errorf("getting vcn: %w", err)
 	}
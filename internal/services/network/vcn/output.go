@@ -153,6 +153,9 @@ func printNSGs(p *printer.Printer, nsgs []domain.NSG) {
 	}
 	headers := []string{"Name", "State"}
 	p.PrintTableNoTruncate("Network Security Groups", headers, toNSGRows(nsgs))
+	for _, n := range nsgs {
+		printSecurityRules(p, "NSG "+n.DisplayName+" Rules", n.Rules)
+	}
 }
 
 func printRouteTables(p *printer.Printer, rts []domain.RouteTable) {
@@ -161,6 +164,13 @@ func printRouteTables(p *printer.Printer, rts []domain.RouteTable) {
 	}
 	headers := []string{"Name", "State"}
 	p.PrintTableNoTruncate("Route Tables", headers, toRouteTableRows(rts))
+	for _, r := range rts {
+		if len(r.Rules) == 0 {
+			continue
+		}
+		headers := []string{"Destination", "Target Type", "Target", "Description"}
+		p.PrintTableNoTruncate("Route Table "+r.DisplayName+" Rules", headers, toRouteRuleRows(r.Rules))
+	}
 }
 
 func printSecurityLists(p *printer.Printer, sls []domain.SecurityList) {
@@ -169,6 +179,18 @@ func printSecurityLists(p *printer.Printer, sls []domain.SecurityList) {
 	}
 	headers := []string{"Name", "State"}
 	p.PrintTableNoTruncate("Security Lists", headers, toSecurityListRows(sls))
+	for _, s := range sls {
+		rules := append(append([]domain.SecurityRule{}, s.IngressRules...), s.EgressRules...)
+		printSecurityRules(p, "Security List "+s.DisplayName+" Rules", rules)
+	}
+}
+
+func printSecurityRules(p *printer.Printer, title string, rules []domain.SecurityRule) {
+	if len(rules) == 0 {
+		return
+	}
+	headers := []string{"Direction", "Protocol", "Source/Destination", "Ports", "Stateless", "Description"}
+	p.PrintTableNoTruncate(title, headers, toSecurityRuleRows(rules))
 }
 
 func toGatewayRows(gateways []domain.Gateway) [][]string {
@@ -297,3 +319,42 @@ func toSecurityListRows(sls []domain.SecurityList) [][]string {
 	}
 	return rows
 }
+
+func toSecurityRuleRows(rules []domain.SecurityRule) [][]string {
+	rows := make([][]string, len(rules))
+	for i, r := range rules {
+		stateless := "No"
+		if r.Stateless {
+			stateless = "Yes"
+		}
+		rows[i] = []string{r.Direction, r.ProtocolName(), formatPeer(r), r.PortSummary(), stateless, dashIfEmpty(r.Description)}
+	}
+	return rows
+}
+
+func toRouteRuleRows(rules []domain.RouteRule) [][]string {
+	rows := make([][]string, len(rules))
+	for i, r := range rules {
+		target := r.TargetName
+		if strings.TrimSpace(target) == "" {
+			target = r.TargetID
+		}
+		rows[i] = []string{r.Destination, dashIfEmpty(r.TargetType), dashIfEmpty(target), dashIfEmpty(r.Description)}
+	}
+	return rows
+}
+
+// formatPeer shows an NSG peer by name when it was resolved and every other peer as given.
+func formatPeer(r domain.SecurityRule) string {
+	if r.PeerType == domain.AddressTypeNSG && strings.TrimSpace(r.PeerName) != "" {
+		return r.PeerName + " (NSG)"
+	}
+	return dashIfEmpty(r.Peer)
+}
+
+func dashIfEmpty(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
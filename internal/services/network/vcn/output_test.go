@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/rozdolsky33/ocloud/internal/app"
+	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
 	"github.com/rozdolsky33/ocloud/internal/logger"
 	"github.com/stretchr/testify/assert"
 )
@@ -60,3 +61,44 @@ func TestPrintVCNsInfo_List_JSONAndTable(t *testing.T) {
 		}
 	}
 }
+
+func TestPrintVCNInfo_Rules(t *testing.T) {
+	buf := &bytes.Buffer{}
+	appCtx := &app.ApplicationContext{Logger: logger.NewTestLogger(), Stdout: buf}
+	icmpType := 3
+
+	v := makeVCN(0)
+	v.SecurityLists = []domain.SecurityList{{
+		DisplayName: "default",
+		IngressRules: []domain.SecurityRule{
+			{Direction: domain.DirectionIngress, Protocol: domain.ProtocolTCP, Peer: "0.0.0.0/0", PeerType: domain.AddressTypeCIDR,
+				DestinationPorts: &domain.PortRange{Min: 22, Max: 22}, Description: "ssh"},
+			{Direction: domain.DirectionIngress, Protocol: domain.ProtocolICMP, Peer: "10.0.0.0/16", IcmpType: &icmpType},
+		},
+	}}
+	v.NSGs = []domain.NSG{{
+		DisplayName: "app",
+		Rules: []domain.SecurityRule{{Direction: domain.DirectionIngress, Protocol: domain.ProtocolTCP, Peer: "ocid1.networksecuritygroup.oc1..web",
+			PeerType: domain.AddressTypeNSG, PeerName: "web", DestinationPorts: &domain.PortRange{Min: 8080, Max: 8081}, Stateless: true}},
+	}}
+	v.RouteTables = []domain.RouteTable{{
+		DisplayName: "private",
+		Rules:       []domain.RouteRule{{Destination: "0.0.0.0/0", TargetID: "ocid1.natgateway.oc1..nat", TargetType: domain.TargetTypeNAT, TargetName: "nat-gw"}},
+	}}
+
+	assert.NoError(t, PrintVCNInfo(v, appCtx, false, false, false, true, true, true))
+	out := buf.String()
+	assert.Contains(t, out, "Security List default Rules")
+	assert.Contains(t, out, "ssh")
+	assert.Contains(t, out, "type 3")
+	assert.Contains(t, out, "NSG app Rules")
+	assert.Contains(t, out, "web (NSG)")
+	assert.Contains(t, out, "8080-8081")
+	assert.Contains(t, out, "Route Table private Rules")
+	assert.Contains(t, out, "nat-gw")
+
+	buf.Reset()
+	assert.NoError(t, PrintVCNInfo(v, appCtx, true, false, false, true, true, true))
+	assert.Contains(t, buf.String(), `"PeerName": "web"`)
+	assert.Contains(t, buf.String(), `"TargetName": "nat-gw"`)
+}
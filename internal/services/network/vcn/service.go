@@ -3,6 +3,7 @@ package vcn
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	domain "github.com/rozdolsky33/ocloud/internal/domain/network/vcn"
@@ -50,6 +51,38 @@ func (s *Service) ListVcns(ctx context.Context) ([]VCN, error) {
 	return allVcn, nil
 }
 
+// FindVCN returns the enriched VCN with the given OCID, or the VCN of the compartment with the given
+// display name (case-insensitive).
+func (s *Service) FindVCN(ctx context.Context, ref string) (VCN, error) {
+	id := ref
+	if !strings.HasPrefix(ref, "ocid1.vcn.") {
+		all, err := s.vcnRepo.ListVcns(ctx, s.compartmentID)
+		if err != nil {
+			return VCN{}, fmt.Errorf("listing vcns from repository: %w", err)
+		}
+		var matches []VCN
+		for _, v := range all {
+			if strings.EqualFold(v.DisplayName, ref) {
+				matches = append(matches, v)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return VCN{}, fmt.Errorf("no VCN named %q in the compartment", ref)
+		case 1:
+			id = matches[0].OCID
+		default:
+			return VCN{}, fmt.Errorf("%d VCNs are named %q: use the OCID", len(matches), ref)
+		}
+	}
+	s.logger.V(logger.Debug).Info("getting enriched vcn", "ocid", id)
+	v, err := s.vcnRepo.GetEnrichedVcn(ctx, id)
+	if err != nil {
+		return VCN{}, fmt.Errorf("getting vcn %s: %w", ref, err)
+	}
+	return v, nil
+}
+
 // FuzzySearch performs a fuzzy search for vcns.
 func (s *Service) FuzzySearch(ctx context.Context, searchPattern string) ([]VCN, error) {
 	all, err := s.vcnRepo.ListEnrichedVcns(ctx, s.compartmentID)
@@ -85,3 +85,27 @@ func TestService_ListAndPaginateVCNs(t *testing.T) {
 	assert.Equal(t, "", next2)
 	assert.Len(t, page2, 1)
 }
+
+func TestService_FindVCN(t *testing.T) {
+	a, b, c := makeVCN(0), makeVCN(1), makeVCN(2)
+	c.DisplayName = "VCN-A"
+	enriched := a
+	enriched.Subnets = []Subnet{{OCID: "ocid1.subnet.oc1..x"}}
+	repo := &fakeVCNRepo{vcns: []VCN{a, b, c}, enrichedByID: map[string]VCN{a.OCID: enriched, b.OCID: b}}
+	svc, _ := makeService(repo)
+	ctx := context.Background()
+
+	v, err := svc.FindVCN(ctx, "vcn-b")
+	assert.NoError(t, err)
+	assert.Equal(t, b.OCID, v.OCID)
+
+	_, err = svc.FindVCN(ctx, "vcn-a")
+	assert.ErrorContains(t, err, `2 VCNs are named "vcn-a": use the OCID`)
+
+	v, err = svc.FindVCN(ctx, a.OCID)
+	assert.NoError(t, err)
+	assert.Len(t, v.Subnets, 1)
+
+	_, err = svc.FindVCN(ctx, "missing")
+	assert.ErrorContains(t, err, `no VCN named "missing"`)
+}
@@ -5,3 +5,4 @@ import (
 )
 
 type VCN = domain.VCN
+type Subnet = domain.Subnet
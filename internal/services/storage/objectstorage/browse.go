@@ -0,0 +1,87 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/tui"
+)
+
+// browsePageSize is how many entries the object browser loads at a time, the most one ListObjects call returns.
+const browsePageSize = 1000
+
+// errBucketEmpty is returned by browseObjects for a bucket without objects.
+var errBucketEmpty = errors.New("bucket is empty")
+
+// browseObjects lets the user walk the folders of a bucket one page at a time and returns the name of the
+// chosen object. Esc goes up one folder; at the top of the bucket it returns tui.ErrCancelled.
+func (s *Service) browseObjects(ctx context.Context, namespace, bucketName string) (string, error) {
+	prefix, cursor := "", ""
+	for {
+		listing := &osadapter.ObjectListing{Bucket: bucketName, Prefix: prefix}
+		start, err := s.loadListingPage(ctx, namespace, listing, "")
+		if err != nil {
+			return "", err
+		}
+		if prefix == "" && len(listing.Prefixes) == 0 && len(listing.Objects) == 0 {
+			return "", errBucketEmpty
+		}
+
+	choose:
+		for {
+			choice, err := tui.Run(osadapter.NewObjectBrowserModel(*listing).Select(cursor))
+			switch {
+			case errors.Is(err, tui.ErrCancelled) || choice == osadapter.BrowseParentID:
+				if prefix == "" {
+					return "", tui.ErrCancelled
+				}
+				cursor, prefix = prefix, parentPrefix(prefix)
+				break choose
+			case err != nil:
+				return "", fmt.Errorf("selecting object: %w", err)
+			case choice == osadapter.BrowseLoadMoreID:
+				loaded := len(listing.Objects)
+				if start, err = s.loadListingPage(ctx, namespace, listing, start); err != nil {
+					return "", err
+				}
+				if len(listing.Objects) > loaded {
+					cursor = listing.Objects[loaded].Name
+				}
+			case strings.HasSuffix(choice, "/"):
+				cursor, prefix = "", choice
+				break choose
+			default:
+				return choice, nil
+			}
+		}
+	}
+}
+
+// loadListingPage appends the page of listing.Prefix that begins at start and returns where the next page begins.
+func (s *Service) loadListingPage(ctx context.Context, namespace string, listing *osadapter.ObjectListing, start string) (string, error) {
+	page, err := s.ListObjectsPage(ctx, namespace, listing.Bucket, listing.Prefix, "/", start, browsePageSize)
+	if err != nil {
+		return "", fmt.Errorf("listing objects: %w", err)
+	}
+	listing.Prefixes = append(listing.Prefixes, page.Prefixes...)
+	for _, o := range page.Objects {
+		// A zero-byte object named like the prefix is a folder placeholder, not something to pick.
+		if o.Name != listing.Prefix {
+			listing.Objects = append(listing.Objects, o)
+		}
+	}
+	listing.HasMore = page.NextStart != ""
+	return page.NextStart, nil
+}
+
+// parentPrefix returns the folder above prefix: "logs/2024/" -> "logs/", "logs/" -> "".
+func parentPrefix(prefix string) string {
+	trimmed := strings.TrimSuffix(prefix, "/")
+	if i := strings.LastIndex(trimmed, "/"); i >= 0 {
+		return trimmed[:i+1]
+	}
+	return ""
+}
@@ -0,0 +1,461 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
+)
+
+// URIScheme prefixes Object Storage locations in cp arguments, as in oci://bucket/prefix/.
+const URIScheme = "oci://"
+
+// Copy operations reported in CopySummary.
+const (
+	OperationUpload   = "upload"
+	OperationDownload = "download"
+	OperationCopy     = "copy"
+)
+
+// Location is a source or destination of cp: an object or prefix in a bucket, a local path, or "-" for stdin/stdout.
+type Location struct {
+	Bucket string
+	Object string
+	Path   string
+	Stdio  bool
+}
+
+// ParseLocation parses a cp argument.
+func ParseLocation(arg string) (Location, error) {
+	if arg == "-" {
+		return Location{Stdio: true}, nil
+	}
+	if !strings.HasPrefix(arg, URIScheme) {
+		if arg == "" {
+			return Location{}, fmt.Errorf("empty path")
+		}
+		return Location{Path: arg}, nil
+	}
+	bucket, object, _ := strings.Cut(strings.TrimPrefix(arg, URIScheme), "/")
+	if bucket == "" {
+		return Location{}, fmt.Errorf("invalid location %q: expected %s<bucket>/<object or prefix>", arg, URIScheme)
+	}
+	return Location{Bucket: bucket, Object: object}, nil
+}
+
+// IsRemote reports whether the location is in Object Storage.
+func (l Location) IsRemote() bool { return l.Bucket != "" }
+
+// String formats the location the way it is written on the command line.
+func (l Location) String() string {
+	switch {
+	case l.Stdio:
+		return "-"
+	case l.IsRemote():
+		return URIScheme + l.Bucket + "/" + l.Object
+	default:
+		return l.Path
+	}
+}
+
+// CopyOptions controls cp.
+type CopyOptions struct {
+	Recursive bool
+	Transfer  TransferOptions
+}
+
+// CopyItem is the outcome of one transfer.
+type CopyItem struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Bytes       int64  `json:"bytes"`
+	Error       string `json:"error,omitempty"`
+}
+
+// CopySummary is the outcome of a cp command.
+type CopySummary struct {
+	Operation string     `json:"operation"`
+	Files     int        `json:"files"`
+	Bytes     int64      `json:"bytes"`
+	Failed    int        `json:"failed"`
+	Items     []CopyItem `json:"items"`
+}
+
+// copyTask is one transfer planned by Copy.
+type copyTask struct {
+	src, dst Location
+	size     int64
+	// err is set when the task was rejected while planning; it is reported without transferring anything.
+	err error
+}
+
+// CopyObjects runs "object-storage cp": it copies between the local filesystem (or stdin/stdout) and Object
+// Storage, or between buckets, then prints a summary. The summary goes to stderr when the data goes to stdout.
+func CopyObjects(appCtx *app.ApplicationContext, srcArg, dstArg string, opts CopyOptions, quiet bool, stdin io.Reader) error {
+	ctx := context.Background()
+	src, err := ParseLocation(srcArg)
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	dst, err := ParseLocation(dstArg)
+	if err != nil {
+		return fmt.Errorf("destination: %w", err)
+	}
+
+	client, err := oci.NewObjectStorageClient(appCtx.Provider)
+	if err != nil {
+		return fmt.Errorf("creating object storage client: %w", err)
+	}
+	service := NewService(osadapter.NewAdapter(client), appCtx.Logger, appCtx.CompartmentID)
+	namespace, err := service.GetNamespace(ctx)
+	if err != nil {
+		return fmt.Errorf("getting namespace: %w", err)
+	}
+	region, err := appCtx.Provider.Region()
+	if err != nil {
+		return fmt.Errorf("getting region: %w", err)
+	}
+
+	summary, err := service.Copy(ctx, namespace, region, src, dst, opts, stdin, appCtx.Stdout)
+	if err != nil {
+		return err
+	}
+	out := appCtx.Stdout
+	if dst.Stdio {
+		out = appCtx.Stderr
+	}
+	if err := PrintCopySummary(summary, out, quiet); err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d transfers failed", summary.Failed, len(summary.Items))
+	}
+	return nil
+}
+
+// Copy plans and runs the transfers from src to dst. opts.Transfer.Parallelism is shared between the files in
+// flight and the parts of each file. Failed transfers are reported in the summary; an error is returned only
+// when nothing could be planned.
+func (s *Service) Copy(ctx context.Context, namespace, region string, src, dst Location, opts CopyOptions, stdin io.Reader, stdout io.Writer) (*CopySummary, error) {
+	if !src.IsRemote() && !dst.IsRemote() {
+		return nil, fmt.Errorf("one of source and destination must be an %s location", URIScheme)
+	}
+	if (src.Stdio || dst.Stdio) && opts.Recursive {
+		return nil, fmt.Errorf("--recursive cannot be used with - (stdin/stdout)")
+	}
+	if opts.Transfer.VersionID != "" && (!src.IsRemote() || opts.Recursive) {
+		return nil, fmt.Errorf("a version ID selects a version of a single source object")
+	}
+
+	summary := &CopySummary{}
+	var tasks []copyTask
+	var err error
+	switch {
+	case !src.IsRemote():
+		summary.Operation = OperationUpload
+		tasks, err = planUpload(src, dst, opts.Recursive)
+	case !dst.IsRemote():
+		summary.Operation = OperationDownload
+		tasks, err = s.planDownload(ctx, namespace, src, dst, opts)
+	default:
+		summary.Operation = OperationCopy
+		if opts.Transfer.ContentType != "" {
+			return nil, fmt.Errorf("the content type cannot be changed by a copy between buckets")
+		}
+		tasks, err = s.planRemoteCopy(ctx, namespace, src, dst, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.logger.V(logger.Debug).Info("copying objects", "operation", summary.Operation, "source", src.String(), "destination", dst.String(), "transfers", len(tasks))
+
+	summary.Items = make([]CopyItem, len(tasks))
+	transfer := opts.Transfer
+	var files int
+	files, transfer.Parallelism = splitParallelism(opts.Transfer.Parallelism, len(tasks))
+	runParallel(files, len(tasks), func(idx int) {
+		t := tasks[idx]
+		item := CopyItem{Source: t.src.String(), Destination: t.dst.String()}
+		if t.err != nil {
+			item.Error = t.err.Error()
+			summary.Items[idx] = item
+			return
+		}
+		n, err := s.transfer(ctx, namespace, region, t, transfer, stdin, stdout)
+		if err != nil {
+			item.Error = err.Error()
+			s.logger.V(logger.Debug).Info("transfer failed", "source", item.Source, "destination", item.Destination, "error", err)
+		}
+		item.Bytes = n
+		summary.Items[idx] = item
+	})
+
+	for _, item := range summary.Items {
+		if item.Error != "" {
+			summary.Failed++
+			continue
+		}
+		summary.Files++
+		summary.Bytes += item.Bytes
+	}
+	return summary, nil
+}
+
+// splitParallelism divides a budget of n concurrent requests between the files transferred at the same time
+// and the parts of each file: a single file gets every part worker, n or more files one part each.
+func splitParallelism(n, files int) (fileWorkers, partWorkers int) {
+	if n < 1 {
+		n = osadapter.DefaultParallelism
+	}
+	fileWorkers = min(n, max(files, 1))
+	return fileWorkers, max(n/fileWorkers, 1)
+}
+
+// runParallel calls fn for every index below n, running up to workers calls at a time.
+func runParallel(workers, n int, fn func(int)) {
+	if workers < 1 {
+		workers = osadapter.DefaultParallelism
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				fn(idx)
+			}
+		}()
+	}
+	for idx := 0; idx < n; idx++ {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// transfer runs one task and returns the number of bytes transferred.
+func (s *Service) transfer(ctx context.Context, namespace, region string, t copyTask, opts TransferOptions, stdin io.Reader, stdout io.Writer) (int64, error) {
+	switch {
+	case t.src.Stdio:
+		return s.osRepo.UploadStream(ctx, namespace, t.dst.Bucket, t.dst.Object, stdin, opts)
+
+	case !t.src.IsRemote():
+		if err := s.osRepo.UploadObject(ctx, namespace, t.dst.Bucket, t.dst.Object, t.src.Path, opts, nil); err != nil {
+			return 0, err
+		}
+		return t.size, nil
+
+	case t.dst.Stdio:
+		r, err := s.osRepo.OpenObject(ctx, namespace, t.src.Bucket, t.src.Object, opts)
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+		n, err := io.Copy(stdout, r)
+		if err != nil {
+			return n, fmt.Errorf("write to stdout: %w", err)
+		}
+		return n, nil
+
+	case !t.dst.IsRemote():
+		if err := os.MkdirAll(filepath.Dir(t.dst.Path), 0o755); err != nil {
+			return 0, fmt.Errorf("create directory: %w", err)
+		}
+		if err := s.osRepo.DownloadObjectToFile(ctx, namespace, t.src.Bucket, t.src.Object, t.dst.Path, opts, nil); err != nil {
+			return 0, err
+		}
+		return t.size, nil
+
+	default:
+		if err := s.osRepo.CopyObject(ctx, namespace, t.src.Bucket, t.src.Object, region, t.dst.Bucket, t.dst.Object, opts); err != nil {
+			return 0, err
+		}
+		return t.size, nil
+	}
+}
+
+// planUpload lists the local files to upload. A directory needs recursive; its contents are placed under
+// the destination prefix. A single file goes to the destination object, or under it when it ends with "/".
+func planUpload(src, dst Location, recursive bool) ([]copyTask, error) {
+	if src.Stdio {
+		if dst.Object == "" || strings.HasSuffix(dst.Object, "/") {
+			return nil, fmt.Errorf("an object name is required when uploading from stdin, e.g. %s%s/data.bin", URIScheme, dst.Bucket)
+		}
+		return []copyTask{{src: src, dst: dst}}, nil
+	}
+
+	info, err := os.Stat(src.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		object := dst.Object
+		if object == "" || strings.HasSuffix(object, "/") {
+			object += filepath.Base(src.Path)
+		}
+		return []copyTask{{src: src, dst: Location{Bucket: dst.Bucket, Object: object}, size: info.Size()}}, nil
+	}
+	if !recursive {
+		return nil, fmt.Errorf("%s is a directory (use --recursive)", src.Path)
+	}
+
+	prefix := prefixDir(dst.Object)
+	var tasks []copyTask
+	err = filepath.WalkDir(src.Path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src.Path, p)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, copyTask{src: Location{Path: p}, dst: Location{Bucket: dst.Bucket, Object: prefix + filepath.ToSlash(rel)}, size: fi.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk %s: %w", src.Path, err)
+	}
+	return tasks, nil
+}
+
+// planDownload lists the objects to download. With recursive, every object under the source prefix is
+// written below the destination directory; otherwise the source must be a single object.
+func (s *Service) planDownload(ctx context.Context, namespace string, src, dst Location, opts CopyOptions) ([]copyTask, error) {
+	if opts.Recursive {
+		objects, err := s.remoteTasks(ctx, namespace, src)
+		if err != nil {
+			return nil, err
+		}
+		tasks := make([]copyTask, 0, len(objects))
+		for _, o := range objects {
+			target, err := containedPath(dst.Path, o.rel)
+			tasks = append(tasks, copyTask{src: o.src, dst: Location{Path: target}, size: o.size, err: err})
+		}
+		return tasks, nil
+	}
+
+	obj, err := s.singleObject(ctx, namespace, src, opts.Transfer.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	if dst.Stdio {
+		return []copyTask{{src: src, dst: dst, size: obj.Size}}, nil
+	}
+	target := dst.Path
+	if info, err := os.Stat(target); (err == nil && info.IsDir()) || strings.HasSuffix(target, string(filepath.Separator)) || strings.HasSuffix(target, "/") {
+		if target, err = containedPath(target, path.Base(src.Object)); err != nil {
+			return nil, err
+		}
+	}
+	return []copyTask{{src: src, dst: Location{Path: target}, size: obj.Size}}, nil
+}
+
+// planRemoteCopy lists the objects to copy between buckets, mirroring planDownload.
+func (s *Service) planRemoteCopy(ctx context.Context, namespace string, src, dst Location, opts CopyOptions) ([]copyTask, error) {
+	if opts.Recursive {
+		objects, err := s.remoteTasks(ctx, namespace, src)
+		if err != nil {
+			return nil, err
+		}
+		prefix := prefixDir(dst.Object)
+		tasks := make([]copyTask, 0, len(objects))
+		for _, o := range objects {
+			tasks = append(tasks, copyTask{src: o.src, dst: Location{Bucket: dst.Bucket, Object: prefix + o.rel}, size: o.size})
+		}
+		return tasks, nil
+	}
+
+	obj, err := s.singleObject(ctx, namespace, src, opts.Transfer.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	object := dst.Object
+	if object == "" || strings.HasSuffix(object, "/") {
+		object += path.Base(src.Object)
+	}
+	return []copyTask{{src: src, dst: Location{Bucket: dst.Bucket, Object: object}, size: obj.Size}}, nil
+}
+
+// remoteObject is an object found under a source prefix, with its name relative to that prefix.
+type remoteObject struct {
+	src  Location
+	rel  string
+	size int64
+}
+
+// remoteTasks lists the objects under the source prefix, skipping folder placeholder objects.
+func (s *Service) remoteTasks(ctx context.Context, namespace string, src Location) ([]remoteObject, error) {
+	prefix := prefixDir(src.Object)
+	objects, err := s.osRepo.ListObjectsByPrefix(ctx, namespace, src.Bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	var out []remoteObject
+	for _, o := range objects {
+		if strings.HasSuffix(o.Name, "/") {
+			continue
+		}
+		out = append(out, remoteObject{src: Location{Bucket: src.Bucket, Object: o.Name}, rel: strings.TrimPrefix(o.Name, prefix), size: o.Size})
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no objects found under %s", src.String())
+	}
+	return out, nil
+}
+
+// singleObject returns the metadata of the source object of a non-recursive download or copy, or of the version
+// of it when versionID is set.
+func (s *Service) singleObject(ctx context.Context, namespace string, src Location, versionID string) (*Object, error) {
+	if src.Object == "" || strings.HasSuffix(src.Object, "/") {
+		return nil, fmt.Errorf("%s is a prefix (use --recursive)", src.String())
+	}
+	if versionID != "" {
+		v, err := s.FindVersion(ctx, namespace, src.Bucket, src.Object, versionID)
+		if err != nil {
+			return nil, err
+		}
+		return &v.Object, nil
+	}
+	obj, err := s.osRepo.GetObjectHead(ctx, namespace, src.Bucket, src.Object)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", src.String(), err)
+	}
+	return obj, nil
+}
+
+// containedPath returns where the object name rel, relative to a source prefix, is written below dir. Names
+// that are absolute or climb out of dir with ".." are rejected so an object cannot be written outside it.
+func containedPath(dir, rel string) (string, error) {
+	p := filepath.Join(dir, filepath.FromSlash(rel))
+	r, err := filepath.Rel(filepath.Clean(dir), p)
+	if rel == "" || path.IsAbs(rel) || filepath.IsAbs(rel) || err != nil || r == "." || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+		return dir, fmt.Errorf("object name %q resolves outside %s", rel, dir)
+	}
+	return p, nil
+}
+
+// prefixDir turns a prefix into a folder prefix ending in "/"; the empty prefix stays empty.
+func prefixDir(prefix string) string {
+	if prefix == "" || strings.HasSuffix(prefix, "/") {
+		return prefix
+	}
+	return prefix + "/"
+}
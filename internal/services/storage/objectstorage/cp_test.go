@@ -0,0 +1,232 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocation(t *testing.T) {
+	cases := []struct {
+		arg  string
+		want Location
+	}{
+		{"-", Location{Stdio: true}},
+		{"./data/file.txt", Location{Path: "./data/file.txt"}},
+		{"oci://bucket", Location{Bucket: "bucket"}},
+		{"oci://bucket/", Location{Bucket: "bucket"}},
+		{"oci://bucket/logs/2024/", Location{Bucket: "bucket", Object: "logs/2024/"}},
+		{"oci://bucket/a/b.txt", Location{Bucket: "bucket", Object: "a/b.txt"}},
+	}
+	for _, c := range cases {
+		got, err := ParseLocation(c.arg)
+		require.NoError(t, err, c.arg)
+		assert.Equal(t, c.want, got, c.arg)
+	}
+
+	_, err := ParseLocation("oci:///object")
+	assert.Error(t, err)
+	_, err = ParseLocation("")
+	assert.Error(t, err)
+}
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestCopy_UploadFile(t *testing.T) {
+	repo := &fakeRepo{}
+	svc, _ := makeSvc(repo)
+	dir := writeTree(t, map[string]string{"report.csv": "a,b\n"})
+	file := filepath.Join(dir, "report.csv")
+
+	summary, err := svc.Copy(context.Background(), "ns", "r1", Location{Path: file}, Location{Bucket: "b", Object: "reports/"}, CopyOptions{}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"upload " + file + " -> b/reports/report.csv"}, repo.calls)
+	assert.Equal(t, OperationUpload, summary.Operation)
+	assert.Equal(t, 1, summary.Files)
+	assert.Equal(t, int64(4), summary.Bytes)
+
+	repo.calls = nil
+	_, err = svc.Copy(context.Background(), "ns", "r1", Location{Path: file}, Location{Bucket: "b", Object: "renamed.csv"}, CopyOptions{}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"upload " + file + " -> b/renamed.csv"}, repo.calls)
+}
+
+func TestCopy_UploadDirectory(t *testing.T) {
+	repo := &fakeRepo{failOn: "site/css/main.css"}
+	svc, _ := makeSvc(repo)
+	dir := writeTree(t, map[string]string{"index.html": "<html>", "css/main.css": "body{}", "img/logo.png": "png"})
+
+	_, err := svc.Copy(context.Background(), "ns", "r1", Location{Path: dir}, Location{Bucket: "web", Object: "site"}, CopyOptions{}, nil, nil)
+	assert.ErrorContains(t, err, "--recursive")
+
+	summary, err := svc.Copy(context.Background(), "ns", "r1", Location{Path: dir}, Location{Bucket: "web", Object: "site"}, CopyOptions{Recursive: true}, nil, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"upload " + filepath.Join(dir, "css", "main.css") + " -> web/site/css/main.css",
+		"upload " + filepath.Join(dir, "img", "logo.png") + " -> web/site/img/logo.png",
+		"upload " + filepath.Join(dir, "index.html") + " -> web/site/index.html",
+	}, repo.calls)
+	assert.Equal(t, 2, summary.Files)
+	assert.Equal(t, 1, summary.Failed, "a failed file does not stop the others")
+	assert.Equal(t, int64(len("<html>")+len("png")), summary.Bytes)
+}
+
+func TestCopy_Download(t *testing.T) {
+	repo := &fakeRepo{
+		content: map[string]string{"logs/app.log": "line\n"},
+		objects: []Object{{Name: "logs/"}, {Name: "logs/app.log", Size: 5}, {Name: "logs/2024/01.log", Size: 7}, {Name: "other.txt", Size: 1}},
+	}
+	svc, _ := makeSvc(repo)
+	dest := t.TempDir()
+
+	summary, err := svc.Copy(context.Background(), "ns", "r1", Location{Bucket: "b", Object: "logs"}, Location{Path: dest}, CopyOptions{Recursive: true}, nil, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"download b/logs/app.log -> " + filepath.Join(dest, "app.log"),
+		"download b/logs/2024/01.log -> " + filepath.Join(dest, "2024", "01.log"),
+	}, repo.calls)
+	assert.Equal(t, int64(12), summary.Bytes)
+	assert.DirExists(t, filepath.Join(dest, "2024"))
+
+	// A single object goes into an existing directory under its own name, or to the given file.
+	repo.calls = nil
+	_, err = svc.Copy(context.Background(), "ns", "r1", Location{Bucket: "b", Object: "logs/app.log"}, Location{Path: dest}, CopyOptions{}, nil, nil)
+	require.NoError(t, err)
+	_, err = svc.Copy(context.Background(), "ns", "r1", Location{Bucket: "b", Object: "logs/app.log"}, Location{Path: filepath.Join(dest, "x.log")}, CopyOptions{}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"download b/logs/app.log -> " + filepath.Join(dest, "app.log"),
+		"download b/logs/app.log -> " + filepath.Join(dest, "x.log"),
+	}, repo.calls)
+
+	_, err = svc.Copy(context.Background(), "ns", "r1", Location{Bucket: "b", Object: "logs/"}, Location{Path: dest}, CopyOptions{}, nil, nil)
+	assert.ErrorContains(t, err, "--recursive")
+	_, err = svc.Copy(context.Background(), "ns", "r1", Location{Bucket: "b", Object: "empty/"}, Location{Path: dest}, CopyOptions{Recursive: true}, nil, nil)
+	assert.ErrorContains(t, err, "no objects found")
+}
+
+func TestCopy_DownloadRejectsEscapingNames(t *testing.T) {
+	repo := &fakeRepo{
+		content: map[string]string{"pre/ok.txt": "ok", "pre/..": "x"},
+		objects: []Object{{Name: "pre/ok.txt", Size: 2}, {Name: "pre/../../.bashrc", Size: 3}, {Name: "pre//etc/passwd", Size: 4}},
+	}
+	svc, _ := makeSvc(repo)
+	dest := t.TempDir()
+
+	summary, err := svc.Copy(context.Background(), "ns", "r1", Location{Bucket: "b", Object: "pre/"}, Location{Path: dest}, CopyOptions{Recursive: true}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"download b/pre/ok.txt -> " + filepath.Join(dest, "ok.txt")}, repo.calls)
+	assert.Equal(t, 1, summary.Files)
+	assert.Equal(t, 2, summary.Failed)
+	for _, item := range summary.Items {
+		if item.Error != "" {
+			assert.Contains(t, item.Error, "resolves outside")
+		}
+	}
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(dest), ".bashrc"))
+
+	repo.calls = nil
+	_, err = svc.Copy(context.Background(), "ns", "r1", Location{Bucket: "b", Object: "pre/.."}, Location{Path: dest}, CopyOptions{}, nil, nil)
+	assert.ErrorContains(t, err, "resolves outside")
+	assert.Empty(t, repo.calls)
+}
+
+func TestCopy_Stdio(t *testing.T) {
+	repo := &fakeRepo{content: map[string]string{"app.yaml": "key: value\n"}}
+	svc, _ := makeSvc(repo)
+
+	var out bytes.Buffer
+	summary, err := svc.Copy(context.Background(), "ns", "r1", Location{Bucket: "cfg", Object: "app.yaml"}, Location{Stdio: true}, CopyOptions{}, nil, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "key: value\n", out.String())
+	assert.Equal(t, int64(11), summary.Bytes)
+
+	summary, err = svc.Copy(context.Background(), "ns", "r1", Location{Stdio: true}, Location{Bucket: "cfg", Object: "dump.sql"}, CopyOptions{}, strings.NewReader("select 1;"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), summary.Bytes)
+	assert.Equal(t, []string{"open cfg/app.yaml", "stream -> cfg/dump.sql"}, repo.calls)
+
+	_, err = svc.Copy(context.Background(), "ns", "r1", Location{Stdio: true}, Location{Bucket: "cfg", Object: "dir/"}, CopyOptions{}, strings.NewReader(""), nil)
+	assert.ErrorContains(t, err, "object name is required")
+	_, err = svc.Copy(context.Background(), "ns", "r1", Location{Stdio: true}, Location{Bucket: "cfg", Object: "x"}, CopyOptions{Recursive: true}, strings.NewReader(""), nil)
+	assert.Error(t, err)
+	_, err = svc.Copy(context.Background(), "ns", "r1", Location{Stdio: true}, Location{Path: "out"}, CopyOptions{}, nil, nil)
+	assert.ErrorContains(t, err, "oci://")
+}
+
+func TestCopy_BetweenBuckets(t *testing.T) {
+	repo := &fakeRepo{
+		content: map[string]string{"data/a.bin": "aa"},
+		objects: []Object{{Name: "data/a.bin", Size: 2}, {Name: "data/sub/b.bin", Size: 3}},
+	}
+	svc, _ := makeSvc(repo)
+
+	summary, err := svc.Copy(context.Background(), "ns", "us-ashburn-1", Location{Bucket: "src", Object: "data/"}, Location{Bucket: "dst", Object: "backup"}, CopyOptions{Recursive: true}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, OperationCopy, summary.Operation)
+	assert.ElementsMatch(t, []string{
+		"copy src/data/a.bin -> us-ashburn-1:dst/backup/a.bin",
+		"copy src/data/sub/b.bin -> us-ashburn-1:dst/backup/sub/b.bin",
+	}, repo.calls)
+
+	repo.calls = nil
+	_, err = svc.Copy(context.Background(), "ns", "us-ashburn-1", Location{Bucket: "src", Object: "data/a.bin"}, Location{Bucket: "dst"}, CopyOptions{}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"copy src/data/a.bin -> us-ashburn-1:dst/a.bin"}, repo.calls)
+
+	_, err = svc.Copy(context.Background(), "ns", "us-ashburn-1", Location{Bucket: "src", Object: "data/a.bin"}, Location{Bucket: "dst"},
+		CopyOptions{Transfer: TransferOptions{ContentType: "text/plain"}}, nil, nil)
+	assert.ErrorContains(t, err, "content type")
+}
+
+func TestSplitParallelism(t *testing.T) {
+	for _, tc := range []struct {
+		n, files, wantFiles, wantParts int
+	}{
+		{n: 8, files: 1, wantFiles: 1, wantParts: 8},
+		{n: 8, files: 2, wantFiles: 2, wantParts: 4},
+		{n: 8, files: 3, wantFiles: 3, wantParts: 2},
+		{n: 8, files: 100, wantFiles: 8, wantParts: 1},
+		{n: 0, files: 100, wantFiles: 4, wantParts: 1},
+		{n: 4, files: 0, wantFiles: 1, wantParts: 4},
+	} {
+		files, parts := splitParallelism(tc.n, tc.files)
+		assert.Equal(t, tc.wantFiles, files, "files for n=%d, %d files", tc.n, tc.files)
+		assert.Equal(t, tc.wantParts, parts, "parts for n=%d, %d files", tc.n, tc.files)
+	}
+}
+
+func TestPrintCopySummary(t *testing.T) {
+	summary := &CopySummary{Operation: OperationUpload, Files: 1, Bytes: 2048, Failed: 1, Items: []CopyItem{
+		{Source: "a.txt", Destination: "oci://b/a.txt", Bytes: 2048},
+		{Source: "b.txt", Destination: "oci://b/b.txt", Error: "boom"},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintCopySummary(summary, &buf, true))
+	var decoded CopySummary
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, *summary, decoded)
+
+	buf.Reset()
+	require.NoError(t, PrintCopySummary(summary, &buf, false))
+	assert.Contains(t, buf.String(), "oci://b/a.txt")
+	assert.Contains(t, buf.String(), "failed: boom")
+	assert.Contains(t, buf.String(), "1 file(s), 2.00 KiB transferred, 1 failed")
+}
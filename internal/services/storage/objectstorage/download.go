@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/rozdolsky33/ocloud/internal/app"
 	"github.com/rozdolsky33/ocloud/internal/oci"
@@ -13,70 +14,91 @@ import (
 	"github.com/rozdolsky33/ocloud/internal/tui"
 )
 
-// DownloadFile handles the interactive download flow:
-// 1. Show bucket list TUI to select the source bucket
-// 2. Show object list TUI to select object to download
-// 3. Download the file with progress TUI
-func DownloadFile(appCtx *app.ApplicationContext) error {
+// ShareFile lets the user pick an object the same way DownloadFile does and creates a pre-authenticated
+// request for it instead of downloading it.
+func ShareFile(appCtx *app.ApplicationContext, opts PAROptions, useJSON bool) error {
 	ctx := context.Background()
-	client, err := oci.NewObjectStorageClient(appCtx.Provider)
+	service, namespace, err := newServiceForApp(ctx, appCtx)
 	if err != nil {
-		return fmt.Errorf("creating object storage client: %w", err)
+		return err
 	}
-
-	bucketAdapter := osadapter.NewAdapter(client)
-	service := NewService(bucketAdapter, appCtx.Logger, appCtx.CompartmentID)
-
-	// Get namespace
-	namespace, err := service.GetNamespace(ctx)
+	bucketName, objectName, err := service.pickObject(ctx, appCtx, namespace)
+	if err != nil || objectName == "" {
+		return err
+	}
+	par, err := service.CreatePreauthenticatedRequest(ctx, namespace, Location{Bucket: bucketName, Object: objectName}, opts, time.Now())
 	if err != nil {
-		return fmt.Errorf("getting namespace: %w", err)
+		return err
 	}
+	return PrintPreauthenticatedRequest(par, appCtx, useJSON)
+}
 
-	// Step 1: Show bucket list TUI to select the source bucket
-	buckets, err := service.ListBuckets(ctx)
+// pickObject shows the bucket list and then the folders of the chosen bucket, and returns the selected object.
+// The object name is empty when the user cancels or the bucket is empty.
+func (s *Service) pickObject(ctx context.Context, appCtx *app.ApplicationContext, namespace string) (string, string, error) {
+	buckets, err := s.ListBuckets(ctx)
 	if err != nil {
-		return fmt.Errorf("listing buckets: %w", err)
+		return "", "", fmt.Errorf("listing buckets: %w", err)
 	}
 
 	if len(buckets) == 0 {
 		fmt.Fprintln(appCtx.Stdout, "No buckets found in the compartment.")
-		return nil
+		return "", "", nil
 	}
 
 	bucketModel := osadapter.NewBucketListModel(buckets)
 	bucketID, err := tui.Run(bucketModel)
 	if err != nil {
 		if errors.Is(err, tui.ErrCancelled) {
-			return nil
+			return "", "", nil
 		}
-		return fmt.Errorf("selecting bucket: %w", err)
+		return "", "", fmt.Errorf("selecting bucket: %w", err)
 	}
 
 	// Get bucket name from OCID
-	bucketName, err := service.osRepo.GetBucketNameByOCID(ctx, appCtx.CompartmentID, bucketID)
+	bucketName, err := s.osRepo.GetBucketNameByOCID(ctx, appCtx.CompartmentID, bucketID)
 	if err != nil {
-		return fmt.Errorf("getting bucket name: %w", err)
+		return "", "", fmt.Errorf("getting bucket name: %w", err)
 	}
 
-	// Step 2: Show object list TUI to select an object
-	objects, err := service.ListObjects(ctx, namespace, bucketName)
+	objectName, err := s.browseObjects(ctx, namespace, bucketName)
 	if err != nil {
-		return fmt.Errorf("listing objects: %w", err)
+		if errors.Is(err, errBucketEmpty) {
+			fmt.Fprintf(appCtx.Stdout, "Bucket %q is empty.\n", bucketName)
+			return "", "", nil
+		}
+		if errors.Is(err, tui.ErrCancelled) {
+			return "", "", nil
+		}
+		return "", "", err
 	}
+	return bucketName, objectName, nil
+}
 
-	if len(objects) == 0 {
-		fmt.Fprintf(appCtx.Stdout, "Bucket %q is empty.\n", bucketName)
-		return nil
+// DownloadFile handles the interactive download flow:
+// 1. Show bucket list TUI to select the source bucket
+// 2. Browse the folders of the bucket to select the object to download
+// 3. Download the file with progress TUI
+func DownloadFile(appCtx *app.ApplicationContext, opts TransferOptions) error {
+	ctx := context.Background()
+	client, err := oci.NewObjectStorageClient(appCtx.Provider)
+	if err != nil {
+		return fmt.Errorf("creating object storage client: %w", err)
 	}
 
-	objectModel := osadapter.NewObjectListModel(objects, bucketName)
-	objectName, err := tui.Run(objectModel)
+	bucketAdapter := osadapter.NewAdapter(client)
+	service := NewService(bucketAdapter, appCtx.Logger, appCtx.CompartmentID)
+
+	// Get namespace
+	namespace, err := service.GetNamespace(ctx)
 	if err != nil {
-		if errors.Is(err, tui.ErrCancelled) {
-			return nil
-		}
-		return fmt.Errorf("selecting object: %w", err)
+		return fmt.Errorf("getting namespace: %w", err)
+	}
+
+	// Steps 1 and 2: select the bucket and the object
+	bucketName, objectName, err := service.pickObject(ctx, appCtx, namespace)
+	if err != nil || objectName == "" {
+		return err
 	}
 
 	// Step 3: Download the file with progress TUI
@@ -86,6 +108,16 @@ func DownloadFile(appCtx *app.ApplicationContext) error {
 	}
 
 	title := fmt.Sprintf("Downloading %s from %s", objectName, bucketName)
+	if err := service.downloadWithProgress(ctx, namespace, bucketName, objectName, cwd, opts, title); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(appCtx.Stdout, "\nSuccessfully downloaded %s to %s\n", objectName, cwd)
+	return nil
+}
+
+// downloadWithProgress downloads an object into dir while the progress TUI shows how far the download is.
+func (s *Service) downloadWithProgress(ctx context.Context, namespace, bucketName, objectName, dir string, opts TransferOptions, title string) error {
 	progressRunner := tui.NewProgressRunner(title)
 	progressRunner.Start()
 
@@ -102,13 +134,13 @@ func DownloadFile(appCtx *app.ApplicationContext) error {
 
 			status := "Downloading..."
 			if percent >= 1.0 {
-				status = "Complete!"
+				status = "Verifying checksum..."
 			}
 
 			progressRunner.UpdateProgress(percent, bytesInfo, "", status)
 		}
 
-		err := service.DownloadObject(ctx, namespace, bucketName, objectName, cwd, progressFn)
+		err := s.DownloadObject(ctx, namespace, bucketName, objectName, dir, opts, progressFn)
 		if err != nil {
 			progressRunner.SendError(err)
 			done <- err
@@ -124,11 +156,8 @@ func DownloadFile(appCtx *app.ApplicationContext) error {
 	}
 
 	// Wait for download to finish and check a result
-	downloadErr := <-done
-	if downloadErr != nil {
-		return fmt.Errorf("downloading object: %w", downloadErr)
+	if err := <-done; err != nil {
+		return fmt.Errorf("downloading object: %w", err)
 	}
-
-	fmt.Fprintf(appCtx.Stdout, "\nSuccessfully downloaded %s to %s\n", objectName, cwd)
 	return nil
 }
@@ -12,8 +12,9 @@ import (
 
 // GetBuckets retrieves and displays a paginated list of object storage buckets in a given compartment.
 // It uses a specified limit and page number for pagination and can output results as JSON based on the flag.
+// With policies, the lifecycle, retention and replication rules of the listed buckets are fetched and shown too.
 // Returns an error if bucket retrieval or output processing fails.
-func GetBuckets(appCtx *app.ApplicationContext, limit int, page int, useJSON bool) error {
+func GetBuckets(appCtx *app.ApplicationContext, limit int, page int, useJSON bool, policies bool) error {
 	ctx := context.Background()
 	client, err := oci.NewObjectStorageClient(appCtx.Provider)
 	if err != nil {
@@ -27,6 +28,11 @@ func GetBuckets(appCtx *app.ApplicationContext, limit int, page int, useJSON boo
 	if err != nil {
 		return fmt.Errorf("listing buckets: %w", err)
 	}
+	if policies {
+		if err := service.FetchBucketPolicies(ctx, buckets); err != nil {
+			return err
+		}
+	}
 
 	return PrintBucketsInfo(buckets, appCtx, &util.PaginationInfo{CurrentPage: page, TotalCount: total, NextPageToken: next, Limit: limit}, useJSON)
 }
@@ -5,16 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/rozdolsky33/ocloud/internal/app"
 	"github.com/rozdolsky33/ocloud/internal/oci"
 	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
-	"github.com/rozdolsky33/ocloud/internal/services/util"
 	"github.com/rozdolsky33/ocloud/internal/tui"
 )
 
 // ListBuckets retrieves and lists all buckets, allows browsing objects within them,
-// and performs actions (view details or download) on selected objects.
+// and performs actions (view details, download, share or versions) on selected objects.
 func ListBuckets(appCtx *app.ApplicationContext, useJSON bool) error {
 	ctx := context.Background()
 	client, err := oci.NewObjectStorageClient(appCtx.Provider)
@@ -60,101 +60,73 @@ func ListBuckets(appCtx *app.ApplicationContext, useJSON bool) error {
 			return fmt.Errorf("getting bucket name: %w", err)
 		}
 
-		// Inner loop for object browsing (Esc returns to bucket list)
-		for {
-			objects, err := service.ListObjects(ctx, namespace, bucketName)
-			if err != nil {
-				return fmt.Errorf("listing objects: %w", err)
+		// Browse the folders of the bucket (Esc at the top returns to bucket list)
+		objectName, err := service.browseObjects(ctx, namespace, bucketName)
+		if err != nil {
+			if errors.Is(err, errBucketEmpty) {
+				fmt.Fprintf(appCtx.Stdout, "Bucket %q is empty.\n", bucketName)
+				continue // Go back to bucket selection
+			}
+			if errors.Is(err, tui.ErrCancelled) {
+				continue // Go back to bucket selection
 			}
+			return err
+		}
 
-			if len(objects) == 0 {
-				fmt.Fprintf(appCtx.Stdout, "Bucket %q is empty.\n", bucketName)
-				break // Go back to bucket selection
+		// Show action picker (radio button style)
+		actionModel := osadapter.NewActionPickerModel(objectName, bucketVersioned(buckets, bucketID))
+		action, err := tui.RunPicker(actionModel)
+		if err != nil {
+			if errors.Is(err, tui.ErrCancelled) {
+				return nil // Exit
 			}
+			return fmt.Errorf("selecting action: %w", err)
+		}
 
-			// Show object list TUI
-			objectModel := osadapter.NewObjectListModel(objects, bucketName)
-			objectName, err := tui.Run(objectModel)
+		// Execute selected action
+		switch action {
+		case "view":
+			obj, err := service.GetObjectDetails(ctx, namespace, bucketName, objectName)
 			if err != nil {
-				if errors.Is(err, tui.ErrCancelled) {
-					break // Go back to bucket selection
-				}
-				return fmt.Errorf("selecting object: %w", err)
+				return fmt.Errorf("getting object details: %w", err)
 			}
+			return PrintObjectInfo(obj, appCtx, region, useJSON)
 
-			// Show action picker (radio button style)
-			actionModel := osadapter.NewActionPickerModel(objectName)
-			action, err := tui.RunPicker(actionModel)
+		case "download":
+			// Download to current working directory with progress TUI
+			cwd, err := os.Getwd()
 			if err != nil {
-				if errors.Is(err, tui.ErrCancelled) {
-					return nil // Exit
-				}
-				return fmt.Errorf("selecting action: %w", err)
+				return fmt.Errorf("getting current directory: %w", err)
 			}
 
-			// Execute selected action
-			switch action {
-			case "view":
-				obj, err := service.GetObjectDetails(ctx, namespace, bucketName, objectName)
-				if err != nil {
-					return fmt.Errorf("getting object details: %w", err)
-				}
-				return PrintObjectInfo(obj, appCtx, region, useJSON)
-
-			case "download":
-				// Download to current working directory with progress TUI
-				cwd, err := os.Getwd()
-				if err != nil {
-					return fmt.Errorf("getting current directory: %w", err)
-				}
-
-				title := fmt.Sprintf("Downloading %s", objectName)
-				progressRunner := tui.NewProgressRunner(title)
-				progressRunner.Start()
-
-				// Channel to signal completion
-				done := make(chan error, 1)
-
-				// Start download in goroutine
-				go func() {
-					progressFn := func(p TransferProgress) {
-						percent := float64(p.BytesTransferred) / float64(p.TotalBytes)
-						bytesInfo := fmt.Sprintf("%s / %s",
-							util.HumanizeBytesIEC(p.BytesTransferred),
-							util.HumanizeBytesIEC(p.TotalBytes))
-
-						status := "Downloading..."
-						if percent >= 1.0 {
-							status = "Complete!"
-						}
-
-						progressRunner.UpdateProgress(percent, bytesInfo, "", status)
-					}
-
-					err := service.DownloadObject(ctx, namespace, bucketName, objectName, cwd, progressFn)
-					if err != nil {
-						progressRunner.SendError(err)
-						done <- err
-						return
-					}
-					progressRunner.SendDone()
-					done <- nil
-				}()
-
-				// Run the progress TUI (blocks until complete)
-				if err := progressRunner.Run(); err != nil {
-					return err
-				}
-
-				// Wait for download to finish and check result
-				downloadErr := <-done
-				if downloadErr != nil {
-					return fmt.Errorf("downloading object: %w", downloadErr)
-				}
-
-				fmt.Fprintf(appCtx.Stdout, "\nDownloaded %q to %s\n", objectName, cwd)
-				return nil
+			title := fmt.Sprintf("Downloading %s", objectName)
+			if err := service.downloadWithProgress(ctx, namespace, bucketName, objectName, cwd, TransferOptions{}, title); err != nil {
+				return err
 			}
+
+			fmt.Fprintf(appCtx.Stdout, "\nDownloaded %q to %s\n", objectName, cwd)
+			return nil
+
+		case "share":
+			par, err := service.CreatePreauthenticatedRequest(ctx, namespace, Location{Bucket: bucketName, Object: objectName}, PAROptions{}, time.Now())
+			if err != nil {
+				return err
+			}
+			return PrintPreauthenticatedRequest(par, appCtx, useJSON)
+
+		case "versions":
+			return service.manageVersions(ctx, appCtx, namespace, region, bucketName, objectName)
+		}
+	}
+}
+
+// bucketVersioned reports whether the bucket with the OCID has, or had, versioning enabled, so that its objects
+// may have versions.
+func bucketVersioned(buckets []Bucket, bucketID string) bool {
+	for _, b := range buckets {
+		if b.OCID == bucketID {
+			return b.Versioning == "Enabled" || b.Versioning == "Suspended"
 		}
 	}
+	return false
 }
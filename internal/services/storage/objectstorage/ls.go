@@ -0,0 +1,98 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/printer"
+)
+
+// Listing is the JSON output of "object-storage ls".
+type Listing struct {
+	Prefixes []string
+	Objects  []Object
+}
+
+// ListingTotals counts what "object-storage ls" printed.
+type ListingTotals struct {
+	Prefixes int
+	Objects  int
+	Bytes    int64
+}
+
+// ListPath runs "object-storage ls": it lists the folders and objects directly under oci://bucket/prefix, or
+// every object below it when recursive is set. Text output is printed page by page as it is fetched.
+func ListPath(appCtx *app.ApplicationContext, arg string, recursive, useJSON bool) error {
+	ctx := context.Background()
+	loc, err := ParseLocation(arg)
+	if err != nil {
+		return err
+	}
+	if !loc.IsRemote() {
+		return fmt.Errorf("expected an %s<bucket>/<prefix> location, got %q", URIScheme, arg)
+	}
+
+	client, err := oci.NewObjectStorageClient(appCtx.Provider)
+	if err != nil {
+		return fmt.Errorf("creating object storage client: %w", err)
+	}
+	service := NewService(osadapter.NewAdapter(client), appCtx.Logger, appCtx.CompartmentID)
+	namespace, err := service.GetNamespace(ctx)
+	if err != nil {
+		return fmt.Errorf("getting namespace: %w", err)
+	}
+
+	if useJSON {
+		var listing Listing
+		err := service.WalkListing(ctx, namespace, loc, recursive, func(page *ObjectPage) error {
+			listing.Prefixes = append(listing.Prefixes, page.Prefixes...)
+			listing.Objects = append(listing.Objects, page.Objects...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return printer.New(appCtx.Stdout).MarshalToJSON(listing)
+	}
+
+	// Names are shown relative to the folder being listed, or in full when recursive.
+	base := ""
+	if !recursive {
+		base = loc.Object[:strings.LastIndex(loc.Object, "/")+1]
+	}
+	var totals ListingTotals
+	err = service.WalkListing(ctx, namespace, loc, recursive, func(page *ObjectPage) error {
+		return PrintListingPage(appCtx.Stdout, page, base, &totals)
+	})
+	if err != nil {
+		return err
+	}
+	return PrintListingTotals(appCtx.Stdout, loc, totals)
+}
+
+// WalkListing calls fn with every page of the listing of loc: the folders and objects directly under
+// loc.Object, or all objects below it when recursive is set.
+func (s *Service) WalkListing(ctx context.Context, namespace string, loc Location, recursive bool, fn func(*ObjectPage) error) error {
+	delimiter := "/"
+	if recursive {
+		delimiter = ""
+	}
+	start := ""
+	for {
+		page, err := s.ListObjectsPage(ctx, namespace, loc.Bucket, loc.Object, delimiter, start, 0)
+		if err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if page.NextStart == "" {
+			return nil
+		}
+		start = page.NextStart
+	}
+}
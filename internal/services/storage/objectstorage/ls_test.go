@@ -0,0 +1,84 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logObjects() []Object {
+	return []Object{
+		{Name: "logs/", Size: 0},
+		{Name: "logs/2024/01.log", Size: 1024},
+		{Name: "logs/2024/02.log", Size: 2048},
+		{Name: "logs/2025/01.log", Size: 10},
+		{Name: "logs/app.log", Size: 5},
+		{Name: "readme.txt", Size: 3},
+	}
+}
+
+func TestWalkListing(t *testing.T) {
+	svc, _ := makeSvc(&fakeRepo{objects: logObjects()})
+	var listing Listing
+	collect := func(page *ObjectPage) error {
+		listing.Prefixes = append(listing.Prefixes, page.Prefixes...)
+		listing.Objects = append(listing.Objects, page.Objects...)
+		return nil
+	}
+
+	require.NoError(t, svc.WalkListing(context.Background(), "ns", Location{Bucket: "b", Object: "logs/"}, false, collect))
+	assert.Equal(t, []string{"logs/2024/", "logs/2025/"}, listing.Prefixes)
+	assert.Len(t, listing.Objects, 2, "the folder placeholder and app.log")
+
+	listing = Listing{}
+	require.NoError(t, svc.WalkListing(context.Background(), "ns", Location{Bucket: "b", Object: "logs/2"}, true, collect))
+	assert.Empty(t, listing.Prefixes)
+	assert.Len(t, listing.Objects, 3)
+}
+
+func TestPrintListingPage(t *testing.T) {
+	var buf bytes.Buffer
+	var totals ListingTotals
+	page := &ObjectPage{Prefixes: []string{"logs/2024/"}, Objects: []Object{{Name: "logs/app.log", Size: 2048}}}
+
+	require.NoError(t, PrintListingPage(&buf, page, "logs/", &totals))
+	assert.Contains(t, buf.String(), "PRE  2024/")
+	assert.Contains(t, buf.String(), "2.00 KiB  app.log")
+	assert.Equal(t, ListingTotals{Prefixes: 1, Objects: 1, Bytes: 2048}, totals)
+
+	buf.Reset()
+	require.NoError(t, PrintListingTotals(&buf, Location{Bucket: "b", Object: "x/"}, ListingTotals{}))
+	assert.Equal(t, "No objects found under oci://b/x/\n", buf.String())
+}
+
+func TestLoadListingPage(t *testing.T) {
+	objects := logObjects()
+	for i := 0; i < browsePageSize; i++ {
+		objects = append(objects, Object{Name: fmt.Sprintf("logs/zz%04d", i)})
+	}
+	svc, _ := makeSvc(&fakeRepo{objects: objects})
+	listing := &osadapter.ObjectListing{Bucket: "b", Prefix: "logs/"}
+
+	next, err := svc.loadListingPage(context.Background(), "ns", listing, "")
+	require.NoError(t, err)
+	assert.True(t, listing.HasMore)
+	assert.NotEmpty(t, next)
+	assert.Equal(t, []string{"logs/2024/", "logs/2025/"}, listing.Prefixes)
+	assert.Equal(t, "logs/app.log", listing.Objects[0].Name, "the folder placeholder is skipped")
+
+	_, err = svc.loadListingPage(context.Background(), "ns", listing, next)
+	require.NoError(t, err)
+	assert.False(t, listing.HasMore)
+	assert.Len(t, listing.Objects, browsePageSize+1)
+}
+
+func TestParentPrefix(t *testing.T) {
+	assert.Equal(t, "logs/", parentPrefix("logs/2024/"))
+	assert.Equal(t, "", parentPrefix("logs/"))
+	assert.Equal(t, "", parentPrefix(""))
+}
@@ -1,7 +1,14 @@
 package objectstorage
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rozdolsky33/ocloud/internal/app"
 	"github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
@@ -47,8 +54,19 @@ func PrintBucketsInfo(buckets []Bucket, appCtx *app.ApplicationContext, paginati
 			"Name", "OCID", "Namespace", "Created", "StorageTier", "Visibility", "Encryption", "Versioning", "ReplicationEnabled", "ReadOnly", "ApproximateCount", "ApproximateSize", "ApproximateSizeBytes",
 		}
 
+		if bucket.Policies != nil {
+			bucketData["ObjectEvents"] = "disabled"
+			if bucket.ObjectEventsEnabled {
+				bucketData["ObjectEvents"] = "enabled"
+			}
+			orderedKeys = append(orderedKeys, "ObjectEvents")
+		}
+
 		title := util.FormatColoredTitle(appCtx, bucket.Name)
 		p.PrintKeyValues(title, bucketData, orderedKeys)
+		if bucket.Policies != nil {
+			printBucketPolicies(p, appCtx.Stdout, bucket.Policies, time.Now())
+		}
 	}
 
 	util.LogPaginationInfo(pagination, appCtx)
@@ -88,6 +106,93 @@ func PrintBucketInfo(bucket *Bucket, appCtx *app.ApplicationContext, useJSON boo
 	return nil
 }
 
+// printBucketPolicies prints the lifecycle rules, retention rules and replication policies of a bucket in tables.
+func printBucketPolicies(p *printer.Printer, w io.Writer, policies *objectstorage.BucketPolicies, now time.Time) {
+	if len(policies.LifecycleRules) == 0 {
+		fmt.Fprintln(w, "Lifecycle rules: none")
+	} else {
+		rows := make([][]string, 0, len(policies.LifecycleRules))
+		for _, r := range policies.LifecycleRules {
+			enabled := "no"
+			if r.Enabled {
+				enabled = "yes"
+			}
+			rows = append(rows, []string{r.Name, r.Action, r.Target, timeSpan(r.TimeAmount, r.TimeUnit), lifecycleFilters(r), enabled})
+		}
+		p.PrintTableNoTruncate("Lifecycle rules", []string{"Name", "Action", "Target", "After", "Filters", "Enabled"}, rows)
+	}
+
+	if len(policies.RetentionRules) == 0 {
+		fmt.Fprintln(w, "Retention rules: none")
+	} else {
+		rows := make([][]string, 0, len(policies.RetentionRules))
+		for _, r := range policies.RetentionRules {
+			duration := "indefinite"
+			if r.DurationAmount > 0 {
+				duration = timeSpan(r.DurationAmount, r.DurationUnit)
+			}
+			locked := "no"
+			switch {
+			case r.IsLocked(now):
+				locked = "locked " + r.TimeRuleLocked.Local().Format("2006-01-02")
+			case !r.TimeRuleLocked.IsZero():
+				locked = "locks " + r.TimeRuleLocked.Local().Format("2006-01-02 15:04")
+			}
+			modified := "-"
+			if !r.TimeModified.IsZero() {
+				modified = r.TimeModified.Local().Format("2006-01-02 15:04")
+			}
+			rows = append(rows, []string{r.Name, duration, locked, modified})
+		}
+		p.PrintTableNoTruncate("Retention rules", []string{"Name", "Duration", "Locked", "Modified"}, rows)
+	}
+
+	if len(policies.ReplicationPolicies) == 0 {
+		fmt.Fprintln(w, "Replication policies: none")
+	} else {
+		rows := make([][]string, 0, len(policies.ReplicationPolicies))
+		for _, r := range policies.ReplicationPolicies {
+			status := r.Status
+			if r.StatusMessage != "" {
+				status += ": " + r.StatusMessage
+			}
+			lastSync := "never"
+			if !r.TimeLastSync.IsZero() {
+				lastSync = r.TimeLastSync.Local().Format("2006-01-02 15:04")
+			}
+			rows = append(rows, []string{r.Name, r.DestinationRegion + "/" + r.DestinationBucket, status, lastSync})
+		}
+		p.PrintTableNoTruncate("Replication policies", []string{"Name", "Destination", "Status", "Last Sync"}, rows)
+	}
+}
+
+// timeSpan formats an amount of DAYS or YEARS as "30 days" or "1 year".
+func timeSpan(amount int64, unit string) string {
+	unit = strings.ToLower(strings.TrimSuffix(unit, "S"))
+	if amount != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", amount, unit)
+}
+
+// lifecycleFilters summarises the object name filter of a lifecycle rule, or returns "-" for rules on every object.
+func lifecycleFilters(r objectstorage.LifecycleRule) string {
+	var parts []string
+	if len(r.InclusionPrefixes) > 0 {
+		parts = append(parts, "prefix "+strings.Join(r.InclusionPrefixes, ", "))
+	}
+	if len(r.InclusionPatterns) > 0 {
+		parts = append(parts, "include "+strings.Join(r.InclusionPatterns, ", "))
+	}
+	if len(r.ExclusionPatterns) > 0 {
+		parts = append(parts, "exclude "+strings.Join(r.ExclusionPatterns, ", "))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, "; ")
+}
+
 // PrintObjectInfo displays object details in a formatted table or JSON format.
 func PrintObjectInfo(obj *Object, appCtx *app.ApplicationContext, region string, useJSON bool) error {
 	p := printer.New(appCtx.Stdout)
@@ -119,6 +224,10 @@ func PrintObjectInfo(obj *Object, appCtx *app.ApplicationContext, region string,
 	orderedKeys := []string{
 		"Name", "URL (legacy)", "URL (new)", "StorageTier", "Size", "SizeBytes", "ContentType", "ContentMD5", "ETag", "LastModified",
 	}
+	if obj.VersionID != "" {
+		objectData["VersionID"] = obj.VersionID
+		orderedKeys = append(orderedKeys, "VersionID")
+	}
 
 	title := util.FormatColoredTitle(appCtx, obj.Name)
 	p.PrintKeyValues(title, objectData, orderedKeys)
@@ -141,3 +250,360 @@ func urlEncode(s string) string {
 	}
 	return result
 }
+
+// PrintCopySummary prints the outcome of a cp command to w: only the JSON summary when quiet is set,
+// otherwise a table of the transfers followed by a one-line summary.
+func PrintCopySummary(summary *CopySummary, w io.Writer, quiet bool) error {
+	p := printer.New(w)
+	if quiet {
+		return p.MarshalToJSON(summary)
+	}
+
+	rows := make([][]string, 0, len(summary.Items))
+	for _, item := range summary.Items {
+		status := "ok"
+		if item.Error != "" {
+			status = "failed: " + item.Error
+		}
+		rows = append(rows, []string{item.Source, item.Destination, util.HumanizeBytesIEC(item.Bytes), status})
+	}
+	p.PrintTableNoTruncate(fmt.Sprintf("Object Storage %s", summary.Operation), []string{"Source", "Destination", "Size", "Status"}, rows)
+
+	line := fmt.Sprintf("%d file(s), %s transferred", summary.Files, util.HumanizeBytesIEC(summary.Bytes))
+	if summary.Failed > 0 {
+		line += fmt.Sprintf(", %d failed", summary.Failed)
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// PrintSyncSummary prints the actions of a sync command in a table, or the whole summary as JSON.
+func PrintSyncSummary(summary *SyncSummary, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(summary)
+	}
+
+	if len(summary.Actions) > 0 {
+		rows := make([][]string, 0, len(summary.Actions))
+		for _, a := range summary.Actions {
+			status := "ok"
+			switch {
+			case a.Error != "":
+				status = "failed: " + a.Error
+			case summary.DryRun:
+				status = "pending"
+			}
+			rows = append(rows, []string{a.Action, a.Path, a.Reason, util.HumanizeBytesIEC(a.Bytes), status})
+		}
+		title := fmt.Sprintf("Sync %s -> %s", summary.Source, summary.Destination)
+		if summary.DryRun {
+			title += " (dry run)"
+		}
+		p.PrintTableNoTruncate(title, []string{"Action", "Path", "Reason", "Size", "Status"}, rows)
+	}
+
+	line := fmt.Sprintf("%d transferred (%s), %d deleted, %d unchanged", summary.Transferred, util.HumanizeBytesIEC(summary.Bytes), summary.Deleted, summary.Unchanged)
+	if summary.DryRun {
+		line = fmt.Sprintf("Dry run: %d to transfer (%s), %d to delete, %d unchanged", summary.Transferred, util.HumanizeBytesIEC(summary.Bytes), summary.Deleted, summary.Unchanged)
+	}
+	if summary.Failed > 0 {
+		line += fmt.Sprintf(", %d failed", summary.Failed)
+	}
+	_, err := fmt.Fprintln(appCtx.Stdout, line)
+	return err
+}
+
+// PrintListingPage prints one page of "object-storage ls" output, with names relative to base, and adds
+// it to totals. Folders are marked PRE.
+func PrintListingPage(w io.Writer, page *ObjectPage, base string, totals *ListingTotals) error {
+	for _, p := range page.Prefixes {
+		if _, err := fmt.Fprintf(w, "%16s %10s  %s\n", "", "PRE", strings.TrimPrefix(p, base)); err != nil {
+			return err
+		}
+	}
+	for _, o := range page.Objects {
+		modified := ""
+		if !o.LastModified.IsZero() {
+			modified = o.LastModified.Local().Format("2006-01-02 15:04")
+		}
+		if _, err := fmt.Fprintf(w, "%16s %10s  %s\n", modified, util.HumanizeBytesIEC(o.Size), strings.TrimPrefix(o.Name, base)); err != nil {
+			return err
+		}
+		totals.Bytes += o.Size
+	}
+	totals.Prefixes += len(page.Prefixes)
+	totals.Objects += len(page.Objects)
+	return nil
+}
+
+// PrintListingTotals prints the closing line of "object-storage ls".
+func PrintListingTotals(w io.Writer, loc Location, totals ListingTotals) error {
+	if totals.Prefixes == 0 && totals.Objects == 0 {
+		_, err := fmt.Fprintf(w, "No objects found under %s\n", loc.String())
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d folder(s), %d object(s), %s\n", totals.Prefixes, totals.Objects, util.HumanizeBytesIEC(totals.Bytes))
+	return err
+}
+
+// PrintPreauthenticatedRequest prints a newly created pre-authenticated request with its URL.
+func PrintPreauthenticatedRequest(par *PreauthenticatedRequest, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(par)
+	}
+
+	data := map[string]string{
+		"Name":    par.Name,
+		"URL":     par.URL,
+		"Access":  par.AccessType,
+		"Scope":   parScope(par),
+		"Listing": par.BucketListingAction,
+		"Expires": par.TimeExpires.Local().Format(time.RFC3339),
+		"ID":      par.ID,
+	}
+	keys := []string{"Name", "URL", "Access", "Scope", "Listing", "Expires", "ID"}
+	if par.BucketListingAction == "" {
+		keys = slices.DeleteFunc(keys, func(k string) bool { return k == "Listing" })
+	}
+	p.PrintKeyValuesNoTruncate(util.FormatColoredTitle(appCtx, par.Name), data, keys)
+	return nil
+}
+
+// PrintPreauthenticatedRequests prints the pre-authenticated requests of a bucket in a table, marking those
+// that expired before now.
+func PrintPreauthenticatedRequests(pars []PreauthenticatedRequest, appCtx *app.ApplicationContext, now time.Time, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(pars)
+	}
+	if len(pars) == 0 {
+		_, err := fmt.Fprintln(appCtx.Stdout, "No pre-authenticated requests found.")
+		return err
+	}
+
+	rows := make([][]string, 0, len(pars))
+	for _, par := range pars {
+		expires := par.TimeExpires.Local().Format("2006-01-02 15:04")
+		if !par.TimeExpires.After(now) {
+			expires += " (expired)"
+		}
+		rows = append(rows, []string{par.Name, par.AccessType, parScope(&par), expires, par.ID})
+	}
+	p.PrintTableNoTruncate(fmt.Sprintf("Pre-authenticated requests of %s", pars[0].BucketName),
+		[]string{"Name", "Access", "Scope", "Expires", "ID"}, rows)
+	return nil
+}
+
+// parScope describes what a pre-authenticated request gives access to.
+func parScope(par *PreauthenticatedRequest) string {
+	switch {
+	case !strings.HasPrefix(par.AccessType, "AnyObject"):
+		return par.ObjectName
+	case par.ObjectName == "":
+		return "bucket " + par.BucketName
+	default:
+		return par.ObjectName + "*"
+	}
+}
+
+// PrintObjectVersions prints the versions of an object in a table, newest first.
+func PrintObjectVersions(versions []ObjectVersion, loc Location, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(versions)
+	}
+	if len(versions) == 0 {
+		_, err := fmt.Fprintf(appCtx.Stdout, "No versions of %s found.\n", loc)
+		return err
+	}
+
+	rows := make([][]string, 0, len(versions))
+	for _, v := range versions {
+		size, status := util.HumanizeBytesIEC(v.Size), ""
+		switch {
+		case v.IsDeleteMarker:
+			size, status = "-", "delete marker"
+		case v.IsLatest:
+			status = "current"
+		}
+		rows = append(rows, []string{v.VersionID, size, v.LastModified.Local().Format("2006-01-02 15:04:05"), status})
+	}
+	p.PrintTableNoTruncate(fmt.Sprintf("Versions of %s", loc), []string{"Version ID", "Size", "Modified", "Status"}, rows)
+	return nil
+}
+
+// PrintRemoveSummary prints the objects selected or deleted by rm in a table, or the summary as JSON.
+func PrintRemoveSummary(summary *RemoveSummary, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(summary)
+	}
+
+	rows := make([][]string, 0, len(summary.Items))
+	var bytes int64
+	for _, item := range summary.Items {
+		status := "deleted"
+		switch {
+		case item.Error != "":
+			status = "failed: " + item.Error
+		case summary.DryRun:
+			status = "pending"
+		}
+		if item.Warning != "" {
+			status += " (" + item.Warning + ")"
+		}
+		tier := item.StorageTier
+		if tier == "" {
+			tier = "Standard"
+		}
+		rows = append(rows, []string{item.Object, util.HumanizeBytesIEC(item.Bytes), tier, status})
+		bytes += item.Bytes
+	}
+	title := fmt.Sprintf("Delete from %s", summary.Bucket)
+	if summary.DryRun {
+		title += " (dry run)"
+	}
+	p.PrintTableNoTruncate(title, []string{"Object", "Size", "Tier", "Status"}, rows)
+
+	line := fmt.Sprintf("%d object(s) deleted (%s)", summary.Deleted, util.HumanizeBytesIEC(summary.Bytes))
+	if summary.DryRun {
+		line = fmt.Sprintf("Dry run: %d object(s) to delete (%s)", len(summary.Items), util.HumanizeBytesIEC(bytes))
+	}
+	if summary.Failed > 0 {
+		line += fmt.Sprintf(", %d failed", summary.Failed)
+	}
+	_, err := fmt.Fprintln(appCtx.Stdout, line)
+	return err
+}
+
+// PrintRestoreSummary prints the restore state of the objects selected by restore, or the summary as JSON.
+func PrintRestoreSummary(summary *RestoreSummary, appCtx *app.ApplicationContext, useJSON bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(summary)
+	}
+
+	rows := make([][]string, 0, len(summary.Items))
+	for _, item := range summary.Items {
+		state := item.State
+		switch {
+		case item.Error != "":
+			state = "failed: " + item.Error
+		case state == restoreStateSkipped:
+			state = "skipped (not in Archive tier)"
+		}
+		rows = append(rows, []string{item.Object, util.HumanizeBytesIEC(item.Bytes), state})
+	}
+	p.PrintTableNoTruncate(fmt.Sprintf("Restore from %s", summary.Bucket), []string{"Object", "Size", "State"}, rows)
+
+	line := fmt.Sprintf("%d restore(s) requested, %d restoring, %d restored, %d skipped", summary.Requested, summary.Restoring, summary.Restored, summary.Skipped)
+	if summary.Failed > 0 {
+		line += fmt.Sprintf(", %d failed", summary.Failed)
+	}
+	if summary.Restoring > 0 {
+		line += "\nRestoring from Archive takes up to an hour; run again or use --wait to follow it."
+	}
+	_, err := fmt.Fprintln(appCtx.Stdout, line)
+	return err
+}
+
+// PrintUsageReport prints a usage report as tables, JSON, or CSV with one row per bucket.
+func PrintUsageReport(report *UsageReport, appCtx *app.ApplicationContext, useJSON, useCSV bool) error {
+	p := printer.New(appCtx.Stdout)
+	if useJSON {
+		return p.MarshalToJSON(report)
+	}
+	if useCSV {
+		return writeUsageCSV(appCtx.Stdout, report)
+	}
+
+	priced := report.Priced
+	cost := func(v float64) string { return fmt.Sprintf("%.2f %s", v, report.Currency) }
+
+	summary := map[string]string{
+		"Scope":        report.Scope,
+		"Compartments": fmt.Sprintf("%d", report.Compartments),
+		"Buckets":      fmt.Sprintf("%d", report.Buckets),
+		"Objects":      fmt.Sprintf("%d", report.Objects),
+		"Size":         util.HumanizeBytesIEC(report.Bytes),
+	}
+	keys := []string{"Scope", "Compartments", "Buckets", "Objects", "Size"}
+	if priced {
+		summary["MonthlyCost"] = cost(report.MonthlyCost)
+		keys = append(keys, "MonthlyCost")
+	}
+	p.PrintKeyValuesNoTruncate("Object Storage usage", summary, keys)
+
+	groupTable := func(title, keyHeader string, groups []UsageGroup) {
+		if len(groups) == 0 {
+			return
+		}
+		headers := []string{keyHeader, "Buckets", "Objects", "Size"}
+		if priced {
+			headers = append(headers, "Monthly Cost")
+		}
+		rows := make([][]string, 0, len(groups))
+		for _, g := range groups {
+			row := []string{g.Key, fmt.Sprintf("%d", g.Buckets), fmt.Sprintf("%d", g.Objects), util.HumanizeBytesIEC(g.Bytes)}
+			if priced {
+				row = append(row, cost(g.MonthlyCost))
+			}
+			rows = append(rows, row)
+		}
+		p.PrintTableNoTruncate(title, headers, rows)
+	}
+	groupTable("Usage by compartment", "Compartment", report.ByCompartment)
+	groupTable("Usage by default storage tier", "Default Tier", report.ByTier)
+	groupTable("Usage by tag", "Tag", report.ByTag)
+
+	if len(report.TopBuckets) > 0 {
+		headers := []string{"Bucket", "Compartment", "Default Tier", "Objects", "Size"}
+		if priced {
+			headers = append(headers, "Monthly Cost")
+		}
+		rows := make([][]string, 0, len(report.TopBuckets))
+		for _, b := range report.TopBuckets {
+			row := []string{b.Name, b.Compartment, b.StorageTier, fmt.Sprintf("%d", b.Objects), util.HumanizeBytesIEC(b.Bytes)}
+			if priced {
+				row = append(row, cost(b.MonthlyCost))
+			}
+			rows = append(rows, row)
+		}
+		p.PrintTableNoTruncate(fmt.Sprintf("Top %d buckets by size", len(report.TopBuckets)), headers, rows)
+	}
+
+	if len(report.UnpricedTiers) > 0 {
+		fmt.Fprintf(appCtx.Stdout, "No price for storage tier(s) %s: their buckets are not in the cost estimate.\n", strings.Join(report.UnpricedTiers, ", "))
+	}
+	for _, e := range report.Errors {
+		fmt.Fprintf(appCtx.Stdout, "Skipped %s\n", e)
+	}
+	return nil
+}
+
+// writeUsageCSV writes one row per bucket, largest first, with its tags as "key=value" pairs separated by ";".
+func writeUsageCSV(w io.Writer, report *UsageReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"compartment", "bucket", "storage_tier", "objects", "bytes", "monthly_cost", "tags"}); err != nil {
+		return err
+	}
+	for _, b := range report.AllBuckets {
+		tags := make([]string, 0, len(b.Tags))
+		for k, v := range b.Tags {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+		monthlyCost := ""
+		if report.Priced {
+			monthlyCost = strconv.FormatFloat(b.MonthlyCost, 'f', 2, 64)
+		}
+		if err := cw.Write([]string{b.Compartment, b.Name, b.StorageTier, strconv.Itoa(b.Objects),
+			strconv.FormatInt(b.Bytes, 10), monthlyCost, strings.Join(tags, ";")}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -3,8 +3,10 @@ package objectstorage
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/rozdolsky33/ocloud/internal/app"
+	storage "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
 	"github.com/rozdolsky33/ocloud/internal/logger"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,6 +38,38 @@ func TestPrintBucketsInfo_TableAndJSON(t *testing.T) {
 	}
 }
 
+func TestPrintBucketsInfo_Policies(t *testing.T) {
+	buf := &bytes.Buffer{}
+	appCtx := &app.ApplicationContext{Logger: logger.NewTestLogger(), Stdout: buf}
+
+	b := makeBucket(0)
+	b.ObjectEventsEnabled = true
+	b.Policies = &storage.BucketPolicies{
+		LifecycleRules: []storage.LifecycleRule{{Name: "archive-logs", Action: "ARCHIVE", Target: "objects", TimeAmount: 30,
+			TimeUnit: "DAYS", Enabled: true, InclusionPrefixes: []string{"logs/"}, ExclusionPatterns: []string{"*.keep"}}},
+		RetentionRules: []storage.RetentionRule{
+			{Name: "legal", DurationAmount: 1, DurationUnit: "YEARS", TimeRuleLocked: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "hold"},
+		},
+	}
+
+	assert.NoError(t, PrintBucketsInfo([]Bucket{b}, appCtx, nil, false))
+	out := buf.String()
+	assert.Contains(t, out, "enabled")
+	assert.Contains(t, out, "archive-logs")
+	assert.Contains(t, out, "30 days")
+	assert.Contains(t, out, "prefix logs/; exclude *.keep")
+	assert.Contains(t, out, "1 year")
+	assert.Contains(t, out, "locked 2020-01-01")
+	assert.Contains(t, out, "indefinite")
+	assert.Contains(t, out, "Replication policies: none")
+	buf.Reset()
+
+	assert.NoError(t, PrintBucketsInfo([]Bucket{b}, appCtx, nil, true))
+	assert.Contains(t, buf.String(), `"LifecycleRules"`)
+	assert.Contains(t, buf.String(), `"ObjectEventsEnabled": true`)
+}
+
 func TestPrintBucketInfo_TableAndJSON(t *testing.T) {
 	buf := &bytes.Buffer{}
 	appCtx := &app.ApplicationContext{Logger: logger.NewTestLogger(), Stdout: buf}
@@ -0,0 +1,187 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	storage "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
+)
+
+// DefaultPARExpiry is how long a pre-authenticated request stays valid when no expiry is given.
+const DefaultPARExpiry = 24 * time.Hour
+
+// PAROptions describes a pre-authenticated request to create.
+type PAROptions struct {
+	// Name defaults to "ocloud-<creation time>".
+	Name string
+	// Access is read, write or readwrite; empty means read.
+	Access string
+	// Expires is a duration from now such as "90m", "12h" or "7d", or an RFC3339 time; empty means DefaultPARExpiry.
+	Expires      string
+	AllowListing bool
+}
+
+// CreatePAR runs "object-storage par create": it creates a pre-authenticated request for the object, prefix or
+// bucket at arg and prints it with its URL.
+func CreatePAR(appCtx *app.ApplicationContext, arg string, opts PAROptions, useJSON bool) error {
+	ctx := context.Background()
+	loc, err := parseRemoteLocation(arg)
+	if err != nil {
+		return err
+	}
+	service, namespace, err := newServiceForApp(ctx, appCtx)
+	if err != nil {
+		return err
+	}
+	par, err := service.CreatePreauthenticatedRequest(ctx, namespace, loc, opts, time.Now())
+	if err != nil {
+		return err
+	}
+	return PrintPreauthenticatedRequest(par, appCtx, useJSON)
+}
+
+// ListPARs runs "object-storage par list": it lists the pre-authenticated requests of a bucket, optionally only
+// those for objects under a prefix.
+func ListPARs(appCtx *app.ApplicationContext, arg string, useJSON bool) error {
+	ctx := context.Background()
+	loc, err := parseRemoteLocation(arg)
+	if err != nil {
+		return err
+	}
+	service, namespace, err := newServiceForApp(ctx, appCtx)
+	if err != nil {
+		return err
+	}
+	pars, err := service.ListPreauthenticatedRequests(ctx, namespace, loc.Bucket, loc.Object)
+	if err != nil {
+		return err
+	}
+	return PrintPreauthenticatedRequests(pars, appCtx, time.Now(), useJSON)
+}
+
+// DeletePAR runs "object-storage par delete": it deletes a pre-authenticated request of the bucket at arg.
+func DeletePAR(appCtx *app.ApplicationContext, arg, parID string) error {
+	ctx := context.Background()
+	loc, err := parseRemoteLocation(arg)
+	if err != nil {
+		return err
+	}
+	service, namespace, err := newServiceForApp(ctx, appCtx)
+	if err != nil {
+		return err
+	}
+	if err := service.DeletePreauthenticatedRequest(ctx, namespace, loc.Bucket, parID); err != nil {
+		return err
+	}
+	logger.Logger.Info("Deleted pre-authenticated request", "bucket", loc.Bucket, "par_id", parID)
+	return nil
+}
+
+// CreatePreauthenticatedRequest creates a pre-authenticated request for loc: a single object, or every object
+// under loc.Object when it is empty or ends in "/".
+func (s *Service) CreatePreauthenticatedRequest(ctx context.Context, namespace string, loc Location, opts PAROptions, now time.Time) (*PreauthenticatedRequest, error) {
+	expires, err := ParseExpiry(opts.Expires, now)
+	if err != nil {
+		return nil, err
+	}
+	spec := PreauthenticatedRequestSpec{
+		Name:         opts.Name,
+		ObjectName:   loc.Object,
+		Access:       strings.ToLower(opts.Access),
+		TimeExpires:  expires,
+		AllowListing: opts.AllowListing,
+	}
+	if spec.Name == "" {
+		spec.Name = "ocloud-" + now.UTC().Format("20060102-150405")
+	}
+	if spec.Access == "" {
+		spec.Access = storage.PARAccessRead
+	}
+	s.logger.V(logger.Debug).Info("creating pre-authenticated request", "bucket", loc.Bucket, "object", loc.Object,
+		"access", spec.Access, "expires", expires)
+	par, err := s.osRepo.CreatePreauthenticatedRequest(ctx, namespace, loc.Bucket, spec)
+	if err != nil {
+		return nil, fmt.Errorf("creating pre-authenticated request for %s: %w", loc, err)
+	}
+	return par, nil
+}
+
+// ListPreauthenticatedRequests lists the pre-authenticated requests of a bucket whose object name starts with prefix.
+func (s *Service) ListPreauthenticatedRequests(ctx context.Context, namespace, bucketName, prefix string) ([]PreauthenticatedRequest, error) {
+	s.logger.V(logger.Debug).Info("listing pre-authenticated requests", "bucket", bucketName, "prefix", prefix)
+	pars, err := s.osRepo.ListPreauthenticatedRequests(ctx, namespace, bucketName, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing pre-authenticated requests of %s: %w", bucketName, err)
+	}
+	return pars, nil
+}
+
+// DeletePreauthenticatedRequest deletes a pre-authenticated request of a bucket.
+func (s *Service) DeletePreauthenticatedRequest(ctx context.Context, namespace, bucketName, parID string) error {
+	s.logger.V(logger.Debug).Info("deleting pre-authenticated request", "bucket", bucketName, "par_id", parID)
+	if err := s.osRepo.DeletePreauthenticatedRequest(ctx, namespace, bucketName, parID); err != nil {
+		return fmt.Errorf("deleting pre-authenticated request %s: %w", parID, err)
+	}
+	return nil
+}
+
+// ParseExpiry turns an expiry into a time: a duration from now ("90m", "12h", or days as "7d") or an RFC3339
+// time. An empty value is DefaultPARExpiry from now. The time must be in the future.
+func ParseExpiry(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return now.Add(DefaultPARExpiry), nil
+	}
+	var expires time.Time
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		expires = t
+	} else if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid expiry %q: expected a number of days such as 7d", value)
+		}
+		expires = now.AddDate(0, 0, n)
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid expiry %q: expected a duration (e.g., 12h, 7d) or an RFC3339 time", value)
+		}
+		expires = now.Add(d)
+	}
+	if !expires.After(now) {
+		return time.Time{}, fmt.Errorf("expiry %q is not in the future", value)
+	}
+	return expires, nil
+}
+
+// parseRemoteLocation parses an oci://bucket[/object] argument and rejects local paths.
+func parseRemoteLocation(arg string) (Location, error) {
+	loc, err := ParseLocation(arg)
+	if err != nil {
+		return Location{}, err
+	}
+	if !loc.IsRemote() {
+		return Location{}, fmt.Errorf("expected an %s<bucket>/<object or prefix> location, got %q", URIScheme, arg)
+	}
+	return loc, nil
+}
+
+// newServiceForApp creates the service for the application context and looks up the namespace.
+func newServiceForApp(ctx context.Context, appCtx *app.ApplicationContext) (*Service, string, error) {
+	client, err := oci.NewObjectStorageClient(appCtx.Provider)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating object storage client: %w", err)
+	}
+	service := NewService(osadapter.NewAdapter(client), appCtx.Logger, appCtx.CompartmentID)
+	namespace, err := service.GetNamespace(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("getting namespace: %w", err)
+	}
+	return service, namespace, nil
+}
@@ -0,0 +1,92 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"":                     now.Add(24 * time.Hour),
+		"90m":                  now.Add(90 * time.Minute),
+		"12h":                  now.Add(12 * time.Hour),
+		"7d":                   now.AddDate(0, 0, 7),
+		"2024-12-31T23:59:59Z": time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+	}
+	for in, want := range cases {
+		got, err := ParseExpiry(in, now)
+		require.NoError(t, err, in)
+		assert.True(t, got.Equal(want), "%q: got %v, want %v", in, got, want)
+	}
+
+	for _, bad := range []string{"soon", "xd", "-1h", "0d", "2024-01-01T00:00:00Z"} {
+		_, err := ParseExpiry(bad, now)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestCreatePreauthenticatedRequest(t *testing.T) {
+	repo := &fakeRepo{}
+	svc, _ := makeSvc(repo)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := svc.CreatePreauthenticatedRequest(context.Background(), "ns", Location{Bucket: "reports", Object: "q1.pdf"}, PAROptions{}, now)
+	require.NoError(t, err)
+	_, err = svc.CreatePreauthenticatedRequest(context.Background(), "ns", Location{Bucket: "drop", Object: "in/"},
+		PAROptions{Name: "uploads", Access: "Write", Expires: "7d", AllowListing: true}, now)
+	require.NoError(t, err)
+
+	require.Len(t, repo.parSpecs, 2)
+	first := repo.parSpecs[0]
+	assert.Equal(t, "ocloud-20240601-120000", first.Name)
+	assert.Equal(t, "read", first.Access)
+	assert.Equal(t, "q1.pdf", first.ObjectName)
+	assert.True(t, first.TimeExpires.Equal(now.Add(DefaultPARExpiry)))
+	assert.False(t, first.IsPrefixScope())
+
+	second := repo.parSpecs[1]
+	assert.Equal(t, "uploads", second.Name)
+	assert.Equal(t, "write", second.Access)
+	assert.True(t, second.AllowListing)
+	assert.True(t, second.IsPrefixScope())
+
+	_, err = svc.CreatePreauthenticatedRequest(context.Background(), "ns", Location{Bucket: "b"}, PAROptions{Expires: "later"}, now)
+	assert.ErrorContains(t, err, "invalid expiry")
+	assert.Len(t, repo.parSpecs, 2)
+}
+
+func TestPrintPreauthenticatedRequests(t *testing.T) {
+	buf := &bytes.Buffer{}
+	appCtx := &app.ApplicationContext{Logger: logger.NewTestLogger(), Stdout: buf}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	pars := []PreauthenticatedRequest{
+		{ID: "par-1", Name: "report", AccessType: "ObjectRead", ObjectName: "q1.pdf", TimeExpires: now.Add(time.Hour), BucketName: "reports"},
+		{ID: "par-2", Name: "drop", AccessType: "AnyObjectWrite", ObjectName: "in/", TimeExpires: now.Add(-time.Hour), BucketName: "reports"},
+		{ID: "par-3", Name: "all", AccessType: "AnyObjectRead", TimeExpires: now.Add(time.Hour), BucketName: "reports"},
+	}
+
+	require.NoError(t, PrintPreauthenticatedRequests(pars, appCtx, now, false))
+	out := buf.String()
+	assert.Contains(t, out, "q1.pdf")
+	assert.Contains(t, out, "in/*")
+	assert.Contains(t, out, "bucket reports")
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("(expired)")))
+
+	buf.Reset()
+	require.NoError(t, PrintPreauthenticatedRequests(nil, appCtx, now, false))
+	assert.Contains(t, buf.String(), "No pre-authenticated requests found.")
+
+	buf.Reset()
+	par := pars[0]
+	par.URL = "https://objectstorage.us-ashburn-1.oraclecloud.com/p/token/n/ns/b/reports/o/q1.pdf"
+	require.NoError(t, PrintPreauthenticatedRequest(&par, appCtx, false))
+	assert.Contains(t, buf.String(), par.URL, "the URL is printed in full")
+}
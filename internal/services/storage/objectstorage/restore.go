@@ -0,0 +1,170 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	storage "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+)
+
+// restorePollInterval is how often --wait checks the objects being restored; a variable so tests can shorten it.
+var restorePollInterval = time.Minute
+
+// Limits of how long a restored object stays available, in hours.
+const (
+	DefaultRestoreHours = 24
+	MaxRestoreHours     = 240
+)
+
+// RestoreOptions controls restore.
+type RestoreOptions struct {
+	// Recursive restores every archived object under a prefix.
+	Recursive bool
+	// Hours is how long the restored objects stay available for download.
+	Hours int
+	// Wait polls until every object is restored.
+	Wait bool
+}
+
+// RestoreItem is the state of one object selected by restore.
+type RestoreItem struct {
+	Object string `json:"object"`
+	Bytes  int64  `json:"bytes"`
+	// State is the archival state, or "skipped" for objects that are not in the Archive tier.
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+// RestoreSummary is the outcome of a restore command.
+type RestoreSummary struct {
+	Bucket    string        `json:"bucket"`
+	Requested int           `json:"requested"`
+	Restoring int           `json:"restoring"`
+	Restored  int           `json:"restored"`
+	Skipped   int           `json:"skipped"`
+	Failed    int           `json:"failed"`
+	Items     []RestoreItem `json:"items"`
+}
+
+// restoreStateSkipped marks objects that are not in the Archive tier and need no restore.
+const restoreStateSkipped = "skipped"
+
+// RestoreObjects runs "object-storage restore": it requests the restore of the archived objects at arg and,
+// with Wait, polls until they can be downloaded. Progress while waiting goes to stderr.
+func RestoreObjects(appCtx *app.ApplicationContext, arg string, opts RestoreOptions, useJSON bool) error {
+	ctx := context.Background()
+	loc, err := parseRemoteLocation(arg)
+	if err != nil {
+		return err
+	}
+	service, namespace, err := newServiceForApp(ctx, appCtx)
+	if err != nil {
+		return err
+	}
+
+	summary, err := service.Restore(ctx, namespace, loc, opts)
+	if err != nil {
+		return err
+	}
+	if opts.Wait && summary.Restoring > 0 {
+		if err := service.WaitForRestore(ctx, namespace, summary, appCtx.Stderr); err != nil {
+			return err
+		}
+	}
+	if err := PrintRestoreSummary(summary, appCtx, useJSON); err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d restores failed", summary.Failed, len(summary.Items))
+	}
+	return nil
+}
+
+// Restore requests the restore of the archived objects at loc. Objects already restoring or restored are
+// reported with their state, and objects outside the Archive tier are skipped.
+func (s *Service) Restore(ctx context.Context, namespace string, loc Location, opts RestoreOptions) (*RestoreSummary, error) {
+	hours := opts.Hours
+	if hours == 0 {
+		hours = DefaultRestoreHours
+	}
+	if hours < 1 || hours > MaxRestoreHours {
+		return nil, fmt.Errorf("restore hours must be between 1 and %d, got %d", MaxRestoreHours, hours)
+	}
+	objects, err := s.selectObjects(ctx, namespace, loc, opts.Recursive)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.V(logger.Debug).Info("restoring objects", "location", loc.String(), "objects", len(objects), "hours", hours)
+
+	summary := &RestoreSummary{Bucket: loc.Bucket, Items: make([]RestoreItem, len(objects))}
+	requested := make([]bool, len(objects))
+	runParallel(0, len(objects), func(idx int) {
+		o := objects[idx]
+		item := RestoreItem{Object: o.Name, Bytes: o.Size, State: o.ArchivalState}
+		switch {
+		case o.StorageTier != storage.StorageTierArchive:
+			item.State = restoreStateSkipped
+		case o.ArchivalState == storage.ArchivalStateArchived || o.ArchivalState == "":
+			if err := s.osRepo.RestoreObject(ctx, namespace, loc.Bucket, o.Name, hours); err != nil {
+				item.Error = err.Error()
+			} else {
+				item.State = storage.ArchivalStateRestoring
+				requested[idx] = true
+			}
+		}
+		summary.Items[idx] = item
+	})
+	for _, r := range requested {
+		if r {
+			summary.Requested++
+		}
+	}
+	summary.count()
+	return summary, nil
+}
+
+// WaitForRestore polls the objects of the summary that are still restoring until all of them are restored,
+// writing progress to w, and updates the summary.
+func (s *Service) WaitForRestore(ctx context.Context, namespace string, summary *RestoreSummary, w io.Writer) error {
+	for summary.Restoring > 0 {
+		fmt.Fprintf(w, "Waiting for %d of %d object(s) to be restored...\n", summary.Restoring, summary.Restoring+summary.Restored)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(restorePollInterval):
+		}
+		for i, item := range summary.Items {
+			if item.State != storage.ArchivalStateRestoring {
+				continue
+			}
+			head, err := s.osRepo.GetObjectHead(ctx, namespace, summary.Bucket, item.Object)
+			if err != nil {
+				return fmt.Errorf("checking restore of %s: %w", item.Object, err)
+			}
+			summary.Items[i].State = head.ArchivalState
+		}
+		summary.count()
+	}
+	return nil
+}
+
+// count tallies the items of the summary by state.
+func (r *RestoreSummary) count() {
+	r.Restoring, r.Restored, r.Skipped, r.Failed = 0, 0, 0, 0
+	for _, item := range r.Items {
+		switch {
+		case item.Error != "":
+			r.Failed++
+		case item.State == storage.ArchivalStateRestoring:
+			r.Restoring++
+		case item.State == storage.ArchivalStateRestored:
+			r.Restored++
+		case item.State == restoreStateSkipped:
+			r.Skipped++
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	storage "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestore(t *testing.T) {
+	repo := &fakeRepo{objects: []Object{
+		{Name: "logs/2019.tar", Size: 10, StorageTier: storage.StorageTierArchive, ArchivalState: storage.ArchivalStateArchived},
+		{Name: "logs/2020.tar", Size: 20, StorageTier: storage.StorageTierArchive, ArchivalState: storage.ArchivalStateRestoring},
+		{Name: "logs/2021.tar", Size: 30, StorageTier: storage.StorageTierArchive, ArchivalState: storage.ArchivalStateRestored},
+		{Name: "logs/today.log", Size: 1, StorageTier: "Standard"},
+	}}
+	svc, _ := makeSvc(repo)
+
+	summary, err := svc.Restore(context.Background(), "ns", Location{Bucket: "b", Object: "logs/"}, RestoreOptions{Recursive: true, Hours: 48})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"restore b/logs/2019.tar 48h"}, repo.calls, "only archived objects are restored")
+	assert.Equal(t, 1, summary.Requested)
+	assert.Equal(t, 2, summary.Restoring)
+	assert.Equal(t, 1, summary.Restored)
+	assert.Equal(t, 1, summary.Skipped)
+
+	restorePollInterval = time.Millisecond
+	t.Cleanup(func() { restorePollInterval = time.Minute })
+	repo.objects[1].ArchivalState = storage.ArchivalStateRestored
+	var progress bytes.Buffer
+	require.NoError(t, svc.WaitForRestore(context.Background(), "ns", summary, &progress))
+	assert.Equal(t, 0, summary.Restoring)
+	assert.Equal(t, 3, summary.Restored)
+	assert.Contains(t, progress.String(), "Waiting for 2 of 3 object(s) to be restored")
+}
+
+func TestRestore_Invalid(t *testing.T) {
+	repo := &fakeRepo{objects: []Object{{Name: "a.tar", StorageTier: storage.StorageTierArchive}}}
+	svc, _ := makeSvc(repo)
+
+	_, err := svc.Restore(context.Background(), "ns", Location{Bucket: "b", Object: "a.tar"}, RestoreOptions{Hours: 500})
+	assert.ErrorContains(t, err, "between 1 and 240")
+	_, err = svc.Restore(context.Background(), "ns", Location{Bucket: "b", Object: "logs/"}, RestoreOptions{})
+	assert.ErrorContains(t, err, "--recursive")
+	assert.Empty(t, repo.calls)
+
+	repo.failOn = "a.tar"
+	summary, err := svc.Restore(context.Background(), "ns", Location{Bucket: "b", Object: "a.tar"}, RestoreOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"restore b/a.tar 24h"}, repo.calls)
+	assert.Equal(t, 1, summary.Failed)
+}
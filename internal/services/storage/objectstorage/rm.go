@@ -0,0 +1,205 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	storage "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/services/util"
+)
+
+// ErrAborted is returned when the user declines a confirmation prompt.
+var ErrAborted = errors.New("aborted by user")
+
+// minimumRetention is the period an object of a storage tier is billed for, even if it is deleted sooner.
+var minimumRetention = map[string]time.Duration{
+	storage.StorageTierArchive:          90 * 24 * time.Hour,
+	storage.StorageTierInfrequentAccess: 31 * 24 * time.Hour,
+}
+
+// RemoveOptions controls rm.
+type RemoveOptions struct {
+	// Recursive deletes every object under a prefix.
+	Recursive   bool
+	DryRun      bool
+	Parallelism int
+}
+
+// RemoveItem is one object selected by rm.
+type RemoveItem struct {
+	Object      string `json:"object"`
+	Bytes       int64  `json:"bytes"`
+	StorageTier string `json:"storage_tier,omitempty"`
+	Warning     string `json:"warning,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// RemoveSummary is the outcome of an rm command.
+type RemoveSummary struct {
+	Bucket  string       `json:"bucket"`
+	DryRun  bool         `json:"dry_run"`
+	Deleted int          `json:"deleted"`
+	Bytes   int64        `json:"bytes"`
+	Failed  int          `json:"failed"`
+	Items   []RemoveItem `json:"items"`
+}
+
+// RemoveObjects runs "object-storage rm": it deletes the object, the objects under a prefix (with Recursive)
+// or the objects matching a glob at arg. Unless confirm is nil, the objects are listed and confirm must approve
+// the deletion first.
+func RemoveObjects(appCtx *app.ApplicationContext, arg string, opts RemoveOptions, confirm func(question string) bool, useJSON bool) error {
+	ctx := context.Background()
+	loc, err := parseRemoteLocation(arg)
+	if err != nil {
+		return err
+	}
+	service, namespace, err := newServiceForApp(ctx, appCtx)
+	if err != nil {
+		return err
+	}
+
+	items, err := service.PlanRemove(ctx, namespace, loc, opts.Recursive, time.Now())
+	if err != nil {
+		return err
+	}
+	summary := &RemoveSummary{Bucket: loc.Bucket, DryRun: opts.DryRun, Items: items}
+	if len(items) == 0 {
+		if useJSON {
+			return PrintRemoveSummary(summary, appCtx, true)
+		}
+		fmt.Fprintf(appCtx.Stdout, "No objects match %s\n", loc)
+		return nil
+	}
+	if opts.DryRun {
+		for _, item := range items {
+			summary.Deleted++
+			summary.Bytes += item.Bytes
+		}
+		return PrintRemoveSummary(summary, appCtx, useJSON)
+	}
+
+	if confirm != nil {
+		if err := PrintRemoveSummary(&RemoveSummary{Bucket: loc.Bucket, DryRun: true, Items: items}, appCtx, false); err != nil {
+			return err
+		}
+		var bytes int64
+		warned := 0
+		for _, item := range items {
+			bytes += item.Bytes
+			if item.Warning != "" {
+				warned++
+			}
+		}
+		question := fmt.Sprintf("Delete %d object(s) (%s) from bucket %q?", len(items), util.HumanizeBytesIEC(bytes), loc.Bucket)
+		if warned > 0 {
+			question = fmt.Sprintf("%d object(s) are still in their minimum retention period. %s", warned, question)
+		}
+		if !confirm(question) {
+			return ErrAborted
+		}
+	}
+
+	summary = service.Remove(ctx, namespace, loc.Bucket, items, opts.Parallelism)
+	if err := PrintRemoveSummary(summary, appCtx, useJSON); err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d deletions failed", summary.Failed, len(summary.Items))
+	}
+	return nil
+}
+
+// PlanRemove selects the objects rm deletes, with a warning for objects deleted before the minimum retention
+// period of their storage tier ends.
+func (s *Service) PlanRemove(ctx context.Context, namespace string, loc Location, recursive bool, now time.Time) ([]RemoveItem, error) {
+	objects, err := s.selectObjects(ctx, namespace, loc, recursive)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]RemoveItem, 0, len(objects))
+	for _, o := range objects {
+		items = append(items, RemoveItem{Object: o.Name, Bytes: o.Size, StorageTier: o.StorageTier, Warning: retentionWarning(o, now)})
+	}
+	s.logger.V(logger.Debug).Info("planned object removal", "location", loc.String(), "objects", len(items))
+	return items, nil
+}
+
+// Remove deletes the planned objects of a bucket, up to parallelism at a time. Failed deletions are reported
+// in the summary.
+func (s *Service) Remove(ctx context.Context, namespace, bucketName string, items []RemoveItem, parallelism int) *RemoveSummary {
+	summary := &RemoveSummary{Bucket: bucketName, Items: make([]RemoveItem, len(items))}
+	runParallel(parallelism, len(items), func(idx int) {
+		item := items[idx]
+		if err := s.osRepo.DeleteObject(ctx, namespace, bucketName, item.Object); err != nil {
+			item.Error = err.Error()
+			s.logger.V(logger.Debug).Info("delete failed", "bucket", bucketName, "object", item.Object, "error", err)
+		}
+		summary.Items[idx] = item
+	})
+	for _, item := range summary.Items {
+		if item.Error != "" {
+			summary.Failed++
+			continue
+		}
+		summary.Deleted++
+		summary.Bytes += item.Bytes
+	}
+	return summary
+}
+
+// selectObjects returns the objects at loc: every object under the prefix when recursive, the objects matching
+// the glob when loc.Object contains *, ? or [, and otherwise the single object, which must exist. In a glob,
+// * and ? do not match "/".
+func (s *Service) selectObjects(ctx context.Context, namespace string, loc Location, recursive bool) ([]Object, error) {
+	if i := strings.IndexAny(loc.Object, "*?["); i >= 0 {
+		if _, err := path.Match(loc.Object, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", loc.Object, err)
+		}
+		listed, err := s.osRepo.ListObjectsByPrefix(ctx, namespace, loc.Bucket, loc.Object[:i])
+		if err != nil {
+			return nil, fmt.Errorf("listing objects: %w", err)
+		}
+		var objects []Object
+		for _, o := range listed {
+			if ok, _ := path.Match(loc.Object, o.Name); ok {
+				objects = append(objects, o)
+			}
+		}
+		return objects, nil
+	}
+
+	if recursive {
+		objects, err := s.osRepo.ListObjectsByPrefix(ctx, namespace, loc.Bucket, prefixDir(loc.Object))
+		if err != nil {
+			return nil, fmt.Errorf("listing objects: %w", err)
+		}
+		return objects, nil
+	}
+	if loc.Object == "" || strings.HasSuffix(loc.Object, "/") {
+		return nil, fmt.Errorf("%s is a bucket or prefix: use --recursive to select every object under it", loc)
+	}
+	obj, err := s.osRepo.GetObjectHead(ctx, namespace, loc.Bucket, loc.Object)
+	if err != nil {
+		return nil, fmt.Errorf("getting object %s: %w", loc, err)
+	}
+	return []Object{*obj}, nil
+}
+
+// retentionWarning explains the early deletion charge of an object still in its minimum retention period.
+func retentionWarning(o Object, now time.Time) string {
+	period, ok := minimumRetention[o.StorageTier]
+	if !ok || o.LastModified.IsZero() {
+		return ""
+	}
+	age := now.Sub(o.LastModified)
+	if age >= period {
+		return ""
+	}
+	return fmt.Sprintf("%s tier bills %d days minimum; stored %d days", o.StorageTier, int(period.Hours()/24), int(age.Hours()/24))
+}
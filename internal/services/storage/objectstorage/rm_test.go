@@ -0,0 +1,120 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	storage "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func removedObjects(items []RemoveItem) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.Object)
+	}
+	return out
+}
+
+func TestPlanRemove_Selection(t *testing.T) {
+	repo := &fakeRepo{objects: []Object{
+		{Name: "build/1/app.tar", Size: 10},
+		{Name: "build/1/app.tmp", Size: 1},
+		{Name: "build/2/app.tmp", Size: 2},
+		{Name: "build/10/app.tar", Size: 5},
+		{Name: "build/notes.tmp", Size: 3},
+		{Name: "keep.txt", Size: 4},
+	}}
+	svc, _ := makeSvc(repo)
+	ctx, now := context.Background(), time.Now()
+
+	items, err := svc.PlanRemove(ctx, "ns", Location{Bucket: "b", Object: "keep.txt"}, false, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"keep.txt"}, removedObjects(items))
+
+	items, err = svc.PlanRemove(ctx, "ns", Location{Bucket: "b", Object: "build/1/"}, true, now)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"build/1/app.tar", "build/1/app.tmp"}, removedObjects(items))
+
+	items, err = svc.PlanRemove(ctx, "ns", Location{Bucket: "b", Object: "build/1"}, true, now)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"build/1/app.tar", "build/1/app.tmp"}, removedObjects(items), "a prefix without / is a directory")
+
+	items, err = svc.PlanRemove(ctx, "ns", Location{Bucket: "b", Object: "build/*/*.tmp"}, false, now)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"build/1/app.tmp", "build/2/app.tmp"}, removedObjects(items), "* does not cross /")
+
+	_, err = svc.PlanRemove(ctx, "ns", Location{Bucket: "b", Object: "build/"}, false, now)
+	assert.ErrorContains(t, err, "--recursive")
+	_, err = svc.PlanRemove(ctx, "ns", Location{Bucket: "b"}, false, now)
+	assert.ErrorContains(t, err, "--recursive")
+	_, err = svc.PlanRemove(ctx, "ns", Location{Bucket: "b", Object: "missing.txt"}, false, now)
+	assert.Error(t, err)
+	_, err = svc.PlanRemove(ctx, "ns", Location{Bucket: "b", Object: "build/[a-"}, false, now)
+	assert.ErrorContains(t, err, "invalid pattern")
+	assert.Empty(t, repo.calls, "planning deletes nothing")
+}
+
+func TestPlanRemove_RetentionWarnings(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo := &fakeRepo{objects: []Object{
+		{Name: "a/new-archive", StorageTier: storage.StorageTierArchive, LastModified: now.AddDate(0, 0, -10)},
+		{Name: "a/old-archive", StorageTier: storage.StorageTierArchive, LastModified: now.AddDate(0, 0, -100)},
+		{Name: "a/new-ia", StorageTier: storage.StorageTierInfrequentAccess, LastModified: now.AddDate(0, 0, -30)},
+		{Name: "a/standard", StorageTier: "Standard", LastModified: now},
+	}}
+	svc, _ := makeSvc(repo)
+
+	items, err := svc.PlanRemove(context.Background(), "ns", Location{Bucket: "b", Object: "a/"}, true, now)
+	require.NoError(t, err)
+	warnings := map[string]string{}
+	for _, item := range items {
+		warnings[item.Object] = item.Warning
+	}
+	assert.Equal(t, "Archive tier bills 90 days minimum; stored 10 days", warnings["a/new-archive"])
+	assert.Equal(t, "InfrequentAccess tier bills 31 days minimum; stored 30 days", warnings["a/new-ia"])
+	assert.Empty(t, warnings["a/old-archive"])
+	assert.Empty(t, warnings["a/standard"])
+}
+
+func TestRemove(t *testing.T) {
+	repo := &fakeRepo{failOn: "b.txt"}
+	svc, _ := makeSvc(repo)
+	items := []RemoveItem{{Object: "a.txt", Bytes: 5}, {Object: "b.txt", Bytes: 7}, {Object: "c.txt", Bytes: 9}}
+
+	summary := svc.Remove(context.Background(), "ns", "bkt", items, 2)
+	assert.ElementsMatch(t, []string{"delete bkt/a.txt", "delete bkt/b.txt", "delete bkt/c.txt"}, repo.calls)
+	assert.Equal(t, 2, summary.Deleted)
+	assert.Equal(t, int64(14), summary.Bytes)
+	assert.Equal(t, 1, summary.Failed)
+	assert.NotEmpty(t, summary.Items[1].Error)
+}
+
+func TestPrintRemoveSummary_DryRun(t *testing.T) {
+	_, appCtx := makeSvc(&fakeRepo{})
+	summary := &RemoveSummary{Bucket: "b", DryRun: true, Items: []RemoveItem{
+		{Object: "old.tar", Bytes: 2048, StorageTier: storage.StorageTierArchive, Warning: "Archive tier bills 90 days minimum; stored 3 days"},
+		{Object: "a.txt", Bytes: 1024},
+	}}
+
+	require.NoError(t, PrintRemoveSummary(summary, appCtx, false))
+	out := appCtx.Stdout.(*bytes.Buffer).String()
+	assert.Contains(t, out, "Delete from b (dry run)")
+	assert.Contains(t, out, "pending (Archive tier bills 90 days minimum; stored 3 days)")
+	assert.Contains(t, out, "Dry run: 2 object(s) to delete (3.00 KiB)")
+}
+
+func TestPrintRemoveSummary_NoMatchJSON(t *testing.T) {
+	_, appCtx := makeSvc(&fakeRepo{})
+
+	require.NoError(t, PrintRemoveSummary(&RemoveSummary{Bucket: "b", Items: []RemoveItem{}}, appCtx, true))
+	var got RemoveSummary
+	require.NoError(t, json.Unmarshal(appCtx.Stdout.(*bytes.Buffer).Bytes(), &got))
+	assert.Equal(t, "b", got.Bucket)
+	assert.Zero(t, got.Deleted)
+	assert.NotNil(t, got.Items)
+}
@@ -2,6 +2,7 @@ package objectstorage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -107,6 +108,37 @@ func (s *Service) FuzzySearch(ctx context.Context, searchPattern string) ([]Buck
 	return results, nil
 }
 
+// FetchBucketPolicies fills in the Policies of each bucket with its lifecycle, retention and replication rules.
+func (s *Service) FetchBucketPolicies(ctx context.Context, buckets []Bucket) error {
+	s.logger.V(logger.Debug).Info("fetching bucket policies", "buckets", len(buckets))
+	namespace := ""
+	for _, b := range buckets {
+		if b.Namespace == "" {
+			ns, err := s.GetNamespace(ctx)
+			if err != nil {
+				return fmt.Errorf("getting namespace: %w", err)
+			}
+			namespace = ns
+			break
+		}
+	}
+
+	errs := make([]error, len(buckets))
+	runParallel(0, len(buckets), func(idx int) {
+		ns := buckets[idx].Namespace
+		if ns == "" {
+			ns = namespace
+		}
+		policies, err := s.osRepo.GetBucketPolicies(ctx, ns, buckets[idx].Name)
+		if err != nil {
+			errs[idx] = fmt.Errorf("getting policies of bucket %s: %w", buckets[idx].Name, err)
+			return
+		}
+		buckets[idx].Policies = policies
+	})
+	return errors.Join(errs...)
+}
+
 // GetNamespace retrieves the object storage namespace for the compartment.
 func (s *Service) GetNamespace(ctx context.Context) (string, error) {
 	s.logger.V(logger.Debug).Info("getting object storage namespace")
@@ -119,6 +151,12 @@ func (s *Service) ListObjects(ctx context.Context, namespace, bucketName string)
 	return s.osRepo.ListObjects(ctx, namespace, bucketName)
 }
 
+// ListObjectsPage retrieves one page of the objects under a prefix; see ObjectStorageRepository.ListObjectsPage.
+func (s *Service) ListObjectsPage(ctx context.Context, namespace, bucketName, prefix, delimiter, start string, limit int) (*ObjectPage, error) {
+	s.logger.V(logger.Debug).Info("listing objects page", "bucket", bucketName, "prefix", prefix, "delimiter", delimiter, "start", start)
+	return s.osRepo.ListObjectsPage(ctx, namespace, bucketName, prefix, delimiter, start, limit)
+}
+
 // GetObjectDetails retrieves detailed metadata for an object.
 func (s *Service) GetObjectDetails(ctx context.Context, namespace, bucketName, objectName string) (*Object, error) {
 	s.logger.V(logger.Debug).Info("getting object details", "bucket", bucketName, "object", objectName)
@@ -126,13 +164,13 @@ func (s *Service) GetObjectDetails(ctx context.Context, namespace, bucketName, o
 }
 
 // DownloadObject downloads an object to the specified destination path.
-func (s *Service) DownloadObject(ctx context.Context, namespace, bucketName, objectName, destPath string, progressFn func(storage.TransferProgress)) error {
-	s.logger.V(logger.Debug).Info("downloading object", "bucket", bucketName, "object", objectName, "destination", destPath)
-	return s.osRepo.DownloadObject(ctx, namespace, bucketName, objectName, destPath, progressFn)
+func (s *Service) DownloadObject(ctx context.Context, namespace, bucketName, objectName, destPath string, opts TransferOptions, progressFn func(storage.TransferProgress)) error {
+	s.logger.V(logger.Debug).Info("downloading object", "bucket", bucketName, "object", objectName, "destination", destPath, "parallelism", opts.Parallelism)
+	return s.osRepo.DownloadObject(ctx, namespace, bucketName, objectName, destPath, opts, progressFn)
 }
 
 // UploadObject uploads a file to the specified bucket.
-func (s *Service) UploadObject(ctx context.Context, namespace, bucketName, objectName, filePath string, progressFn func(storage.TransferProgress)) error {
-	s.logger.V(logger.Debug).Info("uploading object", "bucket", bucketName, "object", objectName, "file", filePath)
-	return s.osRepo.UploadObject(ctx, namespace, bucketName, objectName, filePath, progressFn)
+func (s *Service) UploadObject(ctx context.Context, namespace, bucketName, objectName, filePath string, opts TransferOptions, progressFn func(storage.TransferProgress)) error {
+	s.logger.V(logger.Debug).Info("uploading object", "bucket", bucketName, "object", objectName, "file", filePath, "parallelism", opts.Parallelism, "resume", opts.Resume)
+	return s.osRepo.UploadObject(ctx, namespace, bucketName, objectName, filePath, opts, progressFn)
 }
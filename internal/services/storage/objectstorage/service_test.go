@@ -3,6 +3,13 @@ package objectstorage
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,6 +24,29 @@ type fakeRepo struct {
 	byName  map[string]Bucket
 	list    []Bucket
 	errList error
+	// byCompartment, when set, holds the buckets ListBuckets returns for each compartment instead of list.
+	byCompartment map[string][]Bucket
+
+	objects  []Object          // returned by ListObjectsByPrefix when the name has the prefix
+	content  map[string]string // object name -> content, for GetObjectHead and OpenObject
+	failOn   string            // object name whose transfer fails
+	versions []ObjectVersion   // returned by ListObjectVersions for their object
+	policies map[string]*storage.BucketPolicies
+
+	mu         sync.Mutex
+	calls      []string                     // transfers made, e.g. "upload <file> -> bucket/object"
+	uploadMeta map[string]map[string]string // object name -> metadata of its upload
+	parSpecs   []storage.PreauthenticatedRequestSpec
+}
+
+func (f *fakeRepo) record(call, object string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+	if object == f.failOn {
+		return assert.AnError
+	}
+	return nil
 }
 
 func (f *fakeRepo) GetBucketNameByOCID(ctx context.Context, compartmentID, bucketOCID string) (string, error) {
@@ -39,6 +69,13 @@ func (f *fakeRepo) ListBuckets(ctx context.Context, compartmentID string) ([]Buc
 	if f.errList != nil {
 		return nil, f.errList
 	}
+	if f.byCompartment != nil {
+		listed, ok := f.byCompartment[compartmentID]
+		if !ok {
+			return nil, assert.AnError
+		}
+		return listed, nil
+	}
 	// return copy
 	out := make([]Bucket, len(f.list))
 	copy(out, f.list)
@@ -54,17 +91,157 @@ func (f *fakeRepo) ListObjects(ctx context.Context, namespace, bucketName string
 }
 
 func (f *fakeRepo) GetObjectHead(ctx context.Context, namespace, bucketName, objectName string) (*Object, error) {
+	for _, o := range f.objects {
+		if o.Name == objectName {
+			return &o, nil
+		}
+	}
+	if c, ok := f.content[objectName]; ok {
+		return &Object{Name: objectName, Size: int64(len(c))}, nil
+	}
 	return nil, assert.AnError
 }
 
-func (f *fakeRepo) DownloadObject(ctx context.Context, namespace, bucketName, objectName, destPath string, progressFn func(storage.TransferProgress)) error {
+func (f *fakeRepo) ListObjectsByPrefix(ctx context.Context, namespace, bucketName, prefix string) ([]Object, error) {
+	var out []Object
+	for _, o := range f.objects {
+		if strings.HasPrefix(o.Name, prefix) {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRepo) ListObjectsPage(ctx context.Context, namespace, bucketName, prefix, delimiter, start string, limit int) (*ObjectPage, error) {
+	byName := map[string]Object{}
+	var names []string
+	for _, o := range f.objects {
+		name := o.Name
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if i := strings.Index(name[len(prefix):], delimiter); delimiter != "" && i >= 0 {
+			name = name[:len(prefix)+i+len(delimiter)]
+		} else {
+			byName[name] = o
+		}
+		if name >= start && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	page := &ObjectPage{}
+	if limit > 0 && len(names) > limit {
+		page.NextStart = names[limit]
+		names = names[:limit]
+	}
+	for _, name := range names {
+		if o, ok := byName[name]; ok {
+			page.Objects = append(page.Objects, o)
+		} else {
+			page.Prefixes = append(page.Prefixes, name)
+		}
+	}
+	return page, nil
+}
+
+func (f *fakeRepo) ListObjectVersions(ctx context.Context, namespace, bucketName, objectName string) ([]ObjectVersion, error) {
+	var out []ObjectVersion
+	for _, v := range f.versions {
+		if v.Name == objectName {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+// versionSuffix marks the calls that transfer an older version of an object.
+func versionSuffix(opts storage.TransferOptions) string {
+	if opts.VersionID == "" {
+		return ""
+	}
+	return " @" + opts.VersionID
+}
+
+func (f *fakeRepo) DownloadObjectToFile(ctx context.Context, namespace, bucketName, objectName, filePath string, opts storage.TransferOptions, progressFn func(storage.TransferProgress)) error {
+	if err := f.record("download "+bucketName+"/"+objectName+versionSuffix(opts)+" -> "+filePath, objectName); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, []byte(f.content[objectName]), 0o644)
+}
+
+func (f *fakeRepo) OpenObject(ctx context.Context, namespace, bucketName, objectName string, opts storage.TransferOptions) (io.ReadCloser, error) {
+	if err := f.record("open "+bucketName+"/"+objectName+versionSuffix(opts), objectName); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(strings.NewReader(f.content[objectName])), nil
+}
+
+func (f *fakeRepo) UploadStream(ctx context.Context, namespace, bucketName, objectName string, r io.Reader, opts storage.TransferOptions) (int64, error) {
+	n, _ := io.Copy(io.Discard, r)
+	return n, f.record("stream -> "+bucketName+"/"+objectName, objectName)
+}
+
+func (f *fakeRepo) CopyObject(ctx context.Context, namespace, bucketName, objectName, destRegion, destBucket, destObject string, opts storage.TransferOptions) error {
+	return f.record("copy "+bucketName+"/"+objectName+versionSuffix(opts)+" -> "+destRegion+":"+destBucket+"/"+destObject, objectName)
+}
+
+func (f *fakeRepo) DownloadObject(ctx context.Context, namespace, bucketName, objectName, destPath string, opts storage.TransferOptions, progressFn func(storage.TransferProgress)) error {
 	return nil
 }
 
-func (f *fakeRepo) UploadObject(ctx context.Context, namespace, bucketName, objectName, filePath string, progressFn func(storage.TransferProgress)) error {
+func (f *fakeRepo) DeleteObject(ctx context.Context, namespace, bucketName, objectName string) error {
+	return f.record("delete "+bucketName+"/"+objectName, objectName)
+}
+
+// RestoreObject records the request and completes the restore at once, so the next GetObjectHead sees it.
+func (f *fakeRepo) RestoreObject(ctx context.Context, namespace, bucketName, objectName string, hours int) error {
+	if err := f.record(fmt.Sprintf("restore %s/%s %dh", bucketName, objectName, hours), objectName); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.objects {
+		if f.objects[i].Name == objectName {
+			f.objects[i].ArchivalState = storage.ArchivalStateRestored
+		}
+	}
 	return nil
 }
 
+func (f *fakeRepo) CreatePreauthenticatedRequest(ctx context.Context, namespace, bucketName string, spec storage.PreauthenticatedRequestSpec) (*PreauthenticatedRequest, error) {
+	f.parSpecs = append(f.parSpecs, spec)
+	return &PreauthenticatedRequest{ID: "par-1", Name: spec.Name, ObjectName: spec.ObjectName, TimeExpires: spec.TimeExpires, BucketName: bucketName, Namespace: namespace}, nil
+}
+
+func (f *fakeRepo) ListPreauthenticatedRequests(ctx context.Context, namespace, bucketName, prefix string) ([]PreauthenticatedRequest, error) {
+	return nil, nil
+}
+
+func (f *fakeRepo) DeletePreauthenticatedRequest(ctx context.Context, namespace, bucketName, parID string) error {
+	return f.record("delete par "+bucketName+"/"+parID, parID)
+}
+
+func (f *fakeRepo) GetBucketPolicies(ctx context.Context, namespace, bucketName string) (*storage.BucketPolicies, error) {
+	if err := f.record("policies "+namespace+"/"+bucketName, bucketName); err != nil {
+		return nil, err
+	}
+	if p, ok := f.policies[bucketName]; ok {
+		return p, nil
+	}
+	return &storage.BucketPolicies{}, nil
+}
+
+func (f *fakeRepo) UploadObject(ctx context.Context, namespace, bucketName, objectName, filePath string, opts storage.TransferOptions, progressFn func(storage.TransferProgress)) error {
+	f.mu.Lock()
+	if f.uploadMeta == nil {
+		f.uploadMeta = map[string]map[string]string{}
+	}
+	f.uploadMeta[objectName] = opts.Metadata
+	f.mu.Unlock()
+	return f.record("upload "+filePath+" -> "+bucketName+"/"+objectName, objectName)
+}
+
 func makeBucket(i int) Bucket {
 	return Bucket{
 		Name:        "bucket-" + string(rune('a'+i)),
@@ -119,3 +296,22 @@ func TestService_ListBuckets_EnrichAndPaginate(t *testing.T) {
 	assert.Equal(t, "2", next)
 	assert.Len(t, page1, 2)
 }
+
+func TestService_FetchBucketPolicies(t *testing.T) {
+	legal := &storage.BucketPolicies{RetentionRules: []storage.RetentionRule{{Name: "legal"}}}
+	repo := &fakeRepo{policies: map[string]*storage.BucketPolicies{"bucket-a": legal}}
+	svc, _ := makeSvc(repo)
+	buckets := []Bucket{{Name: "bucket-a", Namespace: "ns"}, {Name: "bucket-b"}}
+
+	err := svc.FetchBucketPolicies(context.Background(), buckets)
+	assert.NoError(t, err)
+	assert.Same(t, legal, buckets[0].Policies)
+	assert.NotNil(t, buckets[1].Policies)
+	assert.ElementsMatch(t, []string{"policies ns/bucket-a", "policies test-namespace/bucket-b"}, repo.calls,
+		"the namespace is looked up for buckets without one")
+
+	repo = &fakeRepo{failOn: "bucket-b"}
+	svc, _ = makeSvc(repo)
+	err = svc.FetchBucketPolicies(context.Background(), []Bucket{{Name: "bucket-a"}, {Name: "bucket-b"}})
+	assert.ErrorContains(t, err, "bucket-b")
+}
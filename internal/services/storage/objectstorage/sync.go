@@ -0,0 +1,421 @@
+package objectstorage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
+)
+
+// SyncMtimeKey is the user metadata key under which sync records the modification time of an uploaded file.
+const SyncMtimeKey = "mtime"
+
+// Sync actions.
+const (
+	SyncUpload   = "upload"
+	SyncDownload = "download"
+	SyncDelete   = "delete"
+)
+
+// Reasons a file is transferred or deleted.
+const (
+	SyncReasonNew        = "new"
+	SyncReasonSize       = "size"
+	SyncReasonChecksum   = "checksum"
+	SyncReasonModified   = "modified"
+	SyncReasonExtraneous = "extraneous"
+)
+
+// SyncOptions controls sync.
+type SyncOptions struct {
+	// Delete removes destination files that are not in the source.
+	Delete bool
+	// DryRun only reports what would be done.
+	DryRun bool
+	// Include and Exclude are glob patterns; see MatchSyncPattern.
+	Include  []string
+	Exclude  []string
+	Transfer TransferOptions
+}
+
+// SyncAction is one file that sync transfers or deletes. Path is relative to the directory and the prefix.
+type SyncAction struct {
+	Action string `json:"action"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+	Bytes  int64  `json:"bytes"`
+	Error  string `json:"error,omitempty"`
+}
+
+// SyncSummary is the outcome of a sync command.
+type SyncSummary struct {
+	Source      string       `json:"source"`
+	Destination string       `json:"destination"`
+	DryRun      bool         `json:"dry_run"`
+	Transferred int          `json:"transferred"`
+	Deleted     int          `json:"deleted"`
+	Unchanged   int          `json:"unchanged"`
+	Failed      int          `json:"failed"`
+	Bytes       int64        `json:"bytes"`
+	Actions     []SyncAction `json:"actions"`
+}
+
+// syncFile is a file or object found on one side of a sync.
+type syncFile struct {
+	size    int64
+	modTime time.Time
+	md5     string // objects only: the MD5 from the listing, empty or "<md5>-<parts>" for multipart objects
+}
+
+// SyncObjects runs "object-storage sync" between a local directory and a bucket prefix, in the direction
+// given by the arguments, and prints the actions taken.
+func SyncObjects(appCtx *app.ApplicationContext, srcArg, dstArg string, opts SyncOptions, useJSON bool) error {
+	ctx := context.Background()
+	src, err := ParseLocation(srcArg)
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	dst, err := ParseLocation(dstArg)
+	if err != nil {
+		return fmt.Errorf("destination: %w", err)
+	}
+
+	client, err := oci.NewObjectStorageClient(appCtx.Provider)
+	if err != nil {
+		return fmt.Errorf("creating object storage client: %w", err)
+	}
+	service := NewService(osadapter.NewAdapter(client), appCtx.Logger, appCtx.CompartmentID)
+	namespace, err := service.GetNamespace(ctx)
+	if err != nil {
+		return fmt.Errorf("getting namespace: %w", err)
+	}
+
+	summary, err := service.Sync(ctx, namespace, src, dst, opts)
+	if err != nil {
+		return err
+	}
+	if err := PrintSyncSummary(summary, appCtx, useJSON); err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d sync actions failed", summary.Failed, len(summary.Actions))
+	}
+	return nil
+}
+
+// Sync makes dst match src, where one is a local directory and the other a bucket prefix. A file is
+// transferred when it is missing or its size differs. Files of the same size are compared by MD5 when the
+// object has one; objects uploaded in parts are compared by modification time, which sync records in the
+// SyncMtimeKey metadata on upload and applies to the local file on download. opts.Transfer.Parallelism is
+// shared between the files in flight and the parts of each file, as in Copy.
+func (s *Service) Sync(ctx context.Context, namespace string, src, dst Location, opts SyncOptions) (*SyncSummary, error) {
+	if src.Stdio || dst.Stdio || src.IsRemote() == dst.IsRemote() {
+		return nil, fmt.Errorf("sync needs a local directory and an %s<bucket>/<prefix> location", URIScheme)
+	}
+	for _, p := range append(append([]string{}, opts.Include...), opts.Exclude...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+	}
+	upload := !src.IsRemote()
+	dir, remote := src.Path, dst
+	if !upload {
+		dir, remote = dst.Path, src
+	}
+	prefix := prefixDir(remote.Object)
+
+	localFiles, err := listLocalFiles(dir, upload)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := s.osRepo.ListObjectsByPrefix(ctx, namespace, remote.Bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles := make(map[string]syncFile, len(objects))
+	for _, o := range objects {
+		if strings.HasSuffix(o.Name, "/") {
+			continue
+		}
+		remoteFiles[strings.TrimPrefix(o.Name, prefix)] = syncFile{size: o.Size, modTime: o.LastModified, md5: o.ContentMD5}
+	}
+
+	from, to := localFiles, remoteFiles
+	action := SyncUpload
+	if !upload {
+		from, to = remoteFiles, localFiles
+		action = SyncDownload
+	}
+	s.logger.V(logger.Debug).Info("syncing", "source", src.String(), "destination", dst.String(), "source_files", len(from), "destination_files", len(to))
+
+	summary := &SyncSummary{Source: src.String(), Destination: dst.String(), DryRun: opts.DryRun}
+	for _, rel := range sortedKeys(from) {
+		if !syncSelected(rel, opts.Include, opts.Exclude) {
+			continue
+		}
+		f := from[rel]
+		other, exists := to[rel]
+		localPath, err := containedPath(dir, rel)
+		if err != nil {
+			summary.Actions = append(summary.Actions, SyncAction{Action: action, Path: rel, Bytes: f.size, Error: err.Error()})
+			continue
+		}
+		reason, err := s.syncReason(ctx, namespace, remote.Bucket, prefix+rel, localPath, f, other, exists, upload)
+		if err != nil {
+			summary.Actions = append(summary.Actions, SyncAction{Action: action, Path: rel, Bytes: f.size, Error: err.Error()})
+			continue
+		}
+		if reason == "" {
+			summary.Unchanged++
+			continue
+		}
+		summary.Actions = append(summary.Actions, SyncAction{Action: action, Path: rel, Reason: reason, Bytes: f.size})
+	}
+	if opts.Delete {
+		for _, rel := range sortedKeys(to) {
+			if _, ok := from[rel]; !ok && syncSelected(rel, opts.Include, opts.Exclude) {
+				summary.Actions = append(summary.Actions, SyncAction{Action: SyncDelete, Path: rel, Reason: SyncReasonExtraneous, Bytes: to[rel].size})
+			}
+		}
+	}
+
+	if !opts.DryRun {
+		transfer := opts.Transfer
+		var files int
+		files, transfer.Parallelism = splitParallelism(opts.Transfer.Parallelism, len(summary.Actions))
+		runParallel(files, len(summary.Actions), func(idx int) {
+			a := &summary.Actions[idx]
+			if a.Error != "" {
+				return
+			}
+			localPath, err := containedPath(dir, a.Path)
+			if err != nil {
+				a.Error = err.Error()
+				return
+			}
+			if err := s.applySyncAction(ctx, namespace, remote.Bucket, prefix+a.Path, localPath, *a, from[a.Path], upload, transfer); err != nil {
+				a.Error = err.Error()
+				s.logger.V(logger.Debug).Info("sync action failed", "action", a.Action, "path", a.Path, "error", err)
+			}
+		})
+	}
+
+	for _, a := range summary.Actions {
+		switch {
+		case a.Error != "":
+			summary.Failed++
+		case a.Action == SyncDelete:
+			summary.Deleted++
+		default:
+			summary.Transferred++
+			summary.Bytes += a.Bytes
+		}
+	}
+	return summary, nil
+}
+
+// syncReason returns why the source file f must be transferred over the destination file other, or "" if
+// it is unchanged.
+func (s *Service) syncReason(ctx context.Context, namespace, bucket, objectName, localPath string, f, other syncFile, exists, upload bool) (string, error) {
+	if !exists {
+		return SyncReasonNew, nil
+	}
+	if other.size != f.size {
+		return SyncReasonSize, nil
+	}
+	local, object := f, other
+	if !upload {
+		local, object = other, f
+	}
+
+	if object.md5 != "" && !strings.Contains(object.md5, "-") {
+		sum, err := fileMD5(localPath)
+		if err != nil {
+			return "", err
+		}
+		if sum != object.md5 {
+			return SyncReasonChecksum, nil
+		}
+		return "", nil
+	}
+
+	// Multipart objects have no MD5 of their content, so fall back to the modification time.
+	objectTime, err := s.objectModTime(ctx, namespace, bucket, objectName, object.modTime)
+	if err != nil {
+		return "", err
+	}
+	if upload && !objectTime.Before(local.modTime.Truncate(time.Second)) {
+		return "", nil
+	}
+	if sameSecond(objectTime, local.modTime) {
+		return "", nil
+	}
+	return SyncReasonModified, nil
+}
+
+// applySyncAction uploads, downloads or deletes one file. f is the source entry of a transfer.
+func (s *Service) applySyncAction(ctx context.Context, namespace, bucket, objectName, localPath string, a SyncAction, f syncFile, upload bool, opts TransferOptions) error {
+	switch a.Action {
+	case SyncUpload:
+		metadata := make(map[string]string, len(opts.Metadata)+1)
+		for k, v := range opts.Metadata {
+			metadata[k] = v
+		}
+		metadata[SyncMtimeKey] = f.modTime.UTC().Format(time.RFC3339Nano)
+		opts.Metadata = metadata
+		return s.osRepo.UploadObject(ctx, namespace, bucket, objectName, localPath, opts, nil)
+
+	case SyncDownload:
+		if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+			return fmt.Errorf("create directory: %w", err)
+		}
+		if err := s.osRepo.DownloadObjectToFile(ctx, namespace, bucket, objectName, localPath, opts, nil); err != nil {
+			return err
+		}
+		modTime, err := s.objectModTime(ctx, namespace, bucket, objectName, f.modTime)
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(localPath, modTime, modTime)
+
+	default:
+		if upload {
+			return s.osRepo.DeleteObject(ctx, namespace, bucket, objectName)
+		}
+		return os.Remove(localPath)
+	}
+}
+
+// objectModTime returns the modification time sync recorded for an object, or listed when there is none.
+func (s *Service) objectModTime(ctx context.Context, namespace, bucket, objectName string, listed time.Time) (time.Time, error) {
+	head, err := s.osRepo.GetObjectHead(ctx, namespace, bucket, objectName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if v, ok := head.Metadata[SyncMtimeKey]; ok {
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, nil
+		}
+	}
+	return listed, nil
+}
+
+// listLocalFiles returns the regular files below dir by slash-separated relative path. A missing directory
+// is an error when it is the source, and empty when it is the destination.
+func listLocalFiles(dir string, isSource bool) (map[string]syncFile, error) {
+	files := make(map[string]syncFile)
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) && !isSource {
+		return files, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = syncFile{size: fi.Size(), modTime: fi.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// syncSelected reports whether a path passes the include and exclude patterns. Excludes win; when
+// there are includes, the path must match one of them.
+func syncSelected(rel string, include, exclude []string) bool {
+	for _, p := range exclude {
+		if MatchSyncPattern(p, rel) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, p := range include {
+		if MatchSyncPattern(p, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchSyncPattern matches a glob against a slash-separated relative path. A pattern without a slash, such
+// as "*.tmp" or "node_modules", matches any element of the path. A pattern with a slash, such as
+// "assets/*.png" or "build/", matches the whole path or one of its parent directories.
+func MatchSyncPattern(pattern, rel string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	elems := strings.Split(rel, "/")
+	if !strings.Contains(pattern, "/") {
+		for _, e := range elems {
+			if ok, _ := path.Match(pattern, e); ok {
+				return true
+			}
+		}
+		return false
+	}
+	for i := len(elems); i > 0; i-- {
+		if ok, _ := path.Match(pattern, strings.Join(elems[:i], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fileMD5 returns the base64 MD5 of a file, in the form Object Storage reports it.
+func fileMD5(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("read %s: %w", p, err)
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// sameSecond compares times at the one-second precision that every filesystem keeps.
+func sameSecond(a, b time.Time) bool {
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
+
+func sortedKeys(m map[string]syncFile) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,206 @@
+package objectstorage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func md5Of(s string) string {
+	sum := md5.Sum([]byte(s))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func actionPaths(summary *SyncSummary) []string {
+	var out []string
+	for _, a := range summary.Actions {
+		out = append(out, a.Action+" "+a.Path+" ("+a.Reason+")")
+	}
+	return out
+}
+
+func TestSync_Upload(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"index.html":    "<html>",
+		"css/main.css":  "body{color:red}",
+		"img/logo.png":  "png",
+		"big.bin":       "multipart",
+		"cache/tmp.dat": "x",
+	})
+	mtime := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "big.bin"), mtime, mtime))
+
+	repo := &fakeRepo{objects: []Object{
+		{Name: "site/index.html", Size: 6, ContentMD5: md5Of("<html>")},
+		{Name: "site/css/main.css", Size: 15, ContentMD5: md5Of("body{color:blu}")},
+		{Name: "site/big.bin", Size: 9, ContentMD5: "abc==-2", Metadata: map[string]string{SyncMtimeKey: mtime.Format(time.RFC3339Nano)}},
+		{Name: "site/old.html", Size: 1},
+		{Name: "site/cache/keep.dat", Size: 1},
+	}}
+	svc, _ := makeSvc(repo)
+	opts := SyncOptions{Delete: true, Exclude: []string{"cache"}, DryRun: true}
+	src, dst := Location{Path: dir}, Location{Bucket: "web", Object: "site"}
+
+	summary, err := svc.Sync(context.Background(), "ns", src, dst, opts)
+	require.NoError(t, err)
+	assert.Empty(t, repo.calls, "a dry run changes nothing")
+	assert.Equal(t, []string{
+		"upload css/main.css (checksum)",
+		"upload img/logo.png (new)",
+		"delete old.html (extraneous)",
+	}, actionPaths(summary))
+	assert.Equal(t, 2, summary.Unchanged)
+
+	opts.DryRun = false
+	summary, err = svc.Sync(context.Background(), "ns", src, dst, opts)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"upload " + filepath.Join(dir, "css", "main.css") + " -> web/site/css/main.css",
+		"upload " + filepath.Join(dir, "img", "logo.png") + " -> web/site/img/logo.png",
+		"delete web/site/old.html",
+	}, repo.calls)
+	assert.Equal(t, 2, summary.Transferred)
+	assert.Equal(t, 1, summary.Deleted)
+	assert.Equal(t, int64(18), summary.Bytes)
+
+	info, err := os.Stat(filepath.Join(dir, "img", "logo.png"))
+	require.NoError(t, err)
+	assert.Equal(t, info.ModTime().UTC().Format(time.RFC3339Nano), repo.uploadMeta["site/img/logo.png"][SyncMtimeKey])
+}
+
+func TestSync_UploadModifiedMultipartObject(t *testing.T) {
+	dir := writeTree(t, map[string]string{"big.bin": "multipart"})
+	uploaded := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	edited := uploaded.Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "big.bin"), edited, edited))
+
+	// Without recorded metadata the upload time is used: the file was edited after it.
+	repo := &fakeRepo{objects: []Object{{Name: "big.bin", Size: 9, ContentMD5: "abc==-2", LastModified: uploaded}}}
+	svc, _ := makeSvc(repo)
+	summary, err := svc.Sync(context.Background(), "ns", Location{Path: dir}, Location{Bucket: "b"}, SyncOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"upload big.bin (modified)"}, actionPaths(summary))
+}
+
+func TestSync_Download(t *testing.T) {
+	listed := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	recorded := time.Date(2024, 5, 31, 7, 0, 0, 0, time.UTC)
+	repo := &fakeRepo{
+		content: map[string]string{"logs/a.log": "aaaa", "logs/2024/b.log": "bb"},
+		objects: []Object{
+			{Name: "logs/a.log", Size: 4, ContentMD5: md5Of("aaaa"), LastModified: listed},
+			{Name: "logs/2024/b.log", Size: 2, ContentMD5: "xyz==-1", LastModified: listed, Metadata: map[string]string{SyncMtimeKey: recorded.Format(time.RFC3339Nano)}},
+			{Name: "logs/skip.tmp", Size: 1},
+		},
+	}
+	svc, _ := makeSvc(repo)
+	dest := writeTree(t, map[string]string{"a.log": "aaaa", "stale.log": "old"})
+
+	summary, err := svc.Sync(context.Background(), "ns", Location{Bucket: "b", Object: "logs/"}, Location{Path: dest},
+		SyncOptions{Delete: true, Include: []string{"*.log"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"download 2024/b.log (new)", "delete stale.log (extraneous)"}, actionPaths(summary))
+	assert.Equal(t, []string{"download b/logs/2024/b.log -> " + filepath.Join(dest, "2024", "b.log")}, repo.calls)
+	assert.NoFileExists(t, filepath.Join(dest, "stale.log"))
+
+	info, err := os.Stat(filepath.Join(dest, "2024", "b.log"))
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(recorded), "the recorded modification time is applied")
+
+	// The second run finds everything in sync: a.log by MD5, b.log by modification time.
+	repo.calls = nil
+	summary, err = svc.Sync(context.Background(), "ns", Location{Bucket: "b", Object: "logs/"}, Location{Path: dest},
+		SyncOptions{Delete: true, Include: []string{"*.log"}})
+	require.NoError(t, err)
+	assert.Empty(t, summary.Actions)
+	assert.Equal(t, 2, summary.Unchanged)
+	assert.Empty(t, repo.calls)
+}
+
+func TestSync_DownloadRejectsEscapingNames(t *testing.T) {
+	repo := &fakeRepo{
+		content: map[string]string{"pre/ok.txt": "ok"},
+		objects: []Object{{Name: "pre/ok.txt", Size: 2}, {Name: "pre/../../.bashrc", Size: 3}},
+	}
+	svc, _ := makeSvc(repo)
+	dest := t.TempDir()
+
+	summary, err := svc.Sync(context.Background(), "ns", Location{Bucket: "b", Object: "pre/"}, Location{Path: dest}, SyncOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Transferred)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, []string{"download b/pre/ok.txt -> " + filepath.Join(dest, "ok.txt")}, repo.calls)
+	for _, a := range summary.Actions {
+		if a.Path == "../../.bashrc" {
+			assert.Contains(t, a.Error, "resolves outside")
+		}
+	}
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(dest), ".bashrc"))
+}
+
+func TestSync_IntoMissingDirectory(t *testing.T) {
+	repo := &fakeRepo{content: map[string]string{"a": "1"}, objects: []Object{{Name: "a", Size: 1}}}
+	svc, _ := makeSvc(repo)
+	dest := filepath.Join(t.TempDir(), "new")
+
+	summary, err := svc.Sync(context.Background(), "ns", Location{Bucket: "b"}, Location{Path: dest}, SyncOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Transferred)
+	assert.FileExists(t, filepath.Join(dest, "a"))
+}
+
+func TestSync_FailuresAreReported(t *testing.T) {
+	dir := writeTree(t, map[string]string{"a.txt": "a", "b.txt": "b"})
+	repo := &fakeRepo{failOn: "a.txt"}
+	svc, _ := makeSvc(repo)
+
+	summary, err := svc.Sync(context.Background(), "ns", Location{Path: dir}, Location{Bucket: "b"}, SyncOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, 1, summary.Transferred)
+	assert.NotEmpty(t, summary.Actions[0].Error)
+}
+
+func TestSync_InvalidArguments(t *testing.T) {
+	svc, _ := makeSvc(&fakeRepo{})
+	ctx := context.Background()
+
+	_, err := svc.Sync(ctx, "ns", Location{Path: "a"}, Location{Path: "b"}, SyncOptions{})
+	assert.Error(t, err)
+	_, err = svc.Sync(ctx, "ns", Location{Bucket: "a"}, Location{Bucket: "b"}, SyncOptions{})
+	assert.Error(t, err)
+	_, err = svc.Sync(ctx, "ns", Location{Stdio: true}, Location{Bucket: "b"}, SyncOptions{})
+	assert.Error(t, err)
+	_, err = svc.Sync(ctx, "ns", Location{Path: t.TempDir()}, Location{Bucket: "b"}, SyncOptions{Include: []string{"[a-"}})
+	assert.ErrorContains(t, err, "invalid pattern")
+	_, err = svc.Sync(ctx, "ns", Location{Path: filepath.Join(t.TempDir(), "missing")}, Location{Bucket: "b"}, SyncOptions{})
+	assert.Error(t, err, "a missing source directory is an error")
+}
+
+func TestMatchSyncPattern(t *testing.T) {
+	cases := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"*.tmp", "a.tmp", true},
+		{"*.tmp", "dir/sub/a.tmp", true},
+		{"*.tmp", "a.tmp.bak", false},
+		{"node_modules", "web/node_modules/react/index.js", true},
+		{"node_modules", "web/node_modules.txt", false},
+		{"assets/*.png", "assets/logo.png", true},
+		{"assets/*.png", "web/assets/logo.png", false},
+		{"build/", "build/out/app.js", true},
+		{"build/out", "build/out/app.js", true},
+		{"build/out", "build/outer.js", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, MatchSyncPattern(c.pattern, c.path), "%s ~ %s", c.pattern, c.path)
+	}
+}
@@ -4,4 +4,9 @@ import storage "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstor
 
 type Bucket = storage.Bucket
 type Object = storage.Object
+type ObjectPage = storage.ObjectPage
+type PreauthenticatedRequest = storage.PreauthenticatedRequest
+type PreauthenticatedRequestSpec = storage.PreauthenticatedRequestSpec
 type TransferProgress = storage.TransferProgress
+type TransferOptions = storage.TransferOptions
+type ObjectVersion = storage.ObjectVersion
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"github.com/rozdolsky33/ocloud/internal/app"
+	storage "github.com/rozdolsky33/ocloud/internal/domain/storage/objectstorage"
 	"github.com/rozdolsky33/ocloud/internal/oci"
 	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
 	"github.com/rozdolsky33/ocloud/internal/services/util"
@@ -17,7 +18,9 @@ import (
 // 1. Show bucket list TUI to select destination bucket
 // 2. Show file picker TUI to select file to upload
 // 3. Upload the file with progress TUI
-func UploadFile(appCtx *app.ApplicationContext) error {
+//
+// With opts.Resume, an interrupted multipart upload of the same file is continued.
+func UploadFile(appCtx *app.ApplicationContext, opts TransferOptions) error {
 	ctx := context.Background()
 	client, err := oci.NewObjectStorageClient(appCtx.Provider)
 	if err != nil {
@@ -105,7 +108,7 @@ func UploadFile(appCtx *app.ApplicationContext) error {
 			progressRunner.UpdateProgress(percent, bytesInfo, extraInfo, status)
 		}
 
-		err := service.UploadObject(ctx, namespace, bucketName, objectName, filePath, progressFn)
+		err := service.UploadObject(ctx, namespace, bucketName, objectName, filePath, opts, progressFn)
 		if err != nil {
 			progressRunner.SendError(err)
 			done <- err
@@ -123,6 +126,10 @@ func UploadFile(appCtx *app.ApplicationContext) error {
 	// Wait for upload to finish and check result
 	uploadErr := <-done
 	if uploadErr != nil {
+		var incomplete *storage.IncompleteUploadError
+		if errors.As(uploadErr, &incomplete) {
+			return fmt.Errorf("uploading object: %w; run the upload again with --resume to continue", uploadErr)
+		}
 		return fmt.Errorf("uploading object: %w", uploadErr)
 	}
 
@@ -0,0 +1,317 @@
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/domain/identity"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/rozdolsky33/ocloud/internal/oci"
+	"github.com/rozdolsky33/ocloud/internal/oci/identity/compartment"
+	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
+	compartmentSvc "github.com/rozdolsky33/ocloud/internal/services/identity/compartment"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultUsageTop is the number of largest buckets a usage report lists when no number is given.
+const DefaultUsageTop = 10
+
+// untaggedGroup collects the buckets without the grouped tag.
+const untaggedGroup = "(untagged)"
+
+// bytesPerGB is the unit of the prices in a price sheet.
+const bytesPerGB = 1 << 30
+
+// UsageOptions controls the usage report.
+type UsageOptions struct {
+	// TenancyScope reports every compartment of the tenancy instead of the configured compartment and its children.
+	TenancyScope bool
+	// Top is the number of largest buckets to list; values below 1 use DefaultUsageTop.
+	Top int
+	// TagKey groups buckets by the value of one tag, a freeform key or a defined "namespace.key". When empty,
+	// buckets are grouped under every "key=value" tag they carry.
+	TagKey string
+	// PriceSheet is the path of a price sheet used to estimate the monthly cost; empty means no estimate. The
+	// estimate prices every object in its bucket's default storage tier.
+	PriceSheet string
+}
+
+// PriceSheet holds the monthly price of a gigabyte (2^30 bytes) of storage in each storage tier.
+//
+//	currency: USD
+//	tiers:
+//	  Standard: 0.0255
+//	  InfrequentAccess: 0.01
+//	  Archive: 0.0026
+type PriceSheet struct {
+	Currency string             `yaml:"currency"`
+	Tiers    map[string]float64 `yaml:"tiers"`
+}
+
+// LoadPriceSheet reads a YAML (or JSON) price sheet.
+func LoadPriceSheet(path string) (*PriceSheet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading price sheet: %w", err)
+	}
+	var sheet PriceSheet
+	if err := yaml.Unmarshal(data, &sheet); err != nil {
+		return nil, fmt.Errorf("parsing price sheet %s: %w", path, err)
+	}
+	if len(sheet.Tiers) == 0 {
+		return nil, fmt.Errorf("price sheet %s has no tier prices", path)
+	}
+	return &sheet, nil
+}
+
+// MonthlyCost estimates the monthly cost of storing bytes in a tier; ok is false when the sheet has no price
+// for the tier.
+func (p *PriceSheet) MonthlyCost(tier string, bytes int64) (cost float64, ok bool) {
+	price, ok := p.Tiers[tier]
+	return float64(bytes) / bytesPerGB * price, ok
+}
+
+// UsageCompartment is a compartment whose buckets are counted in a usage report.
+type UsageCompartment = compartmentSvc.Ref
+
+// UsageBucket is the storage used by one bucket.
+type UsageBucket struct {
+	Name        string `json:"name"`
+	Compartment string `json:"compartment"`
+	// StorageTier is the bucket's default storage tier. Object Storage reports no per-tier breakdown, so every
+	// object of the bucket is counted (and priced) in it, including objects archived by lifecycle rules.
+	StorageTier string            `json:"storage_tier"`
+	Objects     int               `json:"objects"`
+	Bytes       int64             `json:"bytes"`
+	MonthlyCost float64           `json:"monthly_cost,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// UsageGroup is the storage used by the buckets sharing a compartment, storage tier or tag.
+type UsageGroup struct {
+	Key         string  `json:"key"`
+	Buckets     int     `json:"buckets"`
+	Objects     int     `json:"objects"`
+	Bytes       int64   `json:"bytes"`
+	MonthlyCost float64 `json:"monthly_cost,omitempty"`
+}
+
+// UsageReport aggregates the storage used by the buckets of a set of compartments. Groups and buckets are
+// sorted by size, largest first.
+type UsageReport struct {
+	Scope         string       `json:"scope"`
+	Compartments  int          `json:"compartments"`
+	Buckets       int          `json:"buckets"`
+	Objects       int          `json:"objects"`
+	Bytes         int64        `json:"bytes"`
+	MonthlyCost   float64      `json:"monthly_cost,omitempty"`
+	Currency      string       `json:"currency,omitempty"`
+	ByCompartment []UsageGroup `json:"by_compartment"`
+	// ByTier groups buckets by their default storage tier, not by the tier their objects are in.
+	ByTier     []UsageGroup  `json:"by_tier"`
+	ByTag      []UsageGroup  `json:"by_tag"`
+	TopBuckets []UsageBucket `json:"top_buckets"`
+	// UnpricedTiers are storage tiers missing from the price sheet; their buckets are not in the estimate.
+	UnpricedTiers []string `json:"unpriced_tiers,omitempty"`
+	// Errors lists the compartments and buckets that could not be read.
+	Errors []string `json:"errors,omitempty"`
+
+	// Priced is set when the report has a cost estimate.
+	Priced bool `json:"-"`
+	// AllBuckets holds every bucket, for the CSV output.
+	AllBuckets []UsageBucket `json:"-"`
+}
+
+// ReportUsage runs "object-storage usage": it walks the compartments in scope, aggregates the approximate size
+// and object count of their buckets and prints the report as a table, JSON or CSV.
+func ReportUsage(appCtx *app.ApplicationContext, opts UsageOptions, useJSON, useCSV bool) error {
+	ctx := context.Background()
+	var sheet *PriceSheet
+	if opts.PriceSheet != "" {
+		s, err := LoadPriceSheet(opts.PriceSheet)
+		if err != nil {
+			return err
+		}
+		sheet = s
+	}
+
+	compartmentRepo := compartment.NewCompartmentAdapter(appCtx.IdentityClient, appCtx.TenancyID)
+	compartments, scope, err := UsageCompartments(ctx, compartmentRepo, appCtx, opts.TenancyScope)
+	if err != nil {
+		return err
+	}
+
+	client, err := oci.NewObjectStorageClient(appCtx.Provider)
+	if err != nil {
+		return fmt.Errorf("creating object storage client: %w", err)
+	}
+	service := NewService(osadapter.NewAdapter(client), appCtx.Logger, appCtx.CompartmentID)
+	report := service.Usage(ctx, compartments, opts, sheet)
+	report.Scope = scope
+
+	return PrintUsageReport(report, appCtx, useJSON, useCSV)
+}
+
+// UsageCompartments returns the compartments a usage report covers: the root compartment and every
+// compartment of the tenancy with tenancyScope, otherwise the configured compartment and its descendants.
+// The scope describes the choice for the report.
+func UsageCompartments(ctx context.Context, repo identity.CompartmentRepository, appCtx *app.ApplicationContext, tenancyScope bool) ([]UsageCompartment, string, error) {
+	return compartmentSvc.Walk(ctx, repo, appCtx, tenancyScope, true)
+}
+
+// Usage reads the buckets of the compartments and aggregates their storage. Compartments and buckets that
+// cannot be read are listed in the report's Errors rather than failing it.
+func (s *Service) Usage(ctx context.Context, compartments []UsageCompartment, opts UsageOptions, sheet *PriceSheet) *UsageReport {
+	s.logger.V(logger.Debug).Info("collecting bucket usage", "compartments", len(compartments), "tag_key", opts.TagKey)
+	perCompartment := make([][]UsageBucket, len(compartments))
+	errs := make([][]string, len(compartments))
+	runParallel(0, len(compartments), func(idx int) {
+		perCompartment[idx], errs[idx] = s.compartmentUsage(ctx, compartments[idx])
+	})
+
+	report := &UsageReport{Compartments: len(compartments)}
+	for i := range compartments {
+		report.AllBuckets = append(report.AllBuckets, perCompartment[i]...)
+		report.Errors = append(report.Errors, errs[i]...)
+	}
+	if sheet != nil {
+		report.Priced, report.Currency = true, sheet.Currency
+		unpriced := map[string]bool{}
+		for i, b := range report.AllBuckets {
+			cost, ok := sheet.MonthlyCost(b.StorageTier, b.Bytes)
+			if !ok && !unpriced[b.StorageTier] {
+				unpriced[b.StorageTier] = true
+				report.UnpricedTiers = append(report.UnpricedTiers, b.StorageTier)
+			}
+			report.AllBuckets[i].MonthlyCost = cost
+		}
+		sort.Strings(report.UnpricedTiers)
+	}
+
+	sort.SliceStable(report.AllBuckets, func(i, j int) bool { return report.AllBuckets[i].Bytes > report.AllBuckets[j].Bytes })
+	byCompartment := newUsageGroups()
+	byTier := newUsageGroups()
+	byTag := newUsageGroups()
+	for _, b := range report.AllBuckets {
+		report.Buckets++
+		report.Objects += b.Objects
+		report.Bytes += b.Bytes
+		report.MonthlyCost += b.MonthlyCost
+		byCompartment.add(b.Compartment, b)
+		byTier.add(b.StorageTier, b)
+		for _, key := range tagGroups(b.Tags, opts.TagKey) {
+			byTag.add(key, b)
+		}
+	}
+	report.ByCompartment = byCompartment.sorted()
+	report.ByTier = byTier.sorted()
+	report.ByTag = byTag.sorted()
+
+	top := opts.Top
+	if top < 1 {
+		top = DefaultUsageTop
+	}
+	report.TopBuckets = report.AllBuckets[:min(top, len(report.AllBuckets))]
+	return report
+}
+
+// compartmentUsage reads the size, object count and tags of every bucket in a compartment.
+func (s *Service) compartmentUsage(ctx context.Context, c UsageCompartment) ([]UsageBucket, []string) {
+	listed, err := s.osRepo.ListBuckets(ctx, c.ID)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("compartment %s: %v", c.Name, err)}
+	}
+	buckets := make([]UsageBucket, len(listed))
+	errs := make([]string, len(listed))
+	runParallel(0, len(listed), func(idx int) {
+		b, err := s.osRepo.GetBucketByName(ctx, c.ID, listed[idx].Name)
+		if err != nil {
+			errs[idx] = fmt.Sprintf("bucket %s in %s: %v", listed[idx].Name, c.Name, err)
+			return
+		}
+		buckets[idx] = UsageBucket{Name: b.Name, Compartment: c.Name, StorageTier: b.StorageTier,
+			Objects: b.ApproximateCount, Bytes: b.ApproximateSize, Tags: bucketTags(b)}
+	})
+
+	var read []UsageBucket
+	var failed []string
+	for i := range listed {
+		if errs[i] != "" {
+			failed = append(failed, errs[i])
+			continue
+		}
+		read = append(read, buckets[i])
+	}
+	return read, failed
+}
+
+// bucketTags flattens the tags of a bucket: freeform tags by key, defined tags as "namespace.key".
+func bucketTags(b *Bucket) map[string]string {
+	if len(b.FreeformTags) == 0 && len(b.DefinedTags) == 0 {
+		return nil
+	}
+	tags := map[string]string{}
+	for k, v := range b.FreeformTags {
+		tags[k] = v
+	}
+	for ns, kv := range b.DefinedTags {
+		for k, v := range kv {
+			tags[ns+"."+k] = fmt.Sprint(v)
+		}
+	}
+	return tags
+}
+
+// tagGroups returns the tag groups a bucket belongs to: the value of tagKey, or every "key=value" tag when
+// tagKey is empty. Buckets without the tag, or without tags, are untagged.
+func tagGroups(tags map[string]string, tagKey string) []string {
+	if tagKey != "" {
+		if v, ok := tags[tagKey]; ok {
+			return []string{v}
+		}
+		return []string{untaggedGroup}
+	}
+	if len(tags) == 0 {
+		return []string{untaggedGroup}
+	}
+	groups := make([]string, 0, len(tags))
+	for k, v := range tags {
+		groups = append(groups, k+"="+v)
+	}
+	return groups
+}
+
+// usageGroups accumulates usage by key.
+type usageGroups map[string]*UsageGroup
+
+func newUsageGroups() usageGroups { return usageGroups{} }
+
+func (g usageGroups) add(key string, b UsageBucket) {
+	group, ok := g[key]
+	if !ok {
+		group = &UsageGroup{Key: key}
+		g[key] = group
+	}
+	group.Buckets++
+	group.Objects += b.Objects
+	group.Bytes += b.Bytes
+	group.MonthlyCost += b.MonthlyCost
+}
+
+// sorted returns the groups by size, largest first, and by key for equal sizes.
+func (g usageGroups) sorted() []UsageGroup {
+	out := make([]UsageGroup, 0, len(g))
+	for _, group := range g {
+		out = append(out, *group)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Bytes != out[j].Bytes {
+			return out[i].Bytes > out[j].Bytes
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
@@ -0,0 +1,159 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/domain/identity"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gib = 1 << 30
+
+// fakeCompartments implements identity.CompartmentRepository with children by parent OCID.
+type fakeCompartments map[string][]identity.Compartment
+
+func (f fakeCompartments) GetCompartment(ctx context.Context, ocid string) (*identity.Compartment, error) {
+	return nil, assert.AnError
+}
+
+func (f fakeCompartments) ListCompartments(ctx context.Context, ocid string) ([]identity.Compartment, error) {
+	return f[ocid], nil
+}
+
+func usageRepo() *fakeRepo {
+	return &fakeRepo{
+		byCompartment: map[string][]Bucket{
+			"c-prod": {{Name: "logs"}, {Name: "backups"}},
+			"c-dev":  {{Name: "scratch"}, {Name: "gone"}},
+		},
+		byName: map[string]Bucket{
+			"logs":    {Name: "logs", StorageTier: "Standard", ApproximateSize: 30 * gib, ApproximateCount: 300, FreeformTags: map[string]string{"team": "web"}},
+			"backups": {Name: "backups", StorageTier: "Archive", ApproximateSize: 100 * gib, ApproximateCount: 10, DefinedTags: map[string]map[string]interface{}{"ops": {"cost-center": 42}}},
+			"scratch": {Name: "scratch", StorageTier: "Standard", ApproximateSize: 1 * gib, ApproximateCount: 5, FreeformTags: map[string]string{"team": "data"}},
+		},
+	}
+}
+
+func TestUsage_Aggregates(t *testing.T) {
+	svc, _ := makeSvc(usageRepo())
+	compartments := []UsageCompartment{{ID: "c-prod", Name: "prod"}, {ID: "c-dev", Name: "dev"}, {ID: "c-denied", Name: "secret"}}
+
+	report := svc.Usage(context.Background(), compartments, UsageOptions{Top: 2}, nil)
+
+	assert.Equal(t, 3, report.Compartments)
+	assert.Equal(t, 3, report.Buckets)
+	assert.Equal(t, 315, report.Objects)
+	assert.Equal(t, int64(131*gib), report.Bytes)
+	assert.Equal(t, []UsageGroup{
+		{Key: "prod", Buckets: 2, Objects: 310, Bytes: 130 * gib},
+		{Key: "dev", Buckets: 1, Objects: 5, Bytes: 1 * gib},
+	}, report.ByCompartment)
+	assert.Equal(t, []UsageGroup{
+		{Key: "Archive", Buckets: 1, Objects: 10, Bytes: 100 * gib},
+		{Key: "Standard", Buckets: 2, Objects: 305, Bytes: 31 * gib},
+	}, report.ByTier)
+	assert.Equal(t, []string{"ops.cost-center=42", "team=web", "team=data"}, groupKeys(report.ByTag))
+	require.Len(t, report.TopBuckets, 2)
+	assert.Equal(t, "backups", report.TopBuckets[0].Name)
+	assert.Equal(t, "logs", report.TopBuckets[1].Name)
+	assert.Len(t, report.AllBuckets, 3)
+	assert.Len(t, report.Errors, 2, "the unreadable bucket and compartment are reported")
+	assert.False(t, report.Priced)
+}
+
+func TestUsage_TagKeyAndCost(t *testing.T) {
+	svc, _ := makeSvc(usageRepo())
+	compartments := []UsageCompartment{{ID: "c-prod", Name: "prod"}, {ID: "c-dev", Name: "dev"}}
+	sheet := &PriceSheet{Currency: "USD", Tiers: map[string]float64{"Standard": 0.5}}
+
+	report := svc.Usage(context.Background(), compartments, UsageOptions{TagKey: "team"}, sheet)
+
+	assert.Equal(t, []string{"(untagged)", "web", "data"}, groupKeys(report.ByTag))
+	assert.True(t, report.Priced)
+	assert.Equal(t, "USD", report.Currency)
+	assert.InDelta(t, 15.5, report.MonthlyCost, 0.001)
+	assert.InDelta(t, 15.0, report.ByCompartment[0].MonthlyCost, 0.001)
+	assert.Equal(t, []string{"Archive"}, report.UnpricedTiers)
+}
+
+func TestUsageCompartments(t *testing.T) {
+	repo := fakeCompartments{
+		"tenancy":  {{OCID: "c-prod", DisplayName: "prod"}, {OCID: "c-team", DisplayName: "team"}, {OCID: "c-dev", DisplayName: "dev"}},
+		"c-prod":   {{OCID: "c-team", DisplayName: "team"}},
+		"c-team":   {{OCID: "c-nested", DisplayName: "nested"}},
+		"c-nested": nil,
+	}
+	appCtx := &app.ApplicationContext{TenancyID: "tenancy", TenancyName: "acme", CompartmentID: "c-prod", CompartmentName: "prod"}
+
+	compartments, scope, err := UsageCompartments(context.Background(), repo, appCtx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "compartment prod and its descendants", scope)
+	assert.Equal(t, []UsageCompartment{{ID: "c-prod", Name: "prod"}, {ID: "c-team", Name: "team"}, {ID: "c-nested", Name: "nested"}}, compartments)
+
+	compartments, scope, err = UsageCompartments(context.Background(), repo, appCtx, true)
+	require.NoError(t, err)
+	assert.Equal(t, "tenancy acme", scope)
+	assert.Len(t, compartments, 4, "the root compartment and every compartment of the tenancy")
+	assert.Equal(t, UsageCompartment{ID: "tenancy", Name: "acme"}, compartments[0])
+}
+
+func TestLoadPriceSheet(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "prices.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("currency: EUR\ntiers:\n  Standard: 0.02\n  Archive: 0.002\n"), 0o600))
+
+	sheet, err := LoadPriceSheet(path)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", sheet.Currency)
+	cost, ok := sheet.MonthlyCost("Standard", 50*gib)
+	assert.True(t, ok)
+	assert.InDelta(t, 1.0, cost, 0.0001)
+	_, ok = sheet.MonthlyCost("InfrequentAccess", gib)
+	assert.False(t, ok)
+
+	empty := filepath.Join(dir, "empty.yaml")
+	require.NoError(t, os.WriteFile(empty, []byte("currency: EUR\n"), 0o600))
+	_, err = LoadPriceSheet(empty)
+	assert.ErrorContains(t, err, "no tier prices")
+}
+
+func TestPrintUsageReport(t *testing.T) {
+	svc, _ := makeSvc(usageRepo())
+	report := svc.Usage(context.Background(), []UsageCompartment{{ID: "c-prod", Name: "prod"}}, UsageOptions{},
+		&PriceSheet{Currency: "USD", Tiers: map[string]float64{"Standard": 0.5, "Archive": 0.01}})
+	report.Scope = "compartment prod"
+	buf := &bytes.Buffer{}
+	appCtx := &app.ApplicationContext{Logger: logger.NewTestLogger(), Stdout: buf}
+
+	require.NoError(t, PrintUsageReport(report, appCtx, false, false))
+	out := buf.String()
+	assert.Contains(t, out, "Usage by compartment")
+	assert.Contains(t, out, "Top 2 buckets by size")
+	assert.Contains(t, out, "16.00 USD")
+
+	buf.Reset()
+	require.NoError(t, PrintUsageReport(report, appCtx, false, true))
+	assert.Equal(t, "compartment,bucket,storage_tier,objects,bytes,monthly_cost,tags\n"+
+		"prod,backups,Archive,10,107374182400,1.00,ops.cost-center=42\n"+
+		"prod,logs,Standard,300,32212254720,15.00,team=web\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, PrintUsageReport(report, appCtx, true, false))
+	assert.Contains(t, buf.String(), `"by_tier"`)
+	assert.NotContains(t, buf.String(), "AllBuckets")
+}
+
+func groupKeys(groups []UsageGroup) []string {
+	keys := make([]string, 0, len(groups))
+	for _, g := range groups {
+		keys = append(keys, g.Key)
+	}
+	return keys
+}
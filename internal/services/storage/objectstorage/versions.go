@@ -0,0 +1,160 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/rozdolsky33/ocloud/internal/app"
+	"github.com/rozdolsky33/ocloud/internal/logger"
+	osadapter "github.com/rozdolsky33/ocloud/internal/oci/storage/objectstorage"
+	"github.com/rozdolsky33/ocloud/internal/tui"
+)
+
+// ListVersions runs "object-storage versions": it lists the versions of the object at arg, newest first.
+func ListVersions(appCtx *app.ApplicationContext, arg string, useJSON bool) error {
+	ctx := context.Background()
+	loc, err := parseRemoteLocation(arg)
+	if err != nil {
+		return err
+	}
+	service, namespace, err := newServiceForApp(ctx, appCtx)
+	if err != nil {
+		return err
+	}
+	versions, err := service.ListObjectVersions(ctx, namespace, loc.Bucket, loc.Object)
+	if err != nil {
+		return err
+	}
+	return PrintObjectVersions(versions, loc, appCtx, useJSON)
+}
+
+// PromoteVersion runs "object-storage versions --promote": it makes an older version of the object at arg the
+// current one by copying it over the object. The replaced version is kept as a previous version.
+func PromoteVersion(appCtx *app.ApplicationContext, arg, versionID string) error {
+	ctx := context.Background()
+	loc, err := parseRemoteLocation(arg)
+	if err != nil {
+		return err
+	}
+	service, namespace, err := newServiceForApp(ctx, appCtx)
+	if err != nil {
+		return err
+	}
+	region, err := appCtx.Provider.Region()
+	if err != nil {
+		return fmt.Errorf("getting region: %w", err)
+	}
+	v, err := service.PromoteObjectVersion(ctx, namespace, region, loc.Bucket, loc.Object, versionID)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(appCtx.Stdout, "Version %s of %s (modified %s) is now the current version\n",
+		v.VersionID, loc, v.LastModified.Local().Format("2006-01-02 15:04:05"))
+	return nil
+}
+
+// ListObjectVersions lists the versions of an object, newest first.
+func (s *Service) ListObjectVersions(ctx context.Context, namespace, bucketName, objectName string) ([]ObjectVersion, error) {
+	if objectName == "" {
+		return nil, fmt.Errorf("an object name is required to list versions")
+	}
+	s.logger.V(logger.Debug).Info("listing object versions", "bucket", bucketName, "object", objectName)
+	versions, err := s.osRepo.ListObjectVersions(ctx, namespace, bucketName, objectName)
+	if err != nil {
+		return nil, fmt.Errorf("listing versions of %s: %w", objectName, err)
+	}
+	return versions, nil
+}
+
+// FindVersion returns a version of an object that has content; delete markers cannot be downloaded or copied.
+func (s *Service) FindVersion(ctx context.Context, namespace, bucketName, objectName, versionID string) (*ObjectVersion, error) {
+	versions, err := s.ListObjectVersions(ctx, namespace, bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.VersionID != versionID {
+			continue
+		}
+		if v.IsDeleteMarker {
+			return nil, fmt.Errorf("version %s of %s is a delete marker", versionID, objectName)
+		}
+		return &v, nil
+	}
+	return nil, fmt.Errorf("version %s of %s not found", versionID, objectName)
+}
+
+// PromoteObjectVersion copies an older version of an object over the object, so that its content becomes current.
+func (s *Service) PromoteObjectVersion(ctx context.Context, namespace, region, bucketName, objectName, versionID string) (*ObjectVersion, error) {
+	v, err := s.FindVersion(ctx, namespace, bucketName, objectName, versionID)
+	if err != nil {
+		return nil, err
+	}
+	if v.IsLatest {
+		return nil, fmt.Errorf("version %s is already the current version of %s", versionID, objectName)
+	}
+	s.logger.V(logger.Debug).Info("promoting object version", "bucket", bucketName, "object", objectName, "version", versionID)
+	if err := s.osRepo.CopyObject(ctx, namespace, bucketName, objectName, region, bucketName, objectName, TransferOptions{VersionID: versionID}); err != nil {
+		return nil, fmt.Errorf("copying version %s over %s: %w", versionID, objectName, err)
+	}
+	return v, nil
+}
+
+// manageVersions lists the versions of an object in the TUI and downloads the selected version into the current
+// directory or makes it the current version.
+func (s *Service) manageVersions(ctx context.Context, appCtx *app.ApplicationContext, namespace, region, bucketName, objectName string) error {
+	versions, err := s.ListObjectVersions(ctx, namespace, bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		fmt.Fprintf(appCtx.Stdout, "No versions of %s found.\n", objectName)
+		return nil
+	}
+	versionID, err := tui.Run(osadapter.NewObjectVersionListModel(objectName, versions))
+	if err != nil {
+		if errors.Is(err, tui.ErrCancelled) {
+			return nil
+		}
+		return fmt.Errorf("selecting version: %w", err)
+	}
+	idx := slices.IndexFunc(versions, func(v ObjectVersion) bool { return v.VersionID == versionID })
+	if idx < 0 {
+		return fmt.Errorf("version %s of %s not found", versionID, objectName)
+	}
+	v := versions[idx]
+	if v.IsDeleteMarker {
+		fmt.Fprintf(appCtx.Stdout, "Version %s of %s is a delete marker and has no content.\n", versionID, objectName)
+		return nil
+	}
+
+	action, err := tui.RunPicker(osadapter.NewVersionActionPickerModel(v))
+	if err != nil {
+		if errors.Is(err, tui.ErrCancelled) {
+			return nil
+		}
+		return fmt.Errorf("selecting action: %w", err)
+	}
+	switch action {
+	case "download":
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("getting current directory: %w", err)
+		}
+		title := fmt.Sprintf("Downloading version %s of %s", versionID, objectName)
+		if err := s.downloadWithProgress(ctx, namespace, bucketName, objectName, cwd, TransferOptions{VersionID: versionID}, title); err != nil {
+			return err
+		}
+		fmt.Fprintf(appCtx.Stdout, "\nDownloaded version %s of %q to %s\n", versionID, objectName, cwd)
+
+	case "promote":
+		if _, err := s.PromoteObjectVersion(ctx, namespace, region, bucketName, objectName, versionID); err != nil {
+			return err
+		}
+		fmt.Fprintf(appCtx.Stdout, "Version %s of %q is now the current version\n", versionID, objectName)
+	}
+	return nil
+}
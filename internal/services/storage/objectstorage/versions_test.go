@@ -0,0 +1,89 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func versionedRepo() *fakeRepo {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	return &fakeRepo{versions: []ObjectVersion{
+		{Object: Object{Name: "app.yaml", VersionID: "v3", LastModified: at}, IsDeleteMarker: true, IsLatest: true},
+		{Object: Object{Name: "app.yaml", VersionID: "v2", Size: 11, LastModified: at.Add(-time.Hour)}},
+		{Object: Object{Name: "app.yaml", VersionID: "v1", Size: 9, LastModified: at.Add(-2 * time.Hour)}},
+		{Object: Object{Name: "other.yaml", VersionID: "o1", Size: 1, LastModified: at}, IsLatest: true},
+	}}
+}
+
+func TestFindVersion(t *testing.T) {
+	svc, _ := makeSvc(versionedRepo())
+	ctx := context.Background()
+
+	v, err := svc.FindVersion(ctx, "ns", "b", "app.yaml", "v2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), v.Size)
+
+	_, err = svc.FindVersion(ctx, "ns", "b", "app.yaml", "v3")
+	assert.ErrorContains(t, err, "delete marker")
+	_, err = svc.FindVersion(ctx, "ns", "b", "app.yaml", "o1")
+	assert.ErrorContains(t, err, "not found")
+	_, err = svc.ListObjectVersions(ctx, "ns", "b", "")
+	assert.Error(t, err)
+}
+
+func TestPromoteObjectVersion(t *testing.T) {
+	repo := versionedRepo()
+	svc, _ := makeSvc(repo)
+	ctx := context.Background()
+
+	v, err := svc.PromoteObjectVersion(ctx, "ns", "r1", "b", "app.yaml", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", v.VersionID)
+	assert.Equal(t, []string{"copy b/app.yaml @v1 -> r1:b/app.yaml"}, repo.calls)
+
+	_, err = svc.PromoteObjectVersion(ctx, "ns", "r1", "b", "other.yaml", "o1")
+	assert.ErrorContains(t, err, "already the current version")
+}
+
+func TestCopy_Version(t *testing.T) {
+	repo := versionedRepo()
+	svc, _ := makeSvc(repo)
+	ctx := context.Background()
+	dest := t.TempDir()
+	opts := CopyOptions{Transfer: TransferOptions{VersionID: "v2"}}
+
+	summary, err := svc.Copy(ctx, "ns", "r1", Location{Bucket: "b", Object: "app.yaml"}, Location{Path: dest}, opts, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"download b/app.yaml @v2 -> " + filepath.Join(dest, "app.yaml")}, repo.calls)
+	assert.Equal(t, int64(11), summary.Bytes, "the size is that of the version")
+
+	var out bytes.Buffer
+	repo.calls = nil
+	_, err = svc.Copy(ctx, "ns", "r1", Location{Bucket: "b", Object: "app.yaml"}, Location{Stdio: true}, opts, nil, &out)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"open b/app.yaml @v2"}, repo.calls)
+
+	_, err = svc.Copy(ctx, "ns", "r1", Location{Bucket: "b", Object: "app.yaml"}, Location{Path: dest},
+		CopyOptions{Recursive: true, Transfer: opts.Transfer}, nil, nil)
+	assert.Error(t, err, "a version selects a single object")
+	_, err = svc.Copy(ctx, "ns", "r1", Location{Bucket: "b", Object: "app.yaml"}, Location{Path: dest},
+		CopyOptions{Transfer: TransferOptions{VersionID: "v3"}}, nil, nil)
+	assert.ErrorContains(t, err, "delete marker")
+}
+
+func TestPrintObjectVersions(t *testing.T) {
+	repo := versionedRepo()
+	_, appCtx := makeSvc(repo)
+
+	require.NoError(t, PrintObjectVersions(repo.versions[:3], Location{Bucket: "b", Object: "app.yaml"}, appCtx, false))
+	out := appCtx.Stdout.(*bytes.Buffer).String()
+	assert.Contains(t, out, "Versions of oci://b/app.yaml")
+	assert.Contains(t, out, "delete marker")
+	assert.Contains(t, out, "v1")
+}
@@ -53,3 +53,13 @@ func IsLocalTCPPortInUse(port int) bool {
 	}
 	return false
 }
+
+// CheckLocalTCPPortBindable reports whether the current process may listen on 127.0.0.1:port.
+// Ports below 1024 usually fail with a permission error unless the process runs with elevated privileges.
+func CheckLocalTCPPortBindable(port int) error {
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return fmt.Errorf("cannot listen on 127.0.0.1:%d: %w", port, err)
+	}
+	return ln.Close()
+}
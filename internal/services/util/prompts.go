@@ -87,3 +87,25 @@ func PromptString(question string, defaultVal string) (string, error) {
 	}
 	return input, nil
 }
+
+// PromptInt prompts the user to enter an integer within [minVal, maxVal]. If the user enters empty input, defaultVal is returned.
+func PromptInt(question string, defaultVal, minVal, maxVal int) (int, error) {
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("%s [%d]: ", question, defaultVal)
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return defaultVal, nil
+		}
+		v, err := strconv.Atoi(input)
+		if err != nil || v < minVal || v > maxVal {
+			fmt.Printf("Please enter a number between %d and %d.\n", minVal, maxVal)
+			continue
+		}
+		return v, nil
+	}
+}
@@ -23,13 +23,23 @@ type FileInfo struct {
 // fileItem implements list.Item for the file picker.
 type fileItem struct {
 	info FileInfo
+	// multi shows a selection box; marked reports whether the entry is selected.
+	multi  bool
+	marked bool
 }
 
 func (i fileItem) Title() string {
+	name := i.info.Name
 	if i.info.IsDir {
-		return i.info.Name + "/"
+		name += "/"
 	}
-	return i.info.Name
+	if !i.multi || i.info.Name == ".." {
+		return name
+	}
+	if i.marked {
+		return "[x] " + name
+	}
+	return "[ ] " + name
 }
 
 func (i fileItem) Description() string {
@@ -42,6 +52,7 @@ func (i fileItem) Description() string {
 func (i fileItem) FilterValue() string { return i.info.Name }
 
 // FilePickerModel is a TUI for selecting a file from the current directory.
+// In multi-select mode files and directories are marked with space and confirmed together.
 type FilePickerModel struct {
 	list       list.Model
 	currentDir string
@@ -49,6 +60,10 @@ type FilePickerModel struct {
 	confirmed  bool
 	keys       KeyMap
 	showHidden bool
+
+	multi   bool
+	marked  map[string]bool
+	choices []string
 }
 
 // NewFilePickerModel creates a file picker starting from the given directory.
@@ -81,6 +96,48 @@ func NewFilePickerModel(startDir string) (FilePickerModel, error) {
 	return m, nil
 }
 
+// NewMultiFilePickerModel creates a file picker that selects several files and directories.
+// Space marks the highlighted entry, enter on a directory opens it, enter on a file confirms the
+// marked entries together with that file, and "c" confirms the marked entries.
+func NewMultiFilePickerModel(startDir string) (FilePickerModel, error) {
+	m, err := NewFilePickerModel(startDir)
+	if err != nil {
+		return FilePickerModel{}, err
+	}
+	m.multi = true
+	m.marked = make(map[string]bool)
+	m.list.Title = m.title()
+	m.refresh()
+	return m, nil
+}
+
+// title returns the list title for the current directory and selection.
+func (m *FilePickerModel) title() string {
+	if !m.multi {
+		return fmt.Sprintf("Select file from: %s", m.currentDir)
+	}
+	return fmt.Sprintf("Select files from: %s (%d marked; space: mark, c: confirm)", m.currentDir, len(m.marked))
+}
+
+// refresh re-reads the current directory into the list.
+func (m *FilePickerModel) refresh() {
+	items, err := m.readDir()
+	if err == nil {
+		m.list.SetItems(items)
+	}
+	m.list.Title = m.title()
+}
+
+// markedPaths returns the marked paths in a stable order.
+func (m *FilePickerModel) markedPaths() []string {
+	paths := make([]string, 0, len(m.marked))
+	for p := range m.marked {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 func (m *FilePickerModel) readDir() ([]list.Item, error) {
 	entries, err := os.ReadDir(m.currentDir)
 	if err != nil {
@@ -140,7 +197,7 @@ func (m *FilePickerModel) readDir() ([]list.Item, error) {
 
 	items := make([]list.Item, len(files))
 	for i, f := range files {
-		items[i] = fileItem{info: f}
+		items[i] = fileItem{info: f, multi: m.multi, marked: m.marked[f.Path]}
 	}
 
 	return items, nil
@@ -162,27 +219,45 @@ func (m FilePickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if item.info.IsDir {
 					// Navigate into directory
 					m.currentDir = item.info.Path
-					items, err := m.readDir()
-					if err == nil {
-						m.list.SetItems(items)
-						m.list.Title = fmt.Sprintf("Select file from: %s", m.currentDir)
-						m.list.ResetSelected()
-					}
+					m.refresh()
+					m.list.ResetSelected()
 					return m, nil
 				}
 				// File selected
 				m.choice = item.info.Path
+				if m.multi {
+					m.marked[item.info.Path] = true
+					m.choices = m.markedPaths()
+				}
 				m.confirmed = true
 				return m, tea.Quit
 			}
 		}
+		if m.multi && m.list.FilterState() != list.Filtering {
+			switch msg.String() {
+			case " ":
+				if item, ok := m.list.SelectedItem().(fileItem); ok && item.info.Name != ".." {
+					if m.marked[item.info.Path] {
+						delete(m.marked, item.info.Path)
+					} else {
+						m.marked[item.info.Path] = true
+					}
+					m.refresh()
+				}
+				return m, nil
+			case "c":
+				if len(m.marked) > 0 {
+					m.choices = m.markedPaths()
+					m.confirmed = true
+					return m, tea.Quit
+				}
+				return m, nil
+			}
+		}
 		// Toggle hidden files with '.'
 		if msg.String() == "." {
 			m.showHidden = !m.showHidden
-			items, err := m.readDir()
-			if err == nil {
-				m.list.SetItems(items)
-			}
+			m.refresh()
 			return m, nil
 		}
 	}
@@ -210,6 +285,19 @@ func RunFilePicker(m FilePickerModel) (string, error) {
 	return "", ErrCancelled
 }
 
+// RunMultiFilePicker runs a multi-select file picker and returns the selected files and directories.
+func RunMultiFilePicker(m FilePickerModel) ([]string, error) {
+	p := tea.NewProgram(m)
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+	if mm, ok := finalModel.(FilePickerModel); ok && mm.confirmed && len(mm.choices) > 0 {
+		return mm.choices, nil
+	}
+	return nil, ErrCancelled
+}
+
 // humanizeBytes formats bytes in human readable format.
 func humanizeBytes(b int64) string {
 	const unit = 1024
@@ -69,6 +69,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) View() string   { return m.list.View() }
 func (m Model) Choice() string { return m.choice }
 
+// Select moves the cursor to the item with the given ID; an unknown ID leaves the cursor where it is.
+func (m Model) Select(id string) Model {
+	for i, it := range m.list.Items() {
+		if ri, ok := it.(resourceItem); ok && ri.id == id {
+			m.list.Select(i)
+			break
+		}
+	}
+	return m
+}
+
 // NewModel creates a list model from arbitrary data by using an adapter.
 // adapter maps T -> ResourceItemData (id/title/description).
 func NewModel[T any](title string, data []T, adapter func(T) ResourceItemData) Model {
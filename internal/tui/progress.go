@@ -194,6 +194,34 @@ func (r *ProgressRunner) SendDone() {
 	}
 }
 
+// TransferStats is the aggregate state of a transfer that spans several files.
+type TransferStats struct {
+	FileIndex  int // 1-based index of the file being transferred
+	FileCount  int
+	File       string
+	BytesDone  int64
+	BytesTotal int64
+	Status     string
+}
+
+// UpdateTransfer reports progress aggregated across all files of a transfer.
+// The bar stays below 100% until SendDone, so verification after the last byte is still shown.
+func (r *ProgressRunner) UpdateTransfer(s TransferStats) {
+	percent := 0.0
+	if s.BytesTotal > 0 {
+		percent = float64(s.BytesDone) / float64(s.BytesTotal)
+	}
+	if percent > 0.99 {
+		percent = 0.99
+	}
+	bytesInfo := fmt.Sprintf("%s / %s", humanizeBytes(s.BytesDone), humanizeBytes(s.BytesTotal))
+	extraInfo := ""
+	if s.FileCount > 0 {
+		extraInfo = fmt.Sprintf("File %d/%d: %s", s.FileIndex, s.FileCount, s.File)
+	}
+	r.UpdateProgress(percent, bytesInfo, extraInfo, s.Status)
+}
+
 // Program returns the underlying tea.Program for sending custom messages.
 func (r *ProgressRunner) Program() *tea.Program {
 	return r.program